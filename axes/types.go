@@ -67,15 +67,21 @@ func (o AxisOrientation) IsVertical() bool {
 // TickFormatFunc formats tick values into labels
 type TickFormatFunc func(value interface{}) string
 
-// NewAxis creates a new axis with the given scale and orientation
+// NewAxis creates a new axis with the given scale and orientation.
+// Time scales default to multi-scale labels ("2024", "Feb", "Mar 3", ...).
 func NewAxis(scale scales.Scale, orientation AxisOrientation) *Axis {
+	formatter := DefaultTickFormatter
+	if timeScale, ok := scale.(*scales.TimeScale); ok {
+		formatter = MultiTimeTickFormatter(timeScale)
+	}
+
 	return &Axis{
 		scale:       scale,
 		orientation: orientation,
 		tickCount:   10,
 		tickSize:    units.Px(6),
 		tickPadding: units.Px(3),
-		formatter:   DefaultTickFormatter,
+		formatter:   formatter,
 		showGrid:    false,
 		gridLength:  units.Px(0),
 	}
@@ -219,6 +225,32 @@ func TimeTickFormatter(format string) TickFormatFunc {
 	}
 }
 
+// LocaleTimeTickFormatter creates a formatter for time values with custom
+// format, using the locale's month and day names
+//
+// Example:
+//   axis.TickFormat(LocaleTimeTickFormatter("2 January", &scales.GermanTimeLocale))
+func LocaleTimeTickFormatter(format string, locale *scales.TimeLocale) TickFormatFunc {
+	return func(value interface{}) string {
+		if t, ok := value.(time.Time); ok {
+			return locale.Format(t, format)
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// MultiTimeTickFormatter creates a formatter that labels each time tick by
+// the coarsest calendar boundary it falls on ("2024", "Feb", "Mar 3", "06 PM"),
+// honouring the scale's time zone, week start and locale.
+func MultiTimeTickFormatter(scale *scales.TimeScale) TickFormatFunc {
+	return func(value interface{}) string {
+		if t, ok := value.(time.Time); ok {
+			return scale.TickFormat()(t)
+		}
+		return fmt.Sprintf("%v", value)
+	}
+}
+
 // NumberTickFormatter creates a formatter for numbers with custom precision
 func NumberTickFormatter(precision int) TickFormatFunc {
 	formatStr := fmt.Sprintf("%%.%df", precision)
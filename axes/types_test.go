@@ -171,6 +171,65 @@ func TestAxis_Ticks_TimeScale(t *testing.T) {
 	}
 }
 
+func TestAxis_Ticks_TimeScale_MultiFormat(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	scale := scales.NewTimeScale(
+		[2]time.Time{start, end},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	axis := NewAxis(scale, AxisOrientationBottom)
+	axis.TickCount(3)
+
+	ticks := axis.Ticks()
+	expectedLabels := []string{"2024", "Feb", "Mar", "Apr"}
+
+	if len(ticks) != len(expectedLabels) {
+		t.Fatalf("Expected %d ticks, got %d", len(expectedLabels), len(ticks))
+	}
+	for i, tick := range ticks {
+		if tick.Label != expectedLabels[i] {
+			t.Errorf("Tick %d label = %q, expected %q", i, tick.Label, expectedLabels[i])
+		}
+	}
+
+	// Locale changes after the axis is created are picked up
+	scale.Locale(&scales.FrenchTimeLocale)
+	if label := axis.Ticks()[1].Label; label != "févr." {
+		t.Errorf("French tick label = %q, expected \"févr.\"", label)
+	}
+}
+
+func TestAxis_Ticks_SymlogScale(t *testing.T) {
+	scale := scales.NewSymlogScale(
+		[2]float64{-1000, 1000},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	axis := NewAxis(scale, AxisOrientationBottom)
+	axis.TickCount(10).TickFormat(SITickFormatter)
+
+	ticks := axis.Ticks()
+
+	expectedLabels := []string{"-1k", "-100", "-10", "-1", "0", "1", "10", "100", "1k"}
+	if len(ticks) != len(expectedLabels) {
+		t.Fatalf("Expected %d ticks, got %d", len(expectedLabels), len(ticks))
+	}
+
+	for i, tick := range ticks {
+		if tick.Label != expectedLabels[i] {
+			t.Errorf("Tick %d label = %q, expected %q", i, tick.Label, expectedLabels[i])
+		}
+	}
+
+	// Zero sits in the middle of the axis
+	if ticks[4].Position.Value != 250 {
+		t.Errorf("Zero tick position = %v, expected 250", ticks[4].Position.Value)
+	}
+}
+
 func TestAxis_Title(t *testing.T) {
 	scale := scales.NewLinearScale(
 		[2]float64{0, 100},
@@ -243,6 +302,18 @@ func TestTimeTickFormatter(t *testing.T) {
 	}
 }
 
+func TestLocaleTimeTickFormatter(t *testing.T) {
+	formatter := LocaleTimeTickFormatter("2. January 2006", &scales.GermanTimeLocale)
+	testTime := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	result := formatter(testTime)
+	expected := "15. März 2024"
+
+	if result != expected {
+		t.Errorf("LocaleTimeTickFormatter(2. January 2006)(%v) = %q, expected %q", testTime, result, expected)
+	}
+}
+
 func TestNumberTickFormatter(t *testing.T) {
 	formatter := NumberTickFormatter(3)
 
@@ -0,0 +1,375 @@
+package charts
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/SCKelemen/dataviz/charts/legends"
+	"github.com/SCKelemen/svg"
+	"github.com/SCKelemen/units"
+)
+
+// ArcOrder controls the order of nodes along an arc diagram's axis
+type ArcOrder string
+
+const (
+	ArcOrderInput   ArcOrder = "input"   // The order nodes are given in (default)
+	ArcOrderDegree  ArcOrder = "degree"  // Most connected nodes first, by total link value
+	ArcOrderGroup   ArcOrder = "group"   // Nodes of a group together, groups in order of first appearance
+	ArcOrderCluster ArcOrder = "cluster" // Average-linkage clustering of hop distances, so linked nodes sit close together
+)
+
+// ArcDiagramSpec configures arc diagram rendering. Nodes sit on a
+// horizontal line and each link is drawn as a semicircle above it, so the
+// height of an arc shows how far apart its nodes are in the order.
+type ArcDiagramSpec struct {
+	Nodes        []NetworkNode
+	Links        []NetworkLink // Self-links are not drawn
+	Width        float64
+	Height       float64
+	Order        ArcOrder // Node order along the axis (default: input)
+	Directed     bool     // Draw arrowheads at link targets
+	ShowLabels   bool     // Show node labels below the axis
+	ShowLegend   bool     // Show a legend of node groups
+	MinRadius    float64  // Radius of the smallest node (default: 4)
+	MaxRadius    float64  // Radius of the largest node (default: 10)
+	Colors       []string // Group colors, in order of first appearance
+	DefaultColor string   // Color of nodes without a group or color
+	LinkColor    string   // Default link color (default: the source node's color)
+	Title        string
+}
+
+// RenderArcDiagram generates an SVG arc diagram
+func RenderArcDiagram(spec ArcDiagramSpec) string {
+	n := len(spec.Nodes)
+	if n == 0 {
+		return ""
+	}
+
+	// Set defaults
+	if spec.MinRadius == 0 {
+		spec.MinRadius = 4
+	}
+	if spec.MaxRadius == 0 {
+		spec.MaxRadius = 10
+	}
+	if len(spec.Colors) == 0 {
+		spec.Colors = defaultPieColors
+	}
+	if spec.DefaultColor == "" {
+		spec.DefaultColor = "#3b82f6"
+	}
+
+	groupColors, groups := assignGroupColors(spec.Nodes, spec.Colors)
+	index := make(map[string]int, n)
+	for i, node := range spec.Nodes {
+		index[node.ID] = i
+	}
+
+	// Node area grows linearly with the value
+	radius := make([]float64, n)
+	maxValue := 0.0
+	for _, node := range spec.Nodes {
+		maxValue = math.Max(maxValue, node.Value)
+	}
+	for i, node := range spec.Nodes {
+		radius[i] = spec.MinRadius
+		if maxValue > 0 {
+			radius[i] += (spec.MaxRadius - spec.MinRadius) * math.Sqrt(node.Value/maxValue)
+		}
+	}
+
+	// Reserve room for the title, legend and labels
+	top := 10.0
+	if spec.Title != "" {
+		top = 36
+	}
+	right := 10.0
+	var legend *legends.Legend
+	if spec.ShowLegend && len(groups) > 0 {
+		legend = groupLegend(groups, groupColors, spec.DefaultColor)
+		right += legend.GetBounds(int(spec.Width), int(spec.Height)).Width
+	}
+	labelHeight := 0.0
+	if spec.ShowLabels {
+		labels := make([]string, n)
+		for i, node := range spec.Nodes {
+			labels[i] = networkLabel(node)
+		}
+		labelHeight = math.Min(estimateLabelWidth(labels, 10)+6, spec.Height/3)
+	}
+	left := 10 + spec.MaxRadius
+	right += spec.MaxRadius
+	baseline := spec.Height - 10 - labelHeight - spec.MaxRadius
+
+	// Place the nodes along the axis
+	x := make([]float64, n)
+	step := 0.0
+	if n > 1 {
+		step = (spec.Width - left - right) / float64(n-1)
+	}
+	for pos, i := range arcDiagramOrder(spec) {
+		x[i] = left + float64(pos)*step
+		if n == 1 {
+			x[i] = (left + spec.Width - right) / 2
+		}
+	}
+
+	// Arcs are semicircles unless the tallest would not fit, in which case
+	// every arc is flattened by the same factor
+	maxSpan := 0.0
+	for _, l := range spec.Links {
+		maxSpan = math.Max(maxSpan, math.Abs(x[index[l.Target]]-x[index[l.Source]]))
+	}
+	flatten := 1.0
+	if available := baseline - top; maxSpan/2 > available {
+		flatten = math.Max(available, 0) / (maxSpan / 2)
+	}
+
+	var result string
+
+	// Draw title
+	if spec.Title != "" {
+		titleStyle := svg.Style{
+			FontSize:         units.Px(16),
+			FontFamily:       "sans-serif",
+			FontWeight:       "bold",
+			TextAnchor:       svg.TextAnchorMiddle,
+			DominantBaseline: svg.DominantBaselineHanging,
+		}
+		result += svg.Text(spec.Title, spec.Width/2, 10, titleStyle) + "\n"
+	}
+
+	// Draw the axis and arcs under the nodes
+	axisStyle := svg.Style{Stroke: "#e5e7eb", StrokeWidth: 1}
+	result += svg.Line(left, baseline, spec.Width-right, baseline, axisStyle) + "\n"
+
+	maxLinkValue := 0.0
+	for _, l := range spec.Links {
+		maxLinkValue = math.Max(maxLinkValue, l.Value)
+	}
+	for _, l := range spec.Links {
+		s, t := index[l.Source], index[l.Target]
+		if s == t {
+			continue
+		}
+		width := 1.5
+		if maxLinkValue > 0 && l.Value > 0 {
+			width = 1 + 3*l.Value/maxLinkValue
+		}
+		linkColor := l.Color
+		if linkColor == "" {
+			linkColor = spec.LinkColor
+		}
+		if linkColor == "" {
+			linkColor = nodeColor(spec.Nodes[s], groupColors, spec.DefaultColor)
+		}
+
+		// Left-to-right arcs sweep clockwise over the top
+		r := math.Abs(x[t]-x[s]) / 2
+		sweep := 0
+		if x[t] > x[s] {
+			sweep = 1
+		}
+		d := fmt.Sprintf("M %.2f %.2f A %.2f %.2f 0 0 %d %.2f %.2f", x[s], baseline, r, r*flatten, sweep, x[t], baseline)
+		arcStyle := svg.Style{
+			Fill:          "none",
+			Stroke:        linkColor,
+			StrokeWidth:   width,
+			StrokeOpacity: 0.6,
+		}
+		result += svg.Path(d, arcStyle) + "\n"
+
+		// Arcs meet the axis vertically, so arrowheads point straight down
+		if spec.Directed {
+			arrowStyle := svg.Style{Fill: linkColor, FillOpacity: 0.9}
+			result += networkArrow(x[t], baseline-radius[t], 0, 1, 6+2*width, arrowStyle)
+		}
+	}
+
+	// Draw nodes
+	for i, node := range spec.Nodes {
+		nodeStyle := svg.Style{
+			Fill:        nodeColor(node, groupColors, spec.DefaultColor),
+			Stroke:      "#ffffff",
+			StrokeWidth: 1.5,
+		}
+		result += svg.Circle(x[i], baseline, radius[i], nodeStyle) + "\n"
+	}
+
+	// Draw labels, reading upward from below each node
+	if spec.ShowLabels {
+		labelStyle := svg.Style{
+			FontSize:         units.Px(10),
+			FontFamily:       "sans-serif",
+			Fill:             "#374151",
+			TextAnchor:       svg.TextAnchorEnd,
+			DominantBaseline: svg.DominantBaselineMiddle,
+		}
+		for i, node := range spec.Nodes {
+			label := svg.Text(networkLabel(node), 0, 0, labelStyle)
+			transform := fmt.Sprintf("translate(%.2f %.2f) rotate(-90)", x[i], baseline+spec.MaxRadius+4)
+			result += svg.Group(label, transform, svg.Style{}) + "\n"
+		}
+	}
+
+	if legend != nil {
+		result += legend.Render(int(spec.Width), int(spec.Height))
+	}
+
+	return result
+}
+
+// arcDiagramOrder returns the node indices in axis order
+func arcDiagramOrder(spec ArcDiagramSpec) []int {
+	n := len(spec.Nodes)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	index := make(map[string]int, n)
+	for i, node := range spec.Nodes {
+		index[node.ID] = i
+	}
+
+	switch spec.Order {
+	case ArcOrderDegree:
+		degree := make([]float64, n)
+		for _, l := range spec.Links {
+			w := l.Value
+			if w == 0 {
+				w = 1
+			}
+			degree[index[l.Source]] += w
+			degree[index[l.Target]] += w
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return degree[order[a]] > degree[order[b]]
+		})
+
+	case ArcOrderGroup:
+		rank := make(map[string]int)
+		for _, node := range spec.Nodes {
+			if _, ok := rank[node.Group]; !ok {
+				rank[node.Group] = len(rank)
+			}
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return rank[spec.Nodes[order[a]].Group] < rank[spec.Nodes[order[b]].Group]
+		})
+
+	case ArcOrderCluster:
+		if n < 3 {
+			return order
+		}
+		c, err := ClusterDistances(hopDistances(n, spec.Links, index), ClusterOptions{Linkage: LinkageAverage})
+		if err != nil {
+			return order
+		}
+		return c.Order()
+	}
+	return order
+}
+
+// hopDistances returns the number of links on the shortest undirected path
+// between each pair of nodes. Nodes in different components are n hops
+// apart, farther than any real path.
+func hopDistances(n int, links []NetworkLink, index map[string]int) [][]float64 {
+	adjacent := make([][]int, n)
+	for _, l := range links {
+		s, t := index[l.Source], index[l.Target]
+		if s != t {
+			adjacent[s] = append(adjacent[s], t)
+			adjacent[t] = append(adjacent[t], s)
+		}
+	}
+
+	dist := make([][]float64, n)
+	hops := make([]int, n)
+	for start := range dist {
+		for i := range hops {
+			hops[i] = -1
+		}
+		hops[start] = 0
+		queue := []int{start}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adjacent[u] {
+				if hops[v] < 0 {
+					hops[v] = hops[u] + 1
+					queue = append(queue, v)
+				}
+			}
+		}
+
+		dist[start] = make([]float64, n)
+		for i, h := range hops {
+			if h < 0 {
+				h = n
+			}
+			dist[start][i] = float64(h)
+		}
+	}
+	return dist
+}
+
+// Validate checks that node IDs are unique, links reference known nodes
+// and values are non-negative
+func (s ArcDiagramSpec) Validate() error {
+	if err := validateSize("arc", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Nodes) == 0 {
+		return &ValidationError{Chart: "arc", Field: "Nodes", Err: ErrEmptyData}
+	}
+	ids := make(map[string]bool, len(s.Nodes))
+	for i, n := range s.Nodes {
+		field := fmt.Sprintf("Nodes[%d]", i)
+		if n.ID == "" {
+			return invalid("arc", field+".ID", ErrMissingField, "node has no ID")
+		}
+		if ids[n.ID] {
+			return invalid("arc", field+".ID", ErrDuplicateID, "%q", n.ID)
+		}
+		ids[n.ID] = true
+		if err := validateNonNegative("arc", field+".Value", n.Value); err != nil {
+			return err
+		}
+	}
+	for i, l := range s.Links {
+		field := fmt.Sprintf("Links[%d]", i)
+		if !ids[l.Source] {
+			return invalid("arc", field+".Source", ErrUnknownReference, "no node %q", l.Source)
+		}
+		if !ids[l.Target] {
+			return invalid("arc", field+".Target", ErrUnknownReference, "no node %q", l.Target)
+		}
+		if err := validateNonNegative("arc", field+".Value", l.Value); err != nil {
+			return err
+		}
+	}
+	switch s.Order {
+	case "", ArcOrderInput, ArcOrderDegree, ArcOrderGroup, ArcOrderCluster:
+	default:
+		return invalid("arc", "Order", ErrUnsupported, "%q", s.Order)
+	}
+	for _, v := range []struct {
+		field string
+		value float64
+	}{{"MinRadius", s.MinRadius}, {"MaxRadius", s.MaxRadius}} {
+		if err := validateNonNegative("arc", v.field, v.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the arc diagram to SVG
+func (s ArcDiagramSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "arc", target, func() string {
+		return RenderArcDiagram(s)
+	})
+}
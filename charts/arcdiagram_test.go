@@ -0,0 +1,80 @@
+package charts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderArcDiagram(t *testing.T) {
+	spec := ArcDiagramSpec{
+		Nodes:      testNetwork().Nodes,
+		Links:      testNetwork().Links,
+		Width:      600,
+		Height:     400,
+		ShowLabels: true,
+	}
+	svg := RenderArcDiagram(spec)
+	if svg == "" {
+		t.Fatal("RenderArcDiagram returned empty output")
+	}
+	if n := strings.Count(svg, "<circle"); n != len(spec.Nodes) {
+		t.Errorf("got %d circles, expected %d", n, len(spec.Nodes))
+	}
+	if n := strings.Count(svg, " A "); n != len(spec.Links) {
+		t.Errorf("got %d arcs, expected %d", n, len(spec.Links))
+	}
+	if !strings.Contains(svg, "rotate(-90)") {
+		t.Error("labels should be rotated below the axis")
+	}
+	if strings.Contains(svg, "<polygon") {
+		t.Error("undirected diagram should not have arrowheads")
+	}
+
+	spec.Directed = true
+	svg = RenderArcDiagram(spec)
+	if n := strings.Count(svg, "<polygon"); n != len(spec.Links) {
+		t.Errorf("got %d arrowheads, expected %d", n, len(spec.Links))
+	}
+}
+
+func TestArcDiagramOrder(t *testing.T) {
+	// Two triangles listed interleaved, joined by one bridge link
+	nodes := []NetworkNode{
+		{ID: "a1", Group: "a"}, {ID: "b1", Group: "b"}, {ID: "a2", Group: "a"},
+		{ID: "b2", Group: "b"}, {ID: "a3", Group: "a"}, {ID: "b3", Group: "b"},
+	}
+	links := []NetworkLink{
+		{Source: "a1", Target: "a2"}, {Source: "a2", Target: "a3"}, {Source: "a3", Target: "a1"},
+		{Source: "b1", Target: "b2"}, {Source: "b2", Target: "b3"}, {Source: "b3", Target: "b1"},
+		{Source: "a3", Target: "b3", Value: 5},
+	}
+
+	tests := []struct {
+		order    ArcOrder
+		expected []int
+	}{
+		{ArcOrderInput, []int{0, 1, 2, 3, 4, 5}},
+		{ArcOrderDegree, []int{4, 5, 0, 1, 2, 3}},
+		{ArcOrderGroup, []int{0, 2, 4, 1, 3, 5}},
+	}
+	for _, tt := range tests {
+		order := arcDiagramOrder(ArcDiagramSpec{Nodes: nodes, Links: links, Order: tt.order})
+		if !reflect.DeepEqual(order, tt.expected) {
+			t.Errorf("%s order = %v, expected %v", tt.order, order, tt.expected)
+		}
+	}
+
+	// Clustering keeps each triangle together
+	order := arcDiagramOrder(ArcDiagramSpec{Nodes: nodes, Links: links, Order: ArcOrderCluster})
+	if len(order) != len(nodes) {
+		t.Fatalf("cluster order = %v", order)
+	}
+	first := nodes[order[0]].Group
+	for i, idx := range order {
+		if (nodes[idx].Group == first) != (i < 3) {
+			t.Errorf("cluster order %v splits a triangle", order)
+			break
+		}
+	}
+}
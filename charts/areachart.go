@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync/atomic"
@@ -19,34 +20,39 @@ import (
 func RenderAreaChart(data AreaChartData, x, y int, width, height int, designTokens *design.DesignTokens) string {
 	var b strings.Builder
 
-	if len(data.Points) == 0 {
+	// Reserve space for Y-axis labels
+	labelAreaWidth := 2 * designTokens.Layout.CardPaddingRight
+	plotWidth := width - labelAreaWidth
+
+	// Missing points split the area into runs, after thinning the points
+	// to what the plot's pixel columns can show
+	runs := timeSeriesRuns(decimate(data.Points, plotWidth), data.Gaps)
+	if len(runs) == 0 {
 		return ""
 	}
 
 	// Find min/max values for domain
-	minValue := data.Points[0].Value
-	maxValue := data.Points[0].Value
-	minTime := data.Points[0].Date
-	maxTime := data.Points[0].Date
-	for _, point := range data.Points {
-		if point.Value < minValue {
-			minValue = point.Value
-		}
-		if point.Value > maxValue {
-			maxValue = point.Value
-		}
-		if point.Date.Before(minTime) {
-			minTime = point.Date
-		}
-		if point.Date.After(maxTime) {
-			maxTime = point.Date
+	minValue := runs[0][0].Value
+	maxValue := runs[0][0].Value
+	minTime := runs[0][0].Date
+	maxTime := runs[0][0].Date
+	for _, run := range runs {
+		for _, point := range run {
+			if point.Value < minValue {
+				minValue = point.Value
+			}
+			if point.Value > maxValue {
+				maxValue = point.Value
+			}
+			if point.Date.Before(minTime) {
+				minTime = point.Date
+			}
+			if point.Date.After(maxTime) {
+				maxTime = point.Date
+			}
 		}
 	}
 
-	// Reserve space for Y-axis labels
-	labelAreaWidth := 2 * designTokens.Layout.CardPaddingRight
-	plotWidth := width - labelAreaWidth
-
 	// Create TimeScale for X-axis (dates to positions)
 	xScale := scales.NewTimeScale(
 		[2]time.Time{minTime, maxTime},
@@ -101,13 +107,19 @@ func RenderAreaChart(data AreaChartData, x, y int, width, height int, designToke
 	b.WriteString(yAxis.Render(axisOpts))
 
 	// Calculate scaled points using scales
-	scaledPoints := make([]svg.Point, len(data.Points))
-	for i, point := range data.Points {
-		scaledPoints[i] = svg.Point{
-			X: xScale.Apply(point.Date).Value,
-			Y: yScale.Apply(float64(point.Value)).Value,
+	scaledRuns := make([][]svg.Point, len(runs))
+	for i, run := range runs {
+		for _, point := range run {
+			scaledRuns[i] = append(scaledRuns[i], svg.Point{
+				X: xScale.Apply(point.Date).Value,
+				Y: yScale.Apply(float64(point.Value)).Value,
+			})
 		}
 	}
+	tension := data.Tension
+	if tension == 0 {
+		tension = 0.3 // Default tension
+	}
 
 	// Determine baseline using Y scale
 	baselineY := yScale.Apply(0.0).Value // Default to 0
@@ -116,17 +128,13 @@ func RenderAreaChart(data AreaChartData, x, y int, width, height int, designToke
 	}
 
 	// Draw filled area
-	if len(data.Points) > 1 {
-		var areaPath string
+	areaPath := runsPath(scaledRuns, func(run []svg.Point) string {
 		if data.Smooth {
-			tension := data.Tension
-			if tension == 0 {
-				tension = 0.3 // Default tension
-			}
-			areaPath = svg.SmoothAreaPath(scaledPoints, baselineY, tension)
-		} else {
-			areaPath = svg.AreaPath(scaledPoints, baselineY)
+			return svg.SmoothAreaPath(run, baselineY, tension)
 		}
+		return svg.AreaPath(run, baselineY)
+	})
+	if areaPath != "" {
 
 		pathStyle := svg.Style{
 			Fill: fillValue,
@@ -140,17 +148,13 @@ func RenderAreaChart(data AreaChartData, x, y int, width, height int, designToke
 	}
 
 	// Draw border line
-	if data.Color != "" && len(data.Points) > 1 {
-		var linePath string
+	linePath := runsPath(scaledRuns, func(run []svg.Point) string {
 		if data.Smooth {
-			tension := data.Tension
-			if tension == 0 {
-				tension = 0.3
-			}
-			linePath = svg.SmoothLinePath(scaledPoints, tension)
-		} else {
-			linePath = svg.PolylinePath(scaledPoints)
+			return svg.SmoothLinePath(run, tension)
 		}
+		return svg.PolylinePath(run)
+	})
+	if data.Color != "" && linePath != "" {
 
 		pathStyle := svg.Style{
 			Fill:           "none",
@@ -192,3 +196,26 @@ func RenderAreaChart(data AreaChartData, x, y int, width, height int, designToke
 
 	return b.String()
 }
+
+// AreaChartSpec renders area chart data through the Chart interface
+type AreaChartSpec struct {
+	Data   AreaChartData
+	Bounds Bounds
+	Config RenderConfig // A nil DesignTokens uses the default theme
+}
+
+// Validate checks that the area chart has points with values and a known
+// gap mode
+func (s AreaChartSpec) Validate() error {
+	if err := validateBounds("area-chart", s.Bounds); err != nil {
+		return err
+	}
+	return validateTimeSeries("area-chart", s.Data.Points, s.Data.Gaps)
+}
+
+// Render renders the area chart to SVG or the terminal
+func (s AreaChartSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderWith(ctx, s, "area-chart", target, func(r Renderer) Output {
+		return r.RenderAreaChart(s.Data, s.Bounds, s.Config.withDefaults())
+	})
+}
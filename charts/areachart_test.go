@@ -139,6 +139,29 @@ func TestRenderAreaChart_SinglePoint(t *testing.T) {
 	}
 }
 
+func TestRenderAreaChart_Gaps(t *testing.T) {
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []TimeSeriesData{
+		{Date: startDate, Value: 10},
+		{Date: startDate.AddDate(0, 0, 1), Value: 30},
+		{Date: startDate.AddDate(0, 0, 2), Missing: true},
+		{Date: startDate.AddDate(0, 0, 3), Value: 20},
+		{Date: startDate.AddDate(0, 0, 4), Value: 50},
+	}
+
+	tokens := design.DefaultTheme()
+	broken := RenderAreaChart(AreaChartData{Points: points, FillColor: "#10B981"}, 0, 0, 400, 200, tokens)
+	bridged := RenderAreaChart(AreaChartData{Points: points, FillColor: "#10B981", Gaps: GapBridge}, 0, 0, 400, 200, tokens)
+
+	// The area closes around each run separately, or around all points
+	if got := strings.Count(broken, "M "); got != 2 {
+		t.Errorf("Expected 2 areas with a gap, got %d", got)
+	}
+	if got := strings.Count(bridged, "M "); got != 1 {
+		t.Errorf("Expected 1 bridged area, got %d", got)
+	}
+}
+
 func BenchmarkRenderAreaChart(b *testing.B) {
 	startDate := time.Now()
 	points := make([]TimeSeriesData, 100)
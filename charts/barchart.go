@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -145,3 +146,36 @@ func RenderBarChart(data BarChartData, x, y int, width, height int, designTokens
 
 	return b.String()
 }
+
+// BarChartSpec renders bar chart data through the Chart interface
+type BarChartSpec struct {
+	Data   BarChartData
+	Bounds Bounds
+	Config RenderConfig // A nil DesignTokens uses the default theme
+}
+
+// Validate checks that the bar chart has bars with non-negative values
+func (s BarChartSpec) Validate() error {
+	if err := validateBounds("bar-chart", s.Bounds); err != nil {
+		return err
+	}
+	if len(s.Data.Bars) == 0 {
+		return &ValidationError{Chart: "bar-chart", Field: "Data.Bars", Err: ErrEmptyData}
+	}
+	for i, bar := range s.Data.Bars {
+		if bar.Value < 0 {
+			return invalid("bar-chart", fmt.Sprintf("Data.Bars[%d].Value", i), ErrNegativeValue, "%d", bar.Value)
+		}
+		if bar.Secondary < 0 {
+			return invalid("bar-chart", fmt.Sprintf("Data.Bars[%d].Secondary", i), ErrNegativeValue, "%d", bar.Secondary)
+		}
+	}
+	return nil
+}
+
+// Render renders the bar chart to SVG or the terminal
+func (s BarChartSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderWith(ctx, s, "bar-chart", target, func(r Renderer) Output {
+		return r.RenderBarChart(s.Data, s.Bounds, s.Config.withDefaults())
+	})
+}
@@ -1,6 +1,8 @@
 package charts
 
 import (
+	"context"
+	"fmt"
 	"math"
 	"sort"
 
@@ -311,3 +313,36 @@ func valueOrDefault(val *float64, def float64) float64 {
 	}
 	return def
 }
+
+// Validate checks that every box has values or pre-calculated statistics
+func (s BoxPlotSpec) Validate() error {
+	if err := validateSize("boxplot", s.Width, s.Height); err != nil {
+		return err
+	}
+	if s.Horizontal {
+		return invalid("boxplot", "Horizontal", ErrUnsupported, "horizontal box plots are not implemented")
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "boxplot", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, box := range s.Data {
+		field := fmt.Sprintf("Data[%d]", i)
+		if box == nil {
+			return invalid("boxplot", field, ErrMissingField, "nil box")
+		}
+		if box.Q1 != nil && box.Median != nil && box.Q3 != nil {
+			continue
+		}
+		if err := validateValues("boxplot", field+".Values", box.Values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the box plot to SVG
+func (s BoxPlotSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "boxplot", target, func() string {
+		return RenderVerticalBoxPlot(s)
+	})
+}
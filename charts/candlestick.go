@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/SCKelemen/dataviz/scales"
@@ -438,3 +439,83 @@ func RenderBollingerBands(data []CandlestickData, bands BollingerBands, xScale,
 
 	return result
 }
+
+// validateOHLC checks one open/high/low/close bar
+func validateOHLC(chart, field string, open, high, low, close float64) error {
+	for _, v := range []struct {
+		name  string
+		value float64
+	}{{"Open", open}, {"High", high}, {"Low", low}, {"Close", close}} {
+		if err := validateNumber(chart, field+"."+v.name, v.value); err != nil {
+			return err
+		}
+	}
+	if high < low {
+		return invalid(chart, field, ErrInvalidValue, "high %v is below low %v", high, low)
+	}
+	return nil
+}
+
+// Validate checks that the chart has scales and well-formed candles
+func (s CandlestickSpec) Validate() error {
+	if err := validateSize("candlestick", s.Width, s.Height); err != nil {
+		return err
+	}
+	if s.XScale == nil {
+		return &ValidationError{Chart: "candlestick", Field: "XScale", Err: ErrMissingField}
+	}
+	if s.YScale == nil {
+		return &ValidationError{Chart: "candlestick", Field: "YScale", Err: ErrMissingField}
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "candlestick", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, d := range s.Data {
+		field := fmt.Sprintf("Data[%d]", i)
+		if err := validateOHLC("candlestick", field, d.Open, d.High, d.Low, d.Close); err != nil {
+			return err
+		}
+		if s.ShowVolume {
+			if err := validateNonNegative("candlestick", field+".Volume", d.Volume); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Render renders the candlestick chart to SVG
+func (s CandlestickSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "candlestick", target, func() string {
+		return RenderCandlestick(s)
+	})
+}
+
+// Validate checks that the chart has scales and well-formed bars
+func (s OHLCSpec) Validate() error {
+	if err := validateSize("ohlc", s.Width, s.Height); err != nil {
+		return err
+	}
+	if s.XScale == nil {
+		return &ValidationError{Chart: "ohlc", Field: "XScale", Err: ErrMissingField}
+	}
+	if s.YScale == nil {
+		return &ValidationError{Chart: "ohlc", Field: "YScale", Err: ErrMissingField}
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "ohlc", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, d := range s.Data {
+		if err := validateOHLC("ohlc", fmt.Sprintf("Data[%d]", i), d.Open, d.High, d.Low, d.Close); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the OHLC chart to SVG
+func (s OHLCSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "ohlc", target, func() string {
+		return RenderOHLC(s)
+	})
+}
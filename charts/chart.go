@@ -0,0 +1,132 @@
+package charts
+
+import (
+	"context"
+	"fmt"
+
+	design "github.com/SCKelemen/design-system"
+)
+
+// Target selects the output format a chart is rendered to
+type Target string
+
+// Render targets
+const (
+	TargetSVG      Target = "svg"
+	TargetTerminal Target = "terminal"
+)
+
+// Chart is the common interface implemented by every chart spec.
+//
+// Validate reports why a spec cannot be rendered as a *ValidationError
+// wrapping one of the Err* kinds. Render validates the spec and renders it
+// to the target, returning ErrUnsupportedTarget for targets the chart does
+// not support and the context's error if it is already done.
+//
+// Example:
+//
+//	var chart charts.Chart = charts.SankeySpec{Nodes: nodes, Links: links, Width: 800, Height: 600}
+//	out, err := chart.Render(ctx, charts.TargetSVG)
+//	if errors.Is(err, charts.ErrCycle) {
+//	    // links form a loop
+//	}
+type Chart interface {
+	Validate() error
+	Render(ctx context.Context, target Target) (Output, error)
+}
+
+// renderSVG validates a chart and renders it with render, for charts that
+// only support SVG output
+func renderSVG(ctx context.Context, c Chart, name string, target Target, render func() string) (Output, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if target != TargetSVG {
+		return nil, unsupportedTarget(name, target)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return SVGOutput(render()), nil
+}
+
+// rendererFor returns the renderer for a target
+func rendererFor(name string, target Target) (Renderer, error) {
+	switch target {
+	case TargetSVG:
+		return NewSVGRenderer(), nil
+	case TargetTerminal:
+		return NewTerminalRenderer(), nil
+	}
+	return nil, unsupportedTarget(name, target)
+}
+
+// unsupportedTarget returns the error for a target a chart cannot render to
+func unsupportedTarget(name string, target Target) error {
+	return &ValidationError{Chart: name, Err: ErrUnsupportedTarget, Detail: fmt.Sprintf("%q", target)}
+}
+
+// renderWith validates a chart and renders it with the renderer for the
+// target, for charts that support both SVG and terminal output
+func renderWith(ctx context.Context, c Chart, name string, target Target, render func(Renderer) Output) (Output, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r, err := rendererFor(name, target)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return render(r), nil
+}
+
+// withDefaults returns the config with the default theme's design tokens
+// when none are set
+func (c RenderConfig) withDefaults() RenderConfig {
+	if c.DesignTokens == nil {
+		c.DesignTokens = design.DefaultTheme()
+	}
+	return c
+}
+
+// Every chart spec implements Chart
+var (
+	_ Chart = HeatmapSpec{}
+	_ Chart = LineGraphSpec{}
+	_ Chart = BarChartSpec{}
+	_ Chart = StatCardSpec{}
+	_ Chart = AreaChartSpec{}
+	_ Chart = ScatterPlotSpec{}
+	_ Chart = PieChartSpec{}
+	_ Chart = ArcDiagramSpec{}
+	_ Chart = BoxPlotSpec{}
+	_ Chart = CandlestickSpec{}
+	_ Chart = OHLCSpec{}
+	_ Chart = ChordDiagramSpec{}
+	_ Chart = CirclePackingSpec{}
+	_ Chart = ClusteredHeatmapSpec{}
+	_ Chart = CircularBarPlotSpec{}
+	_ Chart = ConnectedScatterSpec{}
+	_ Chart = CorrelogramSpec{}
+	_ Chart = DendrogramSpec{}
+	_ Chart = EdgeBundlingSpec{}
+	_ Chart = SimpleDensitySpec{}
+	_ Chart = HistogramSpec{}
+	_ Chart = DensityPlotSpec{}
+	_ Chart = IcicleSpec{}
+	_ Chart = LollipopSpec{}
+	_ Chart = NetworkSpec{}
+	_ Chart = ParallelCoordinatesSpec{}
+	_ Chart = RadarChartSpec{}
+	_ Chart = RidgelineSpec{}
+	_ Chart = SankeySpec{}
+	_ Chart = StackedAreaSpec{}
+	_ Chart = StreamChartSpec{}
+	_ Chart = SunburstSpec{}
+	_ Chart = TreeSpec{}
+	_ Chart = TreemapSpec{}
+	_ Chart = ViolinPlotSpec{}
+	_ Chart = WordCloudSpec{}
+)
@@ -0,0 +1,250 @@
+package charts
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SCKelemen/dataviz/kde"
+	"github.com/SCKelemen/dataviz/scales"
+	"github.com/SCKelemen/dataviz/transforms"
+	"github.com/SCKelemen/units"
+)
+
+func TestChartsRender(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	xScale := scales.NewBandScale([]string{"a", "b"}, [2]units.Length{units.Px(0), units.Px(400)})
+	yScale := scales.NewLinearScale([2]float64{0, 20}, [2]units.Length{units.Px(300), units.Px(0)})
+
+	tests := []struct {
+		name  string
+		chart Chart
+	}{
+		{"heatmap", HeatmapSpec{Data: HeatmapData{Days: []ContributionDay{{Date: day, Count: 3}}, StartDate: day, EndDate: day.AddDate(0, 0, 7)}, Bounds: Bounds{Width: 400, Height: 100}}},
+		{"line-graph", LineGraphSpec{Data: LineGraphData{Points: []TimeSeriesData{{Date: day, Value: 1}, {Date: day.AddDate(0, 0, 1), Value: 3}}}, Bounds: Bounds{Width: 400, Height: 200}}},
+		{"bar-chart", BarChartSpec{Data: BarChartData{Bars: []BarData{{Value: 3, Label: "a"}}}, Bounds: Bounds{Width: 400, Height: 200}}},
+		{"pie-chart", PieChartSpec{Data: PieChartData{Slices: []PieSlice{{Label: "a", Value: 1}, {Label: "b", Value: 2}}}, Bounds: Bounds{Width: 400, Height: 400}}},
+		{"candlestick", CandlestickSpec{Data: []CandlestickData{{X: "a", Open: 10, High: 15, Low: 5, Close: 12}}, Width: 400, Height: 300, XScale: xScale, YScale: yScale}},
+		{"treemap", TreemapSpec{Root: createTestTree(), Width: 400, Height: 300}},
+		{"binary treemap", TreemapSpec{Root: createTestTree(), Width: 400, Height: 300, Tiling: TreemapBinary}},
+		{"sankey", SankeySpec{
+			Nodes: []SankeyNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+			Links: []SankeyLink{{Source: "a", Target: "b", Value: 2}, {Source: "b", Target: "c", Value: 1}},
+			Width: 600, Height: 400,
+		}},
+		{"sankey with cycles", SankeySpec{
+			Nodes:       []SankeyNode{{ID: "a"}, {ID: "b"}},
+			Links:       []SankeyLink{{Source: "a", Target: "b", Value: 2}, {Source: "b", Target: "a", Value: 1}},
+			AllowCycles: true, LinkGradient: true, Width: 600, Height: 400,
+		}},
+		{"directed chord", ChordDiagramSpec{
+			Entities:  []ChordEntity{{ID: "a"}, {ID: "b"}},
+			Relations: []ChordRelation{{Source: "a", Target: "b", Value: 2}, {Source: "b", Target: "a", Value: 1}},
+			Directed:  true, SortGroups: ChordSortDescending, ShowTicks: true, Width: 600, Height: 600,
+		}},
+		{"masked wordcloud", WordCloudSpec{
+			Words: []WordCloudWord{{Text: "a", Frequency: 2}, {Text: "b", Frequency: 1}},
+			Shape: WordCloudShapePolygon, Mask: [][2]float64{{0.5, 0}, {1, 1}, {0, 1}}, Rotations: []float64{0, 90}, Width: 400, Height: 300,
+		}},
+		{"radial cluster tree", TreeSpec{Root: createTestTree(), Layout: TreeLayoutCluster, Orientation: TreeRadial, MaxDepth: 1, ShowLabels: true, Width: 400, Height: 400}},
+		{"histogram", HistogramSpec{Data: &HistogramData{Values: []float64{1, 2, 2, 3}}, Width: 400, Height: 300}},
+		{"radar", RadarChartSpec{
+			Axes:   []RadarAxis{{Label: "x", Max: 10}, {Label: "y", Max: 10}, {Label: "z", Max: 10}},
+			Series: []*RadarSeries{{Label: "s", Values: []float64{1, 5, 9}}},
+			Width:  400, Height: 400,
+		}},
+		{"network", NetworkSpec{
+			Nodes:    []NetworkNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+			Links:    []NetworkLink{{Source: "a", Target: "b"}, {Source: "b", Target: "c"}},
+			Directed: true, Width: 400, Height: 300,
+		}},
+		{"clustered-heatmap", ClusteredHeatmapSpec{
+			Values:      [][]float64{{1, 2}, {3, 4}, {1, 1}},
+			ClusterRows: true, ClusterColumns: true,
+			Width: 400, Height: 300,
+		}},
+		{"arc", ArcDiagramSpec{
+			Nodes: []NetworkNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+			Links: []NetworkLink{{Source: "a", Target: "c"}, {Source: "b", Target: "c"}},
+			Order: ArcOrderCluster, Width: 400, Height: 300,
+		}},
+		{"edge-bundling", EdgeBundlingSpec{
+			Root: &TreeNode{Name: "root", Children: []*TreeNode{
+				{Name: "x", Children: []*TreeNode{{Name: "a"}, {Name: "b"}}},
+				{Name: "y", Children: []*TreeNode{{Name: "c"}}},
+			}},
+			Links: []NetworkLink{{Source: "a", Target: "c"}},
+			Width: 400, Height: 400,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.chart.Render(context.Background(), TargetSVG)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if out.String() == "" {
+				t.Error("Render() returned empty output")
+			}
+		})
+	}
+}
+
+func TestChartsValidate(t *testing.T) {
+	cyclic := NewTreeNode("root", 0)
+	child := NewTreeNode("child", 0)
+	cyclic.AddChild(child)
+	child.AddChild(cyclic)
+
+	negative := NewTreeNode("root", 0)
+	negative.AddChild(NewTreeNode("a", 10)).AddChild(NewTreeNode("b", -5))
+
+	tests := []struct {
+		name     string
+		chart    Chart
+		expected error
+		field    string
+	}{
+		{"empty histogram", HistogramSpec{Data: &HistogramData{}, Width: 400, Height: 300}, ErrEmptyData, "Data.Values"},
+		{"zero width", HistogramSpec{Data: &HistogramData{Values: []float64{1}}, Height: 300}, ErrInvalidSize, "Width"},
+		{"NaN value", HistogramSpec{Data: &HistogramData{Values: []float64{1, math.NaN()}}, Width: 400, Height: 300}, ErrInvalidValue, "Data.Values[1]"},
+		{"negative pie slice", PieChartSpec{Data: PieChartData{Slices: []PieSlice{{Value: 1}, {Value: -2}}}, Bounds: Bounds{Width: 400, Height: 400}}, ErrNegativeValue, "Data.Slices[1].Value"},
+		{"negative treemap value", TreemapSpec{Root: negative, Width: 400, Height: 300}, ErrNegativeValue, "Root.Children[1].Value"},
+		{"unknown treemap tiling", TreemapSpec{Root: createTestTree(), Width: 400, Height: 300, Tiling: "spiral"}, ErrUnsupported, "Tiling"},
+		{"treemap ratio below 1", TreemapSpec{Root: createTestTree(), Width: 400, Height: 300, Ratio: 0.5}, ErrInvalidValue, "Ratio"},
+		{"cyclic treemap", TreemapSpec{Root: cyclic, Width: 400, Height: 300}, ErrCycle, "Root.Children[0].Children[0]"},
+		{"empty tree", SunburstSpec{Root: NewTreeNode("root", 0), Width: 400, Height: 400}, ErrEmptyData, "Root"},
+		{"sankey cycle", SankeySpec{
+			Nodes: []SankeyNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+			Links: []SankeyLink{{Source: "a", Target: "b", Value: 1}, {Source: "b", Target: "c", Value: 1}, {Source: "c", Target: "a", Value: 1}},
+			Width: 600, Height: 400,
+		}, ErrCycle, "Links"},
+		{"sankey unknown align", SankeySpec{
+			Nodes: []SankeyNode{{ID: "a"}, {ID: "b"}},
+			Links: []SankeyLink{{Source: "a", Target: "b", Value: 1}},
+			Align: "top", Width: 600, Height: 400,
+		}, ErrUnsupported, "Align"},
+		{"chord unknown sort", ChordDiagramSpec{
+			Entities:      []ChordEntity{{ID: "a"}},
+			Relations:     []ChordRelation{{Source: "a", Target: "a", Value: 1}},
+			SortSubgroups: "random", Width: 600, Height: 600,
+		}, ErrUnsupported, "SortSubgroups"},
+		{"wordcloud polygon without mask", WordCloudSpec{
+			Words: []WordCloudWord{{Text: "a", Frequency: 1}},
+			Shape: WordCloudShapePolygon, Mask: [][2]float64{{0, 0}, {1, 1}}, Width: 400, Height: 300,
+		}, ErrMissingField, "Mask"},
+		{"wordcloud unknown spiral", WordCloudSpec{
+			Words:  []WordCloudWord{{Text: "a", Frequency: 1}},
+			Spiral: "fermat", Width: 400, Height: 300,
+		}, ErrUnsupported, "Spiral"},
+		{"sankey unknown node", SankeySpec{
+			Nodes: []SankeyNode{{ID: "a"}},
+			Links: []SankeyLink{{Source: "a", Target: "z", Value: 1}},
+			Width: 600, Height: 400,
+		}, ErrUnknownReference, "Links[0].Target"},
+		{"sankey duplicate node", SankeySpec{
+			Nodes: []SankeyNode{{ID: "a"}, {ID: "a"}},
+			Links: []SankeyLink{{Source: "a", Target: "a", Value: 1}},
+			Width: 600, Height: 400,
+		}, ErrDuplicateID, "Nodes[1].ID"},
+		{"radar mismatched lengths", RadarChartSpec{
+			Axes:   []RadarAxis{{Label: "x"}, {Label: "y"}},
+			Series: []*RadarSeries{{Values: []float64{1}}},
+			Width:  400, Height: 400,
+		}, ErrMismatchedLengths, "Series[0].Values"},
+		{"stacked area mismatched lengths", StackedAreaSpec{
+			Points: []StackedAreaPoint{{X: 0, Values: []float64{1, 2}}},
+			Series: []StackedAreaSeries{{Label: "a"}},
+			Width:  400, Height: 300,
+		}, ErrMismatchedLengths, "Points[0].Values"},
+		{"non-square correlogram", CorrelogramSpec{
+			Data:  CorrelationMatrix{Variables: []string{"a", "b"}, Matrix: [][]float64{{1, 0.5}, {0.5}}},
+			Width: 400, Height: 400,
+		}, ErrMismatchedLengths, "Data.Matrix[1]"},
+		{"candlestick without scales", CandlestickSpec{Data: []CandlestickData{{Open: 1, High: 2, Low: 0, Close: 1}}, Width: 400, Height: 300}, ErrMissingField, "XScale"},
+		{"horizontal boxplot", BoxPlotSpec{Data: []*BoxPlotData{{Values: []float64{1, 2}}}, Width: 400, Height: 300, Horizontal: true}, ErrUnsupported, "Horizontal"},
+		{"network unknown node", NetworkSpec{
+			Nodes: []NetworkNode{{ID: "a"}}, Links: []NetworkLink{{Source: "a", Target: "b"}}, Width: 400, Height: 300,
+		}, ErrUnknownReference, "Links[0].Target"},
+		{"network duplicate node", NetworkSpec{Nodes: []NetworkNode{{ID: "a"}, {ID: "a"}}, Width: 400, Height: 300}, ErrDuplicateID, "Nodes[1].ID"},
+		{"ragged clustered heatmap", ClusteredHeatmapSpec{Values: [][]float64{{1, 2}, {3}}, Width: 400, Height: 300}, ErrMismatchedLengths, "Values[1]"},
+		{"unclusterable columns", ClusteredHeatmapSpec{
+			Values: [][]float64{{1, 0}, {2, 0}}, ClusterColumns: true, Metric: DistanceCosine, Width: 400, Height: 300,
+		}, ErrInvalidValue, "ClusterColumns"},
+		{"unknown arc order", ArcDiagramSpec{Nodes: []NetworkNode{{ID: "a"}}, Order: "alphabetical", Width: 400, Height: 300}, ErrUnsupported, "Order"},
+		{"edge bundling unknown leaf", EdgeBundlingSpec{
+			Root:  &TreeNode{Name: "root", Children: []*TreeNode{{Name: "a"}, {Name: "b"}}},
+			Links: []NetworkLink{{Source: "a", Target: "root"}}, Width: 400, Height: 400,
+		}, ErrUnknownReference, "Links[0].Target"},
+		{"line graph unknown gaps", LineGraphSpec{Data: LineGraphData{Points: []TimeSeriesData{{Value: 1}}, Gaps: "skip"}, Bounds: Bounds{Width: 400, Height: 200}}, ErrUnsupported, "Data.Gaps"},
+		{"scatter unknown trendline", ScatterPlotSpec{Data: ScatterPlotData{Points: []ScatterPoint{{Value: 1}}, Trend: &Trendline{Regression: transforms.RegressionOptions{Method: "spline"}}}, Bounds: Bounds{Width: 400, Height: 200}}, ErrUnsupported, "Data.Trend.Regression.Method"},
+		{"connected scatter unknown interval", ConnectedScatterSpec{Series: []*ConnectedScatterSeries{{Points: []ConnectedScatterPoint{{X: 1, Y: 1}}, Trend: &Trendline{Regression: transforms.RegressionOptions{Interval: "credible"}}}}, Width: 400, Height: 300}, ErrUnsupported, "Series[0].Trend.Regression.Interval"},
+		{"area chart all missing", AreaChartSpec{Data: AreaChartData{Points: []TimeSeriesData{{Missing: true}}}, Bounds: Bounds{Width: 400, Height: 200}}, ErrEmptyData, "Data.Points"},
+		{"tree unknown orientation", TreeSpec{Root: createTestTree(), Orientation: "diagonal", Width: 400, Height: 400}, ErrUnsupported, "Orientation"},
+		{"tree negative depth", TreeSpec{Root: createTestTree(), MaxDepth: -1, Width: 400, Height: 400}, ErrNegativeValue, "MaxDepth"},
+		{"edge bundling tension", EdgeBundlingSpec{Root: &TreeNode{Name: "a"}, Tension: ptr(1.5), Width: 400, Height: 400}, ErrInvalidValue, "Tension"},
+		{"violin unknown kernel", ViolinPlotSpec{Data: []*ViolinPlotData{{Values: []float64{1, 2}}}, KDE: kde.Options{Kernel: "cosine"}, Width: 400, Height: 300}, ErrUnsupported, "KDE.Kernel"},
+		{"density mismatched weights", SimpleDensitySpec{Data: []*SimpleDensityData{{Values: []float64{1, 2}, Weights: []float64{1}}}, Width: 400, Height: 300}, ErrMismatchedLengths, "Data[0].Weights"},
+		{"ridgeline negative weight", RidgelineSpec{Data: []*RidgelineData{{Values: []float64{1, 2}, Weights: []float64{1, -1}}}, Width: 400, Height: 300}, ErrNegativeValue, "Data[0].Weights[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.chart.Validate()
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Validate() error = %v, expected %v", err, tt.expected)
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error %T is not a *ValidationError", err)
+			}
+			if verr.Field != tt.field {
+				t.Errorf("ValidationError.Field = %q, expected %q", verr.Field, tt.field)
+			}
+
+			// Render reports the same error instead of an empty string
+			if _, err := tt.chart.Render(context.Background(), TargetSVG); !errors.Is(err, tt.expected) {
+				t.Errorf("Render() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := invalid("sankey", "Links[2].Value", ErrNegativeValue, "%v", -3.0)
+	expected := "sankey: Links[2].Value: negative value: -3"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q, expected %q", err.Error(), expected)
+	}
+}
+
+func TestChartRenderTargets(t *testing.T) {
+	bar := BarChartSpec{Data: BarChartData{Bars: []BarData{{Value: 3, Label: "a"}}}, Bounds: Bounds{Width: 40, Height: 10}}
+
+	out, err := bar.Render(context.Background(), TargetTerminal)
+	if err != nil {
+		t.Fatalf("Render(terminal) error = %v", err)
+	}
+	if strings.Contains(out.String(), "<svg") || strings.Contains(out.String(), "<rect") {
+		t.Error("Render(terminal) returned SVG")
+	}
+
+	tree := TreemapSpec{Root: createTestTree(), Width: 400, Height: 300}
+	if _, err := tree.Render(context.Background(), TargetTerminal); !errors.Is(err, ErrUnsupportedTarget) {
+		t.Errorf("Render(terminal) error = %v, expected %v", err, ErrUnsupportedTarget)
+	}
+	if _, err := tree.Render(context.Background(), Target("pdf")); !errors.Is(err, ErrUnsupportedTarget) {
+		t.Errorf("Render(pdf) error = %v, expected %v", err, ErrUnsupportedTarget)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := tree.Render(ctx, TargetSVG); !errors.Is(err, context.Canceled) {
+		t.Errorf("Render(canceled) error = %v, expected %v", err, context.Canceled)
+	}
+}
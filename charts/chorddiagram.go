@@ -1,8 +1,11 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
 
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
@@ -22,20 +25,66 @@ type ChordRelation struct {
 	Value  float64 // Relationship strength (determines chord width)
 }
 
-// ChordDiagramSpec configures chord diagram rendering
+// ChordSort orders entities around the circle or flows within an
+// entity's arc
+type ChordSort string
+
+const (
+	ChordSortInput      ChordSort = "input"      // The order given (default)
+	ChordSortAscending  ChordSort = "ascending"  // Smallest first
+	ChordSortDescending ChordSort = "descending" // Largest first
+)
+
+// ChordDiagramSpec configures chord diagram rendering.
+//
+// By default relations are undirected: each pair of entities shares one
+// ribbon, and the end at each entity is as wide as the flow that entity
+// sends, so A->B and B->A flows of different sizes give a ribbon with ends
+// of different widths. A relation given in one direction only is drawn
+// symmetrically. With Directed set, every relation gets its own ribbon
+// running from the source's outgoing flows to an arrow tip among the
+// target's incoming flows.
 type ChordDiagramSpec struct {
 	Entities      []ChordEntity
 	Relations     []ChordRelation
 	Width         float64
 	Height        float64
-	InnerRadius   float64 // Inner radius for entity arcs (auto if 0)
-	ArcWidth      float64 // Width of entity arcs (default: 20)
-	ArcPadding    float64 // Padding between entity arcs in degrees (default: 2)
-	DefaultColor  string  // Default entity color
-	ShowLabels    bool    // Show entity labels
+	InnerRadius   float64   // Inner radius for entity arcs (auto if 0)
+	ArcWidth      float64   // Width of entity arcs (default: 20)
+	ArcPadding    float64   // Padding between entity arcs in degrees (default: 2)
+	Directed      bool      // Draw one arrow-tipped ribbon per relation
+	SortGroups    ChordSort // Order of entities around the circle, by total flow
+	SortSubgroups ChordSort // Order of flows within each entity's arc, by value
+	ShowTicks     bool      // Ring each arc with ticks labeled with cumulative totals
+	TickStep      float64   // Value between ticks (auto if 0)
+	DefaultColor  string    // Default entity color
+	ShowLabels    bool      // Show entity labels
 	Title         string
 }
 
+// chordGroup is an entity's arc, in radians clockwise from 12 o'clock
+type chordGroup struct {
+	entity     int
+	startAngle float64
+	endAngle   float64
+	value      float64
+}
+
+// chordEnd is one end of a ribbon: the part of an entity's arc a flow
+// occupies
+type chordEnd struct {
+	entity     int
+	startAngle float64
+	endAngle   float64
+	value      float64
+}
+
+// chordRibbon joins two entities. In undirected layouts the source is the
+// end with the larger flow.
+type chordRibbon struct {
+	source, target chordEnd
+}
+
 // RenderChordDiagram generates an SVG chord diagram
 func RenderChordDiagram(spec ChordDiagramSpec) string {
 	if len(spec.Entities) == 0 || len(spec.Relations) == 0 {
@@ -57,6 +106,9 @@ func RenderChordDiagram(spec ChordDiagramSpec) string {
 	centerX := spec.Width / 2
 	centerY := spec.Height / 2
 	margin := 80.0
+	if spec.ShowTicks {
+		margin += 25 // Room for the tick ring and its labels
+	}
 	maxRadius := math.Min(spec.Width, spec.Height)/2 - margin
 
 	// Set inner radius
@@ -65,49 +117,20 @@ func RenderChordDiagram(spec ChordDiagramSpec) string {
 		innerRadius = maxRadius - spec.ArcWidth
 	}
 
-	// Build entity map
-	entityMap := make(map[string]*ChordEntity)
-	for i := range spec.Entities {
-		entityMap[spec.Entities[i].ID] = &spec.Entities[i]
-	}
+	groups, ribbons := layoutChord(spec)
 
-	// Calculate total value for each entity (sum of all relationships)
-	entityTotals := make(map[string]float64)
-	for _, rel := range spec.Relations {
-		entityTotals[rel.Source] += rel.Value
-		if rel.Source != rel.Target { // Don't double-count self-loops
-			entityTotals[rel.Target] += rel.Value
+	// Entity colors
+	defaultColors := []string{"#3b82f6", "#10b981", "#f59e0b", "#ef4444", "#8b5cf6", "#ec4899"}
+	colors := make([]string, len(spec.Entities))
+	for i, entity := range spec.Entities {
+		colors[i] = entity.Color
+		if colors[i] == "" {
+			colors[i] = defaultColors[i%len(defaultColors)]
 		}
 	}
 
-	// Calculate total of all relationships
-	totalValue := 0.0
-	for _, total := range entityTotals {
-		totalValue += total
-	}
-	if totalValue == 0 {
-		totalValue = 1
-	}
-
-	// Calculate total available angle (360 - padding between arcs)
-	totalPadding := spec.ArcPadding * float64(len(spec.Entities))
-	availableAngle := 360.0 - totalPadding
-
-	// Assign arc angles to each entity based on their total value
-	entityArcs := make(map[string]arcSegment)
-	currentAngle := 0.0
-
-	for _, entity := range spec.Entities {
-		total := entityTotals[entity.ID]
-		arcAngle := (total / totalValue) * availableAngle
-
-		entityArcs[entity.ID] = arcSegment{
-			startAngle: currentAngle,
-			endAngle:   currentAngle + arcAngle,
-			value:      total,
-		}
-
-		currentAngle += arcAngle + spec.ArcPadding
+	point := func(r, angle float64) (float64, float64) {
+		return centerX + r*math.Sin(angle), centerY - r*math.Cos(angle)
 	}
 
 	var result string
@@ -125,76 +148,59 @@ func RenderChordDiagram(spec ChordDiagramSpec) string {
 	}
 
 	// Draw chords (relationships) first, so they appear below arcs
-	for _, rel := range spec.Relations {
-		sourceArc, sourceExists := entityArcs[rel.Source]
-		targetArc, targetExists := entityArcs[rel.Target]
-
-		if !sourceExists || !targetExists {
-			continue
-		}
-
-		// Calculate chord positions
-		// For simplicity, center the chord on each arc
-		sourceAngle := (sourceArc.startAngle + sourceArc.endAngle) / 2
-		targetAngle := (targetArc.startAngle + targetArc.endAngle) / 2
-
-		// Create chord ribbon
-		chordPath := createChordRibbon(centerX, centerY, innerRadius, sourceAngle, targetAngle, rel.Value/sourceArc.value*20)
-
-		// Get chord color (use source entity color)
-		chordColor := spec.DefaultColor
-		if entity, ok := entityMap[rel.Source]; ok && entity.Color != "" {
-			chordColor = entity.Color
-		}
-
+	headRadius := 0.0
+	if spec.Directed {
+		headRadius = math.Min(10, innerRadius*0.05)
+	}
+	for _, ribbon := range ribbons {
 		chordStyle := svg.Style{
-			Fill:        chordColor,
+			Fill:        colors[ribbon.source.entity],
 			FillOpacity: 0.5,
 			Stroke:      "none",
 		}
-		result += svg.Path(chordPath, chordStyle) + "\n"
+		result += svg.Path(createChordRibbon(centerX, centerY, innerRadius, ribbon, headRadius), chordStyle) + "\n"
 	}
 
 	// Draw entity arcs
-	defaultColors := []string{"#3b82f6", "#10b981", "#f59e0b", "#ef4444", "#8b5cf6", "#ec4899"}
-
-	for idx, entity := range spec.Entities {
-		arc, exists := entityArcs[entity.ID]
-		if !exists {
-			continue
-		}
-
-		// Get entity color
-		entityColor := entity.Color
-		if entityColor == "" {
-			entityColor = defaultColors[idx%len(defaultColors)]
-		}
+	tickStep := spec.TickStep
+	if tickStep <= 0 {
+		tickStep = chordTickStep(groups)
+	}
+	for _, group := range groups {
+		entity := spec.Entities[group.entity]
+		startDeg := group.startAngle * 180 / math.Pi
+		endDeg := group.endAngle * 180 / math.Pi
 
 		// Draw arc
-		arcPath := createAnnularSector(centerX, centerY, innerRadius, maxRadius, arc.startAngle, arc.endAngle)
+		arcPath := createAnnularSector(centerX, centerY, innerRadius, maxRadius, startDeg, endDeg)
 
 		arcStyle := svg.Style{
-			Fill:        entityColor,
+			Fill:        colors[group.entity],
 			Stroke:      "#ffffff",
 			StrokeWidth: 1,
 		}
 		result += svg.Path(arcPath, arcStyle) + "\n"
 
+		if spec.ShowTicks {
+			result += chordTicks(group, tickStep, maxRadius, point)
+		}
+
 		// Draw label
 		if spec.ShowLabels && entity.Label != "" {
 			// Calculate label position (middle of arc, outside radius)
-			midAngle := (arc.startAngle + arc.endAngle) / 2
-			angleRad := (midAngle - 90) * math.Pi / 180
+			midAngle := (group.startAngle + group.endAngle) / 2
 
 			labelDistance := maxRadius + 15
-			labelX := centerX + labelDistance*math.Cos(angleRad)
-			labelY := centerY + labelDistance*math.Sin(angleRad)
+			if spec.ShowTicks {
+				labelDistance += 25
+			}
+			labelX, labelY := point(labelDistance, midAngle)
 
 			// Adjust text anchor based on position
 			var textAnchor string
-			if math.Abs(math.Cos(angleRad)) < 0.1 {
+			if math.Abs(math.Sin(midAngle)) < 0.1 {
 				textAnchor = "middle"
-			} else if math.Cos(angleRad) > 0 {
+			} else if math.Sin(midAngle) > 0 {
 				textAnchor = "start"
 			} else {
 				textAnchor = "end"
@@ -213,52 +219,254 @@ func RenderChordDiagram(spec ChordDiagramSpec) string {
 	return result
 }
 
-// arcSegment stores the angular segment for an entity
-type arcSegment struct {
-	startAngle float64
-	endAngle   float64
-	value      float64
+// layoutChord sizes each entity's arc by its flows and places the ribbon
+// ends within the arcs, following d3-chord. Undirected layouts give each
+// entity one subgroup per partner; directed layouts give it subgroups for
+// incoming flows, in reverse partner order, followed by outgoing flows.
+func layoutChord(spec ChordDiagramSpec) ([]chordGroup, []chordRibbon) {
+	n := len(spec.Entities)
+	index := make(map[string]int, n)
+	for i, e := range spec.Entities {
+		index[e.ID] = i
+	}
+
+	// matrix[i][j] is the flow from i to j
+	matrix := make([][]float64, n)
+	given := make([][]bool, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		given[i] = make([]bool, n)
+	}
+	for _, rel := range spec.Relations {
+		i, ok1 := index[rel.Source]
+		j, ok2 := index[rel.Target]
+		if !ok1 || !ok2 {
+			continue
+		}
+		matrix[i][j] += rel.Value
+		given[i][j] = true
+	}
+
+	// A subgroup is a flow within an entity's arc: to partner j, or from
+	// partner ^j in directed layouts
+	type subgroup struct {
+		partner int
+		value   float64
+	}
+	subgroups := make([][]subgroup, n)
+	values := make([]float64, n)
+	for i := 0; i < n; i++ {
+		if spec.Directed {
+			for j := n - 1; j >= 0; j-- {
+				if given[j][i] {
+					subgroups[i] = append(subgroups[i], subgroup{^j, matrix[j][i]})
+				}
+			}
+			for j := 0; j < n; j++ {
+				if given[i][j] {
+					subgroups[i] = append(subgroups[i], subgroup{j, matrix[i][j]})
+				}
+			}
+		} else {
+			for j := 0; j < n; j++ {
+				// A relation given one way only is symmetric
+				switch {
+				case given[i][j]:
+					subgroups[i] = append(subgroups[i], subgroup{j, matrix[i][j]})
+				case given[j][i]:
+					subgroups[i] = append(subgroups[i], subgroup{j, matrix[j][i]})
+				}
+			}
+		}
+		for _, sg := range subgroups[i] {
+			values[i] += sg.value
+		}
+		if spec.SortSubgroups != "" && spec.SortSubgroups != ChordSortInput {
+			descending := spec.SortSubgroups == ChordSortDescending
+			sort.SliceStable(subgroups[i], func(a, b int) bool {
+				if descending {
+					return subgroups[i][a].value > subgroups[i][b].value
+				}
+				return subgroups[i][a].value < subgroups[i][b].value
+			})
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	if spec.SortGroups != "" && spec.SortGroups != ChordSortInput {
+		descending := spec.SortGroups == ChordSortDescending
+		sort.SliceStable(order, func(a, b int) bool {
+			if descending {
+				return values[order[a]] > values[order[b]]
+			}
+			return values[order[a]] < values[order[b]]
+		})
+	}
+
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	pad := spec.ArcPadding * math.Pi / 180
+	k := 0.0
+	if total > 0 {
+		k = math.Max(0, 2*math.Pi-pad*float64(n)) / total
+	}
+
+	// Walk the circle, recording where each flow sits
+	groups := make([]chordGroup, 0, n)
+	outEnds := make(map[[2]int]chordEnd)
+	inEnds := make(map[[2]int]chordEnd)
+	angle := 0.0
+	for _, i := range order {
+		group := chordGroup{entity: i, startAngle: angle, value: values[i]}
+		for _, sg := range subgroups[i] {
+			end := chordEnd{entity: i, startAngle: angle, endAngle: angle + sg.value*k, value: sg.value}
+			if sg.partner < 0 {
+				inEnds[[2]int{^sg.partner, i}] = end
+			} else {
+				outEnds[[2]int{i, sg.partner}] = end
+			}
+			angle = end.endAngle
+		}
+		group.endAngle = angle
+		groups = append(groups, group)
+		angle += pad
+	}
+
+	var ribbons []chordRibbon
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if spec.Directed {
+				if given[i][j] {
+					ribbons = append(ribbons, chordRibbon{source: outEnds[[2]int{i, j}], target: inEnds[[2]int{i, j}]})
+				}
+				continue
+			}
+			if j < i || !(given[i][j] || given[j][i]) {
+				continue
+			}
+			a, b := outEnds[[2]int{i, j}], outEnds[[2]int{j, i}]
+			if a.value < b.value {
+				a, b = b, a
+			}
+			ribbons = append(ribbons, chordRibbon{source: a, target: b})
+		}
+	}
+	return groups, ribbons
 }
 
-// createChordRibbon creates a bezier ribbon connecting two points on a circle
-func createChordRibbon(cx, cy, radius, angle1Deg, angle2Deg, width float64) string {
-	// Convert angles to radians
-	angle1 := (angle1Deg - 90) * math.Pi / 180
-	angle2 := (angle2Deg - 90) * math.Pi / 180
-
-	// Calculate points on the circle
-	x1 := cx + radius*math.Cos(angle1)
-	y1 := cy + radius*math.Sin(angle1)
-	x2 := cx + radius*math.Cos(angle2)
-	y2 := cy + radius*math.Sin(angle2)
-
-	// Calculate perpendicular offsets for width
-	offset1X := -width/2 * math.Sin(angle1)
-	offset1Y := width/2 * math.Cos(angle1)
-	offset2X := -width/2 * math.Sin(angle2)
-	offset2Y := width/2 * math.Cos(angle2)
-
-	// Start and end points with width
-	x1a := x1 + offset1X
-	y1a := y1 + offset1Y
-	x1b := x1 - offset1X
-	y1b := y1 - offset1Y
-	x2a := x2 + offset2X
-	y2a := y2 + offset2Y
-	x2b := x2 - offset2X
-	y2b := y2 - offset2Y
-
-	// Create quadratic bezier curve through center
-	// Control point is at the center for a nice arc
-	path := fmt.Sprintf("M %.2f %.2f ", x1a, y1a)
-	path += fmt.Sprintf("Q %.2f %.2f %.2f %.2f ", cx, cy, x2a, y2a)
-	path += fmt.Sprintf("L %.2f %.2f ", x2b, y2b)
-	path += fmt.Sprintf("Q %.2f %.2f %.2f %.2f ", cx, cy, x1b, y1b)
+// createChordRibbon creates a ribbon between two ends on a circle, curving
+// through the center. With a head radius the target end narrows to an
+// arrow tip on the circle.
+func createChordRibbon(cx, cy, radius float64, ribbon chordRibbon, headRadius float64) string {
+	point := func(r, angle float64) (float64, float64) {
+		return cx + r*math.Sin(angle), cy - r*math.Cos(angle)
+	}
+	arc := func(r, a0, a1 float64) string {
+		x, y := point(r, a1)
+		large := 0
+		if a1-a0 > math.Pi {
+			large = 1
+		}
+		return fmt.Sprintf("A %.2f %.2f 0 %d 1 %.2f %.2f ", r, r, large, x, y)
+	}
+
+	s, t := ribbon.source, ribbon.target
+	sx, sy := point(radius, s.startAngle)
+	path := fmt.Sprintf("M %.2f %.2f ", sx, sy)
+	path += arc(radius, s.startAngle, s.endAngle)
+	if s != t {
+		if headRadius > 0 {
+			tr := radius - headRadius
+			x0, y0 := point(tr, t.startAngle)
+			tipX, tipY := point(radius, (t.startAngle+t.endAngle)/2)
+			x1, y1 := point(tr, t.endAngle)
+			path += fmt.Sprintf("Q %.2f %.2f %.2f %.2f ", cx, cy, x0, y0)
+			path += fmt.Sprintf("L %.2f %.2f L %.2f %.2f ", tipX, tipY, x1, y1)
+		} else {
+			tx, ty := point(radius, t.startAngle)
+			path += fmt.Sprintf("Q %.2f %.2f %.2f %.2f ", cx, cy, tx, ty)
+			path += arc(radius, t.startAngle, t.endAngle)
+		}
+	}
+	path += fmt.Sprintf("Q %.2f %.2f %.2f %.2f ", cx, cy, sx, sy)
 	path += "Z"
 
 	return path
 }
 
+// chordTickStep picks a round value between ticks giving about 80 ticks
+// around the circle
+func chordTickStep(groups []chordGroup) float64 {
+	total := 0.0
+	for _, g := range groups {
+		total += g.value
+	}
+	if total <= 0 {
+		return 1
+	}
+	raw := total / 80
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, f := range []float64{1, 2, 5} {
+		if f*magnitude >= raw {
+			return f * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// chordTicks draws ticks outside an arc at each multiple of the step from
+// the arc's start, labeling every fifth with the cumulative total
+func chordTicks(group chordGroup, step, radius float64, point func(r, angle float64) (float64, float64)) string {
+	if group.value <= 0 || group.endAngle <= group.startAngle {
+		return ""
+	}
+	k := (group.endAngle - group.startAngle) / group.value
+
+	tickStyle := svg.Style{Stroke: "#6b7280", StrokeWidth: 1}
+	labelStyle := svg.Style{
+		FontSize:         units.Px(9),
+		FontFamily:       "sans-serif",
+		Fill:             "#6b7280",
+		DominantBaseline: svg.DominantBaselineMiddle,
+	}
+
+	var result string
+	for i := 0; float64(i)*step <= group.value*(1+1e-9); i++ {
+		value := float64(i) * step
+		angle := group.startAngle + value*k
+		major := i%5 == 0
+		length := 3.0
+		if major {
+			length = 6
+		}
+		x1, y1 := point(radius+2, angle)
+		x2, y2 := point(radius+2+length, angle)
+		result += svg.Line(x1, y1, x2, y2, tickStyle) + "\n"
+
+		if major {
+			// Labels read outward, flipped on the left half
+			degrees := angle*180/math.Pi - 90
+			labelStyle.TextAnchor = svg.TextAnchorStart
+			offset := 2.0
+			if angle > math.Pi {
+				degrees += 180
+				labelStyle.TextAnchor = svg.TextAnchorEnd
+				offset = -offset
+			}
+			x, y := point(radius+10, angle)
+			label := svg.Text(strconv.FormatFloat(value, 'g', 4, 64), offset, 0, labelStyle)
+			transform := fmt.Sprintf("translate(%.2f %.2f) rotate(%.2f)", x, y, degrees)
+			result += svg.Group(label, transform, svg.Style{}) + "\n"
+		}
+	}
+	return result
+}
+
 // ChordDiagramFromMatrix creates a chord diagram from an adjacency matrix
 // entities are the entity labels
 // matrix is a square matrix where matrix[i][j] is the relationship from entity i to entity j
@@ -308,3 +516,55 @@ func ChordDiagramFromMatrix(entities []string, matrix [][]float64, width, height
 
 	return RenderChordDiagram(spec)
 }
+
+// Validate checks that relations reference known entities and have
+// non-negative values
+func (s ChordDiagramSpec) Validate() error {
+	if err := validateSize("chord", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Entities) == 0 {
+		return &ValidationError{Chart: "chord", Field: "Entities", Err: ErrEmptyData}
+	}
+	if len(s.Relations) == 0 {
+		return &ValidationError{Chart: "chord", Field: "Relations", Err: ErrEmptyData}
+	}
+
+	ids := make(map[string]bool, len(s.Entities))
+	for i, e := range s.Entities {
+		if ids[e.ID] {
+			return invalid("chord", fmt.Sprintf("Entities[%d].ID", i), ErrDuplicateID, "%q", e.ID)
+		}
+		ids[e.ID] = true
+	}
+	for i, r := range s.Relations {
+		field := fmt.Sprintf("Relations[%d]", i)
+		if !ids[r.Source] {
+			return invalid("chord", field+".Source", ErrUnknownReference, "no entity %q", r.Source)
+		}
+		if !ids[r.Target] {
+			return invalid("chord", field+".Target", ErrUnknownReference, "no entity %q", r.Target)
+		}
+		if err := validateNonNegative("chord", field+".Value", r.Value); err != nil {
+			return err
+		}
+	}
+	for _, v := range []struct {
+		field string
+		value ChordSort
+	}{{"SortGroups", s.SortGroups}, {"SortSubgroups", s.SortSubgroups}} {
+		switch v.value {
+		case "", ChordSortInput, ChordSortAscending, ChordSortDescending:
+		default:
+			return invalid("chord", v.field, ErrUnsupported, "%q", v.value)
+		}
+	}
+	return validateNonNegative("chord", "TickStep", s.TickStep)
+}
+
+// Render renders the chord diagram to SVG
+func (s ChordDiagramSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "chord", target, func() string {
+		return RenderChordDiagram(s)
+	})
+}
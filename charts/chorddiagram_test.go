@@ -0,0 +1,138 @@
+package charts
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestChordLayout(t *testing.T) {
+	entities := []ChordEntity{{ID: "a"}, {ID: "b"}}
+	relations := []ChordRelation{{Source: "a", Target: "b", Value: 3}, {Source: "b", Target: "a", Value: 1}}
+
+	// Undirected: one ribbon whose ends show the flow each way
+	groups, ribbons := layoutChord(ChordDiagramSpec{Entities: entities, Relations: relations})
+	if len(groups) != 2 || len(ribbons) != 1 {
+		t.Fatalf("got %d groups and %d ribbons, expected 2 and 1", len(groups), len(ribbons))
+	}
+	if groups[0].value != 3 || groups[1].value != 1 {
+		t.Errorf("group values %v and %v, expected 3 and 1", groups[0].value, groups[1].value)
+	}
+	if end := groups[0].endAngle; math.Abs(end-1.5*math.Pi) > 1e-9 {
+		t.Errorf("first group ends at %v, expected 3π/2", end)
+	}
+	r := ribbons[0]
+	if r.source.entity != 0 || r.source.value != 3 || r.target.entity != 1 || r.target.value != 1 {
+		t.Errorf("ribbon %+v, expected the larger end at a", r)
+	}
+
+	// Directed: one ribbon per relation, from outgoing to incoming flows
+	groups, ribbons = layoutChord(ChordDiagramSpec{Entities: entities, Relations: relations, Directed: true})
+	if len(ribbons) != 2 {
+		t.Fatalf("got %d ribbons, expected 2", len(ribbons))
+	}
+	for _, g := range groups {
+		if g.value != 4 {
+			t.Errorf("group %d value %v, expected in + out = 4", g.entity, g.value)
+		}
+	}
+	for _, r := range ribbons {
+		if r.source.value != r.target.value {
+			t.Errorf("ribbon ends %v and %v should match", r.source.value, r.target.value)
+		}
+	}
+	// Incoming flows come first within each arc
+	if r := ribbons[0]; r.source.entity != 0 || r.target.startAngle != groups[1].startAngle {
+		t.Errorf("a -> b should end at the start of b's arc, got %+v", r)
+	}
+}
+
+func TestChordSort(t *testing.T) {
+	entities := []ChordEntity{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	relations := []ChordRelation{
+		{Source: "a", Target: "b", Value: 1},
+		{Source: "b", Target: "c", Value: 2},
+		{Source: "c", Target: "a", Value: 4},
+	}
+
+	tests := []struct {
+		sort  ChordSort
+		order []int
+	}{
+		{"", []int{0, 1, 2}},
+		{ChordSortInput, []int{0, 1, 2}},
+		{ChordSortAscending, []int{1, 0, 2}},
+		{ChordSortDescending, []int{2, 0, 1}},
+	}
+	for _, tt := range tests {
+		groups, _ := layoutChord(ChordDiagramSpec{Entities: entities, Relations: relations, SortGroups: tt.sort})
+		for i, g := range groups {
+			if g.entity != tt.order[i] {
+				t.Errorf("%q: group %d is entity %d, expected %d", tt.sort, i, g.entity, tt.order[i])
+			}
+		}
+	}
+
+	// Sorting subgroups descending puts b's flow to c before its flow to a
+	_, ribbons := layoutChord(ChordDiagramSpec{Entities: entities, Relations: relations, SortSubgroups: ChordSortDescending})
+	var toA, toC chordEnd
+	for _, r := range ribbons {
+		for _, end := range []chordEnd{r.source, r.target} {
+			if end.entity == 1 && end.value == 1 {
+				toA = end
+			}
+			if end.entity == 1 && end.value == 2 {
+				toC = end
+			}
+		}
+	}
+	if toC.startAngle >= toA.startAngle {
+		t.Errorf("b's larger flow starts at %v, after its smaller one at %v", toC.startAngle, toA.startAngle)
+	}
+}
+
+func TestRenderChordDiagram(t *testing.T) {
+	spec := ChordDiagramSpec{
+		Entities:  []ChordEntity{{ID: "a", Label: "A"}, {ID: "b", Label: "B"}},
+		Relations: []ChordRelation{{Source: "a", Target: "b", Value: 3}, {Source: "b", Target: "a", Value: 1}},
+		Width:     600,
+		Height:    600,
+	}
+	if n := strings.Count(RenderChordDiagram(spec), "<path"); n != 3 {
+		t.Errorf("got %d paths, expected 2 arcs and 1 ribbon", n)
+	}
+
+	spec.Directed = true
+	if n := strings.Count(RenderChordDiagram(spec), "<path"); n != 4 {
+		t.Errorf("got %d paths, expected 2 arcs and 2 ribbons", n)
+	}
+
+	// Ticks at 0, 1, 2, 3 and 4 on each arc, labeled at 0
+	spec.ShowTicks = true
+	spec.TickStep = 1
+	svg := RenderChordDiagram(spec)
+	if n := strings.Count(svg, "<line"); n != 10 {
+		t.Errorf("got %d ticks, expected 10", n)
+	}
+	if n := strings.Count(svg, ">0</text>"); n != 2 {
+		t.Errorf("got %d zero labels, expected 2", n)
+	}
+}
+
+func TestChordTickStep(t *testing.T) {
+	tests := []struct {
+		total, expected float64
+	}{
+		{80, 1},
+		{100, 2},
+		{400, 5},
+		{1000, 20},
+		{5, 0.1},
+	}
+	for _, tt := range tests {
+		step := chordTickStep([]chordGroup{{value: tt.total}})
+		if math.Abs(step-tt.expected) > 1e-9 {
+			t.Errorf("total %v: step %v, expected %v", tt.total, step, tt.expected)
+		}
+	}
+}
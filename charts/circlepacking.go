@@ -1,8 +1,9 @@
 package charts
 
 import (
-	"math"
+	"context"
 
+	"github.com/SCKelemen/dataviz/hierarchy"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
 )
@@ -30,18 +31,17 @@ func RenderCirclePacking(spec CirclePackingSpec) string {
 		return ""
 	}
 
-	// Calculate center
-	centerX := spec.Width / 2
-	centerY := spec.Height / 2
-	maxRadius := math.Min(spec.Width, spec.Height) / 2
-
 	// Compute circle packing layout
-	circles := packCircles(spec.Root, centerX, centerY, maxRadius, spec.Padding, 0)
+	circles := PackCircles(spec.Root, spec.Width, spec.Height, spec.Padding)
 
-	// Render circles
+	// Render circles, parents under their children
 	var result string
 
 	for _, circle := range circles {
+		if circle.Radius <= 0 {
+			continue
+		}
+
 		// Determine color
 		color := circle.Node.Color
 		if color == "" {
@@ -62,8 +62,8 @@ func RenderCirclePacking(spec CirclePackingSpec) string {
 
 		result += svg.Circle(circle.X, circle.Y, circle.Radius, circleStyle) + "\n"
 
-		// Draw label if enabled and circle is large enough
-		if spec.ShowLabels && circle.Radius > 20 {
+		// Draw label if enabled and the leaf circle is large enough
+		if spec.ShowLabels && len(circle.Node.Children) == 0 && circle.Radius > 20 {
 			labelStyle := svg.Style{
 				FontSize:         units.Px(10),
 				FontFamily:       "sans-serif",
@@ -80,100 +80,35 @@ func RenderCirclePacking(spec CirclePackingSpec) string {
 	return result
 }
 
-// packCircles computes circle positions using hierarchical circle packing
-func packCircles(node *TreeNode, cx, cy, maxRadius, padding float64, depth int) []PackedCircle {
-	if node == nil {
+// PackCircles lays out a hierarchy as nested circles with the
+// hierarchy package's pack layout, largest siblings first. Circles are
+// returned parents before children, starting with the root at depth 0.
+func PackCircles(root *TreeNode, width, height, padding float64) []PackedCircle {
+	h := hierarchy.New(root)
+	if h == nil {
 		return nil
 	}
+	h.Sum(nil).Sort(hierarchy.ValueDescending)
 
-	// Leaf node - single circle
-	if len(node.Children) == 0 {
-		radius := math.Sqrt(node.Value) * maxRadius / 10 // Scale radius based on value
-		if radius > maxRadius {
-			radius = maxRadius
-		}
-		return []PackedCircle{{
-			X:      cx,
-			Y:      cy,
-			Radius: radius - padding,
-			Node:   node,
-			Depth:  depth,
-		}}
-	}
-
-	var circles []PackedCircle
-
-	// Calculate radii for children based on their values
-	childCircles := make([]PackedCircle, len(node.Children))
-	totalValue := 0.0
-	for _, child := range node.Children {
-		totalValue += calculateTreeValue(child)
-	}
-
-	for i, child := range node.Children {
-		childValue := calculateTreeValue(child)
-		// Radius proportional to square root of value (area-based)
-		radius := math.Sqrt(childValue/totalValue) * maxRadius * 0.8
-		childCircles[i] = PackedCircle{
-			Radius: radius,
-			Node:   child,
-			Depth:  depth + 1,
-		}
-	}
-
-	// Position circles using simple packing algorithm
-	positionedCircles := positionCirclesPacked(childCircles, cx, cy, maxRadius*0.9)
-
-	// Recursively pack children
-	for _, circle := range positionedCircles {
-		if len(circle.Node.Children) > 0 {
-			nestedCircles := packCircles(
-				circle.Node,
-				circle.X,
-				circle.Y,
-				circle.Radius,
-				padding,
-				depth+1,
-			)
-			circles = append(circles, nestedCircles...)
-		} else {
-			circles = append(circles, circle)
-		}
+	layout := hierarchy.Pack{Width: width, Height: height, Padding: padding}.Layout(h)
+	circles := make([]PackedCircle, len(layout))
+	for i, c := range layout {
+		circles[i] = PackedCircle{X: c.X, Y: c.Y, Radius: c.R, Node: c.Node.Data, Depth: c.Node.Depth}
 	}
-
 	return circles
 }
 
-// positionCirclesPacked positions circles using a simple packing algorithm
-func positionCirclesPacked(circles []PackedCircle, cx, cy, maxRadius float64) []PackedCircle {
-	if len(circles) == 0 {
-		return circles
-	}
-
-	// Sort circles by radius (descending) for better packing
-	sortedCircles := make([]PackedCircle, len(circles))
-	copy(sortedCircles, circles)
-
-	// Simple circular arrangement for now (can be improved with better packing algorithms)
-	if len(sortedCircles) == 1 {
-		sortedCircles[0].X = cx
-		sortedCircles[0].Y = cy
-		return sortedCircles
-	}
-
-	// Place first circle at center
-	sortedCircles[0].X = cx
-	sortedCircles[0].Y = cy
-
-	// Place remaining circles in a circle around the first one
-	angleStep := 2 * math.Pi / float64(len(sortedCircles)-1)
-	placementRadius := sortedCircles[0].Radius + sortedCircles[1].Radius + 5
-
-	for i := 1; i < len(sortedCircles); i++ {
-		angle := float64(i-1) * angleStep
-		sortedCircles[i].X = cx + placementRadius*math.Cos(angle)
-		sortedCircles[i].Y = cy + placementRadius*math.Sin(angle)
+// Validate checks that the hierarchy is non-empty, acyclic and non-negative
+func (s CirclePackingSpec) Validate() error {
+	if err := validateSize("circle-packing", s.Width, s.Height); err != nil {
+		return err
 	}
+	return validateTree("circle-packing", s.Root)
+}
 
-	return sortedCircles
+// Render renders the circle packing to SVG
+func (s CirclePackingSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "circle-packing", target, func() string {
+		return RenderCirclePacking(s)
+	})
 }
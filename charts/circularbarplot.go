@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -273,3 +274,26 @@ func MultiColorCircularBarPlot(labels []string, values []float64, colors []strin
 
 	return RenderCircularBarPlot(spec)
 }
+
+// Validate checks that the plot has bars with non-negative values
+func (s CircularBarPlotSpec) Validate() error {
+	if err := validateSize("circular-bar", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "circular-bar", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, d := range s.Data {
+		if err := validateNonNegative("circular-bar", fmt.Sprintf("Data[%d].Value", i), d.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the circular bar plot to SVG
+func (s CircularBarPlotSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "circular-bar", target, func() string {
+		return RenderCircularBarPlot(s)
+	})
+}
@@ -0,0 +1,376 @@
+package charts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/dataviz/scales"
+	"github.com/SCKelemen/svg"
+	"github.com/SCKelemen/units"
+)
+
+// ClusteredHeatmapSpec configures a clustered heatmap: a value matrix drawn
+// as a grid of colored cells, with rows and columns reordered by
+// hierarchical clustering and their dendrograms along the margins
+type ClusteredHeatmapSpec struct {
+	Values         [][]float64 // Matrix rows, each with one value per column
+	RowLabels      []string    // Optional, one per row (drawn right of the grid)
+	ColumnLabels   []string    // Optional, one per column (drawn below the grid)
+	Width          float64
+	Height         float64
+	ClusterRows    bool              // Reorder rows and draw their dendrogram on the left
+	ClusterColumns bool              // Reorder columns and draw their dendrogram on top
+	Linkage        Linkage           // Cluster distance (default: average)
+	Metric         DistanceMetric    // Distance between rows or columns (default: euclidean)
+	RowClusters    int               // Color row branches by cutting into this many clusters (0 = one color)
+	ColumnClusters int               // Color column branches by cutting into this many clusters (0 = one color)
+	ColorScale     scales.ColorScale // Cell colors; nil uses ColorScheme over the value range
+	ColorScheme    string            // Named scales interpolator when ColorScale is nil (default: "viridis")
+	ShowValues     bool              // Show values in cells
+	DendrogramSize float64           // Depth of the dendrograms (default: 15% of the smaller side)
+	CellPadding    float64           // Gap between cells
+	Title          string
+}
+
+// clusteredHeatmapLayout holds the cell order and clustering of a
+// clustered heatmap
+type clusteredHeatmapLayout struct {
+	rowOrder, colOrder []int
+	rows, cols         *Clustering // nil when not clustered
+}
+
+// RenderClusteredHeatmap generates an SVG clustered heatmap
+func RenderClusteredHeatmap(spec ClusteredHeatmapSpec) string {
+	layout, err := spec.layout()
+	if err != nil {
+		return ""
+	}
+	nRows, nCols := len(spec.Values), len(spec.Values[0])
+
+	// Set defaults
+	if spec.DendrogramSize == 0 {
+		spec.DendrogramSize = math.Min(spec.Width, spec.Height) * 0.15
+	}
+	lo, hi := matrixExtent(spec.Values)
+	colorScale := spec.ColorScale
+	if colorScale == nil {
+		interpolator, ok := scales.NamedInterpolator(spec.ColorScheme)
+		if !ok {
+			interpolator = scales.InterpolateViridis
+		}
+		colorScale = scales.NewSequentialColorScaleWithInterpolator([2]float64{lo, hi}, interpolator)
+	}
+	if domain, ok := colorScale.Domain().([2]float64); ok {
+		lo, hi = domain[0], domain[1]
+	}
+
+	// Calculate margins: dendrograms left and top, labels right and
+	// bottom, then the color bar
+	top := 10.0
+	if spec.Title != "" {
+		top = 36
+	}
+	left := 10.0
+	if layout.rows != nil {
+		left += spec.DendrogramSize
+	}
+	if layout.cols != nil {
+		top += spec.DendrogramSize
+	}
+	rowLabelWidth := 0.0
+	if spec.RowLabels != nil {
+		rowLabelWidth = math.Min(estimateLabelWidth(spec.RowLabels, 10)+8, spec.Width*0.25)
+	}
+	colLabelHeight := 0.0
+	if spec.ColumnLabels != nil {
+		// Labels are rotated 45°
+		colLabelHeight = math.Min(estimateLabelWidth(spec.ColumnLabels, 10)*math.Sqrt2/2+16, spec.Height*0.25)
+	}
+	legendWidth := 70.0
+
+	gridWidth := spec.Width - left - rowLabelWidth - legendWidth
+	gridHeight := spec.Height - top - colLabelHeight - 10
+	if gridWidth <= 0 || gridHeight <= 0 {
+		return ""
+	}
+	cellWidth := gridWidth / float64(nCols)
+	cellHeight := gridHeight / float64(nRows)
+
+	var result string
+
+	// Draw title
+	if spec.Title != "" {
+		titleStyle := svg.Style{
+			FontSize:         units.Px(16),
+			FontFamily:       "sans-serif",
+			FontWeight:       "bold",
+			TextAnchor:       svg.TextAnchorMiddle,
+			DominantBaseline: svg.DominantBaselineHanging,
+		}
+		result += svg.Text(spec.Title, spec.Width/2, 10, titleStyle) + "\n"
+	}
+
+	// Draw cells in clustered order
+	for i, row := range layout.rowOrder {
+		for j, col := range layout.colOrder {
+			value := spec.Values[row][col]
+			cellColor := colorScale.ApplyColor(value)
+			x := left + float64(j)*cellWidth
+			y := top + float64(i)*cellHeight
+
+			cellStyle := svg.Style{Fill: color.RGBToHex(cellColor)}
+			if spec.CellPadding > 0 {
+				cellStyle.Stroke = "#ffffff"
+				cellStyle.StrokeWidth = spec.CellPadding
+			}
+			result += svg.Rect(x, y, cellWidth, cellHeight, cellStyle) + "\n"
+
+			if spec.ShowValues {
+				valueStyle := svg.Style{
+					Fill:             contrastingTextColor(cellColor),
+					FontSize:         units.Px(math.Min(12, math.Min(cellWidth*0.3, cellHeight*0.6))),
+					FontFamily:       "sans-serif",
+					TextAnchor:       svg.TextAnchorMiddle,
+					DominantBaseline: svg.DominantBaselineMiddle,
+				}
+				result += svg.Text(strconv.FormatFloat(value, 'g', 3, 64), x+cellWidth/2, y+cellHeight/2, valueStyle) + "\n"
+			}
+		}
+	}
+
+	// Draw dendrograms with the same drawing code as RenderDendrogram
+	lineStyle := svg.Style{Stroke: "#374151", StrokeWidth: 1}
+	depth := spec.DendrogramSize - 4
+	if layout.cols != nil {
+		positions := make(map[*DendrogramNode]position)
+		leafIndex := 0
+		calculatePositions(layout.cols.Root, &leafIndex, cellWidth, gridWidth, depth, dendrogramMaxHeight(layout.cols.Root), "vertical", positions)
+		colors := clusterColors(layout.cols, spec.ColumnClusters)
+		result += drawDendrogramNode(layout.cols.Root, positions, left, top-spec.DendrogramSize, lineStyle, colors, "vertical")
+	}
+	if layout.rows != nil {
+		// The horizontal layout puts leaves at x=0 with the root to the
+		// right; mirror it so the leaves face the grid
+		positions := make(map[*DendrogramNode]position)
+		leafIndex := 0
+		calculatePositions(layout.rows.Root, &leafIndex, cellHeight, depth, gridHeight, dendrogramMaxHeight(layout.rows.Root), "horizontal", positions)
+		colors := clusterColors(layout.rows, spec.RowClusters)
+		lines := drawDendrogramNode(layout.rows.Root, positions, 0, 0, lineStyle, colors, "horizontal")
+		result += svg.Group(lines, fmt.Sprintf("translate(%.2f %.2f) scale(-1 1)", left-4, top), svg.Style{}) + "\n"
+	}
+
+	// Draw row labels (right side)
+	labelStyle := svg.Style{
+		FontSize:         units.Px(10),
+		FontFamily:       "sans-serif",
+		TextAnchor:       svg.TextAnchorStart,
+		DominantBaseline: svg.DominantBaselineMiddle,
+	}
+	if spec.RowLabels != nil {
+		for i, row := range layout.rowOrder {
+			y := top + float64(i)*cellHeight + cellHeight/2
+			result += svg.Text(spec.RowLabels[row], left+gridWidth+6, y, labelStyle) + "\n"
+		}
+	}
+
+	// Draw column labels (bottom, rotated for better fit)
+	if spec.ColumnLabels != nil {
+		for j, col := range layout.colOrder {
+			x := left + float64(j)*cellWidth + cellWidth/2
+			y := top + gridHeight + 8
+			label := svg.Text(spec.ColumnLabels[col], 0, 0, labelStyle)
+			result += svg.Group(label, fmt.Sprintf("translate(%.2f %.2f) rotate(45)", x, y), svg.Style{}) + "\n"
+		}
+	}
+
+	// Draw color bar legend
+	result += renderColorBar(spec.Width-legendWidth+16, top, 16, gridHeight, colorScale, lo, hi)
+
+	return result
+}
+
+// layout validates the matrix and computes the clustered row and column
+// order
+func (s ClusteredHeatmapSpec) layout() (clusteredHeatmapLayout, error) {
+	var layout clusteredHeatmapLayout
+	if len(s.Values) == 0 || len(s.Values[0]) == 0 {
+		return layout, &ValidationError{Chart: "clustered-heatmap", Field: "Values", Err: ErrEmptyData}
+	}
+	nRows, nCols := len(s.Values), len(s.Values[0])
+	for i, row := range s.Values {
+		field := fmt.Sprintf("Values[%d]", i)
+		if len(row) != nCols {
+			return layout, invalid("clustered-heatmap", field, ErrMismatchedLengths, "%d values, expected %d", len(row), nCols)
+		}
+		for j, v := range row {
+			if err := validateNumber("clustered-heatmap", fmt.Sprintf("%s[%d]", field, j), v); err != nil {
+				return layout, err
+			}
+		}
+	}
+	if s.RowLabels != nil && len(s.RowLabels) != nRows {
+		return layout, invalid("clustered-heatmap", "RowLabels", ErrMismatchedLengths, "%d labels for %d rows", len(s.RowLabels), nRows)
+	}
+	if s.ColumnLabels != nil && len(s.ColumnLabels) != nCols {
+		return layout, invalid("clustered-heatmap", "ColumnLabels", ErrMismatchedLengths, "%d labels for %d columns", len(s.ColumnLabels), nCols)
+	}
+
+	opts := ClusterOptions{Linkage: s.Linkage, Metric: s.Metric}
+	layout.rowOrder = identityOrder(nRows)
+	layout.colOrder = identityOrder(nCols)
+	if s.ClusterRows {
+		c, err := HierarchicalCluster(s.Values, opts)
+		if err != nil {
+			return layout, clusterError("ClusterRows", err)
+		}
+		layout.rows, layout.rowOrder = c, c.Order()
+	}
+	if s.ClusterColumns {
+		columns := make([][]float64, nCols)
+		for j := range columns {
+			columns[j] = make([]float64, nRows)
+			for i, row := range s.Values {
+				columns[j][i] = row[j]
+			}
+		}
+		c, err := HierarchicalCluster(columns, opts)
+		if err != nil {
+			return layout, clusterError("ClusterColumns", err)
+		}
+		layout.cols, layout.colOrder = c, c.Order()
+	}
+	return layout, nil
+}
+
+// clusterError reports a clustering failure as a clustered heatmap
+// validation error on field
+func clusterError(field string, err error) error {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	detail := verr.Field
+	if verr.Detail != "" {
+		detail += ": " + verr.Detail
+	}
+	return &ValidationError{Chart: "clustered-heatmap", Field: field, Err: verr.Err, Detail: detail}
+}
+
+// identityOrder returns 0..n-1
+func identityOrder(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// matrixExtent returns the smallest and largest values of a matrix
+func matrixExtent(values [][]float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, row := range values {
+		for _, v := range row {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// dendrogramMaxHeight returns the root height of a tree for scaling, or 1
+// when every merge is at height 0
+func dendrogramMaxHeight(root *DendrogramNode) float64 {
+	if h := findMaxHeight(root); h > 0 {
+		return h
+	}
+	return 1
+}
+
+// clusterColors returns branch colors for k clusters, or nil for k <= 1
+func clusterColors(c *Clustering, k int) map[*DendrogramNode]string {
+	if k <= 1 {
+		return nil
+	}
+	return c.BranchColors(c.CutClusters(k), defaultPieColors)
+}
+
+// estimateLabelWidth estimates the width of the longest label in pixels
+func estimateLabelWidth(labels []string, fontSize float64) float64 {
+	longest := 0
+	for _, label := range labels {
+		longest = max(longest, len([]rune(label)))
+	}
+	return float64(longest) * fontSize * 0.6
+}
+
+// contrastingTextColor returns black or white, whichever reads better on c
+func contrastingTextColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	if 0.299*r+0.587*g+0.114*b > 0.55 {
+		return "#000000"
+	}
+	return "#ffffff"
+}
+
+// renderColorBar draws a vertical color scale legend from hi (top) to lo
+// (bottom) with tick labels
+func renderColorBar(x, y, width, height float64, colorScale scales.ColorScale, lo, hi float64) string {
+	var result string
+
+	steps := 50
+	stepHeight := height / float64(steps)
+	for i := 0; i < steps; i++ {
+		value := hi - (hi-lo)*(float64(i)+0.5)/float64(steps)
+		rectStyle := svg.Style{
+			Fill:   color.RGBToHex(colorScale.ApplyColor(value)),
+			Stroke: "none",
+		}
+		result += svg.Rect(x, y+float64(i)*stepHeight, width, stepHeight+0.5, rectStyle) + "\n"
+	}
+
+	borderStyle := svg.Style{
+		Fill:        "none",
+		Stroke:      "#374151",
+		StrokeWidth: 1,
+	}
+	result += svg.Rect(x, y, width, height, borderStyle) + "\n"
+
+	labelStyle := svg.Style{
+		FontSize:         units.Px(10),
+		FontFamily:       "sans-serif",
+		DominantBaseline: svg.DominantBaselineMiddle,
+	}
+	tickStyle := svg.Style{Stroke: "#374151", StrokeWidth: 1}
+	scale := scales.NewLinearScale([2]float64{lo, hi}, [2]units.Length{units.Px(y + height), units.Px(y)})
+	for _, tick := range scale.Ticks(5) {
+		ty := scale.Apply(tick).Value
+		result += svg.Line(x+width, ty, x+width+3, ty, tickStyle) + "\n"
+		result += svg.Text(strconv.FormatFloat(tick, 'g', 4, 64), x+width+5, ty, labelStyle) + "\n"
+	}
+
+	return result
+}
+
+// Validate checks that the matrix is rectangular and finite, that labels
+// match it and that the rows and columns can be clustered
+func (s ClusteredHeatmapSpec) Validate() error {
+	if err := validateSize("clustered-heatmap", s.Width, s.Height); err != nil {
+		return err
+	}
+	_, err := s.layout()
+	return err
+}
+
+// Render renders the clustered heatmap to SVG
+func (s ClusteredHeatmapSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "clustered-heatmap", target, func() string {
+		return RenderClusteredHeatmap(s)
+	})
+}
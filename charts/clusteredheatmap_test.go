@@ -0,0 +1,82 @@
+package charts
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/dataviz/scales"
+)
+
+func TestRenderClusteredHeatmap(t *testing.T) {
+	// Rows a and c are alike, as are columns x and z
+	spec := ClusteredHeatmapSpec{
+		Values: [][]float64{
+			{1, 9, 1},
+			{9, 1, 9},
+			{1, 8, 2},
+		},
+		RowLabels:      []string{"a", "b", "c"},
+		ColumnLabels:   []string{"x", "y", "z"},
+		Width:          500,
+		Height:         400,
+		ClusterRows:    true,
+		ClusterColumns: true,
+		RowClusters:    2,
+		Title:          "Clustered",
+	}
+
+	svg := RenderClusteredHeatmap(spec)
+	if svg == "" {
+		t.Fatal("RenderClusteredHeatmap returned empty output")
+	}
+
+	// Labels follow the clustered order: similar rows and columns are
+	// adjacent
+	labels := regexp.MustCompile(`>([abcxyz])</text>`).FindAllStringSubmatch(svg, -1)
+	var order string
+	for _, m := range labels {
+		order += m[1]
+	}
+	if len(order) != 6 || order[1] == 'b' || order[4] == 'y' {
+		t.Errorf("label order = %q, expected a next to c and x next to z", order)
+	}
+
+	// One cell per value, two dendrograms and a colored row cluster
+	if n := strings.Count(svg, "<rect"); n < 9 {
+		t.Errorf("got %d rects, expected at least 9 cells", n)
+	}
+	if !strings.Contains(svg, "scale(-1 1)") {
+		t.Error("missing row dendrogram")
+	}
+	// b is a cluster on its own, so only the a-c branch is colored
+	if !strings.Contains(svg, defaultPieColors[1]) {
+		t.Error("row branches are not colored by cluster")
+	}
+	if !strings.Contains(svg, "Clustered") {
+		t.Error("missing title")
+	}
+}
+
+func TestClusteredHeatmapColorScale(t *testing.T) {
+	white, _ := color.ParseColor("#ffffff")
+	black, _ := color.ParseColor("#000000")
+	spec := ClusteredHeatmapSpec{
+		Values:     [][]float64{{0, 10}},
+		Width:      400,
+		Height:     200,
+		ColorScale: scales.NewSequentialColorScale([2]float64{0, 10}, white, black),
+	}
+
+	// The lowest value is (nearly) white and the highest black
+	svg := strings.ToLower(RenderClusteredHeatmap(spec))
+	cells := regexp.MustCompile(`<rect [^>]*fill="(#[0-9a-f]{6})"/>`).FindAllStringSubmatch(svg, 2)
+	if len(cells) != 2 || !strings.HasPrefix(cells[0][1], "#ff") || cells[1][1] != "#000000" {
+		t.Errorf("cells are not colored by the color scale: %v", cells)
+	}
+	// Unclustered: no dendrograms
+	if strings.Contains(svg, "scale(-1 1)") {
+		t.Error("unclustered rows should not have a dendrogram")
+	}
+}
@@ -0,0 +1,400 @@
+package charts
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Linkage selects how the distance between two clusters is computed from
+// the distances between their members
+type Linkage string
+
+const (
+	LinkageSingle   Linkage = "single"   // Closest pair of members
+	LinkageComplete Linkage = "complete" // Farthest pair of members
+	LinkageAverage  Linkage = "average"  // Mean distance between members (UPGMA)
+	LinkageWard     Linkage = "ward"     // Increase in within-cluster variance; assumes Euclidean distances
+)
+
+// DistanceMetric selects the distance between two observations
+type DistanceMetric string
+
+const (
+	DistanceEuclidean   DistanceMetric = "euclidean"   // Straight-line distance
+	DistanceCosine      DistanceMetric = "cosine"      // 1 - cosine similarity
+	DistanceCorrelation DistanceMetric = "correlation" // 1 - Pearson correlation
+)
+
+// ClusterOptions configures hierarchical clustering
+type ClusterOptions struct {
+	Linkage Linkage        // Cluster distance (default: average)
+	Metric  DistanceMetric // Observation distance (default: euclidean); ignored by ClusterDistances
+	Labels  []string       // Leaf labels, one per observation (default: "0", "1", ...)
+}
+
+// ClusterMerge is one step of agglomerative clustering. Clusters are
+// numbered like SciPy's linkage matrix: observations are 0..n-1 and the
+// cluster formed by Merges[i] is n+i.
+type ClusterMerge struct {
+	Left   int     // First merged cluster
+	Right  int     // Second merged cluster
+	Height float64 // Linkage distance at which the clusters merged
+	Size   int     // Number of observations in the merged cluster
+}
+
+// Clustering is the result of hierarchical clustering
+type Clustering struct {
+	Root   *DendrogramNode // Tree for RenderDendrogram; merge heights are node heights
+	Merges []ClusterMerge  // Merges in order of increasing height
+
+	nodes  []*DendrogramNode       // Node of each cluster number
+	leaves map[*DendrogramNode]int // Observation index of each leaf
+}
+
+// HierarchicalCluster clusters the rows of an observation matrix bottom-up,
+// repeatedly merging the two closest clusters until one remains.
+//
+//	c, err := charts.HierarchicalCluster(samples, charts.ClusterOptions{
+//	    Linkage: charts.LinkageWard,
+//	    Labels:  names,
+//	})
+//	svg := charts.RenderDendrogram(charts.DendrogramSpec{Root: c.Root, ...})
+func HierarchicalCluster(data [][]float64, opts ClusterOptions) (*Clustering, error) {
+	dist, err := PairwiseDistances(data, opts.Metric)
+	if err != nil {
+		return nil, err
+	}
+	return ClusterDistances(dist, opts)
+}
+
+// ClusterDistances clusters observations given a symmetric matrix of
+// pairwise distances with a zero diagonal
+func ClusterDistances(dist [][]float64, opts ClusterOptions) (*Clustering, error) {
+	n := len(dist)
+	if n == 0 {
+		return nil, &ValidationError{Chart: "cluster", Field: "Distances", Err: ErrEmptyData}
+	}
+	if opts.Labels != nil && len(opts.Labels) != n {
+		return nil, invalid("cluster", "Labels", ErrMismatchedLengths, "%d labels for %d observations", len(opts.Labels), n)
+	}
+
+	linkage := opts.Linkage
+	if linkage == "" {
+		linkage = LinkageAverage
+	}
+	update, ok := linkageUpdates[linkage]
+	if !ok {
+		return nil, invalid("cluster", "Linkage", ErrUnsupported, "unknown linkage %q", linkage)
+	}
+
+	// Work on a copy; merged clusters reuse the slot of one of their parts
+	d := make([][]float64, n)
+	for i, row := range dist {
+		if len(row) != n {
+			return nil, invalid("cluster", "Distances["+strconv.Itoa(i)+"]", ErrMismatchedLengths, "%d columns for %d rows", len(row), n)
+		}
+		for j, v := range row {
+			field := "Distances[" + strconv.Itoa(i) + "][" + strconv.Itoa(j) + "]"
+			if err := validateNonNegative("cluster", field, v); err != nil {
+				return nil, err
+			}
+			if j < i && v != dist[j][i] {
+				return nil, invalid("cluster", field, ErrInvalidValue, "matrix is not symmetric")
+			}
+		}
+		d[i] = append([]float64(nil), row...)
+	}
+
+	c := &Clustering{leaves: make(map[*DendrogramNode]int, n)}
+	slots := make([]*DendrogramNode, n)
+	sizes := make([]int, n)
+	for i := range slots {
+		label := strconv.Itoa(i)
+		if opts.Labels != nil {
+			label = opts.Labels[i]
+		}
+		slots[i] = &DendrogramNode{Label: label}
+		sizes[i] = 1
+		c.leaves[slots[i]] = i
+	}
+	leafNodes := append([]*DendrogramNode(nil), slots...)
+
+	type merge struct {
+		node        *DendrogramNode
+		left, right *DendrogramNode
+		size        int
+	}
+	merges := make([]merge, 0, n-1)
+
+	// Nearest-neighbor chain: follow nearest neighbors until two clusters
+	// are each other's nearest, then merge them. This finds the same tree
+	// as merging the globally closest pair for these linkages, in O(n²).
+	active := make([]bool, n)
+	for i := range active {
+		active[i] = true
+	}
+	var chain []int
+	for remaining := n; remaining > 1; remaining-- {
+		if len(chain) == 0 {
+			for i := range active {
+				if active[i] {
+					chain = append(chain, i)
+					break
+				}
+			}
+		}
+
+		var a, b int
+		for {
+			a = chain[len(chain)-1]
+			b = -1
+			best := math.Inf(1)
+			// Prefer the previous chain element on ties so the chain ends
+			if len(chain) > 1 {
+				b = chain[len(chain)-2]
+				best = d[a][b]
+			}
+			for k := range active {
+				if active[k] && k != a && d[a][k] < best {
+					b, best = k, d[a][k]
+				}
+			}
+			if len(chain) > 1 && b == chain[len(chain)-2] {
+				break
+			}
+			chain = append(chain, b)
+		}
+		chain = chain[:len(chain)-2]
+
+		// Merge a into b's slot
+		if a > b {
+			a, b = b, a
+		}
+		left, right := slots[a], slots[b]
+		height := math.Max(d[a][b], math.Max(left.Height, right.Height))
+		node := &DendrogramNode{Height: height, Children: []*DendrogramNode{left, right}}
+		for k := range active {
+			if active[k] && k != a && k != b {
+				v := update(d[a][k], d[b][k], d[a][b], sizes[a], sizes[b], sizes[k])
+				d[b][k], d[k][b] = v, v
+			}
+		}
+		merges = append(merges, merge{node: node, left: left, right: right, size: sizes[a] + sizes[b]})
+		active[a] = false
+		slots[b] = node
+		sizes[b] += sizes[a]
+	}
+
+	// Number clusters in order of height; children always precede their
+	// parent, so a stable sort keeps the numbering valid
+	sort.SliceStable(merges, func(i, j int) bool { return merges[i].node.Height < merges[j].node.Height })
+	ids := make(map[*DendrogramNode]int, 2*n-1)
+	c.nodes = leafNodes
+	for i, leaf := range leafNodes {
+		ids[leaf] = i
+	}
+	for _, m := range merges {
+		ids[m.node] = len(c.nodes)
+		c.nodes = append(c.nodes, m.node)
+		c.Merges = append(c.Merges, ClusterMerge{Left: ids[m.left], Right: ids[m.right], Height: m.node.Height, Size: m.size})
+	}
+	c.Root = c.nodes[len(c.nodes)-1]
+	return c, nil
+}
+
+// linkageUpdates are Lance–Williams updates: the distance from cluster k to
+// the union of clusters a and b
+var linkageUpdates = map[Linkage]func(dak, dbk, dab float64, na, nb, nk int) float64{
+	LinkageSingle: func(dak, dbk, dab float64, na, nb, nk int) float64 {
+		return math.Min(dak, dbk)
+	},
+	LinkageComplete: func(dak, dbk, dab float64, na, nb, nk int) float64 {
+		return math.Max(dak, dbk)
+	},
+	LinkageAverage: func(dak, dbk, dab float64, na, nb, nk int) float64 {
+		return (float64(na)*dak + float64(nb)*dbk) / float64(na+nb)
+	},
+	LinkageWard: func(dak, dbk, dab float64, na, nb, nk int) float64 {
+		a, b, k := float64(na), float64(nb), float64(nk)
+		v := ((a+k)*dak*dak + (b+k)*dbk*dbk - k*dab*dab) / (a + b + k)
+		return math.Sqrt(math.Max(v, 0))
+	},
+}
+
+// PairwiseDistances returns the matrix of distances between the rows of an
+// observation matrix
+func PairwiseDistances(data [][]float64, metric DistanceMetric) ([][]float64, error) {
+	n := len(data)
+	if n == 0 {
+		return nil, &ValidationError{Chart: "cluster", Field: "Data", Err: ErrEmptyData}
+	}
+	if metric == "" {
+		metric = DistanceEuclidean
+	}
+
+	rows := data
+	switch metric {
+	case DistanceEuclidean, DistanceCosine:
+	case DistanceCorrelation:
+		// Correlation distance is cosine distance between centered rows
+		rows = make([][]float64, n)
+	default:
+		return nil, invalid("cluster", "Metric", ErrUnsupported, "unknown distance metric %q", metric)
+	}
+
+	dims := len(data[0])
+	for i, row := range data {
+		field := "Data[" + strconv.Itoa(i) + "]"
+		if len(row) != dims {
+			return nil, invalid("cluster", field, ErrMismatchedLengths, "%d values, expected %d", len(row), dims)
+		}
+		for j, v := range row {
+			if err := validateNumber("cluster", field+"["+strconv.Itoa(j)+"]", v); err != nil {
+				return nil, err
+			}
+		}
+		if metric == DistanceCorrelation {
+			mean := 0.0
+			for _, v := range row {
+				mean += v
+			}
+			mean /= float64(dims)
+			rows[i] = make([]float64, dims)
+			for j, v := range row {
+				rows[i][j] = v - mean
+			}
+		}
+		if metric != DistanceEuclidean && norm(rows[i]) == 0 {
+			detail := "zero vector has no direction"
+			if metric == DistanceCorrelation {
+				detail = "constant row has no correlation"
+			}
+			return nil, invalid("cluster", field, ErrInvalidValue, "%s", detail)
+		}
+	}
+
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			var v float64
+			if metric == DistanceEuclidean {
+				for k := range rows[i] {
+					diff := rows[i][k] - rows[j][k]
+					v += diff * diff
+				}
+				v = math.Sqrt(v)
+			} else {
+				dot := 0.0
+				for k := range rows[i] {
+					dot += rows[i][k] * rows[j][k]
+				}
+				v = math.Max(0, 1-dot/(norm(rows[i])*norm(rows[j])))
+			}
+			dist[i][j], dist[j][i] = v, v
+		}
+	}
+	return dist, nil
+}
+
+// norm returns the Euclidean length of a vector
+func norm(v []float64) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+// Order returns the observation indices in the left-to-right leaf order of
+// the tree, e.g. to reorder the rows of a heatmap
+func (c *Clustering) Order() []int {
+	leaves := collectLeaves(c.Root)
+	order := make([]int, len(leaves))
+	for i, leaf := range leaves {
+		order[i] = c.leaves[leaf]
+	}
+	return order
+}
+
+// CutHeight cuts the tree at a height and returns the cluster of each
+// observation. Observations merged at or below the height share a cluster;
+// clusters are numbered from 0 in leaf order.
+func (c *Clustering) CutHeight(height float64) []int {
+	return c.cut(func(node *DendrogramNode) bool {
+		return node.Height > height
+	})
+}
+
+// CutClusters cuts the tree into k clusters, undoing the k-1 highest
+// merges, and returns the cluster of each observation. k is clamped to
+// [1, number of observations].
+func (c *Clustering) CutClusters(k int) []int {
+	n := len(c.leaves)
+	k = max(1, min(k, n))
+	split := make(map[*DendrogramNode]bool, k-1)
+	for _, node := range c.nodes[len(c.nodes)-k+1:] {
+		split[node] = true
+	}
+	return c.cut(func(node *DendrogramNode) bool {
+		return split[node]
+	})
+}
+
+// cut assigns each observation to the highest subtree that is not split
+func (c *Clustering) cut(split func(*DendrogramNode) bool) []int {
+	assignments := make([]int, len(c.leaves))
+	cluster := 0
+	var walk func(node *DendrogramNode)
+	walk = func(node *DendrogramNode) {
+		if len(node.Children) > 0 && split(node) {
+			for _, child := range node.Children {
+				walk(child)
+			}
+			return
+		}
+		for _, leaf := range collectLeaves(node) {
+			assignments[c.leaves[leaf]] = cluster
+		}
+		cluster++
+	}
+	walk(c.Root)
+	return assignments
+}
+
+// BranchColors colors each subtree whose observations all share a cluster
+// with that cluster's color, cycling through colors, for
+// DendrogramSpec.BranchColors. Branches joining clusters keep the default
+// line color.
+func (c *Clustering) BranchColors(assignments []int, colors []string) map[*DendrogramNode]string {
+	result := make(map[*DendrogramNode]string)
+	if len(colors) == 0 {
+		return result
+	}
+	// clusterOf returns the shared cluster of a subtree, or -1
+	var clusterOf func(node *DendrogramNode) int
+	clusterOf = func(node *DendrogramNode) int {
+		if len(node.Children) == 0 {
+			i, ok := c.leaves[node]
+			if !ok || i >= len(assignments) {
+				return -1
+			}
+			return assignments[i]
+		}
+		cluster := clusterOf(node.Children[0])
+		for _, child := range node.Children[1:] {
+			if clusterOf(child) != cluster {
+				cluster = -1
+			}
+		}
+		if cluster >= 0 {
+			result[node] = colors[cluster%len(colors)]
+		}
+		return cluster
+	}
+	clusterOf(c.Root)
+	return result
+}
@@ -0,0 +1,206 @@
+package charts
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Points on a line at 0, 1, 3 and 7
+var linePoints = [][]float64{{0}, {1}, {3}, {7}}
+
+func TestHierarchicalClusterLinkage(t *testing.T) {
+	tests := []struct {
+		linkage Linkage
+		heights []float64
+	}{
+		{LinkageSingle, []float64{1, 2, 4}},
+		{LinkageComplete, []float64{1, 3, 7}},
+		{LinkageAverage, []float64{1, 2.5, 17.0 / 3}},
+		// Ward heights are sqrt(2·n1·n2/(n1+n2)) times the centroid distance
+		{LinkageWard, []float64{1, math.Sqrt(25.0 / 3), math.Sqrt(1.5) * 17 / 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.linkage), func(t *testing.T) {
+			c, err := HierarchicalCluster(linePoints, ClusterOptions{Linkage: tt.linkage, Labels: []string{"a", "b", "c", "d"}})
+			if err != nil {
+				t.Fatalf("HierarchicalCluster failed: %v", err)
+			}
+			if len(c.Merges) != 3 {
+				t.Fatalf("got %d merges, expected 3", len(c.Merges))
+			}
+			for i, m := range c.Merges {
+				if math.Abs(m.Height-tt.heights[i]) > 1e-9 {
+					t.Errorf("merge %d height = %v, expected %v", i, m.Height, tt.heights[i])
+				}
+			}
+
+			// a and b merge first, then c joins them, then d
+			expected := []ClusterMerge{
+				{Left: 0, Right: 1, Height: c.Merges[0].Height, Size: 2},
+				{Left: 2, Right: 4, Height: c.Merges[1].Height, Size: 3},
+				{Left: 3, Right: 5, Height: c.Merges[2].Height, Size: 4},
+			}
+			for i := range expected {
+				m := c.Merges[i]
+				if min(m.Left, m.Right) != expected[i].Left || max(m.Left, m.Right) != expected[i].Right || m.Size != expected[i].Size {
+					t.Errorf("merge %d = %+v, expected %+v", i, m, expected[i])
+				}
+			}
+
+			if c.Root.Height != c.Merges[2].Height {
+				t.Errorf("root height = %v, expected %v", c.Root.Height, c.Merges[2].Height)
+			}
+			labels := []string{}
+			for _, leaf := range collectLeaves(c.Root) {
+				labels = append(labels, leaf.Label)
+			}
+			if strings.Join(labels, "") != "abcd" {
+				t.Errorf("leaf order = %v", labels)
+			}
+			if order := c.Order(); !reflect.DeepEqual(order, []int{0, 1, 2, 3}) {
+				t.Errorf("Order = %v", order)
+			}
+		})
+	}
+}
+
+func TestPairwiseDistances(t *testing.T) {
+	data := [][]float64{{1, 0}, {0, 2}, {3, 0}, {1, 2}}
+
+	tests := []struct {
+		metric   DistanceMetric
+		i, j     int
+		expected float64
+	}{
+		{DistanceEuclidean, 0, 1, math.Sqrt(5)},
+		{DistanceCosine, 0, 1, 1},
+		{DistanceCosine, 0, 2, 0},
+		{DistanceCosine, 0, 3, 1 - 1/math.Sqrt(5)},
+		// Centered rows: {0.5,-0.5} vs {-1,1} are perfectly anti-correlated
+		{DistanceCorrelation, 0, 1, 2},
+		{DistanceCorrelation, 0, 2, 0},
+	}
+
+	for _, tt := range tests {
+		dist, err := PairwiseDistances(data, tt.metric)
+		if err != nil {
+			t.Fatalf("PairwiseDistances(%s) failed: %v", tt.metric, err)
+		}
+		if math.Abs(dist[tt.i][tt.j]-tt.expected) > 1e-9 || dist[tt.i][tt.j] != dist[tt.j][tt.i] {
+			t.Errorf("%s distance(%d, %d) = %v, expected %v", tt.metric, tt.i, tt.j, dist[tt.i][tt.j], tt.expected)
+		}
+	}
+}
+
+func TestClusterCut(t *testing.T) {
+	// Two tight groups far apart, plus an outlier
+	data := [][]float64{{0, 0}, {10, 10}, {0, 1}, {10, 11}, {1, 0}, {50, 50}}
+	c, err := HierarchicalCluster(data, ClusterOptions{Linkage: LinkageComplete})
+	if err != nil {
+		t.Fatalf("HierarchicalCluster failed: %v", err)
+	}
+
+	sameCluster := func(assignments []int, groups ...[]int) {
+		t.Helper()
+		for _, group := range groups {
+			for _, i := range group[1:] {
+				if assignments[i] != assignments[group[0]] {
+					t.Errorf("observations %d and %d are in different clusters: %v", group[0], i, assignments)
+				}
+			}
+		}
+	}
+	count := func(assignments []int) int {
+		seen := map[int]bool{}
+		for _, a := range assignments {
+			seen[a] = true
+		}
+		return len(seen)
+	}
+
+	three := c.CutClusters(3)
+	sameCluster(three, []int{0, 2, 4}, []int{1, 3})
+	if count(three) != 3 {
+		t.Errorf("CutClusters(3) found %d clusters: %v", count(three), three)
+	}
+	if !reflect.DeepEqual(c.CutHeight(5), three) {
+		t.Errorf("CutHeight(5) = %v, expected %v", c.CutHeight(5), three)
+	}
+
+	for k, expected := range map[int]int{0: 1, 1: 1, 2: 2, 6: 6, 10: 6} {
+		if got := count(c.CutClusters(k)); got != expected {
+			t.Errorf("CutClusters(%d) found %d clusters, expected %d", k, got, expected)
+		}
+	}
+	if got := count(c.CutHeight(-1)); got != 6 {
+		t.Errorf("CutHeight(-1) found %d clusters, expected 6", got)
+	}
+
+	// Clusters are numbered in leaf order
+	order := c.Order()
+	if three[order[0]] != 0 || three[order[len(order)-1]] != 2 {
+		t.Errorf("clusters are not numbered in leaf order: %v for order %v", three, order)
+	}
+
+	colors := c.BranchColors(three, []string{"red", "green", "blue"})
+	if _, ok := colors[c.Root]; ok {
+		t.Error("root joins clusters and should keep the default color")
+	}
+	svg := RenderDendrogram(DendrogramSpec{Root: c.Root, Width: 400, Height: 300, BranchColors: colors})
+	for _, color := range []string{"red", "green"} {
+		if !strings.Contains(svg, color) {
+			t.Errorf("dendrogram has no %s branches", color)
+		}
+	}
+}
+
+func TestClusterErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func() error
+		kind error
+	}{
+		{"empty", func() error { _, err := HierarchicalCluster(nil, ClusterOptions{}); return err }, ErrEmptyData},
+		{"ragged", func() error {
+			_, err := HierarchicalCluster([][]float64{{1, 2}, {3}}, ClusterOptions{})
+			return err
+		}, ErrMismatchedLengths},
+		{"NaN", func() error {
+			_, err := HierarchicalCluster([][]float64{{1}, {math.NaN()}}, ClusterOptions{})
+			return err
+		}, ErrInvalidValue},
+		{"zero vector", func() error {
+			_, err := HierarchicalCluster([][]float64{{1, 1}, {0, 0}}, ClusterOptions{Metric: DistanceCosine})
+			return err
+		}, ErrInvalidValue},
+		{"unknown linkage", func() error {
+			_, err := HierarchicalCluster(linePoints, ClusterOptions{Linkage: "median"})
+			return err
+		}, ErrUnsupported},
+		{"labels", func() error {
+			_, err := HierarchicalCluster(linePoints, ClusterOptions{Labels: []string{"a"}})
+			return err
+		}, ErrMismatchedLengths},
+		{"asymmetric", func() error {
+			_, err := ClusterDistances([][]float64{{0, 1}, {2, 0}}, ClusterOptions{})
+			return err
+		}, ErrInvalidValue},
+		{"negative", func() error {
+			_, err := ClusterDistances([][]float64{{0, -1}, {-1, 0}}, ClusterOptions{})
+			return err
+		}, ErrNegativeValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("error = %v, expected %v", err, tt.kind)
+			}
+		})
+	}
+}
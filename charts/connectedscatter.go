@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -27,6 +28,7 @@ type ConnectedScatterSeries struct {
 	LineWidth  float64 // Width of connecting line
 	MarkerType string  // "circle", "square", "diamond", "triangle", "cross", "x"
 	MarkerSize float64 // Size of markers
+	Trend      *Trendline // Optional regression fit drawn under the series
 }
 
 // ConnectedScatterSpec configures connected scatter plot rendering
@@ -80,6 +82,32 @@ func RenderConnectedScatter(spec ConnectedScatterSpec) string {
 		}
 	}
 
+	// Default colors for multiple series
+	defaultColors := []string{"#3b82f6", "#10b981", "#f59e0b", "#ef4444", "#8b5cf6", "#ec4899"}
+
+	// Fit trendlines, widening the Y range to fit their bands
+	trends := make([]*ConfidenceBand, len(spec.Series))
+	for seriesIdx, series := range spec.Series {
+		if series.Trend == nil {
+			continue
+		}
+		xs := make([]float64, len(series.Points))
+		ys := make([]float64, len(series.Points))
+		for i, point := range series.Points {
+			xs[i], ys[i] = point.X, point.Y
+		}
+		seriesColor := series.Color
+		if seriesColor == "" {
+			seriesColor = defaultColors[seriesIdx%len(defaultColors)]
+		}
+		trends[seriesIdx] = series.Trend.fit(xs, ys, seriesColor)
+		if trends[seriesIdx] != nil {
+			lo, hi := trends[seriesIdx].extent()
+			yMin = math.Min(yMin, lo)
+			yMax = math.Max(yMax, hi)
+		}
+	}
+
 	// Apply forced axis ranges if specified
 	if spec.XAxisMin != nil {
 		xMin = *spec.XAxisMin
@@ -160,8 +188,12 @@ func RenderConnectedScatter(spec ConnectedScatterSpec) string {
 	result += svg.Line(margin, margin, margin, margin+chartHeight, axisStyle) + "\n"
 	result += svg.Line(margin, margin+chartHeight, margin+chartWidth, margin+chartHeight, axisStyle) + "\n"
 
-	// Default colors for multiple series
-	defaultColors := []string{"#3b82f6", "#10b981", "#f59e0b", "#ef4444", "#8b5cf6", "#ec4899"}
+	// Draw trendlines under every series
+	for _, trend := range trends {
+		if trend != nil {
+			result += renderTrendline(trend, xScale, yScale)
+		}
+	}
 
 	// Draw each series
 	for seriesIdx, series := range spec.Series {
@@ -387,3 +419,46 @@ func RenderConnectedScatter(spec ConnectedScatterSpec) string {
 
 	return result
 }
+
+// Validate checks that the plot has series with finite points and known
+// trendline models
+func (s ConnectedScatterSpec) Validate() error {
+	if err := validateSize("connected-scatter", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Series) == 0 {
+		return &ValidationError{Chart: "connected-scatter", Field: "Series", Err: ErrEmptyData}
+	}
+	points := 0
+	for i, series := range s.Series {
+		if series == nil {
+			return invalid("connected-scatter", fmt.Sprintf("Series[%d]", i), ErrMissingField, "nil series")
+		}
+		if series.Trend != nil {
+			if err := validateTrendline("connected-scatter", fmt.Sprintf("Series[%d].Trend", i), series.Trend); err != nil {
+				return err
+			}
+		}
+		for j, p := range series.Points {
+			field := fmt.Sprintf("Series[%d].Points[%d]", i, j)
+			if err := validateNumber("connected-scatter", field+".X", p.X); err != nil {
+				return err
+			}
+			if err := validateNumber("connected-scatter", field+".Y", p.Y); err != nil {
+				return err
+			}
+		}
+		points += len(series.Points)
+	}
+	if points == 0 {
+		return invalid("connected-scatter", "Series", ErrEmptyData, "no points")
+	}
+	return nil
+}
+
+// Render renders the connected scatter plot to SVG
+func (s ConnectedScatterSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "connected-scatter", target, func() string {
+		return RenderConnectedScatter(s)
+	})
+}
@@ -3,6 +3,8 @@ package charts
 import (
 	"strings"
 	"testing"
+
+	"github.com/SCKelemen/dataviz/transforms"
 )
 
 func TestRenderConnectedScatter(t *testing.T) {
@@ -206,3 +208,33 @@ func TestRenderConnectedScatter_MultipleSeries(t *testing.T) {
 		t.Errorf("Expected at least 2 paths for multiple series, got %d", pathCount)
 	}
 }
+
+func TestRenderConnectedScatter_Trendline(t *testing.T) {
+	spec := ConnectedScatterSpec{
+		Width:  400,
+		Height: 300,
+		Series: []*ConnectedScatterSeries{
+			{
+				Points: []ConnectedScatterPoint{
+					{X: 1, Y: 2}, {X: 2, Y: 4}, {X: 3, Y: 5}, {X: 4, Y: 4}, {X: 5, Y: 5},
+				},
+				Color: "#10b981",
+				Trend: &Trendline{Regression: transforms.RegressionOptions{Level: 0.99}},
+			},
+		},
+		ShowMarkers: true,
+	}
+
+	result := RenderConnectedScatter(spec)
+
+	// The band and fitted line take the series color
+	if got := strings.Count(result, `fill="#10b981"`); got < 6 {
+		t.Errorf("Expected the band and markers in the series color, got %d fills", got)
+	}
+	if !strings.Contains(result, " Z") {
+		t.Error("Expected a closed band path")
+	}
+	if strings.Index(result, "<path") > strings.Index(result, "<circle") {
+		t.Error("Expected the trendline before the markers")
+	}
+}
@@ -1,9 +1,12 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/dataviz/scales"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
 )
@@ -22,7 +25,7 @@ type CorrelogramSpec struct {
 	ShowValues     bool   // Show correlation values in cells
 	ShowDiagonal   bool   // Show diagonal (always 1.0)
 	TriangleMode   string // "full", "upper", "lower"
-	ColorScheme    string // "redblue", "bluered", "coolwarm"
+	ColorScheme    string // "redblue", "bluered", "coolwarm", or any scales.NamedInterpolator name
 	Title          string
 	CellPadding    float64 // Padding between cells
 }
@@ -176,52 +179,31 @@ func getCorrelationColor(correlation float64, scheme string) string {
 		correlation = 1
 	}
 
-	// Map correlation to color
+	// Map correlation from [-1, 1] to [0, 1]
+	t := (correlation + 1) / 2
+	return color.RGBToHex(correlationInterpolator(scheme)(t))
+}
+
+// correlationInterpolator resolves a color scheme name to an interpolator.
+// Besides the built-in scheme names, any interpolator registered in the
+// scales package (e.g. "viridis", "PiYG", "Spectral") may be used.
+func correlationInterpolator(scheme string) scales.ColorInterpolatorFunc {
 	switch scheme {
 	case "redblue":
 		// -1 = red, 0 = white, +1 = blue
-		return interpolateRedBlue(correlation)
+		return scales.InterpolateRdBu
 	case "bluered":
 		// -1 = blue, 0 = white, +1 = red
-		return interpolateRedBlue(-correlation)
+		return scales.ReverseInterpolator(scales.InterpolateRdBu)
 	case "coolwarm":
 		// -1 = cool (blue), 0 = neutral, +1 = warm (red)
-		return interpolateCoolWarm(correlation)
-	default:
-		return interpolateRedBlue(correlation)
-	}
-}
-
-// interpolateRedBlue maps correlation to red-white-blue color scale
-func interpolateRedBlue(value float64) string {
-	if value < 0 {
-		// Negative: white to red
-		intensity := uint8(255 * (1 + value))
-		return fmt.Sprintf("rgb(255,%d,%d)", intensity, intensity)
-	} else {
-		// Positive: white to blue
-		intensity := uint8(255 * (1 - value))
-		return fmt.Sprintf("rgb(%d,%d,255)", intensity, intensity)
+		return scales.InterpolateCoolWarm
 	}
-}
 
-// interpolateCoolWarm maps correlation to cool-warm color scale
-func interpolateCoolWarm(value float64) string {
-	if value < 0 {
-		// Negative: neutral to cool (blue)
-		t := -value
-		r := uint8(220 * (1 - t) + 59*t)
-		g := uint8(220 * (1 - t) + 76*t)
-		b := uint8(220 * (1 - t) + 192*t)
-		return fmt.Sprintf("rgb(%d,%d,%d)", r, g, b)
-	} else {
-		// Positive: neutral to warm (red)
-		t := value
-		r := uint8(220 * (1 - t) + 220*t)
-		g := uint8(220 * (1 - t) + 50*t)
-		b := uint8(220 * (1 - t) + 47*t)
-		return fmt.Sprintf("rgb(%d,%d,%d)", r, g, b)
+	if interpolator, ok := scales.NamedInterpolator(scheme); ok {
+		return interpolator
 	}
+	return scales.InterpolateRdBu
 }
 
 // renderCorrelationLegend draws a vertical color scale legend
@@ -331,3 +313,40 @@ func pearsonCorrelation(x, y []float64) float64 {
 
 	return numerator / math.Sqrt(denomX*denomY)
 }
+
+// Validate checks that the matrix is square, matches the variables and
+// holds correlations in [-1, 1]
+func (s CorrelogramSpec) Validate() error {
+	if err := validateSize("correlogram", s.Width, s.Height); err != nil {
+		return err
+	}
+	n := len(s.Data.Variables)
+	if n == 0 {
+		return &ValidationError{Chart: "correlogram", Field: "Data.Variables", Err: ErrEmptyData}
+	}
+	if len(s.Data.Matrix) != n {
+		return invalid("correlogram", "Data.Matrix", ErrMismatchedLengths, "%d rows for %d variables", len(s.Data.Matrix), n)
+	}
+	for i, row := range s.Data.Matrix {
+		if len(row) != n {
+			return invalid("correlogram", fmt.Sprintf("Data.Matrix[%d]", i), ErrMismatchedLengths, "%d columns for %d variables", len(row), n)
+		}
+		for j, v := range row {
+			field := fmt.Sprintf("Data.Matrix[%d][%d]", i, j)
+			if err := validateNumber("correlogram", field, v); err != nil {
+				return err
+			}
+			if v < -1 || v > 1 {
+				return invalid("correlogram", field, ErrInvalidValue, "correlation %v is outside [-1, 1]", v)
+			}
+		}
+	}
+	return nil
+}
+
+// Render renders the correlogram to SVG
+func (s CorrelogramSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "correlogram", target, func() string {
+		return RenderCorrelogram(s)
+	})
+}
@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/SCKelemen/svg"
@@ -24,6 +25,7 @@ type DendrogramSpec struct {
 	ShowHeights  bool    // Show height scale
 	LineWidth    float64 // Width of dendrogram lines (default: 2)
 	LineColor    string  // Color of dendrogram lines
+	BranchColors map[*DendrogramNode]string // Line colors below a node, e.g. from Clustering.BranchColors
 	Title        string
 }
 
@@ -95,7 +97,7 @@ func RenderDendrogram(spec DendrogramSpec) string {
 		StrokeWidth: spec.LineWidth,
 	}
 
-	result += drawDendrogramNode(spec.Root, positions, sideMargin, topMargin, lineStyle, spec.Orientation)
+	result += drawDendrogramNode(spec.Root, positions, sideMargin, topMargin, lineStyle, spec.BranchColors, spec.Orientation)
 
 	// Draw labels
 	if spec.ShowLabels {
@@ -268,13 +270,16 @@ func calculatePositions(node *DendrogramNode, leafIndex *int, leafSpacing, chart
 }
 
 // drawDendrogramNode recursively draws the dendrogram lines
-func drawDendrogramNode(node *DendrogramNode, positions map[*DendrogramNode]position, xOffset, yOffset float64, style svg.Style, orientation string) string {
+func drawDendrogramNode(node *DendrogramNode, positions map[*DendrogramNode]position, xOffset, yOffset float64, style svg.Style, colors map[*DendrogramNode]string, orientation string) string {
 	if node == nil || len(node.Children) == 0 {
 		return ""
 	}
 
 	var result string
 	nodePos := positions[node]
+	if color, ok := colors[node]; ok {
+		style.Stroke = color
+	}
 
 	for _, child := range node.Children {
 		childPos := positions[child]
@@ -303,7 +308,7 @@ func drawDendrogramNode(node *DendrogramNode, positions map[*DendrogramNode]posi
 		}
 
 		// Recursively draw child
-		result += drawDendrogramNode(child, positions, xOffset, yOffset, style, orientation)
+		result += drawDendrogramNode(child, positions, xOffset, yOffset, style, colors, orientation)
 	}
 
 	return result
@@ -327,7 +332,8 @@ func SimpleDendrogram(labels []string, clusters [][]int, heights []float64, widt
 	}
 
 	// Build tree bottom-up from clusters
-	// This is a simplified version - in practice you'd use hierarchical clustering algorithm
+	// This is a simplified version - use HierarchicalCluster to build the
+	// tree from observations
 	// For now, create a simple two-way merge tree
 	if len(leaves) == 0 {
 		return ""
@@ -379,3 +385,44 @@ func mergeDendrogram(nodes []*DendrogramNode, heights []float64) *DendrogramNode
 	}
 	return mergeDendrogram(remaining, []float64{h + 1})
 }
+
+// Validate checks that the tree is non-empty, acyclic and has
+// non-negative merge heights
+func (s DendrogramSpec) Validate() error {
+	if err := validateSize("dendrogram", s.Width, s.Height); err != nil {
+		return err
+	}
+	if s.Root == nil {
+		return &ValidationError{Chart: "dendrogram", Field: "Root", Err: ErrEmptyData}
+	}
+
+	onPath := make(map[*DendrogramNode]bool)
+	var walk func(node *DendrogramNode, field string) error
+	walk = func(node *DendrogramNode, field string) error {
+		if node == nil {
+			return invalid("dendrogram", field, ErrMissingField, "nil node")
+		}
+		if onPath[node] {
+			return invalid("dendrogram", field, ErrCycle, "node %q is its own ancestor", node.Label)
+		}
+		if err := validateNonNegative("dendrogram", field+".Height", node.Height); err != nil {
+			return err
+		}
+		onPath[node] = true
+		for i, child := range node.Children {
+			if err := walk(child, fmt.Sprintf("%s.Children[%d]", field, i)); err != nil {
+				return err
+			}
+		}
+		delete(onPath, node)
+		return nil
+	}
+	return walk(s.Root, "Root")
+}
+
+// Render renders the dendrogram to SVG
+func (s DendrogramSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "dendrogram", target, func() string {
+		return RenderDendrogram(s)
+	})
+}
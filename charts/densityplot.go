@@ -1,9 +1,11 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
+	"github.com/SCKelemen/dataviz/kde"
 	"github.com/SCKelemen/dataviz/scales"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
@@ -21,6 +23,7 @@ type SimpleDensityData struct {
 	Label     string
 	Color     string
 	Bandwidth float64 // KDE bandwidth (0 = auto)
+	Weights   []float64 // Optional per-value weights
 }
 
 // SimpleDensitySpec configures simple density plot rendering
@@ -30,6 +33,7 @@ type SimpleDensitySpec struct {
 	Height     float64
 	ShowFill   bool
 	ShowRug    bool    // Show rug plot (data points on x-axis)
+	KDE        kde.Options // Kernel, bandwidth selection, bounds and grid; each series' Bandwidth takes precedence
 	LineWidth  float64
 	Title      string
 	XAxisLabel string
@@ -76,7 +80,8 @@ func RenderSimpleDensity(spec SimpleDensitySpec) string {
 		}
 
 		// Calculate KDE
-		density := calculateKDE(data.Values, data.Bandwidth)
+		density := calculateKDE(data.Values, seriesKDE(spec.KDE, data.Bandwidth, data.Weights))
+		globalMin, globalMax = densityExtent(globalMin, globalMax, density)
 
 		// Find max density
 		for _, dp := range density {
@@ -277,3 +282,36 @@ func RenderSimpleDensity(spec SimpleDensitySpec) string {
 
 	return result
 }
+
+// Validate checks that every series has finite values
+func (s SimpleDensitySpec) Validate() error {
+	if err := validateSize("density", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "density", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, d := range s.Data {
+		field := fmt.Sprintf("Data[%d]", i)
+		if d == nil {
+			return invalid("density", field, ErrMissingField, "nil series")
+		}
+		if err := validateValues("density", field+".Values", d.Values); err != nil {
+			return err
+		}
+		if d.Bandwidth < 0 {
+			return invalid("density", field+".Bandwidth", ErrNegativeValue, "%v", d.Bandwidth)
+		}
+		if err := validateWeights("density", field+".Weights", d.Values, d.Weights); err != nil {
+			return err
+		}
+	}
+	return validateKDE("density", s.KDE)
+}
+
+// Render renders the density plot to SVG
+func (s SimpleDensitySpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "density", target, func() string {
+		return RenderSimpleDensity(s)
+	})
+}
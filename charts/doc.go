@@ -49,6 +49,20 @@
 //
 //	svgChart := charts.RenderBarChart(data, config)
 //
+// Example - Chart interface with validation errors:
+//
+//	chart := charts.SankeySpec{Nodes: nodes, Links: links, Width: 800, Height: 600}
+//	out, err := chart.Render(ctx, charts.TargetSVG)
+//	var verr *charts.ValidationError
+//	if errors.As(err, &verr) {
+//	    log.Printf("%s: %s: %v", verr.Chart, verr.Field, verr.Err) // e.g. sankey: Links: cycle
+//	}
+//
+// Every chart spec implements Chart. Render validates first and returns a
+// *ValidationError wrapping one of the Err* kinds (ErrEmptyData,
+// ErrMismatchedLengths, ErrNegativeValue, ErrCycle, ...) instead of the
+// empty string returned by the Render* functions.
+//
 // This package uses:
 //   - layout/ - For positioning chart elements
 //   - render/svg/ or render/terminal/ - For output
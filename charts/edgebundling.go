@@ -0,0 +1,331 @@
+package charts
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/SCKelemen/svg"
+	"github.com/SCKelemen/units"
+)
+
+// EdgeBundlingSpec configures hierarchical edge bundling. The leaves of
+// the hierarchy are placed around a circle and each link between two
+// leaves is routed as a B-spline through their ancestors, so links between
+// the same branches run together in bundles.
+type EdgeBundlingSpec struct {
+	Root         *TreeNode     // Hierarchy; only names and colors are used
+	Links        []NetworkLink // Links between leaves, by leaf name
+	Width        float64
+	Height       float64
+	Tension      *float64 // How closely links follow the hierarchy, 0-1; 0 draws straight links (default: 0.85)
+	ShowLabels   bool     // Show leaf labels around the circle
+	Colors       []string // Colors of the root's branches
+	DefaultColor string   // Color used when there are no branches
+	LinkColor    string   // Default link color (default: the source leaf's branch color)
+	Title        string
+}
+
+// bundleNode is a hierarchy node placed by the radial cluster layout
+type bundleNode struct {
+	node   *TreeNode
+	parent *bundleNode
+	depth  int
+	angle  float64 // Radians clockwise from 12 o'clock
+	radius float64
+	color  string
+}
+
+// RenderEdgeBundling generates an SVG hierarchical edge bundling chart
+func RenderEdgeBundling(spec EdgeBundlingSpec) string {
+	if spec.Root == nil {
+		return ""
+	}
+
+	// Set defaults
+	tension := 0.85
+	if spec.Tension != nil {
+		tension = *spec.Tension
+	}
+	if len(spec.Colors) == 0 {
+		spec.Colors = defaultPieColors
+	}
+	if spec.DefaultColor == "" {
+		spec.DefaultColor = "#3b82f6"
+	}
+
+	leaves := bundleLayout(spec.Root, spec.Colors, spec.DefaultColor)
+	leafIndex := make(map[string]*bundleNode, len(leaves))
+	labels := make([]string, len(leaves))
+	for i, leaf := range leaves {
+		leafIndex[leaf.node.Name] = leaf
+		labels[i] = leaf.node.Name
+	}
+
+	// Fit the circle and its labels below the title
+	top := 10.0
+	if spec.Title != "" {
+		top = 36
+	}
+	labelWidth := 0.0
+	if spec.ShowLabels {
+		labelWidth = estimateLabelWidth(labels, 10) + 6
+	}
+	centerX := spec.Width / 2
+	centerY := top + (spec.Height-top)/2
+	outerRadius := math.Min(spec.Width, spec.Height-top)/2 - 10 - labelWidth
+	outerRadius = math.Max(outerRadius, 10)
+
+	point := func(b *bundleNode) (float64, float64) {
+		r := b.radius * outerRadius
+		return centerX + r*math.Sin(b.angle), centerY - r*math.Cos(b.angle)
+	}
+
+	var result string
+
+	// Draw title
+	if spec.Title != "" {
+		titleStyle := svg.Style{
+			FontSize:         units.Px(16),
+			FontFamily:       "sans-serif",
+			FontWeight:       "bold",
+			TextAnchor:       svg.TextAnchorMiddle,
+			DominantBaseline: svg.DominantBaselineHanging,
+		}
+		result += svg.Text(spec.Title, spec.Width/2, 10, titleStyle) + "\n"
+	}
+
+	// Draw links under the leaves
+	maxValue := 0.0
+	for _, l := range spec.Links {
+		maxValue = math.Max(maxValue, l.Value)
+	}
+	for _, l := range spec.Links {
+		source, target := leafIndex[l.Source], leafIndex[l.Target]
+		if source == nil || target == nil || source == target {
+			continue
+		}
+		route := bundlePath(source, target)
+		xs := make([]float64, len(route))
+		ys := make([]float64, len(route))
+		for i, b := range route {
+			xs[i], ys[i] = point(b)
+		}
+
+		width := 1.2
+		if maxValue > 0 && l.Value > 0 {
+			width = 0.8 + 2.4*l.Value/maxValue
+		}
+		linkColor := l.Color
+		if linkColor == "" {
+			linkColor = spec.LinkColor
+		}
+		if linkColor == "" {
+			linkColor = source.color
+		}
+		linkStyle := svg.Style{
+			Fill:          "none",
+			Stroke:        linkColor,
+			StrokeWidth:   width,
+			StrokeOpacity: 0.5,
+		}
+		result += svg.Path(bundleSpline(xs, ys, tension), linkStyle) + "\n"
+	}
+
+	// Draw leaves and their labels, flipping labels on the left half so
+	// they read left to right
+	labelStyle := svg.Style{
+		FontSize:         units.Px(10),
+		FontFamily:       "sans-serif",
+		Fill:             "#374151",
+		DominantBaseline: svg.DominantBaselineMiddle,
+	}
+	for _, leaf := range leaves {
+		x, y := point(leaf)
+		result += svg.Circle(x, y, 2.5, svg.Style{Fill: leaf.color}) + "\n"
+
+		if spec.ShowLabels {
+			degrees := leaf.angle*180/math.Pi - 90
+			labelStyle.TextAnchor = svg.TextAnchorStart
+			offset := 6.0
+			if leaf.angle > math.Pi {
+				degrees += 180
+				labelStyle.TextAnchor = svg.TextAnchorEnd
+				offset = -offset
+			}
+			label := svg.Text(leaf.node.Name, offset, 0, labelStyle)
+			transform := fmt.Sprintf("translate(%.2f %.2f) rotate(%.2f)", x, y, degrees)
+			result += svg.Group(label, transform, svg.Style{}) + "\n"
+		}
+	}
+
+	return result
+}
+
+// bundleLayout places the hierarchy with a radial cluster layout: leaves
+// are spaced evenly around the unit circle, each parent sits at the mean
+// angle of its children, and radii shrink with height so the root is at
+// the center. Each of the root's branches takes a color from the palette.
+// It returns the leaves in order.
+func bundleLayout(root *TreeNode, colors []string, fallback string) []*bundleNode {
+	leafCount := countBundleLeaves(root)
+	var leaves []*bundleNode
+	var nodes []*bundleNode
+	heights := make(map[*bundleNode]int)
+
+	var build func(node *TreeNode, parent *bundleNode, depth int, color string) *bundleNode
+	build = func(node *TreeNode, parent *bundleNode, depth int, color string) *bundleNode {
+		if node.Color != "" {
+			color = node.Color
+		}
+		b := &bundleNode{node: node, parent: parent, depth: depth, color: color}
+		nodes = append(nodes, b)
+		if len(node.Children) == 0 {
+			b.angle = 2 * math.Pi * float64(len(leaves)) / float64(leafCount)
+			leaves = append(leaves, b)
+			return b
+		}
+
+		for i, child := range node.Children {
+			childColor := color
+			if depth == 0 {
+				childColor = colors[i%len(colors)]
+			}
+			c := build(child, b, depth+1, childColor)
+			b.angle += c.angle
+			heights[b] = max(heights[b], heights[c]+1)
+		}
+		b.angle /= float64(len(node.Children))
+		return b
+	}
+	rootHeight := heights[build(root, nil, 0, fallback)]
+
+	for _, b := range nodes {
+		b.radius = 1
+		if rootHeight > 0 {
+			b.radius = 1 - float64(heights[b])/float64(rootHeight)
+		}
+	}
+	return leaves
+}
+
+// countBundleLeaves counts the leaves of a hierarchy
+func countBundleLeaves(node *TreeNode) int {
+	if len(node.Children) == 0 {
+		return 1
+	}
+	count := 0
+	for _, child := range node.Children {
+		count += countBundleLeaves(child)
+	}
+	return count
+}
+
+// bundlePath returns the route from one leaf to another through the
+// hierarchy: up to their lowest common ancestor and back down
+func bundlePath(source, target *bundleNode) []*bundleNode {
+	var up, down []*bundleNode
+	a, b := source, target
+	for a.depth > b.depth {
+		up = append(up, a)
+		a = a.parent
+	}
+	for b.depth > a.depth {
+		down = append(down, b)
+		b = b.parent
+	}
+	for a != b {
+		up = append(up, a)
+		down = append(down, b)
+		a, b = a.parent, b.parent
+	}
+	up = append(up, a)
+	for i := len(down) - 1; i >= 0; i-- {
+		up = append(up, down[i])
+	}
+	return up
+}
+
+// bundleSpline draws a uniform cubic B-spline through the control points,
+// first pulling each point toward the straight line between the ends. A
+// tension of 1 keeps the control points; 0 gives a straight line.
+func bundleSpline(xs, ys []float64, tension float64) string {
+	n := len(xs) - 1
+	px := make([]float64, len(xs))
+	py := make([]float64, len(ys))
+	for i := range xs {
+		t := float64(i) / float64(n)
+		px[i] = tension*xs[i] + (1-tension)*(xs[0]+t*(xs[n]-xs[0]))
+		py[i] = tension*ys[i] + (1-tension)*(ys[0]+t*(ys[n]-ys[0]))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "M %.2f %.2f", px[0], py[0])
+	if n == 1 {
+		fmt.Fprintf(&b, " L %.2f %.2f", px[1], py[1])
+		return b.String()
+	}
+
+	// Each segment is the Bézier form of the B-spline span between three
+	// consecutive control points; the ends are clamped to the first and
+	// last points
+	segment := func(i, j, k int) {
+		fmt.Fprintf(&b, " C %.2f %.2f %.2f %.2f %.2f %.2f",
+			(2*px[i]+px[j])/3, (2*py[i]+py[j])/3,
+			(px[i]+2*px[j])/3, (py[i]+2*py[j])/3,
+			(px[i]+4*px[j]+px[k])/6, (py[i]+4*py[j]+py[k])/6)
+	}
+	fmt.Fprintf(&b, " L %.2f %.2f", (5*px[0]+px[1])/6, (5*py[0]+py[1])/6)
+	for k := 2; k <= n; k++ {
+		segment(k-2, k-1, k)
+	}
+	segment(n-1, n, n)
+	fmt.Fprintf(&b, " L %.2f %.2f", px[n], py[n])
+	return b.String()
+}
+
+// Validate checks that the hierarchy is acyclic with unique leaf names and
+// that links join known leaves
+func (s EdgeBundlingSpec) Validate() error {
+	if err := validateSize("edge-bundling", s.Width, s.Height); err != nil {
+		return err
+	}
+	leaves := make(map[string]bool)
+	err := walkTree("edge-bundling", s.Root, func(node *TreeNode, field string) error {
+		if len(node.Children) > 0 {
+			return nil
+		}
+		if leaves[node.Name] {
+			return invalid("edge-bundling", field+".Name", ErrDuplicateID, "leaf %q", node.Name)
+		}
+		leaves[node.Name] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, l := range s.Links {
+		field := fmt.Sprintf("Links[%d]", i)
+		if !leaves[l.Source] {
+			return invalid("edge-bundling", field+".Source", ErrUnknownReference, "no leaf %q", l.Source)
+		}
+		if !leaves[l.Target] {
+			return invalid("edge-bundling", field+".Target", ErrUnknownReference, "no leaf %q", l.Target)
+		}
+		if err := validateNonNegative("edge-bundling", field+".Value", l.Value); err != nil {
+			return err
+		}
+	}
+	if s.Tension != nil && (*s.Tension < 0 || *s.Tension > 1) {
+		return invalid("edge-bundling", "Tension", ErrInvalidValue, "must be between 0 and 1, got %v", *s.Tension)
+	}
+	return nil
+}
+
+// Render renders the edge bundling chart to SVG
+func (s EdgeBundlingSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "edge-bundling", target, func() string {
+		return RenderEdgeBundling(s)
+	})
+}
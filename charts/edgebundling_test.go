@@ -0,0 +1,116 @@
+package charts
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+func testBundleTree() *TreeNode {
+	return &TreeNode{Name: "root", Children: []*TreeNode{
+		{Name: "ui", Children: []*TreeNode{{Name: "button"}, {Name: "menu"}}},
+		{Name: "data", Children: []*TreeNode{
+			{Name: "query", Children: []*TreeNode{{Name: "parser"}, {Name: "planner"}}},
+			{Name: "cache"},
+		}},
+	}}
+}
+
+func TestRenderEdgeBundling(t *testing.T) {
+	spec := EdgeBundlingSpec{
+		Root: testBundleTree(),
+		Links: []NetworkLink{
+			{Source: "button", Target: "parser"},
+			{Source: "menu", Target: "planner"},
+			{Source: "parser", Target: "cache"},
+		},
+		Width:      500,
+		Height:     500,
+		ShowLabels: true,
+	}
+	svg := RenderEdgeBundling(spec)
+	if n := strings.Count(svg, "<circle"); n != 5 {
+		t.Errorf("got %d leaves, expected 5", n)
+	}
+	if n := strings.Count(svg, "<path"); n != len(spec.Links) {
+		t.Errorf("got %d links, expected %d", n, len(spec.Links))
+	}
+	for _, label := range []string{"button", "cache"} {
+		if !strings.Contains(svg, ">"+label+"<") {
+			t.Errorf("missing label %q", label)
+		}
+	}
+	// Zero tension is kept rather than replaced by the default
+	straight := spec
+	straight.Tension = ptr(0)
+	if RenderEdgeBundling(straight) == svg {
+		t.Error("zero tension should straighten the links")
+	}
+
+	// Branch colors follow the root's children
+	if !strings.Contains(svg, defaultPieColors[0]) || !strings.Contains(svg, defaultPieColors[1]) {
+		t.Error("leaves should be colored by branch")
+	}
+}
+
+func TestBundleLayout(t *testing.T) {
+	leaves := bundleLayout(testBundleTree(), defaultPieColors, "#000000")
+	names := []string{}
+	for i, leaf := range leaves {
+		names = append(names, leaf.node.Name)
+		if leaf.radius != 1 {
+			t.Errorf("leaf %s radius = %v, expected 1", leaf.node.Name, leaf.radius)
+		}
+		if expected := 2 * math.Pi * float64(i) / 5; math.Abs(leaf.angle-expected) > 1e-9 {
+			t.Errorf("leaf %s angle = %v, expected %v", leaf.node.Name, leaf.angle, expected)
+		}
+	}
+	if strings.Join(names, ",") != "button,menu,parser,planner,cache" {
+		t.Errorf("leaf order = %v", names)
+	}
+
+	// button -> parser climbs to the root and back down through data and query
+	route := bundlePath(leaves[0], leaves[2])
+	path := []string{}
+	for _, b := range route {
+		path = append(path, b.node.Name)
+	}
+	if strings.Join(path, ",") != "button,ui,root,data,query,parser" {
+		t.Errorf("route = %v", path)
+	}
+	if route[2].radius != 0 || math.Abs(route[1].radius-2.0/3) > 1e-9 {
+		t.Errorf("root radius %v, ui radius %v", route[2].radius, route[1].radius)
+	}
+}
+
+func TestBundleSpline(t *testing.T) {
+	xs := []float64{0, 0, 10, 20}
+	ys := []float64{0, 20, 20, 0}
+
+	// Zero tension collapses the control points onto the chord, so every
+	// point of the path lies on y = 0
+	d := bundleSpline(xs, ys, 0)
+	coords := []float64{}
+	for _, field := range strings.Fields(d) {
+		if v, err := strconv.ParseFloat(field, 64); err == nil {
+			coords = append(coords, v)
+		}
+	}
+	for i := 1; i < len(coords); i += 2 {
+		if coords[i] != 0 {
+			t.Errorf("zero tension path leaves the chord: %s", d)
+			break
+		}
+	}
+
+	d = bundleSpline(xs, ys, 1)
+	if !strings.HasPrefix(d, "M 0.00 0.00") || !strings.HasSuffix(d, "L 20.00 0.00") {
+		t.Errorf("spline should start and end at the leaves: %s", d)
+	}
+	if n := strings.Count(d, " C "); n != 3 {
+		t.Errorf("got %d cubic segments, expected 3", n)
+	}
+}
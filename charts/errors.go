@@ -0,0 +1,166 @@
+package charts
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Validation error kinds. A *ValidationError wraps one of these, so callers
+// can test for a kind with errors.Is:
+//
+//	if errors.Is(err, charts.ErrNegativeValue) { ... }
+var (
+	ErrEmptyData         = errors.New("empty data")
+	ErrMismatchedLengths = errors.New("mismatched lengths")
+	ErrNegativeValue     = errors.New("negative value")
+	ErrInvalidValue      = errors.New("invalid value")
+	ErrInvalidSize       = errors.New("invalid size")
+	ErrMissingField      = errors.New("missing required field")
+	ErrUnknownReference  = errors.New("unknown reference")
+	ErrDuplicateID       = errors.New("duplicate id")
+	ErrCycle             = errors.New("cycle")
+	ErrUnsupported       = errors.New("unsupported option")
+	ErrUnsupportedTarget = errors.New("unsupported render target")
+)
+
+// ValidationError describes why a chart spec cannot be rendered
+type ValidationError struct {
+	Chart  string // Chart type, e.g. "sankey"
+	Field  string // Offending field, e.g. "Links[2].Value" (empty for the whole spec)
+	Err    error  // Error kind, one of the Err* values
+	Detail string // Optional human-readable detail
+}
+
+// Error formats the error as "chart: field: kind: detail"
+func (e *ValidationError) Error() string {
+	msg := e.Chart
+	if e.Field != "" {
+		msg += ": " + e.Field
+	}
+	msg += ": " + e.Err.Error()
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// Unwrap returns the error kind
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// invalid creates a validation error with a formatted detail
+func invalid(chart, field string, kind error, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Chart:  chart,
+		Field:  field,
+		Err:    kind,
+		Detail: fmt.Sprintf(format, args...),
+	}
+}
+
+// validateSize checks that a chart's width and height are positive and finite
+func validateSize(chart string, width, height float64) error {
+	if !(width > 0) || math.IsInf(width, 0) {
+		return invalid(chart, "Width", ErrInvalidSize, "must be positive, got %v", width)
+	}
+	if !(height > 0) || math.IsInf(height, 0) {
+		return invalid(chart, "Height", ErrInvalidSize, "must be positive, got %v", height)
+	}
+	return nil
+}
+
+// validateNumber checks that a value is finite
+func validateNumber(chart, field string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return invalid(chart, field, ErrInvalidValue, "must be finite, got %v", v)
+	}
+	return nil
+}
+
+// validateNonNegative checks that a value is finite and not negative
+func validateNonNegative(chart, field string, v float64) error {
+	if err := validateNumber(chart, field, v); err != nil {
+		return err
+	}
+	if v < 0 {
+		return invalid(chart, field, ErrNegativeValue, "%v", v)
+	}
+	return nil
+}
+
+// validateValues checks that a series of values is non-empty and finite
+func validateValues(chart, field string, values []float64) error {
+	if len(values) == 0 {
+		return &ValidationError{Chart: chart, Field: field, Err: ErrEmptyData}
+	}
+	for i, v := range values {
+		if err := validateNumber(chart, fmt.Sprintf("%s[%d]", field, i), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTree checks that a hierarchy is non-empty, acyclic and has
+// non-negative values with a positive total
+func validateTree(chart string, root *TreeNode) error {
+	err := walkTree(chart, root, func(node *TreeNode, field string) error {
+		if len(node.Children) == 0 {
+			return validateNonNegative(chart, field+".Value", node.Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if calculateTreeValue(root) <= 0 {
+		return invalid(chart, "Root", ErrEmptyData, "tree has no positive values")
+	}
+	return nil
+}
+
+// walkTree checks that a hierarchy is non-empty and acyclic, calling visit
+// on each node in depth-first order with its field path
+func walkTree(chart string, root *TreeNode, visit func(node *TreeNode, field string) error) error {
+	if root == nil {
+		return &ValidationError{Chart: chart, Field: "Root", Err: ErrEmptyData}
+	}
+
+	onPath := make(map[*TreeNode]bool)
+	var walk func(node *TreeNode, field string) error
+	walk = func(node *TreeNode, field string) error {
+		if node == nil {
+			return invalid(chart, field, ErrMissingField, "nil node")
+		}
+		if onPath[node] {
+			return invalid(chart, field, ErrCycle, "node %q is its own ancestor", node.Name)
+		}
+		if err := visit(node, field); err != nil {
+			return err
+		}
+
+		onPath[node] = true
+		for i, child := range node.Children {
+			if err := walk(child, fmt.Sprintf("%s.Children[%d]", field, i)); err != nil {
+				return err
+			}
+		}
+		delete(onPath, node)
+		return nil
+	}
+	return walk(root, "Root")
+}
+
+// validateBounds checks that rendering bounds have a positive size
+func validateBounds(chart string, b Bounds) error {
+	if b.Width <= 0 {
+		return invalid(chart, "Bounds.Width", ErrInvalidSize, "must be positive, got %d", b.Width)
+	}
+	if b.Height <= 0 {
+		return invalid(chart, "Bounds.Height", ErrInvalidSize, "must be positive, got %d", b.Height)
+	}
+	return nil
+}
@@ -0,0 +1,393 @@
+package charts
+
+import (
+	"math"
+	"math/rand"
+)
+
+// forceNode is a node in a force simulation
+type forceNode struct {
+	x, y   float64
+	vx, vy float64
+	radius float64 // Collision radius
+}
+
+// forceLink is a spring between two nodes
+type forceLink struct {
+	source, target int
+	distance       float64 // Rest length
+	strength       float64
+	bias           float64 // Share of the correction applied to the target
+}
+
+// forceSimulation is a velocity Verlet simulation in the style of
+// d3-force, combining link, many-body, collision and centering forces.
+// Coincident nodes are separated by jitter from a seeded source, so the
+// same input always produces the same layout.
+type forceSimulation struct {
+	nodes []forceNode
+	links []forceLink
+
+	alpha         float64 // Cooling temperature; forces scale with it
+	alphaMin      float64
+	alphaDecay    float64
+	velocityDecay float64
+
+	charge       float64 // Many-body strength; negative repels
+	theta2       float64 // Barnes–Hut accuracy, squared
+	distanceMin2 float64 // Many-body distance floor, squared
+	collide      float64 // Collision strength (0-1)
+	gravity      float64 // Pull toward the center on each axis
+	centerX      float64
+	centerY      float64
+	rng          *rand.Rand
+}
+
+// maxQuadDepth bounds quadtree depth so coincident points share a leaf
+const maxQuadDepth = 32
+
+// newForceSimulation creates a simulation with nodes placed on a
+// phyllotaxis spiral around the origin, cooling over iterations ticks
+func newForceSimulation(radii []float64, iterations int, seed int64) *forceSimulation {
+	s := &forceSimulation{
+		nodes:         make([]forceNode, len(radii)),
+		alpha:         1,
+		alphaMin:      0.001,
+		velocityDecay: 0.6,
+		charge:        -30,
+		theta2:        0.81,
+		distanceMin2:  1,
+		collide:       0.7,
+		rng:           rand.New(rand.NewSource(seed)),
+	}
+	s.alphaDecay = 1 - math.Pow(s.alphaMin, 1/float64(iterations))
+
+	golden := math.Pi * (3 - math.Sqrt(5))
+	for i, r := range radii {
+		radius := 10 * math.Sqrt(0.5+float64(i))
+		angle := float64(i) * golden
+		s.nodes[i] = forceNode{x: radius * math.Cos(angle), y: radius * math.Sin(angle), radius: r}
+	}
+	return s
+}
+
+// addLink adds a spring between two nodes. Call initLinks after adding
+// every link.
+func (s *forceSimulation) addLink(source, target int, distance float64) {
+	s.links = append(s.links, forceLink{source: source, target: target, distance: distance})
+}
+
+// initLinks weakens springs on well-connected nodes so hubs are not pulled
+// in every direction at once
+func (s *forceSimulation) initLinks() {
+	degree := make([]int, len(s.nodes))
+	for _, l := range s.links {
+		degree[l.source]++
+		degree[l.target]++
+	}
+	for i := range s.links {
+		l := &s.links[i]
+		ds, dt := float64(degree[l.source]), float64(degree[l.target])
+		l.strength = 1 / math.Min(ds, dt)
+		l.bias = ds / (ds + dt)
+	}
+}
+
+// run ticks the simulation until it cools
+func (s *forceSimulation) run() {
+	for s.alpha >= s.alphaMin {
+		s.tick()
+	}
+}
+
+// tick advances the simulation one step
+func (s *forceSimulation) tick() {
+	s.alpha += -s.alpha * s.alphaDecay
+
+	s.applyLinks()
+	s.applyCharge()
+	s.applyCollide()
+	s.applyGravity()
+
+	for i := range s.nodes {
+		n := &s.nodes[i]
+		n.vx *= s.velocityDecay
+		n.vy *= s.velocityDecay
+		n.x += n.vx
+		n.y += n.vy
+	}
+	s.applyCenter()
+}
+
+// jiggle returns a tiny random offset to separate coincident nodes
+func (s *forceSimulation) jiggle() float64 {
+	return (s.rng.Float64() - 0.5) * 1e-6
+}
+
+// applyLinks pulls or pushes linked nodes toward their rest distance
+func (s *forceSimulation) applyLinks() {
+	for _, l := range s.links {
+		source, target := &s.nodes[l.source], &s.nodes[l.target]
+		x := target.x + target.vx - source.x - source.vx
+		y := target.y + target.vy - source.y - source.vy
+		if x == 0 {
+			x = s.jiggle()
+		}
+		if y == 0 {
+			y = s.jiggle()
+		}
+		d := math.Sqrt(x*x + y*y)
+		k := (d - l.distance) / d * s.alpha * l.strength
+		x, y = x*k, y*k
+		target.vx -= x * l.bias
+		target.vy -= y * l.bias
+		source.vx += x * (1 - l.bias)
+		source.vy += y * (1 - l.bias)
+	}
+}
+
+// applyCharge applies the many-body force with the Barnes–Hut
+// approximation: distant groups of nodes act as one node at their centroid
+func (s *forceSimulation) applyCharge() {
+	if s.charge == 0 || len(s.nodes) < 2 {
+		return
+	}
+	points := make([][2]float64, len(s.nodes))
+	for i, n := range s.nodes {
+		points[i] = [2]float64{n.x, n.y}
+	}
+	tree := s.buildQuadtree(points)
+
+	for i := range s.nodes {
+		node := &s.nodes[i]
+		var visit func(q *quad)
+		visit = func(q *quad) {
+			dx, dy := q.cx-node.x, q.cy-node.y
+			w := q.x1 - q.x0
+			l := dx*dx + dy*dy
+
+			// Far enough away to treat as a single body
+			if w*w/s.theta2 < l {
+				if l < s.distanceMin2 {
+					l = math.Sqrt(s.distanceMin2 * l)
+				}
+				node.vx += dx * q.charge * s.alpha / l
+				node.vy += dy * q.charge * s.alpha / l
+				return
+			}
+			if q.points == nil {
+				for _, child := range q.children {
+					if child != nil {
+						visit(child)
+					}
+				}
+				return
+			}
+			for _, j := range q.points {
+				if j == i {
+					continue
+				}
+				dx, dy := points[j][0]-node.x, points[j][1]-node.y
+				if dx == 0 {
+					dx = s.jiggle()
+				}
+				if dy == 0 {
+					dy = s.jiggle()
+				}
+				l := dx*dx + dy*dy
+				if l < s.distanceMin2 {
+					l = math.Sqrt(s.distanceMin2 * l)
+				}
+				node.vx += dx * s.charge * s.alpha / l
+				node.vy += dy * s.charge * s.alpha / l
+			}
+		}
+		visit(tree)
+	}
+}
+
+// applyCollide pushes apart nodes whose circles overlap at their next
+// positions
+func (s *forceSimulation) applyCollide() {
+	if s.collide == 0 || len(s.nodes) < 2 {
+		return
+	}
+	points := make([][2]float64, len(s.nodes))
+	for i, n := range s.nodes {
+		points[i] = [2]float64{n.x + n.vx, n.y + n.vy}
+	}
+	tree := s.buildQuadtree(points)
+
+	for i := range s.nodes {
+		node := &s.nodes[i]
+		ri := node.radius
+		xi, yi := points[i][0], points[i][1]
+		var visit func(q *quad)
+		visit = func(q *quad) {
+			// Skip quadrants that cannot hold an overlapping node
+			r := ri + q.radius
+			if q.x0 > xi+r || q.x1 < xi-r || q.y0 > yi+r || q.y1 < yi-r {
+				return
+			}
+			if q.points == nil {
+				for _, child := range q.children {
+					if child != nil {
+						visit(child)
+					}
+				}
+				return
+			}
+			for _, j := range q.points {
+				// Each pair is resolved once, by its lower index
+				if j <= i {
+					continue
+				}
+				other := &s.nodes[j]
+				rj := other.radius
+				r := ri + rj
+				x := xi - other.x - other.vx
+				y := yi - other.y - other.vy
+				l := x*x + y*y
+				if l >= r*r {
+					continue
+				}
+				if x == 0 {
+					x = s.jiggle()
+					l += x * x
+				}
+				if y == 0 {
+					y = s.jiggle()
+					l += y * y
+				}
+				l = math.Sqrt(l)
+				k := (r - l) / l * s.collide
+				x, y = x*k, y*k
+				share := rj * rj / (ri*ri + rj*rj)
+				node.vx += x * share
+				node.vy += y * share
+				other.vx -= x * (1 - share)
+				other.vy -= y * (1 - share)
+			}
+		}
+		visit(tree)
+	}
+}
+
+// applyGravity pulls every node toward the center on each axis, keeping
+// disconnected parts of the graph together
+func (s *forceSimulation) applyGravity() {
+	if s.gravity == 0 {
+		return
+	}
+	for i := range s.nodes {
+		n := &s.nodes[i]
+		n.vx += (s.centerX - n.x) * s.gravity * s.alpha
+		n.vy += (s.centerY - n.y) * s.gravity * s.alpha
+	}
+}
+
+// applyCenter translates the nodes so their mean position is the center
+func (s *forceSimulation) applyCenter() {
+	if len(s.nodes) == 0 {
+		return
+	}
+	var sx, sy float64
+	for _, n := range s.nodes {
+		sx += n.x
+		sy += n.y
+	}
+	sx = sx/float64(len(s.nodes)) - s.centerX
+	sy = sy/float64(len(s.nodes)) - s.centerY
+	for i := range s.nodes {
+		s.nodes[i].x -= sx
+		s.nodes[i].y -= sy
+	}
+}
+
+// quad is a quadtree cell holding either four children or, at a leaf, the
+// indices of the points inside it
+type quad struct {
+	x0, y0, x1, y1 float64
+	children       [4]*quad
+	points         []int
+
+	charge float64 // Total charge of the points inside
+	cx, cy float64 // Centroid of the points inside
+	radius float64 // Largest node radius inside
+}
+
+// buildQuadtree builds a quadtree over points, which are the positions of
+// the simulation's nodes
+func (s *forceSimulation) buildQuadtree(points [][2]float64) *quad {
+	x0, y0 := math.Inf(1), math.Inf(1)
+	x1, y1 := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		x0, y0 = math.Min(x0, p[0]), math.Min(y0, p[1])
+		x1, y1 = math.Max(x1, p[0]), math.Max(y1, p[1])
+	}
+	// Square cells keep the Barnes–Hut size test meaningful
+	size := math.Max(math.Max(x1-x0, y1-y0), 1)
+
+	all := make([]int, len(points))
+	for i := range all {
+		all[i] = i
+	}
+	return s.buildQuad(points, all, x0, y0, x0+size, y0+size, 0)
+}
+
+// buildQuad builds the quadtree cell for the given points and bounds
+func (s *forceSimulation) buildQuad(points [][2]float64, indices []int, x0, y0, x1, y1 float64, depth int) *quad {
+	q := &quad{x0: x0, y0: y0, x1: x1, y1: y1}
+
+	if len(indices) == 1 || depth >= maxQuadDepth {
+		q.points = indices
+		for _, i := range indices {
+			q.cx += points[i][0]
+			q.cy += points[i][1]
+			q.radius = math.Max(q.radius, s.nodes[i].radius)
+		}
+		q.cx /= float64(len(indices))
+		q.cy /= float64(len(indices))
+		q.charge = s.charge * float64(len(indices))
+		return q
+	}
+
+	mx, my := (x0+x1)/2, (y0+y1)/2
+	var parts [4][]int
+	for _, i := range indices {
+		k := 0
+		if points[i][0] >= mx {
+			k |= 1
+		}
+		if points[i][1] >= my {
+			k |= 2
+		}
+		parts[k] = append(parts[k], i)
+	}
+
+	var count float64
+	for k, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		cx0, cx1 := x0, mx
+		if k&1 != 0 {
+			cx0, cx1 = mx, x1
+		}
+		cy0, cy1 := y0, my
+		if k&2 != 0 {
+			cy0, cy1 = my, y1
+		}
+		child := s.buildQuad(points, part, cx0, cy0, cx1, cy1, depth+1)
+		q.children[k] = child
+		n := float64(len(part))
+		q.cx += child.cx * n
+		q.cy += child.cy * n
+		q.charge += child.charge
+		q.radius = math.Max(q.radius, child.radius)
+		count += n
+	}
+	q.cx /= count
+	q.cy /= count
+	return q
+}
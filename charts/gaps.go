@@ -0,0 +1,81 @@
+package charts
+
+import (
+	"math"
+
+	"github.com/SCKelemen/dataviz/transforms"
+	"github.com/SCKelemen/svg"
+)
+
+// timeSeriesRuns splits points into the runs drawn as one line each: the
+// stretches between missing points, or every present point as a single
+// run when gaps are bridged
+func timeSeriesRuns(points []TimeSeriesData, gaps GapMode) [][]TimeSeriesData {
+	var runs [][]TimeSeriesData
+	var run []TimeSeriesData
+	for _, p := range points {
+		if !p.Missing {
+			run = append(run, p)
+			continue
+		}
+		if gaps != GapBridge && len(run) > 0 {
+			runs = append(runs, run)
+			run = nil
+		}
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// decimate reduces large series with M4 to the first, last, smallest and
+// largest points of each of width pixel columns, which draws the same line
+// as every point. Missing points that break the line are kept.
+func decimate(points []TimeSeriesData, width int) []TimeSeriesData {
+	if width <= 0 || len(points) <= 4*width {
+		return points
+	}
+	data := make([]transforms.DataPoint, len(points))
+	for i, p := range points {
+		data[i] = transforms.DataPoint{X: p.Date, Y: float64(p.Value), Index: i}
+		if p.Missing {
+			data[i].Y = math.NaN()
+		}
+	}
+	kept := transforms.M4(width)(data)
+	result := make([]TimeSeriesData, len(kept))
+	for i, d := range kept {
+		result[i] = points[d.Index]
+	}
+	return result
+}
+
+// runsPath joins the paths of each run of two or more points into one
+// path with a subpath per run
+func runsPath(runs [][]svg.Point, path func([]svg.Point) string) string {
+	var d string
+	for _, run := range runs {
+		if len(run) < 2 {
+			continue
+		}
+		if d != "" {
+			d += " "
+		}
+		d += path(run)
+	}
+	return d
+}
+
+// validateTimeSeries checks that some points have values and that the gap
+// mode is known
+func validateTimeSeries(chart string, points []TimeSeriesData, gaps GapMode) error {
+	if len(timeSeriesRuns(points, gaps)) == 0 {
+		return &ValidationError{Chart: chart, Field: "Data.Points", Err: ErrEmptyData}
+	}
+	switch gaps {
+	case "", GapBreak, GapBridge:
+		return nil
+	}
+	return invalid(chart, "Data.Gaps", ErrUnsupported, "%q", gaps)
+}
@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -53,19 +54,7 @@ func RenderLinearHeatmap(data HeatmapData, x, y int, width, height int, baseColo
 		maxCount = 1
 	}
 
-	// Parse base color
-	baseColor, err := color.ParseColor(baseColorHex)
-	if err != nil {
-		baseColor, _ = color.HexToRGB("#888888") // Fallback gray
-	}
-
-	// Create sequential color scale from light to dark
-	lightColor := color.Lighten(baseColor, 0.8) // Very light version
-	colorScale := scales.NewSequentialColorScale(
-		[2]float64{0, float64(maxCount)},
-		lightColor,
-		baseColor,
-	)
+	colorScale := heatmapColorScale(baseColorHex, maxCount)
 
 	// Position squares at the top of the content area with proper spacing
 	squareY := 8.0 // 8px spacing after title
@@ -136,19 +125,7 @@ func RenderWeeksHeatmap(data HeatmapData, x, y int, width, height int, baseColor
 		maxCount = 1
 	}
 
-	// Parse base color
-	baseColor, err := color.ParseColor(baseColorHex)
-	if err != nil {
-		baseColor, _ = color.HexToRGB("#888888") // Fallback gray
-	}
-
-	// Create sequential color scale from light to dark
-	lightColor := color.Lighten(baseColor, 0.8) // Very light version
-	colorScale := scales.NewSequentialColorScale(
-		[2]float64{0, float64(maxCount)},
-		lightColor,
-		baseColor,
-	)
+	colorScale := heatmapColorScale(baseColorHex, maxCount)
 
 	// Calculate total heatmap height for vertical centering
 	totalHeatmapHeight := float64(daysPerWeek) * cellSize
@@ -228,3 +205,54 @@ func RenderWeeksHeatmap(data HeatmapData, x, y int, width, height int, baseColor
 	b.WriteString(`</g>`) // Close main transform
 	return b.String()
 }
+
+// heatmapColorScale creates the sequential color scale for heatmap cells.
+// baseColorHex is either a color, producing a ramp from a light tint to that
+// color, or a named interpolator such as "viridis" or "YlGnBu".
+func heatmapColorScale(baseColorHex string, maxCount int) *scales.SequentialColorScale {
+	domain := [2]float64{0, float64(maxCount)}
+
+	if interpolator, ok := scales.NamedInterpolator(baseColorHex); ok {
+		return scales.NewSequentialColorScaleWithInterpolator(domain, interpolator)
+	}
+
+	// Parse base color
+	baseColor, err := color.ParseColor(baseColorHex)
+	if err != nil {
+		baseColor, _ = color.HexToRGB("#888888") // Fallback gray
+	}
+
+	// Create sequential color scale from light to dark
+	lightColor := color.Lighten(baseColor, 0.8) // Very light version
+	return scales.NewSequentialColorScale(domain, lightColor, baseColor)
+}
+
+// HeatmapSpec renders heatmap data through the Chart interface
+type HeatmapSpec struct {
+	Data   HeatmapData
+	Bounds Bounds
+	Config RenderConfig // A nil DesignTokens uses the default theme
+}
+
+// Validate checks that the heatmap has days with non-negative counts
+func (s HeatmapSpec) Validate() error {
+	if err := validateBounds("heatmap", s.Bounds); err != nil {
+		return err
+	}
+	if len(s.Data.Days) == 0 {
+		return &ValidationError{Chart: "heatmap", Field: "Data.Days", Err: ErrEmptyData}
+	}
+	for i, day := range s.Data.Days {
+		if day.Count < 0 {
+			return invalid("heatmap", fmt.Sprintf("Data.Days[%d].Count", i), ErrNegativeValue, "%d", day.Count)
+		}
+	}
+	return nil
+}
+
+// Render renders the heatmap to SVG or the terminal
+func (s HeatmapSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderWith(ctx, s, "heatmap", target, func(r Renderer) Output {
+		return r.RenderHeatmap(s.Data, s.Bounds, s.Config.withDefaults())
+	})
+}
@@ -198,3 +198,29 @@ func BenchmarkRenderWeeksHeatmap(b *testing.B) {
 		_ = RenderWeeksHeatmap(data, 0, 0, 800, 150, "#3B82F6", tokens)
 	}
 }
+
+func TestRenderLinearHeatmap_NamedInterpolator(t *testing.T) {
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	days := []ContributionDay{
+		{Date: startDate, Count: 0},
+		{Date: startDate.AddDate(0, 0, 1), Count: 10},
+	}
+
+	data := HeatmapData{
+		Days:      days,
+		StartDate: startDate,
+		EndDate:   startDate.AddDate(0, 0, 1),
+		Type:      "linear",
+	}
+
+	tokens := design.DefaultTheme()
+	result := RenderLinearHeatmap(data, 0, 0, 500, 100, "viridis", tokens)
+
+	// Viridis runs from dark purple to yellow
+	if !strings.Contains(strings.ToLower(result), "#440154") {
+		t.Error("Expected lowest count to use the first viridis color")
+	}
+	if !strings.Contains(strings.ToLower(result), "#fde725") {
+		t.Error("Expected highest count to use the last viridis color")
+	}
+}
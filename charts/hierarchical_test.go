@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"math"
 	"strings"
 	"testing"
 )
@@ -242,6 +243,23 @@ func TestRenderSunburst(t *testing.T) {
 	}
 }
 
+func TestSunburstLayout(t *testing.T) {
+	arcs := sunburstLayout(createTestTree(), 0, 50, 350)
+	if len(arcs) != 8 {
+		t.Fatalf("Expected 8 arcs, got %d", len(arcs))
+	}
+	for _, arc := range arcs {
+		// Three rings of 100 fill the space between the radii
+		if arc.InnerRadius != 50+100*float64(arc.Depth) || arc.OuterRadius != arc.InnerRadius+100 {
+			t.Errorf("%s spans radii %v-%v", arc.Node.Name, arc.InnerRadius, arc.OuterRadius)
+		}
+	}
+	// The root starts at 12 o'clock
+	if math.Abs(arcs[0].StartAngle+math.Pi/2) > 1e-9 {
+		t.Errorf("Expected the root to start at -π/2, got %v", arcs[0].StartAngle)
+	}
+}
+
 func TestRenderSunburstNilRoot(t *testing.T) {
 	spec := SunburstSpec{
 		Root:   nil,
@@ -312,29 +330,6 @@ func TestBuildArcPath(t *testing.T) {
 	}
 }
 
-func TestCalculateMaxDepth(t *testing.T) {
-	tests := []struct {
-		name     string
-		tree     *TreeNode
-		expected int
-	}{
-		{"nil node", nil, 0},
-		{"leaf node", NewTreeNode("Leaf", 10), 0},
-		{"flat tree", createFlatTree(), 1},
-		{"nested tree", createTestTree(), 2},
-		{"deep tree", createDeepTree(), 5},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			result := calculateMaxDepth(test.tree, 0)
-			if result != test.expected {
-				t.Errorf("Expected depth %d, got %d", test.expected, result)
-			}
-		})
-	}
-}
-
 // Circle Packing tests
 
 func TestRenderCirclePacking(t *testing.T) {
@@ -418,76 +413,61 @@ func TestRenderCirclePackingLeafNode(t *testing.T) {
 }
 
 func TestPackCircles(t *testing.T) {
-	root := createFlatTree()
-	circles := packCircles(root, 400, 400, 200, 2, 0)
+	root := createTestTree()
+	root.Children[0].AddChild(NewTreeNode("A4", 5)).AddChild(NewTreeNode("A5", 40))
+	circles := PackCircles(root, 800, 600, 3)
 
-	if len(circles) == 0 {
-		t.Fatal("Expected non-empty circle list")
+	// Root, A, B and seven leaves
+	if len(circles) != 10 {
+		t.Fatalf("Expected 10 circles, got %d", len(circles))
 	}
 
-	// Check that circles have valid properties
-	for i, circle := range circles {
-		if circle.Radius <= 0 {
-			t.Errorf("Circle %d has invalid radius: %f", i, circle.Radius)
-		}
-
-		if circle.Node == nil {
-			t.Errorf("Circle %d has nil node", i)
-		}
-
-		if circle.Depth < 0 {
-			t.Errorf("Circle %d has negative depth: %d", i, circle.Depth)
-		}
+	// The root fills the largest centered circle
+	if r := circles[0]; r.Node != root || r.Depth != 0 || r.X != 400 || r.Y != 300 || math.Abs(r.Radius-300) > 1e-9 {
+		t.Errorf("Expected root at (400, 300) with radius 300, got %+v", r)
 	}
-}
 
-func TestPositionCirclesPacked(t *testing.T) {
-	circles := []PackedCircle{
-		{Radius: 50, Node: NewTreeNode("A", 100), Depth: 1},
-		{Radius: 40, Node: NewTreeNode("B", 80), Depth: 1},
-		{Radius: 30, Node: NewTreeNode("C", 60), Depth: 1},
+	parents := make(map[*TreeNode]PackedCircle)
+	for _, c := range circles {
+		parents[c.Node] = c
 	}
-
-	positioned := positionCirclesPacked(circles, 400, 400, 200)
-
-	if len(positioned) != len(circles) {
-		t.Errorf("Expected %d circles, got %d", len(circles), len(positioned))
-	}
-
-	// Check that all circles have positions assigned
-	for i, circle := range positioned {
-		if circle.X == 0 && circle.Y == 0 && i > 0 {
-			// Only first circle should be at center
-			t.Errorf("Circle %d has uninitialized position", i)
+	const eps = 1e-6
+	for i, c := range circles {
+		if c.Radius <= 0 {
+			t.Errorf("Circle %d has invalid radius: %f", i, c.Radius)
+		}
+		// Children lie inside their parent, padding included
+		for _, child := range c.Node.Children {
+			cc := parents[child]
+			if d := math.Hypot(cc.X-c.X, cc.Y-c.Y); d+cc.Radius+3 > c.Radius+eps {
+				t.Errorf("%s pokes out of %s by %v", child.Name, c.Node.Name, d+cc.Radius+3-c.Radius)
+			}
+			if cc.Depth != c.Depth+1 {
+				t.Errorf("%s at depth %d under %s at depth %d", child.Name, cc.Depth, c.Node.Name, c.Depth)
+			}
+		}
+		// Siblings are at least the padding apart
+		for j, a := range c.Node.Children {
+			for _, b := range c.Node.Children[:j] {
+				ca, cb := parents[a], parents[b]
+				if d := math.Hypot(ca.X-cb.X, ca.Y-cb.Y); d < ca.Radius+cb.Radius+3-eps {
+					t.Errorf("%s and %s overlap", a.Name, b.Name)
+				}
+			}
 		}
 	}
 }
 
-func TestPositionCirclesPackedSingleCircle(t *testing.T) {
-	circles := []PackedCircle{
-		{Radius: 50, Node: NewTreeNode("Single", 100), Depth: 1},
-	}
-
-	cx, cy := 400.0, 400.0
-	positioned := positionCirclesPacked(circles, cx, cy, 200)
-
-	if len(positioned) != 1 {
-		t.Fatalf("Expected 1 circle, got %d", len(positioned))
+func TestPackCirclesAreas(t *testing.T) {
+	circles := PackCircles(createFlatTree(), 400, 400, 0)
+	// Leaf areas are proportional to values: Item4 is 2.5 times Item1
+	byName := make(map[string]float64)
+	for _, c := range circles {
+		byName[c.Node.Name] = c.Radius
 	}
-
-	// Single circle should be centered
-	if positioned[0].X != cx || positioned[0].Y != cy {
-		t.Errorf("Expected circle at center (%f, %f), got (%f, %f)",
-			cx, cy, positioned[0].X, positioned[0].Y)
-	}
-}
-
-func TestPositionCirclesPackedEmpty(t *testing.T) {
-	circles := []PackedCircle{}
-	positioned := positionCirclesPacked(circles, 400, 400, 200)
-
-	if len(positioned) != 0 {
-		t.Error("Expected empty result for empty input")
+	ratio := byName["Item4"] * byName["Item4"] / (byName["Item1"] * byName["Item1"])
+	if math.Abs(ratio-2.5) > 1e-9 {
+		t.Errorf("Expected area ratio 2.5, got %v", ratio)
 	}
 }
 
@@ -612,9 +592,8 @@ func TestRenderIcicleWithColorScheme(t *testing.T) {
 
 func TestIcicleLayoutVertical(t *testing.T) {
 	root := createFlatTree()
-	maxDepth := calculateMaxDepth(root, 0)
 
-	rects := icicleLayoutVertical(root, 0, 0, 800, 600, maxDepth, 2, 0)
+	rects := icicleLayout(root, 800, 600, 2, false)
 
 	if len(rects) == 0 {
 		t.Fatal("Expected non-empty rectangle list")
@@ -642,9 +621,8 @@ func TestIcicleLayoutVertical(t *testing.T) {
 
 func TestIcicleLayoutHorizontal(t *testing.T) {
 	root := createFlatTree()
-	maxDepth := calculateMaxDepth(root, 0)
 
-	rects := icicleLayoutHorizontal(root, 0, 0, 800, 600, maxDepth, 2, 0)
+	rects := icicleLayout(root, 800, 600, 2, true)
 
 	if len(rects) == 0 {
 		t.Fatal("Expected non-empty rectangle list")
@@ -1,6 +1,9 @@
 package charts
 
 import (
+	"context"
+	"fmt"
+	"github.com/SCKelemen/dataviz/kde"
 	"github.com/SCKelemen/dataviz/scales"
 	"github.com/SCKelemen/dataviz/transforms"
 	"github.com/SCKelemen/svg"
@@ -144,6 +147,7 @@ type DensityPlotData struct {
 	FillColor string    // Optional fill color
 	Label     string    // Optional label
 	Bandwidth float64   // KDE bandwidth (0 = auto)
+	Weights   []float64 // Optional per-value weights
 }
 
 // DensityPlotSpec configures density plot rendering
@@ -154,6 +158,7 @@ type DensityPlotSpec struct {
 	ShowFill   bool    // If true, fill area under curve
 	Smooth     bool    // If true, use smooth curves
 	LineWidth  float64 // Line width
+	KDE        kde.Options // Kernel, bandwidth selection, bounds and grid; each series' Bandwidth takes precedence
 
 	// Axis configuration
 	XAxisLabel string
@@ -189,12 +194,12 @@ func RenderDensityPlot(spec DensityPlotSpec) string {
 			}
 		}
 
-		// Calculate KDE using violin plot function
-		stats := CalculateViolinStats(data.Values, data.Bandwidth)
-		allDensities[i] = stats.Density
+		// Calculate KDE
+		allDensities[i] = calculateKDE(data.Values, seriesKDE(spec.KDE, data.Bandwidth, data.Weights))
+		globalMin, globalMax = densityExtent(globalMin, globalMax, allDensities[i])
 
 		// Find max density
-		for _, dp := range stats.Density {
+		for _, dp := range allDensities[i] {
 			if dp.Density > maxDensity {
 				maxDensity = dp.Density
 			}
@@ -284,3 +289,63 @@ func RenderDensityPlot(spec DensityPlotSpec) string {
 
 	return result
 }
+
+// Validate checks that the histogram has finite values and valid binning
+func (s HistogramSpec) Validate() error {
+	if err := validateSize("histogram", s.Width, s.Height); err != nil {
+		return err
+	}
+	if s.Data == nil {
+		return &ValidationError{Chart: "histogram", Field: "Data", Err: ErrEmptyData}
+	}
+	if err := validateValues("histogram", "Data.Values", s.Data.Values); err != nil {
+		return err
+	}
+	if s.BinCount < 0 {
+		return invalid("histogram", "BinCount", ErrNegativeValue, "%d", s.BinCount)
+	}
+	if s.BinSize < 0 {
+		return invalid("histogram", "BinSize", ErrNegativeValue, "%v", s.BinSize)
+	}
+	return nil
+}
+
+// Render renders the histogram to SVG
+func (s HistogramSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "histogram", target, func() string {
+		return RenderHistogram(s)
+	})
+}
+
+// Validate checks that every series has finite values
+func (s DensityPlotSpec) Validate() error {
+	if err := validateSize("density-plot", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "density-plot", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, d := range s.Data {
+		field := fmt.Sprintf("Data[%d]", i)
+		if d == nil {
+			return invalid("density-plot", field, ErrMissingField, "nil series")
+		}
+		if err := validateValues("density-plot", field+".Values", d.Values); err != nil {
+			return err
+		}
+		if d.Bandwidth < 0 {
+			return invalid("density-plot", field+".Bandwidth", ErrNegativeValue, "%v", d.Bandwidth)
+		}
+		if err := validateWeights("density-plot", field+".Weights", d.Values, d.Weights); err != nil {
+			return err
+		}
+	}
+	return validateKDE("density-plot", s.KDE)
+}
+
+// Render renders the density plot to SVG
+func (s DensityPlotSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "density-plot", target, func() string {
+		return RenderDensityPlot(s)
+	})
+}
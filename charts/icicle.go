@@ -1,6 +1,9 @@
 package charts
 
 import (
+	"context"
+
+	"github.com/SCKelemen/dataviz/hierarchy"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
 )
@@ -40,19 +43,8 @@ func RenderIcicle(spec IcicleSpec) string {
 		return ""
 	}
 
-	// Calculate maximum depth for level sizing
-	maxDepth := calculateMaxDepth(spec.Root, 0)
-	if maxDepth == 0 {
-		maxDepth = 1
-	}
-
 	// Compute layout
-	var rects []IcicleRect
-	if spec.Orientation == "horizontal" {
-		rects = icicleLayoutHorizontal(spec.Root, 0, 0, spec.Width, spec.Height, maxDepth, spec.Padding, 0)
-	} else {
-		rects = icicleLayoutVertical(spec.Root, 0, 0, spec.Width, spec.Height, maxDepth, spec.Padding, 0)
-	}
+	rects := icicleLayout(spec.Root, spec.Width, spec.Height, spec.Padding, spec.Orientation == "horizontal")
 
 	// Render rectangles
 	var result string
@@ -107,127 +99,39 @@ func RenderIcicle(spec IcicleSpec) string {
 	return result
 }
 
-// icicleLayoutVertical computes vertical icicle layout (depth goes top to bottom)
-func icicleLayoutVertical(node *TreeNode, x, y, width, height float64, maxDepth int, padding float64, depth int) []IcicleRect {
-	if node == nil || width <= 0 || height <= 0 {
+// icicleLayout partitions the hierarchy into one row per depth, top to
+// bottom or left to right when horizontal, and insets each rectangle by
+// the padding
+func icicleLayout(root *TreeNode, width, height, padding float64, horizontal bool) []IcicleRect {
+	h := hierarchy.New(root)
+	if h == nil {
 		return nil
 	}
+	h.Sum(nil)
 
-	// Calculate height for this level
-	levelHeight := height / float64(maxDepth+1)
-
-	var rects []IcicleRect
-
-	// Add rectangle for current node
-	rects = append(rects, IcicleRect{
-		X:      x + padding,
-		Y:      y + padding,
-		Width:  width - 2*padding,
-		Height: levelHeight - 2*padding,
-		Node:   node,
-		Depth:  depth,
-	})
-
-	// If no children, we're done
-	if len(node.Children) == 0 {
-		return rects
+	partition := hierarchy.Partition{Width: width, Height: height}
+	if horizontal {
+		partition.Width, partition.Height = height, width
 	}
 
-	// Calculate total value of children
-	total := 0.0
-	for _, child := range node.Children {
-		total += calculateTreeValue(child)
-	}
-
-	if total == 0 {
-		return rects
-	}
-
-	// Layout children horizontally at the next level
-	currentX := x
-	nextY := y + levelHeight
-
-	for _, child := range node.Children {
-		childValue := calculateTreeValue(child)
-		childWidth := (childValue / total) * width
-
-		childRects := icicleLayoutVertical(
-			child,
-			currentX,
-			nextY,
-			childWidth,
-			height-levelHeight,
-			maxDepth,
-			padding,
-			depth+1,
-		)
-		rects = append(rects, childRects...)
-
-		currentX += childWidth
-	}
-
-	return rects
-}
-
-// icicleLayoutHorizontal computes horizontal icicle layout (depth goes left to right)
-func icicleLayoutHorizontal(node *TreeNode, x, y, width, height float64, maxDepth int, padding float64, depth int) []IcicleRect {
-	if node == nil || width <= 0 || height <= 0 {
-		return nil
-	}
-
-	// Calculate width for this level
-	levelWidth := width / float64(maxDepth+1)
-
 	var rects []IcicleRect
-
-	// Add rectangle for current node
-	rects = append(rects, IcicleRect{
-		X:      x + padding,
-		Y:      y + padding,
-		Width:  levelWidth - 2*padding,
-		Height: height - 2*padding,
-		Node:   node,
-		Depth:  depth,
-	})
-
-	// If no children, we're done
-	if len(node.Children) == 0 {
-		return rects
-	}
-
-	// Calculate total value of children
-	total := 0.0
-	for _, child := range node.Children {
-		total += calculateTreeValue(child)
-	}
-
-	if total == 0 {
-		return rects
-	}
-
-	// Layout children vertically at the next level
-	currentY := y
-	nextX := x + levelWidth
-
-	for _, child := range node.Children {
-		childValue := calculateTreeValue(child)
-		childHeight := (childValue / total) * height
-
-		childRects := icicleLayoutHorizontal(
-			child,
-			nextX,
-			currentY,
-			width-levelWidth,
-			childHeight,
-			maxDepth,
-			padding,
-			depth+1,
-		)
-		rects = append(rects, childRects...)
-
-		currentY += childHeight
+	for _, r := range partition.Layout(h) {
+		if r.Width() <= 0 {
+			continue
+		}
+		x, y, w, ht := r.X0, r.Y0, r.Width(), r.Height()
+		if horizontal {
+			x, y, w, ht = y, x, ht, w
+		}
+		rects = append(rects, IcicleRect{
+			X:      x + padding,
+			Y:      y + padding,
+			Width:  w - 2*padding,
+			Height: ht - 2*padding,
+			Node:   r.Node.Data,
+			Depth:  r.Node.Depth,
+		})
 	}
-
 	return rects
 }
 
@@ -251,3 +155,18 @@ func calculateIcicleFontSize(width, height float64) units.Length {
 
 	return units.Px(size)
 }
+
+// Validate checks that the hierarchy is non-empty, acyclic and non-negative
+func (s IcicleSpec) Validate() error {
+	if err := validateSize("icicle", s.Width, s.Height); err != nil {
+		return err
+	}
+	return validateTree("icicle", s.Root)
+}
+
+// Render renders the icicle chart to SVG
+func (s IcicleSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "icicle", target, func() string {
+		return RenderIcicle(s)
+	})
+}
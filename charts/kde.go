@@ -0,0 +1,90 @@
+package charts
+
+import (
+	"fmt"
+
+	"github.com/SCKelemen/dataviz/kde"
+)
+
+// calculateKDE estimates the density of values with the kde package, on a
+// grid of 100 points over the data range unless opts sets another
+func calculateKDE(values []float64, opts kde.Options) []DensityPoint {
+	points := kde.Estimate(values, opts)
+	if points == nil {
+		return nil
+	}
+	density := make([]DensityPoint, len(points))
+	for i, p := range points {
+		density[i] = DensityPoint{Value: p.X, Density: p.Density}
+	}
+	return density
+}
+
+// seriesKDE returns a chart's density options for one series, with the
+// series' weights and, when set, its bandwidth
+func seriesKDE(opts kde.Options, bandwidth float64, weights []float64) kde.Options {
+	if bandwidth > 0 {
+		opts.Bandwidth = bandwidth
+	}
+	opts.Weights = weights
+	return opts
+}
+
+// densityExtent widens [lo, hi] to cover the density curves, which extend
+// past the data when the grid has a cut or explicit points
+func densityExtent(lo, hi float64, curves ...[]DensityPoint) (float64, float64) {
+	for _, curve := range curves {
+		for _, dp := range curve {
+			lo = min(lo, dp.Value)
+			hi = max(hi, dp.Value)
+		}
+	}
+	return lo, hi
+}
+
+// validateKDE checks a chart's density options. Weights belong to each
+// series, so the chart-wide options can't set them.
+func validateKDE(chart string, opts kde.Options) error {
+	switch opts.Kernel {
+	case "", kde.Gaussian, kde.Epanechnikov, kde.Triangular, kde.Uniform:
+	default:
+		return invalid(chart, "KDE.Kernel", ErrUnsupported, "%q", opts.Kernel)
+	}
+	switch opts.Method {
+	case "", kde.Silverman, kde.Scott, kde.SheatherJones:
+	default:
+		return invalid(chart, "KDE.Method", ErrUnsupported, "%q", opts.Method)
+	}
+	if opts.Bandwidth < 0 {
+		return invalid(chart, "KDE.Bandwidth", ErrNegativeValue, "%v", opts.Bandwidth)
+	}
+	if opts.Points < 0 {
+		return invalid(chart, "KDE.Points", ErrNegativeValue, "%d", opts.Points)
+	}
+	if opts.Cut < 0 {
+		return invalid(chart, "KDE.Cut", ErrNegativeValue, "%v", opts.Cut)
+	}
+	if opts.Lower != nil && opts.Upper != nil && *opts.Lower >= *opts.Upper {
+		return invalid(chart, "KDE.Upper", ErrInvalidValue, "must be above Lower %v, got %v", *opts.Lower, *opts.Upper)
+	}
+	if opts.Weights != nil {
+		return invalid(chart, "KDE.Weights", ErrUnsupported, "set Weights on each series")
+	}
+	return nil
+}
+
+// validateWeights checks that a series' weights match its values
+func validateWeights(chart, field string, values, weights []float64) error {
+	if weights != nil && len(weights) != len(values) {
+		return invalid(chart, field, ErrMismatchedLengths, "%d weights for %d values", len(weights), len(values))
+	}
+	for i, w := range weights {
+		if err := validateNumber(chart, fmt.Sprintf("%s[%d]", field, i), w); err != nil {
+			return err
+		}
+		if w < 0 {
+			return invalid(chart, fmt.Sprintf("%s[%d]", field, i), ErrNegativeValue, "%v", w)
+		}
+	}
+	return nil
+}
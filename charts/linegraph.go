@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync/atomic"
@@ -22,34 +23,39 @@ var gradientCounter int64
 func RenderLineGraph(data LineGraphData, x, y int, width, height int, designTokens *design.DesignTokens) string {
 	var b strings.Builder
 
-	if len(data.Points) == 0 {
+	// Reserve space for Y-axis labels
+	labelAreaWidth := 2 * designTokens.Layout.CardPaddingRight
+	plotWidth := width - labelAreaWidth
+
+	// Missing points split the line into runs, after thinning the points
+	// to what the plot's pixel columns can show
+	runs := timeSeriesRuns(decimate(data.Points, plotWidth), data.Gaps)
+	if len(runs) == 0 {
 		return ""
 	}
 
 	// Find min/max values for domain
-	minValue := data.Points[0].Value
-	maxValue := data.Points[0].Value
-	minTime := data.Points[0].Date
-	maxTime := data.Points[0].Date
-	for _, point := range data.Points {
-		if point.Value < minValue {
-			minValue = point.Value
-		}
-		if point.Value > maxValue {
-			maxValue = point.Value
-		}
-		if point.Date.Before(minTime) {
-			minTime = point.Date
-		}
-		if point.Date.After(maxTime) {
-			maxTime = point.Date
+	minValue := runs[0][0].Value
+	maxValue := runs[0][0].Value
+	minTime := runs[0][0].Date
+	maxTime := runs[0][0].Date
+	for _, run := range runs {
+		for _, point := range run {
+			if point.Value < minValue {
+				minValue = point.Value
+			}
+			if point.Value > maxValue {
+				maxValue = point.Value
+			}
+			if point.Date.Before(minTime) {
+				minTime = point.Date
+			}
+			if point.Date.After(maxTime) {
+				maxTime = point.Date
+			}
 		}
 	}
 
-	// Reserve space for Y-axis labels
-	labelAreaWidth := 2 * designTokens.Layout.CardPaddingRight
-	plotWidth := width - labelAreaWidth
-
 	// Create TimeScale for X-axis (dates to positions)
 	xScale := scales.NewTimeScale(
 		[2]time.Time{minTime, maxTime},
@@ -101,27 +107,36 @@ func RenderLineGraph(data LineGraphData, x, y int, width, height int, designToke
 	b.WriteString(yAxis.Render(axisOpts))
 
 	// Calculate scaled points using scales
-	scaledPoints := make([]svg.Point, len(data.Points))
-	for i, point := range data.Points {
-		scaledPoints[i] = svg.Point{
-			X: xScale.Apply(point.Date).Value,
-			Y: yScale.Apply(float64(point.Value)).Value,
+	scaledRuns := make([][]svg.Point, len(runs))
+	var scaledPoints []svg.Point
+	for i, run := range runs {
+		for _, point := range run {
+			scaledRuns[i] = append(scaledRuns[i], svg.Point{
+				X: xScale.Apply(point.Date).Value,
+				Y: yScale.Apply(float64(point.Value)).Value,
+			})
 		}
+		scaledPoints = append(scaledPoints, scaledRuns[i]...)
 	}
-
-	// Draw filled area (if fill color specified)
-	if data.FillColor != "" && len(data.Points) > 1 {
-		var areaPath string
+	tension := data.Tension
+	if tension == 0 {
+		tension = 0.3 // Default tension
+	}
+	areaPath := runsPath(scaledRuns, func(run []svg.Point) string {
 		if data.Smooth {
-			tension := data.Tension
-			if tension == 0 {
-				tension = 0.3 // Default tension
-			}
-			areaPath = svg.SmoothAreaPath(scaledPoints, float64(height), tension)
-		} else {
-			areaPath = svg.AreaPath(scaledPoints, float64(height))
+			return svg.SmoothAreaPath(run, float64(height), tension)
 		}
+		return svg.AreaPath(run, float64(height))
+	})
+	linePath := runsPath(scaledRuns, func(run []svg.Point) string {
+		if data.Smooth {
+			return svg.SmoothLinePath(run, tension)
+		}
+		return svg.PolylinePath(run)
+	})
 
+	// Draw filled area (if fill color specified)
+	if data.FillColor != "" && areaPath != "" {
 		pathStyle := svg.Style{
 			Fill: fillValue,
 		}
@@ -134,18 +149,7 @@ func RenderLineGraph(data LineGraphData, x, y int, width, height int, designToke
 	}
 
 	// Draw line using PathBuilder
-	if len(data.Points) > 1 {
-		var linePath string
-		if data.Smooth {
-			tension := data.Tension
-			if tension == 0 {
-				tension = 0.3 // Default tension
-			}
-			linePath = svg.SmoothLinePath(scaledPoints, tension)
-		} else {
-			linePath = svg.PolylinePath(scaledPoints)
-		}
-
+	if linePath != "" {
 		pathStyle := svg.Style{
 			Fill:           "none",
 			Stroke:         data.Color,
@@ -157,6 +161,17 @@ func RenderLineGraph(data LineGraphData, x, y int, width, height int, designToke
 		b.WriteString("\n")
 	}
 
+	// Without markers, points isolated by missing neighbors would not
+	// show at all, so draw them as dots
+	if data.MarkerType == "" && len(data.Points) > 1 {
+		for _, run := range scaledRuns {
+			if len(run) == 1 {
+				b.WriteString(svg.Circle(run[0].X, run[0].Y, 1.5, svg.Style{Fill: data.Color}))
+				b.WriteString("\n")
+			}
+		}
+	}
+
 	// Draw points with custom markers if specified
 	if data.MarkerType != "" {
 		markerSize := data.MarkerSize
@@ -232,3 +247,26 @@ func RenderLineGraph(data LineGraphData, x, y int, width, height int, designToke
 
 	return b.String()
 }
+
+// LineGraphSpec renders line graph data through the Chart interface
+type LineGraphSpec struct {
+	Data   LineGraphData
+	Bounds Bounds
+	Config RenderConfig // A nil DesignTokens uses the default theme
+}
+
+// Validate checks that the line graph has points with values and a known
+// gap mode
+func (s LineGraphSpec) Validate() error {
+	if err := validateBounds("line-graph", s.Bounds); err != nil {
+		return err
+	}
+	return validateTimeSeries("line-graph", s.Data.Points, s.Data.Gaps)
+}
+
+// Render renders the line graph to SVG or the terminal
+func (s LineGraphSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderWith(ctx, s, "line-graph", target, func(r Renderer) Output {
+		return r.RenderLineGraph(s.Data, s.Bounds, s.Config.withDefaults())
+	})
+}
@@ -168,6 +168,36 @@ func TestRenderLineGraph_SinglePoint(t *testing.T) {
 	}
 }
 
+func TestRenderLineGraph_Gaps(t *testing.T) {
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(i int) time.Time { return startDate.AddDate(0, 0, i) }
+
+	tests := []struct {
+		name     string
+		points   []TimeSeriesData
+		gaps     GapMode
+		subpaths int
+		dots     int
+	}{
+		{"break", []TimeSeriesData{{Date: day(0), Value: 1}, {Date: day(1), Value: 3}, {Date: day(2), Missing: true}, {Date: day(3), Value: 2}, {Date: day(4), Value: 5}}, "", 2, 0},
+		{"bridge", []TimeSeriesData{{Date: day(0), Value: 1}, {Date: day(1), Value: 3}, {Date: day(2), Missing: true}, {Date: day(3), Value: 2}, {Date: day(4), Value: 5}}, GapBridge, 1, 0},
+		{"isolated points", []TimeSeriesData{{Date: day(0), Value: 1}, {Date: day(1), Missing: true}, {Date: day(2), Value: 0}, {Date: day(3), Missing: true}, {Date: day(4), Value: 5}}, GapBreak, 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := LineGraphData{Points: tt.points, Color: "#3B82F6", Gaps: tt.gaps}
+			result := RenderLineGraph(data, 0, 0, 400, 200, design.DefaultTheme())
+
+			if got := strings.Count(result, "M "); got != tt.subpaths {
+				t.Errorf("Expected %d subpaths, got %d", tt.subpaths, got)
+			}
+			if got := strings.Count(result, "<circle"); got != tt.dots {
+				t.Errorf("Expected %d dots, got %d", tt.dots, got)
+			}
+		})
+	}
+}
+
 func BenchmarkRenderLineGraph(b *testing.B) {
 	startDate := time.Now()
 	points := make([]TimeSeriesData, 100)
@@ -215,3 +245,24 @@ func BenchmarkRenderLineGraph_Smooth(b *testing.B) {
 		_ = RenderLineGraph(data, 0, 0, 800, 400, tokens)
 	}
 }
+
+func TestRenderLineGraph_Decimates(t *testing.T) {
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := make([]TimeSeriesData, 100000)
+	for i := range points {
+		points[i] = TimeSeriesData{Date: startDate.Add(time.Duration(i) * time.Second), Value: i % 10}
+	}
+	points[54321].Value = 1000
+
+	data := LineGraphData{Points: points, Color: "#3B82F6", MarkerType: "circle"}
+	result := RenderLineGraph(data, 0, 0, 400, 200, design.DefaultTheme())
+
+	// At most four points for each pixel column are drawn
+	if got := strings.Count(result, "<circle"); got == 0 || got > 4*400 {
+		t.Errorf("Expected at most %d markers, got %d", 4*400, got)
+	}
+	// The spike still sets the Y domain
+	if !strings.Contains(result, ">1000<") {
+		t.Error("Expected the spike to be kept")
+	}
+}
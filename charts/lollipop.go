@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/SCKelemen/svg"
@@ -314,3 +315,26 @@ func renderHorizontalLollipops(spec LollipopSpec, margin, chartWidth, chartHeigh
 
 	return result
 }
+
+// Validate checks that the chart has finite values
+func (s LollipopSpec) Validate() error {
+	if err := validateSize("lollipop", s.Width, s.Height); err != nil {
+		return err
+	}
+	if s.Data == nil || len(s.Data.Values) == 0 {
+		return &ValidationError{Chart: "lollipop", Field: "Data.Values", Err: ErrEmptyData}
+	}
+	for i, p := range s.Data.Values {
+		if err := validateNumber("lollipop", fmt.Sprintf("Data.Values[%d].Value", i), p.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the lollipop chart to SVG
+func (s LollipopSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "lollipop", target, func() string {
+		return RenderLollipop(s)
+	})
+}
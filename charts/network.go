@@ -0,0 +1,451 @@
+package charts
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/dataviz/charts/legends"
+	"github.com/SCKelemen/svg"
+	"github.com/SCKelemen/units"
+)
+
+// NetworkNode represents a node in a network graph
+type NetworkNode struct {
+	ID    string
+	Label string  // Display label (default: ID)
+	Group string  // Optional category; nodes in a group share a color
+	Value float64 // Optional size attribute; area grows with the value
+	Color string  // Optional custom color (overrides the group color)
+}
+
+// NetworkLink represents an edge between two nodes
+type NetworkLink struct {
+	Source string  // Source node ID
+	Target string  // Target node ID
+	Value  float64 // Optional weight; scales the line width (default: 1)
+	Color  string  // Optional custom color
+}
+
+// NetworkSpec configures network graph rendering. Nodes are placed by a
+// force simulation: links act as springs, nodes repel each other, circles
+// do not overlap and the graph is pulled toward the center.
+type NetworkSpec struct {
+	Nodes          []NetworkNode
+	Links          []NetworkLink
+	Width          float64
+	Height         float64
+	Directed       bool     // Draw arrowheads at link targets
+	ShowLabels     bool     // Show node labels
+	ShowLegend     bool     // Show a legend of node groups
+	MinRadius      float64  // Radius of the smallest node (default: 5)
+	MaxRadius      float64  // Radius of the largest node (default: 15)
+	LinkDistance   float64  // Rest length of links between node edges (default: 40)
+	ChargeStrength float64  // Node repulsion; more negative spreads nodes out (default: -120)
+	Iterations     int      // Simulation ticks (default: 300)
+	Seed           int64    // Seed for the simulation's jitter; the layout is deterministic for a seed
+	Colors         []string // Group colors, in order of first appearance
+	DefaultColor   string   // Color of nodes without a group or color
+	LinkColor      string   // Default link color
+	Title          string
+}
+
+// networkLayout holds the computed positions and sizes of a network graph
+type networkLayout struct {
+	x, y, radius []float64
+	index        map[string]int
+}
+
+// RenderNetwork generates an SVG force-directed network graph
+func RenderNetwork(spec NetworkSpec) string {
+	if len(spec.Nodes) == 0 {
+		return ""
+	}
+
+	// Set defaults
+	if spec.MinRadius == 0 {
+		spec.MinRadius = 5
+	}
+	if spec.MaxRadius == 0 {
+		spec.MaxRadius = 15
+	}
+	if spec.LinkDistance == 0 {
+		spec.LinkDistance = 40
+	}
+	if spec.ChargeStrength == 0 {
+		spec.ChargeStrength = -120
+	}
+	if spec.Iterations <= 0 {
+		spec.Iterations = 300
+	}
+	if len(spec.Colors) == 0 {
+		spec.Colors = defaultPieColors
+	}
+	if spec.DefaultColor == "" {
+		spec.DefaultColor = "#3b82f6"
+	}
+	if spec.LinkColor == "" {
+		spec.LinkColor = "#9ca3af"
+	}
+
+	groupColors, groups := assignGroupColors(spec.Nodes, spec.Colors)
+
+	// Reserve room for the title and legend
+	top := 10.0
+	if spec.Title != "" {
+		top = 36
+	}
+	right := 10.0
+	var legend *legends.Legend
+	if spec.ShowLegend && len(groups) > 0 {
+		legend = groupLegend(groups, groupColors, spec.DefaultColor)
+		right += legend.GetBounds(int(spec.Width), int(spec.Height)).Width
+	}
+
+	layout := layoutNetwork(spec)
+	fitNetwork(layout, spec, 10, top, spec.Width-right, spec.Height-10)
+
+	var result string
+
+	// Draw title
+	if spec.Title != "" {
+		titleStyle := svg.Style{
+			FontSize:         units.Px(16),
+			FontFamily:       "sans-serif",
+			FontWeight:       "bold",
+			TextAnchor:       svg.TextAnchorMiddle,
+			DominantBaseline: svg.DominantBaselineHanging,
+		}
+		result += svg.Text(spec.Title, spec.Width/2, 10, titleStyle) + "\n"
+	}
+
+	// Draw links under the nodes
+	maxValue := 0.0
+	pairs := make(map[[2]int]bool)
+	for _, l := range spec.Links {
+		maxValue = math.Max(maxValue, l.Value)
+		pairs[[2]int{layout.index[l.Source], layout.index[l.Target]}] = true
+	}
+	for _, l := range spec.Links {
+		s, t := layout.index[l.Source], layout.index[l.Target]
+		width := 1.5
+		if maxValue > 0 && l.Value > 0 {
+			width = 1 + 3*l.Value/maxValue
+		}
+		linkColor := l.Color
+		if linkColor == "" {
+			linkColor = spec.LinkColor
+		}
+		// Bend one of each pair of opposite directed links so both show
+		curved := spec.Directed && s != t && pairs[[2]int{t, s}]
+		result += drawNetworkLink(layout, s, t, width, linkColor, spec.Directed, curved)
+	}
+
+	// Draw nodes
+	for i, n := range spec.Nodes {
+		nodeStyle := svg.Style{
+			Fill:        nodeColor(n, groupColors, spec.DefaultColor),
+			Stroke:      "#ffffff",
+			StrokeWidth: 1.5,
+		}
+		result += svg.Circle(layout.x[i], layout.y[i], layout.radius[i], nodeStyle) + "\n"
+	}
+
+	// Draw labels
+	if spec.ShowLabels {
+		labelStyle := svg.Style{
+			FontSize:         units.Px(10),
+			FontFamily:       "sans-serif",
+			Fill:             "#374151",
+			TextAnchor:       svg.TextAnchorStart,
+			DominantBaseline: svg.DominantBaselineMiddle,
+		}
+		for i, n := range spec.Nodes {
+			result += svg.Text(networkLabel(n), layout.x[i]+layout.radius[i]+3, layout.y[i], labelStyle) + "\n"
+		}
+	}
+
+	if legend != nil {
+		result += legend.Render(int(spec.Width), int(spec.Height))
+	}
+
+	return result
+}
+
+// assignGroupColors gives each node group a color from the palette, in
+// order of first appearance, and returns the groups in that order
+func assignGroupColors(nodes []NetworkNode, colors []string) (map[string]string, []string) {
+	groupColors := make(map[string]string)
+	var groups []string
+	for _, n := range nodes {
+		if n.Group != "" {
+			if _, ok := groupColors[n.Group]; !ok {
+				groupColors[n.Group] = colors[len(groups)%len(colors)]
+				groups = append(groups, n.Group)
+			}
+		}
+	}
+	return groupColors, groups
+}
+
+// groupLegend builds a vertical top-right legend of node groups
+func groupLegend(groups []string, groupColors map[string]string, fallback string) *legends.Legend {
+	items := make([]legends.LegendItem, len(groups))
+	for i, g := range groups {
+		c, err := color.ParseColor(groupColors[g])
+		if err != nil {
+			c, _ = color.HexToRGB(fallback)
+		}
+		items[i] = legends.Item(g, legends.Swatch(c))
+	}
+	return legends.New(items,
+		legends.WithPosition(legends.PositionTopRight),
+		legends.WithLayout(legends.LayoutVertical),
+	)
+}
+
+// nodeColor returns a node's own color, its group color or the fallback
+func nodeColor(n NetworkNode, groupColors map[string]string, fallback string) string {
+	if n.Color != "" {
+		return n.Color
+	}
+	if c := groupColors[n.Group]; c != "" {
+		return c
+	}
+	return fallback
+}
+
+// networkLabel returns the label of a node, defaulting to its ID
+func networkLabel(n NetworkNode) string {
+	if n.Label != "" {
+		return n.Label
+	}
+	return n.ID
+}
+
+// layoutNetwork sizes the nodes and runs the force simulation
+func layoutNetwork(spec NetworkSpec) *networkLayout {
+	n := len(spec.Nodes)
+	layout := &networkLayout{
+		x:      make([]float64, n),
+		y:      make([]float64, n),
+		radius: make([]float64, n),
+		index:  make(map[string]int, n),
+	}
+
+	// Node area grows linearly with the value
+	maxValue := 0.0
+	for i, node := range spec.Nodes {
+		layout.index[node.ID] = i
+		maxValue = math.Max(maxValue, node.Value)
+	}
+	for i, node := range spec.Nodes {
+		layout.radius[i] = spec.MinRadius
+		if maxValue > 0 {
+			layout.radius[i] += (spec.MaxRadius - spec.MinRadius) * math.Sqrt(node.Value/maxValue)
+		}
+	}
+
+	sim := newForceSimulation(layout.radius, spec.Iterations, spec.Seed)
+	sim.charge = spec.ChargeStrength
+	sim.gravity = 0.05
+	for i := range sim.nodes {
+		// Leave room between circles for links and labels
+		sim.nodes[i].radius += 2
+	}
+	for _, l := range spec.Links {
+		s, t := layout.index[l.Source], layout.index[l.Target]
+		if s == t {
+			continue
+		}
+		sim.addLink(s, t, spec.LinkDistance+layout.radius[s]+layout.radius[t])
+	}
+	sim.initLinks()
+	sim.run()
+
+	for i, node := range sim.nodes {
+		layout.x[i], layout.y[i] = node.x, node.y
+	}
+	return layout
+}
+
+// fitNetwork scales and translates the layout into the given box, leaving
+// room for node circles and labels
+func fitNetwork(layout *networkLayout, spec NetworkSpec, x0, y0, x1, y1 float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	maxRadius := 0.0
+	for i := range layout.x {
+		minX, maxX = math.Min(minX, layout.x[i]), math.Max(maxX, layout.x[i])
+		minY, maxY = math.Min(minY, layout.y[i]), math.Max(maxY, layout.y[i])
+		maxRadius = math.Max(maxRadius, layout.radius[i])
+	}
+	labelWidth := 0.0
+	if spec.ShowLabels {
+		labels := make([]string, len(spec.Nodes))
+		for i, n := range spec.Nodes {
+			labels[i] = networkLabel(n)
+		}
+		labelWidth = estimateLabelWidth(labels, 10) + 3
+	}
+
+	// Positions scale; circles and labels keep their size
+	padLeft, padRight := maxRadius, maxRadius+labelWidth
+	availW := x1 - x0 - padLeft - padRight
+	availH := y1 - y0 - 2*maxRadius
+	scale := 3.0 // Spread small graphs out, but not without bound
+	if maxX > minX {
+		scale = math.Min(scale, availW/(maxX-minX))
+	}
+	if maxY > minY {
+		scale = math.Min(scale, availH/(maxY-minY))
+	}
+	scale = math.Max(scale, 0)
+
+	// Center the scaled layout in the box
+	offsetX := x0 + padLeft + (availW-(maxX-minX)*scale)/2
+	offsetY := y0 + maxRadius + (availH-(maxY-minY)*scale)/2
+	for i := range layout.x {
+		layout.x[i] = offsetX + (layout.x[i]-minX)*scale
+		layout.y[i] = offsetY + (layout.y[i]-minY)*scale
+	}
+}
+
+// drawNetworkLink draws a link from the edge of the source circle to the
+// edge of the target circle, with an arrowhead for directed graphs
+func drawNetworkLink(layout *networkLayout, s, t int, width float64, linkColor string, directed, curved bool) string {
+	sx, sy, sr := layout.x[s], layout.y[s], layout.radius[s]
+	tx, ty, tr := layout.x[t], layout.y[t], layout.radius[t]
+	arrow := 0.0
+	if directed {
+		arrow = 6 + 2*width
+	}
+
+	lineStyle := svg.Style{
+		Fill:          "none",
+		Stroke:        linkColor,
+		StrokeWidth:   width,
+		StrokeOpacity: 0.7,
+	}
+	arrowStyle := svg.Style{Fill: linkColor, FillOpacity: 0.9}
+
+	// Self-links loop out of the top of the node
+	if s == t {
+		ax, ay := sx+sr*math.Cos(-math.Pi/3), sy+sr*math.Sin(-math.Pi/3)
+		bx, by := sx+sr*math.Cos(-2*math.Pi/3), sy+sr*math.Sin(-2*math.Pi/3)
+		loop := 3*sr + 12
+		c1x, c1y := sx+loop*0.7, sy-loop
+		c2x, c2y := sx-loop*0.7, sy-loop
+		d := fmt.Sprintf("M %.2f %.2f C %.2f %.2f %.2f %.2f %.2f %.2f", ax, ay, c1x, c1y, c2x, c2y, bx, by)
+		result := svg.Path(d, lineStyle) + "\n"
+		if directed {
+			result += networkArrow(bx, by, bx-c2x, by-c2y, arrow, arrowStyle)
+		}
+		return result
+	}
+
+	dx, dy := tx-sx, ty-sy
+	dist := math.Hypot(dx, dy)
+	if dist <= sr+tr {
+		return "" // Overlapping circles leave nothing to draw
+	}
+	ux, uy := dx/dist, dy/dist
+
+	if !curved {
+		x1, y1 := sx+ux*sr, sy+uy*sr
+		tipX, tipY := tx-ux*tr, ty-uy*tr
+		x2, y2 := tipX-ux*arrow, tipY-uy*arrow
+		result := svg.Line(x1, y1, x2, y2, lineStyle) + "\n"
+		if directed {
+			result += networkArrow(tipX, tipY, ux, uy, arrow, arrowStyle)
+		}
+		return result
+	}
+
+	// Bend to the right of the direction of travel, so the opposite link
+	// bends the other way
+	bend := dist * 0.15
+	cx, cy := (sx+tx)/2-uy*bend, (sy+ty)/2+ux*bend
+	sdx, sdy := cx-sx, cy-sy
+	sl := math.Hypot(sdx, sdy)
+	tdx, tdy := tx-cx, ty-cy
+	tl := math.Hypot(tdx, tdy)
+	x1, y1 := sx+sdx/sl*sr, sy+sdy/sl*sr
+	tipX, tipY := tx-tdx/tl*tr, ty-tdy/tl*tr
+	x2, y2 := tipX-tdx/tl*arrow, tipY-tdy/tl*arrow
+	d := fmt.Sprintf("M %.2f %.2f Q %.2f %.2f %.2f %.2f", x1, y1, cx, cy, x2, y2)
+	return svg.Path(d, lineStyle) + "\n" + networkArrow(tipX, tipY, tdx, tdy, arrow, arrowStyle)
+}
+
+// networkArrow draws an arrowhead with its tip at (x, y) pointing along
+// (dx, dy). Arrowheads are plain polygons rather than SVG markers so they
+// survive PNG and PDF export.
+func networkArrow(x, y, dx, dy, length float64, style svg.Style) string {
+	l := math.Hypot(dx, dy)
+	if l == 0 || length == 0 {
+		return ""
+	}
+	ux, uy := dx/l, dy/l
+	half := length * 0.45
+	bx, by := x-ux*length, y-uy*length
+	points := []svg.Point{
+		{X: x, Y: y},
+		{X: bx - uy*half, Y: by + ux*half},
+		{X: bx + uy*half, Y: by - ux*half},
+	}
+	return svg.Polygon(points, style) + "\n"
+}
+
+// Validate checks that node IDs are unique, links reference known nodes
+// and values are non-negative
+func (s NetworkSpec) Validate() error {
+	if err := validateSize("network", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Nodes) == 0 {
+		return &ValidationError{Chart: "network", Field: "Nodes", Err: ErrEmptyData}
+	}
+	ids := make(map[string]bool, len(s.Nodes))
+	for i, n := range s.Nodes {
+		field := fmt.Sprintf("Nodes[%d]", i)
+		if n.ID == "" {
+			return invalid("network", field+".ID", ErrMissingField, "node has no ID")
+		}
+		if ids[n.ID] {
+			return invalid("network", field+".ID", ErrDuplicateID, "%q", n.ID)
+		}
+		ids[n.ID] = true
+		if err := validateNonNegative("network", field+".Value", n.Value); err != nil {
+			return err
+		}
+	}
+	for i, l := range s.Links {
+		field := fmt.Sprintf("Links[%d]", i)
+		if !ids[l.Source] {
+			return invalid("network", field+".Source", ErrUnknownReference, "no node %q", l.Source)
+		}
+		if !ids[l.Target] {
+			return invalid("network", field+".Target", ErrUnknownReference, "no node %q", l.Target)
+		}
+		if err := validateNonNegative("network", field+".Value", l.Value); err != nil {
+			return err
+		}
+	}
+	for _, v := range []struct {
+		field string
+		value float64
+	}{{"MinRadius", s.MinRadius}, {"MaxRadius", s.MaxRadius}, {"LinkDistance", s.LinkDistance}} {
+		if err := validateNonNegative("network", v.field, v.value); err != nil {
+			return err
+		}
+	}
+	return validateNumber("network", "ChargeStrength", s.ChargeStrength)
+}
+
+// Render renders the network graph to SVG
+func (s NetworkSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "network", target, func() string {
+		return RenderNetwork(s)
+	})
+}
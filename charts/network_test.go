@@ -0,0 +1,112 @@
+package charts
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func testNetwork() NetworkSpec {
+	return NetworkSpec{
+		Nodes: []NetworkNode{
+			{ID: "gateway", Group: "edge", Value: 100},
+			{ID: "auth", Group: "core", Value: 40},
+			{ID: "orders", Group: "core", Value: 80},
+			{ID: "payments", Group: "core", Value: 30},
+			{ID: "db", Group: "data", Value: 90},
+		},
+		Links: []NetworkLink{
+			{Source: "gateway", Target: "auth", Value: 50},
+			{Source: "gateway", Target: "orders", Value: 40},
+			{Source: "orders", Target: "payments", Value: 20},
+			{Source: "payments", Target: "orders", Value: 5},
+			{Source: "orders", Target: "db", Value: 40},
+			{Source: "auth", Target: "db", Value: 10},
+		},
+		Width:      600,
+		Height:     400,
+		ShowLabels: true,
+	}
+}
+
+func TestRenderNetwork(t *testing.T) {
+	spec := testNetwork()
+	svg := RenderNetwork(spec)
+	if svg == "" {
+		t.Fatal("RenderNetwork returned empty output")
+	}
+	if n := strings.Count(svg, "<circle"); n != len(spec.Nodes) {
+		t.Errorf("got %d circles, expected %d", n, len(spec.Nodes))
+	}
+	if strings.Contains(svg, "<polygon") {
+		t.Error("undirected graph should not have arrowheads")
+	}
+	for _, label := range []string{"gateway", "payments"} {
+		if !strings.Contains(svg, ">"+label+"<") {
+			t.Errorf("missing label %q", label)
+		}
+	}
+
+	// Directed graphs get one arrowhead per link, and the two opposite
+	// links between orders and payments are bent apart
+	spec.Directed = true
+	svg = RenderNetwork(spec)
+	if n := strings.Count(svg, "<polygon"); n != len(spec.Links) {
+		t.Errorf("got %d arrowheads, expected %d", n, len(spec.Links))
+	}
+	if n := strings.Count(svg, " Q "); n != 2 {
+		t.Errorf("got %d curved links, expected 2", n)
+	}
+
+	// The layout is deterministic
+	if RenderNetwork(spec) != svg {
+		t.Error("rendering the same spec twice produced different output")
+	}
+}
+
+func TestNetworkLayout(t *testing.T) {
+	spec := testNetwork()
+	spec.MinRadius, spec.MaxRadius, spec.LinkDistance, spec.ChargeStrength, spec.Iterations = 5, 15, 40, -120, 300
+
+	layout := layoutNetwork(spec)
+	fitNetwork(layout, spec, 0, 0, spec.Width, spec.Height)
+
+	// Larger values get larger circles
+	if layout.radius[0] != 15 || layout.radius[3] >= layout.radius[1] {
+		t.Errorf("radii = %v", layout.radius)
+	}
+
+	for i := range layout.x {
+		// Nodes stay inside the chart
+		r := layout.radius[i]
+		if layout.x[i]-r < -1e-9 || layout.x[i]+r > spec.Width+1e-9 || layout.y[i]-r < -1e-9 || layout.y[i]+r > spec.Height+1e-9 {
+			t.Errorf("node %d at (%.1f, %.1f) is outside the chart", i, layout.x[i], layout.y[i])
+		}
+		// and do not overlap
+		for j := i + 1; j < len(layout.x); j++ {
+			if d := math.Hypot(layout.x[i]-layout.x[j], layout.y[i]-layout.y[j]); d < layout.radius[i]+layout.radius[j] {
+				t.Errorf("nodes %d and %d overlap", i, j)
+			}
+		}
+	}
+}
+
+func TestForceSimulationBarnesHut(t *testing.T) {
+	// With theta = 0 the quadtree is always opened, giving the exact
+	// many-body force; the default approximation stays close to it
+	radii := make([]float64, 200)
+	exact := newForceSimulation(radii, 300, 1)
+	approx := newForceSimulation(radii, 300, 1)
+	exact.theta2 = 0
+	exact.applyCharge()
+	approx.applyCharge()
+
+	var errSum, forceSum float64
+	for i := range radii {
+		errSum += math.Hypot(exact.nodes[i].vx-approx.nodes[i].vx, exact.nodes[i].vy-approx.nodes[i].vy)
+		forceSum += math.Hypot(exact.nodes[i].vx, exact.nodes[i].vy)
+	}
+	if errSum/forceSum > 0.1 {
+		t.Errorf("Barnes–Hut relative error %.3f, expected under 0.1", errSum/forceSum)
+	}
+}
@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -336,3 +337,38 @@ func ColoredParallelCoordinates(axisLabels []string, data [][]float64, colors []
 
 	return RenderParallelCoordinates(spec)
 }
+
+// Validate checks that every data point has one finite value per axis
+func (s ParallelCoordinatesSpec) Validate() error {
+	if err := validateSize("parallel", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Axes) == 0 {
+		return &ValidationError{Chart: "parallel", Field: "Axes", Err: ErrEmptyData}
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "parallel", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, axis := range s.Axes {
+		if axis.Max < axis.Min {
+			return invalid("parallel", fmt.Sprintf("Axes[%d]", i), ErrInvalidValue, "max %v is below min %v", axis.Max, axis.Min)
+		}
+	}
+	for i, d := range s.Data {
+		field := fmt.Sprintf("Data[%d].Values", i)
+		if len(d.Values) != len(s.Axes) {
+			return invalid("parallel", field, ErrMismatchedLengths, "%d values for %d axes", len(d.Values), len(s.Axes))
+		}
+		if err := validateValues("parallel", field, d.Values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the parallel coordinates chart to SVG
+func (s ParallelCoordinatesSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "parallel", target, func() string {
+		return RenderParallelCoordinates(s)
+	})
+}
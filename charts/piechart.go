@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -220,3 +221,36 @@ func renderEmptyPieChart(width, height int, title string) string {
 	sb.WriteString(svg.Text("No data available", float64(width)/2, float64(height)/2, emptyStyle))
 	return sb.String()
 }
+
+// PieChartSpec renders pie chart data through the Chart interface
+type PieChartSpec struct {
+	Data        PieChartData
+	Bounds      Bounds
+	Title       string
+	Donut       bool // Render as a donut chart
+	ShowLegend  bool
+	ShowPercent bool // Show percentage labels on slices
+}
+
+// Validate checks that the pie chart has slices with non-negative values
+func (s PieChartSpec) Validate() error {
+	if err := validateBounds("pie-chart", s.Bounds); err != nil {
+		return err
+	}
+	if len(s.Data.Slices) == 0 {
+		return &ValidationError{Chart: "pie-chart", Field: "Data.Slices", Err: ErrEmptyData}
+	}
+	for i, slice := range s.Data.Slices {
+		if err := validateNonNegative("pie-chart", fmt.Sprintf("Data.Slices[%d].Value", i), slice.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the pie chart to SVG
+func (s PieChartSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "pie-chart", target, func() string {
+		return RenderPieChart(s.Data, s.Bounds.X, s.Bounds.Y, s.Bounds.Width, s.Bounds.Height, s.Title, s.Donut, s.ShowLegend, s.ShowPercent)
+	})
+}
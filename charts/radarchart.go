@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -319,3 +320,41 @@ func NormalizedRadarChart(axisLabels []string, seriesData map[string][]float64,
 
 	return RenderRadarChart(spec)
 }
+
+// Validate checks that every series has one finite value per axis
+func (s RadarChartSpec) Validate() error {
+	if err := validateSize("radar", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Axes) == 0 {
+		return &ValidationError{Chart: "radar", Field: "Axes", Err: ErrEmptyData}
+	}
+	if len(s.Series) == 0 {
+		return &ValidationError{Chart: "radar", Field: "Series", Err: ErrEmptyData}
+	}
+	for i, axis := range s.Axes {
+		if axis.Max < axis.Min {
+			return invalid("radar", fmt.Sprintf("Axes[%d]", i), ErrInvalidValue, "max %v is below min %v", axis.Max, axis.Min)
+		}
+	}
+	for i, series := range s.Series {
+		field := fmt.Sprintf("Series[%d]", i)
+		if series == nil {
+			return invalid("radar", field, ErrMissingField, "nil series")
+		}
+		if len(series.Values) != len(s.Axes) {
+			return invalid("radar", field+".Values", ErrMismatchedLengths, "%d values for %d axes", len(series.Values), len(s.Axes))
+		}
+		if err := validateValues("radar", field+".Values", series.Values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render renders the radar chart to SVG
+func (s RadarChartSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "radar", target, func() string {
+		return RenderRadarChart(s)
+	})
+}
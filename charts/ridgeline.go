@@ -1,8 +1,10 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/SCKelemen/dataviz/kde"
 	"github.com/SCKelemen/dataviz/scales"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
@@ -14,6 +16,7 @@ type RidgelineData struct {
 	Label     string    // Category label
 	Color     string    // Fill color
 	Bandwidth float64   // KDE bandwidth (0 = auto)
+	Weights   []float64 // Optional per-value weights
 }
 
 // RidgelineSpec configures ridgeline plot rendering
@@ -25,6 +28,7 @@ type RidgelineSpec struct {
 	ShowFill   bool    // If true, fill ridges with color
 	LineWidth  float64 // Line width
 	Reverse    bool    // If true, reverse order (top to bottom)
+	KDE        kde.Options // Kernel, bandwidth selection, bounds and grid; each ridge's Bandwidth takes precedence
 
 	// Axis configuration
 	XAxisLabel string
@@ -57,12 +61,12 @@ func RenderRidgeline(spec RidgelineSpec) string {
 	maxDensities := make([]float64, len(spec.Data))
 
 	for i, ridge := range spec.Data {
-		stats := CalculateViolinStats(ridge.Values, ridge.Bandwidth)
-		allDensities[i] = stats.Density
+		allDensities[i] = calculateKDE(ridge.Values, seriesKDE(spec.KDE, ridge.Bandwidth, ridge.Weights))
+		globalMin, globalMax = densityExtent(globalMin, globalMax, allDensities[i])
 
 		// Find max density for this ridge
 		maxDensity := 0.0
-		for _, dp := range stats.Density {
+		for _, dp := range allDensities[i] {
 			if dp.Density > maxDensity {
 				maxDensity = dp.Density
 			}
@@ -206,3 +210,42 @@ func RidgelineFromGroups(groups map[string][]float64, colors map[string]string)
 
 	return ridges
 }
+
+// Validate checks that every ridge has finite values
+func (s RidgelineSpec) Validate() error {
+	if err := validateSize("ridgeline", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "ridgeline", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, d := range s.Data {
+		field := fmt.Sprintf("Data[%d]", i)
+		if d == nil {
+			return invalid("ridgeline", field, ErrMissingField, "nil ridge")
+		}
+		if err := validateValues("ridgeline", field+".Values", d.Values); err != nil {
+			return err
+		}
+		if d.Bandwidth < 0 {
+			return invalid("ridgeline", field+".Bandwidth", ErrNegativeValue, "%v", d.Bandwidth)
+		}
+		if err := validateWeights("ridgeline", field+".Weights", d.Values, d.Weights); err != nil {
+			return err
+		}
+	}
+	if err := validateKDE("ridgeline", s.KDE); err != nil {
+		return err
+	}
+	if s.Overlap < 0 || s.Overlap > 1 {
+		return invalid("ridgeline", "Overlap", ErrInvalidValue, "must be within [0, 1], got %v", s.Overlap)
+	}
+	return nil
+}
+
+// Render renders the ridgeline plot to SVG
+func (s RidgelineSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "ridgeline", target, func() string {
+		return RenderRidgeline(s)
+	})
+}
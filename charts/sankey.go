@@ -1,9 +1,12 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
+	"strings"
+	"sync/atomic"
 
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
@@ -26,16 +29,43 @@ type SankeyLink struct {
 	Color  string  // Optional custom color
 }
 
-// SankeySpec configures Sankey diagram rendering
+// SankeyAlign selects the column each node is placed in
+type SankeyAlign string
+
+const (
+	SankeyAlignJustify SankeyAlign = "justify" // Like left, but sinks move to the last column (default)
+	SankeyAlignLeft    SankeyAlign = "left"    // Each node at its depth from the sources
+	SankeyAlignRight   SankeyAlign = "right"   // Each node at its distance from the sinks, counted from the last column
+	SankeyAlignCenter  SankeyAlign = "center"  // Like left, but sources move up to the column before their first target
+)
+
+// SankeyLinkSort selects the order links leave and enter each node
+type SankeyLinkSort string
+
+const (
+	SankeyLinkSortBreadth SankeyLinkSort = "breadth" // By the position of the node at the other end, which avoids crossings (default)
+	SankeyLinkSortValue   SankeyLinkSort = "value"   // Largest flows first
+	SankeyLinkSortInput   SankeyLinkSort = "input"   // The order links are given in
+)
+
+// SankeySpec configures Sankey diagram rendering. Nodes are assigned to
+// columns by Align, then moved vertically over several relaxation passes
+// toward the weighted center of their neighbors, which straightens links
+// and reduces crossings.
 type SankeySpec struct {
 	Nodes        []SankeyNode
 	Links        []SankeyLink
 	Width        float64
 	Height       float64
-	NodeWidth    float64 // Width of node rectangles (default: 15)
-	NodePadding  float64 // Vertical padding between nodes (default: 10)
-	DefaultColor string  // Default node color
-	ShowLabels   bool    // Show node labels
+	NodeWidth    float64        // Width of node rectangles (default: 15)
+	NodePadding  float64        // Vertical padding between nodes (default: 10)
+	Align        SankeyAlign    // Column assignment (default: justify)
+	LinkSort     SankeyLinkSort // Order of links at each node (default: breadth)
+	Iterations   int            // Relaxation passes (default: 6)
+	LinkGradient bool           // Fade each link from its source color to its target color
+	AllowCycles  bool           // Accept cyclic links; links that close a cycle loop back under the diagram
+	DefaultColor string         // Default node color
+	ShowLabels   bool           // Show node labels
 	Title        string
 }
 
@@ -52,6 +82,9 @@ func RenderSankey(spec SankeySpec) string {
 	if spec.NodePadding == 0 {
 		spec.NodePadding = 10
 	}
+	if spec.Iterations <= 0 {
+		spec.Iterations = 6
+	}
 	if spec.DefaultColor == "" {
 		spec.DefaultColor = "#3b82f6"
 	}
@@ -59,16 +92,15 @@ func RenderSankey(spec SankeySpec) string {
 	// Calculate margins
 	margin := 60.0
 	chartWidth := spec.Width - (2 * margin)
-	chartHeight := spec.Height - (2 * margin)
 
-	// Build node map
-	nodeMap := make(map[string]*SankeyNode)
-	for i := range spec.Nodes {
-		nodeMap[spec.Nodes[i].ID] = &spec.Nodes[i]
-	}
+	layout := layoutSankey(spec, margin, margin, spec.Width-margin, spec.Height-margin)
 
-	// Calculate node positions using automatic layout
-	nodePositions := calculateSankeyLayout(spec.Nodes, spec.Links, chartWidth, chartHeight, spec.NodeWidth, spec.NodePadding)
+	nodeColor := func(n *sankeyNode) string {
+		if n.node.Color != "" {
+			return n.node.Color
+		}
+		return spec.DefaultColor
+	}
 
 	var result string
 
@@ -84,306 +116,714 @@ func RenderSankey(spec SankeySpec) string {
 		result += svg.Text(spec.Title, spec.Width/2, 10, titleStyle) + "\n"
 	}
 
-	// Draw links (flows)
-	for _, link := range spec.Links {
-		sourcePos, sourceExists := nodePositions[link.Source]
-		targetPos, targetExists := nodePositions[link.Target]
-
-		if !sourceExists || !targetExists {
-			continue
+	// Gradients run horizontally from the source node to the target node
+	var defs string
+	gradientID := atomic.AddInt64(&gradientCounter, 1)
+	linkFill := func(l *sankeyLink) string {
+		if l.link.Color != "" {
+			return l.link.Color
+		}
+		from, to := nodeColor(l.source), nodeColor(l.target)
+		if !spec.LinkGradient || from == to {
+			return from
 		}
+		id := fmt.Sprintf("sankeyGradient-%d-%d", gradientID, l.index)
+		defs += svg.LinearGradient(svg.LinearGradientDef{
+			ID:    id,
+			X1:    fmt.Sprintf("%.2f", l.source.x1),
+			X2:    fmt.Sprintf("%.2f", l.target.x0),
+			Units: svg.GradientUnitsUserSpaceOnUse,
+			Stops: []svg.GradientStop{
+				{Offset: "0%", Color: from},
+				{Offset: "100%", Color: to},
+			},
+		}) + "\n"
+		return svg.GradientURL(id)
+	}
 
-		// Calculate link path (curved flow)
-		linkPath := createSankeyLink(
-			margin+sourcePos.x+spec.NodeWidth, margin+sourcePos.y+sourcePos.linkOffsets[link.Target],
-			margin+targetPos.x, margin+targetPos.y+targetPos.linkOffsets[link.Source],
-			link.Value, sourcePos.totalOut,
-		)
-
-		// Get link color
-		linkColor := link.Color
-		if linkColor == "" {
-			// Use source node color with transparency
-			sourceNode := nodeMap[link.Source]
-			if sourceNode.Color != "" {
-				linkColor = sourceNode.Color
-			} else {
-				linkColor = spec.DefaultColor
+	// Draw links (flows)
+	var links string
+	for _, link := range layout.links {
+		fill := linkFill(link)
+		if link.circular {
+			linkStyle := svg.Style{
+				Fill:          "none",
+				Stroke:        fill,
+				StrokeWidth:   math.Max(link.width, 1),
+				StrokeOpacity: 0.4,
 			}
+			links += svg.Path(circularSankeyLink(link), linkStyle) + "\n"
+			continue
 		}
 
+		linkPath := createSankeyLink(link.source.x1, link.y0, link.target.x0, link.y1, link.width)
 		linkStyle := svg.Style{
-			Fill:        linkColor,
+			Fill:        fill,
 			FillOpacity: 0.4,
 			Stroke:      "none",
 		}
-		result += svg.Path(linkPath, linkStyle) + "\n"
+		links += svg.Path(linkPath, linkStyle) + "\n"
+	}
+	if defs != "" {
+		result += "<defs>\n" + defs + "</defs>\n"
 	}
+	result += links
 
 	// Draw nodes
-	for _, node := range spec.Nodes {
-		pos, exists := nodePositions[node.ID]
-		if !exists {
-			continue
-		}
-
-		// Get node color
-		nodeColor := node.Color
-		if nodeColor == "" {
-			nodeColor = spec.DefaultColor
-		}
-
+	for _, n := range layout.nodes {
 		// Draw node rectangle
 		nodeStyle := svg.Style{
-			Fill:        nodeColor,
+			Fill:        nodeColor(n),
 			Stroke:      "#ffffff",
 			StrokeWidth: 1,
 		}
-		result += svg.Rect(margin+pos.x, margin+pos.y, spec.NodeWidth, pos.height, nodeStyle) + "\n"
+		result += svg.Rect(n.x0, n.y0, n.x1-n.x0, n.y1-n.y0, nodeStyle) + "\n"
 
 		// Draw node label
-		if spec.ShowLabels && node.Label != "" {
-			labelX := margin + pos.x + spec.NodeWidth + 5
-			labelY := margin + pos.y + pos.height/2
-
-			// If node is on right side, put label on left
-			if pos.x > chartWidth/2 {
-				labelX = margin + pos.x - 5
-			}
+		if spec.ShowLabels && n.node.Label != "" {
+			labelX := n.x1 + 5
+			labelY := (n.y0 + n.y1) / 2
 
 			labelStyle := svg.Style{
 				FontSize:         units.Px(11),
 				FontFamily:       "sans-serif",
 				DominantBaseline: svg.DominantBaselineMiddle,
+				TextAnchor:       svg.TextAnchorStart,
 			}
 
-			if pos.x > chartWidth/2 {
+			// If node is on right side, put label on left
+			if n.x0-margin > chartWidth/2 {
+				labelX = n.x0 - 5
 				labelStyle.TextAnchor = svg.TextAnchorEnd
-			} else {
-				labelStyle.TextAnchor = svg.TextAnchorStart
 			}
 
-			result += svg.Text(node.Label, labelX, labelY, labelStyle) + "\n"
+			result += svg.Text(n.node.Label, labelX, labelY, labelStyle) + "\n"
 		}
 	}
 
 	return result
 }
 
-// sankeyNodePosition stores calculated position and dimensions for a node
-type sankeyNodePosition struct {
-	x           float64
-	y           float64
-	height      float64
-	totalIn     float64
-	totalOut    float64
-	linkOffsets map[string]float64 // Offset for each connected link
+// sankeyNode is a node placed by the Sankey layout
+type sankeyNode struct {
+	node        *SankeyNode
+	index       int
+	sourceLinks []*sankeyLink // Outgoing links, top to bottom
+	targetLinks []*sankeyLink // Incoming links, top to bottom
+	value       float64
+	depth       int // Longest path from a source
+	height      int // Longest path to a sink
+	layer       int // Column
+	pinned      bool
+	x0, x1      float64
+	y0, y1      float64
 }
 
-// calculateSankeyLayout computes positions for all nodes
-func calculateSankeyLayout(nodes []SankeyNode, links []SankeyLink, width, height, nodeWidth, nodePadding float64) map[string]*sankeyNodePosition {
-	positions := make(map[string]*sankeyNodePosition)
+// sankeyLink is a link placed by the Sankey layout
+type sankeyLink struct {
+	link           *SankeyLink
+	index          int
+	source, target *sankeyNode
+	value          float64
+	width          float64
+	y0, y1         float64 // Center of the link at the source and target
+	circular       bool    // Closes a cycle, so it loops back under the diagram
+	laneY          float64 // Center of a circular link's return lane
+	laneX          float64 // How far a circular link leaves its nodes before turning
+}
+
+// sankeyLayout is a Sankey diagram laid out in a box
+type sankeyLayout struct {
+	nodes []*sankeyNode
+	links []*sankeyLink
+}
 
-	// Initialize positions
-	for _, node := range nodes {
-		positions[node.ID] = &sankeyNodePosition{
-			linkOffsets: make(map[string]float64),
+// layoutSankey places the nodes and links of a Sankey diagram in the box
+// from (x0, y0) to (x1, y1), following d3-sankey. Links that close a cycle
+// are marked circular and routed through lanes reserved below the nodes.
+func layoutSankey(spec SankeySpec, x0, y0, x1, y1 float64) *sankeyLayout {
+	layout := &sankeyLayout{}
+	index := make(map[string]*sankeyNode, len(spec.Nodes))
+	for i := range spec.Nodes {
+		n := &sankeyNode{node: &spec.Nodes[i], index: i}
+		layout.nodes = append(layout.nodes, n)
+		index[n.node.ID] = n
+	}
+	for i := range spec.Links {
+		l := &spec.Links[i]
+		source, target := index[l.Source], index[l.Target]
+		if source == nil || target == nil {
+			continue
 		}
+		link := &sankeyLink{link: l, index: i, source: source, target: target, value: l.Value}
+		layout.links = append(layout.links, link)
+		source.sourceLinks = append(source.sourceLinks, link)
+		target.targetLinks = append(target.targetLinks, link)
 	}
+	markCircularLinks(layout.nodes)
 
-	// Calculate total in/out for each node
-	for _, link := range links {
-		if sourcePos, ok := positions[link.Source]; ok {
-			sourcePos.totalOut += link.Value
+	// Nodes are as tall as the larger of their inflow and outflow
+	for _, n := range layout.nodes {
+		var in, out float64
+		for _, l := range n.targetLinks {
+			in += l.value
 		}
-		if targetPos, ok := positions[link.Target]; ok {
-			targetPos.totalIn += link.Value
+		for _, l := range n.sourceLinks {
+			out += l.value
 		}
+		n.value = math.Max(in, out)
 	}
 
-	// Calculate node heights based on max(totalIn, totalOut)
-	maxTotal := 0.0
-	for id, pos := range positions {
-		total := math.Max(pos.totalIn, pos.totalOut)
-		if total > maxTotal {
-			maxTotal = total
-		}
-		pos.height = total
+	columns := sankeyColumns(layout.nodes, spec.Align)
 
-		// Use manual position if specified
-		for _, node := range nodes {
-			if node.ID == id && node.X > 0 {
-				pos.x = node.X * width
-			}
-			if node.ID == id && node.Y > 0 {
-				pos.y = node.Y * height
-			}
+	// Reserve one lane per circular link below the nodes
+	var circular []*sankeyLink
+	circularValue := 0.0
+	for _, l := range layout.links {
+		if l.circular {
+			circular = append(circular, l)
+			circularValue += l.value
 		}
 	}
+	const laneGap = 4.0
+	laneSpace := 0.0
+	if len(circular) > 0 {
+		laneSpace = 2*laneGap + laneGap*float64(len(circular))
+	}
 
-	// Normalize heights to fit in chart
-	// We'll calculate the proper scale after we know the column distribution
-	// For now, just use a reasonable initial scale
-	if maxTotal > 0 {
-		// Reserve 20% of height for padding, use 80% for node heights
-		scale := (height * 0.8) / maxTotal
-		for _, pos := range positions {
-			pos.height *= scale
+	// Scale values to pixels so the fullest column fits
+	maxColumn := 0
+	for _, c := range columns {
+		maxColumn = max(maxColumn, len(c))
+	}
+	py := spec.NodePadding
+	if maxColumn > 1 {
+		py = math.Min(py, (y1-y0-laneSpace)/float64(maxColumn-1))
+	}
+	ky := math.Inf(1)
+	for _, c := range columns {
+		total := circularValue
+		for _, n := range c {
+			total += n.value
 		}
+		if total > 0 {
+			ky = math.Min(ky, (y1-y0-laneSpace-float64(len(c)-1)*py)/total)
+		}
+	}
+	if math.IsInf(ky, 1) || ky < 0 {
+		ky = 0
+	}
+	nodeBottom := y1 - laneSpace - circularValue*ky
+	for _, l := range layout.links {
+		l.width = l.value * ky
 	}
 
-	// Assign nodes to columns based on connectivity (simplified layout)
-	nodeColumns := assignNodesToColumns(nodes, links)
+	// Circular links turn outside the nodes, so keep the outermost turn
+	// inside the box
+	if len(circular) > 0 {
+		extent := laneSpace + circularValue*ky
+		x0 += extent
+		x1 -= extent
+	}
 
-	// Calculate X positions based on columns
-	numColumns := 0
-	for _, col := range nodeColumns {
-		if col > numColumns {
-			numColumns = col
+	// Place columns evenly across the width
+	kx := 0.0
+	if len(columns) > 1 {
+		kx = (x1 - x0 - spec.NodeWidth) / float64(len(columns)-1)
+	}
+	for _, n := range layout.nodes {
+		n.x0 = x0 + float64(n.layer)*kx
+		if n.node.X > 0 {
+			n.x0 = x0 + n.node.X*(x1-x0-spec.NodeWidth)
 		}
+		n.x1 = n.x0 + spec.NodeWidth
 	}
-	numColumns++
 
-	columnWidth := width / float64(numColumns+1)
-
-	for id, col := range nodeColumns {
-		if pos := positions[id]; pos.x == 0 { // Only if not manually set
-			pos.x = columnWidth * float64(col+1) - nodeWidth/2
+	// Stack each column, spreading spare room evenly between the nodes
+	for _, c := range columns {
+		y := y0
+		for _, n := range c {
+			n.y0 = y
+			n.y1 = y + n.value*ky
+			y = n.y1 + py
+		}
+		spare := (nodeBottom - y + py) / float64(len(c)+1)
+		for i, n := range c {
+			n.y0 += spare * float64(i+1)
+			n.y1 += spare * float64(i+1)
+		}
+		for _, n := range c {
+			if n.node.Y > 0 {
+				n.pinned = true
+				h := n.y1 - n.y0
+				n.y0 = y0 + n.node.Y*(nodeBottom-y0-h)
+				n.y1 = n.y0 + h
+			}
+		}
+		for _, n := range c {
+			sortSankeyLinks(n, spec.LinkSort)
 		}
 	}
 
-	// Calculate Y positions within each column
-	columnNodes := make(map[int][]string)
-	for id, col := range nodeColumns {
-		columnNodes[col] = append(columnNodes[col], id)
+	// Relax: pull nodes toward their neighbors, then push overlapping
+	// nodes apart, with movement shrinking and collision strength growing
+	// over the passes
+	for i := 0; i < spec.Iterations; i++ {
+		alpha := math.Pow(0.99, float64(i))
+		beta := math.Max(1-alpha, float64(i+1)/float64(spec.Iterations))
+		relaxSankey(columns, alpha, beta, py, y0, nodeBottom, true, spec.LinkSort)
+		relaxSankey(columns, alpha, beta, py, y0, nodeBottom, false, spec.LinkSort)
 	}
 
-	for col, nodeIDs := range columnNodes {
-		// Sort nodes by connections
-		sort.Slice(nodeIDs, func(i, j int) bool {
-			return nodeIDs[i] < nodeIDs[j]
-		})
+	// Nest circular links: the shortest loops take the innermost lanes
+	sort.SliceStable(circular, func(i, j int) bool {
+		return circular[i].source.layer-circular[i].target.layer < circular[j].source.layer-circular[j].target.layer
+	})
+	lane := nodeBottom + 2*laneGap
+	offset := laneGap
+	for _, l := range circular {
+		l.laneY = lane + l.width/2
+		l.laneX = offset + l.width/2
+		lane += l.width + laneGap
+		offset += l.width + laneGap
+	}
 
-		// Calculate total height needed
-		totalHeight := 0.0
-		for _, id := range nodeIDs {
-			totalHeight += positions[id].height
+	// Stack links at their nodes in sorted order
+	for _, n := range layout.nodes {
+		sortSankeyLinks(n, spec.LinkSort)
+		y := n.y0
+		for _, l := range n.sourceLinks {
+			l.y0 = y + l.width/2
+			y += l.width
+		}
+		y = n.y0
+		for _, l := range n.targetLinks {
+			l.y1 = y + l.width/2
+			y += l.width
 		}
-		totalHeight += nodePadding * float64(len(nodeIDs)-1)
+	}
+
+	return layout
+}
 
-		// Center vertically (but don't go below 0)
-		yOffset := (height - totalHeight) / 2
-		if yOffset < 0 {
-			yOffset = 0
+// markCircularLinks marks the links that close a cycle: those found by a
+// depth-first search to lead back to a node still being visited, including
+// self-links. Removing them leaves the graph acyclic.
+func markCircularLinks(nodes []*sankeyNode) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(nodes))
+	var visit func(n *sankeyNode)
+	visit = func(n *sankeyNode) {
+		state[n.index] = visiting
+		for _, l := range n.sourceLinks {
+			switch state[l.target.index] {
+			case visiting:
+				l.circular = true
+			case unvisited:
+				visit(l.target)
+			}
 		}
+		state[n.index] = done
+	}
+	for _, n := range nodes {
+		if state[n.index] == unvisited {
+			visit(n)
+		}
+	}
+}
 
-		for _, id := range nodeIDs {
-			pos := positions[id]
-			if pos.y == 0 { // Only if not manually set
-				pos.y = yOffset
-				yOffset += pos.height + nodePadding
+// sankeyColumns computes each node's depth and height over the acyclic
+// links, assigns its column by the alignment and returns the columns with
+// nodes in input order
+func sankeyColumns(nodes []*sankeyNode, align SankeyAlign) [][]*sankeyNode {
+	// Longest paths, visiting nodes in topological order
+	order := make([]*sankeyNode, 0, len(nodes))
+	seen := make([]bool, len(nodes))
+	var visit func(n *sankeyNode)
+	visit = func(n *sankeyNode) {
+		seen[n.index] = true
+		for _, l := range n.sourceLinks {
+			if !l.circular && !seen[l.target.index] {
+				visit(l.target)
+			}
+		}
+		order = append(order, n)
+	}
+	for _, n := range nodes {
+		if !seen[n.index] {
+			visit(n)
+		}
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		for _, l := range n.sourceLinks {
+			if !l.circular {
+				l.target.depth = max(l.target.depth, n.depth+1)
 			}
 		}
+	}
+	for _, n := range order {
+		for _, l := range n.sourceLinks {
+			if !l.circular {
+				n.height = max(n.height, l.target.height+1)
+			}
+		}
+	}
 
-		_ = col // Use col variable
+	count := 0
+	for _, n := range nodes {
+		count = max(count, n.depth+1)
 	}
 
-	// Calculate link offsets for each node
-	for id, pos := range positions {
-		// Sort outgoing links
-		var outLinks []SankeyLink
-		for _, link := range links {
-			if link.Source == id {
-				outLinks = append(outLinks, link)
+	hasForward := func(links []*sankeyLink) bool {
+		for _, l := range links {
+			if !l.circular {
+				return true
 			}
 		}
-
-		yOffset := 0.0
-		for _, link := range outLinks {
-			linkHeight := (link.Value / pos.totalOut) * pos.height
-			pos.linkOffsets[link.Target] = yOffset + linkHeight/2
-			yOffset += linkHeight
+		return false
+	}
+	for _, n := range nodes {
+		switch align {
+		case SankeyAlignLeft:
+			n.layer = n.depth
+		case SankeyAlignRight:
+			n.layer = count - 1 - n.height
+		case SankeyAlignCenter:
+			n.layer = n.depth
+			if !hasForward(n.targetLinks) && hasForward(n.sourceLinks) {
+				n.layer = count
+				for _, l := range n.sourceLinks {
+					if !l.circular {
+						n.layer = min(n.layer, l.target.depth-1)
+					}
+				}
+			}
+		default:
+			n.layer = n.depth
+			if !hasForward(n.sourceLinks) {
+				n.layer = count - 1
+			}
 		}
+		n.layer = max(0, min(count-1, n.layer))
+	}
+
+	columns := make([][]*sankeyNode, count)
+	for _, n := range nodes {
+		columns[n.layer] = append(columns[n.layer], n)
+	}
+	return columns
+}
 
-		// Sort incoming links
-		var inLinks []SankeyLink
-		for _, link := range links {
-			if link.Target == id {
-				inLinks = append(inLinks, link)
+// relaxSankey moves each node toward the position that would make its
+// links horizontal, weighted by link value and span, then resolves
+// collisions within each column. Left-to-right passes use incoming links;
+// right-to-left passes use outgoing links.
+func relaxSankey(columns [][]*sankeyNode, alpha, beta, py, y0, y1 float64, leftToRight bool, linkSort SankeyLinkSort) {
+	for k := range columns {
+		c := columns[k]
+		if !leftToRight {
+			c = columns[len(columns)-1-k]
+		}
+		for _, n := range c {
+			if n.pinned {
+				continue
+			}
+			var y, w float64
+			links := n.sourceLinks
+			if leftToRight {
+				links = n.targetLinks
+			}
+			for _, l := range links {
+				if l.circular {
+					continue
+				}
+				v := l.value * float64(l.target.layer-l.source.layer)
+				if leftToRight {
+					y += sankeyTargetTop(l.source, n, py) * v
+				} else {
+					y += sankeySourceTop(n, l.target, py) * v
+				}
+				w += v
 			}
+			if !(w > 0) {
+				continue
+			}
+			dy := (y/w - n.y0) * alpha
+			n.y0 += dy
+			n.y1 += dy
+			reorderSankeyLinks(n, linkSort)
 		}
+		sort.SliceStable(c, func(i, j int) bool { return c[i].y0 < c[j].y0 })
+		resolveSankeyCollisions(c, beta, py, y0, y1)
+	}
+}
 
-		yOffset = 0.0
-		for _, link := range inLinks {
-			linkHeight := (link.Value / pos.totalIn) * pos.height
-			pos.linkOffsets[link.Source] = yOffset + linkHeight/2
-			yOffset += linkHeight
+// sankeyTargetTop returns the target y0 that would make the link from
+// source to target horizontal
+func sankeyTargetTop(source, target *sankeyNode, py float64) float64 {
+	y := source.y0 - float64(len(source.sourceLinks)-1)*py/2
+	for _, l := range source.sourceLinks {
+		if l.target == target {
+			break
 		}
+		y += l.width + py
 	}
-
-	return positions
+	for _, l := range target.targetLinks {
+		if l.source == source {
+			break
+		}
+		y -= l.width
+	}
+	return y
 }
 
-// assignNodesToColumns assigns each node to a column based on connectivity
-func assignNodesToColumns(nodes []SankeyNode, links []SankeyLink) map[string]int {
-	columns := make(map[string]int)
+// sankeySourceTop returns the source y0 that would make the link from
+// source to target horizontal
+func sankeySourceTop(source, target *sankeyNode, py float64) float64 {
+	y := target.y0 - float64(len(target.targetLinks)-1)*py/2
+	for _, l := range target.targetLinks {
+		if l.source == source {
+			break
+		}
+		y += l.width + py
+	}
+	for _, l := range source.sourceLinks {
+		if l.target == target {
+			break
+		}
+		y -= l.width
+	}
+	return y
+}
 
-	// Find source nodes (no incoming links)
-	hasIncoming := make(map[string]bool)
-	for _, link := range links {
-		hasIncoming[link.Target] = true
+// resolveSankeyCollisions pushes overlapping nodes in a column apart,
+// outward from the middle node, then back inside the column's bounds
+func resolveSankeyCollisions(nodes []*sankeyNode, alpha, py, y0, y1 float64) {
+	if len(nodes) == 0 {
+		return
 	}
+	i := len(nodes) / 2
+	subject := nodes[i]
+	pushUp(nodes, subject.y0-py, i-1, alpha, py)
+	pushDown(nodes, subject.y1+py, i+1, alpha, py)
+	pushUp(nodes, y1, len(nodes)-1, alpha, py)
+	pushDown(nodes, y0, 0, alpha, py)
+}
 
-	// Assign column 0 to source nodes
-	for _, node := range nodes {
-		if !hasIncoming[node.ID] {
-			columns[node.ID] = 0
+// pushDown moves nodes from i onward below y and below each other
+func pushDown(nodes []*sankeyNode, y float64, i int, alpha, py float64) {
+	for ; i < len(nodes); i++ {
+		n := nodes[i]
+		if dy := (y - n.y0) * alpha; dy > 1e-6 {
+			n.y0 += dy
+			n.y1 += dy
 		}
+		y = n.y1 + py
 	}
+}
 
-	// Iteratively assign columns based on longest path from sources
-	changed := true
-	for changed {
-		changed = false
-		for _, link := range links {
-			if sourceCol, ok := columns[link.Source]; ok {
-				targetCol, targetExists := columns[link.Target]
-				newCol := sourceCol + 1
-				if !targetExists || newCol > targetCol {
-					columns[link.Target] = newCol
-					changed = true
-				}
-			}
+// pushUp moves nodes from i backward above y and above each other
+func pushUp(nodes []*sankeyNode, y float64, i int, alpha, py float64) {
+	for ; i >= 0; i-- {
+		n := nodes[i]
+		if dy := (n.y1 - y) * alpha; dy > 1e-6 {
+			n.y0 -= dy
+			n.y1 -= dy
 		}
+		y = n.y0 - py
 	}
+}
 
-	// Assign unconnected nodes to column 0
-	for _, node := range nodes {
-		if _, ok := columns[node.ID]; !ok {
-			columns[node.ID] = 0
-		}
+// reorderSankeyLinks re-sorts the links at the other end of each of a
+// node's links after the node has moved
+func reorderSankeyLinks(n *sankeyNode, linkSort SankeyLinkSort) {
+	for _, l := range n.targetLinks {
+		sortSankeyLinks(l.source, linkSort)
+	}
+	for _, l := range n.sourceLinks {
+		sortSankeyLinks(l.target, linkSort)
 	}
+}
 
-	return columns
+// sortSankeyLinks orders a node's outgoing and incoming links. Circular
+// links always come last, since they leave and arrive from below.
+func sortSankeyLinks(n *sankeyNode, linkSort SankeyLinkSort) {
+	less := func(a, b *sankeyLink, other func(*sankeyLink) *sankeyNode) bool {
+		if a.circular != b.circular {
+			return b.circular
+		}
+		if a.circular {
+			return a.laneY < b.laneY || (a.laneY == b.laneY && a.index < b.index)
+		}
+		switch linkSort {
+		case SankeyLinkSortValue:
+			if a.value != b.value {
+				return a.value > b.value
+			}
+		case SankeyLinkSortInput:
+		default:
+			if ya, yb := other(a).y0, other(b).y0; ya != yb {
+				return ya < yb
+			}
+		}
+		return a.index < b.index
+	}
+	target := func(l *sankeyLink) *sankeyNode { return l.target }
+	source := func(l *sankeyLink) *sankeyNode { return l.source }
+	sort.SliceStable(n.sourceLinks, func(i, j int) bool { return less(n.sourceLinks[i], n.sourceLinks[j], target) })
+	sort.SliceStable(n.targetLinks, func(i, j int) bool { return less(n.targetLinks[i], n.targetLinks[j], source) })
 }
 
-// createSankeyLink creates a curved path for a Sankey link
-func createSankeyLink(x1, y1, x2, y2, value, totalOut float64) string {
+// createSankeyLink creates a filled ribbon of the given width from the
+// right edge of a source node to the left edge of a target node
+func createSankeyLink(x1, y1, x2, y2, width float64) string {
 	// Calculate control points for bezier curve
 	midX := (x1 + x2) / 2
-
-	// Link width is proportional to value
-	width := value / totalOut * 50 // Scale factor for visibility
-	if width < 1 {
-		width = 1
-	}
+	half := math.Max(width, 1) / 2
 
 	// Create path with vertical edges and horizontal bezier
-	path := fmt.Sprintf("M %.2f %.2f ", x1, y1-width/2)
-	path += fmt.Sprintf("C %.2f %.2f %.2f %.2f %.2f %.2f ", midX, y1-width/2, midX, y2-width/2, x2, y2-width/2)
-	path += fmt.Sprintf("L %.2f %.2f ", x2, y2+width/2)
-	path += fmt.Sprintf("C %.2f %.2f %.2f %.2f %.2f %.2f ", midX, y2+width/2, midX, y1+width/2, x1, y1+width/2)
+	path := fmt.Sprintf("M %.2f %.2f ", x1, y1-half)
+	path += fmt.Sprintf("C %.2f %.2f %.2f %.2f %.2f %.2f ", midX, y1-half, midX, y2-half, x2, y2-half)
+	path += fmt.Sprintf("L %.2f %.2f ", x2, y2+half)
+	path += fmt.Sprintf("C %.2f %.2f %.2f %.2f %.2f %.2f ", midX, y2+half, midX, y1+half, x1, y1+half)
 	path += "Z"
 
 	return path
 }
+
+// circularSankeyLink creates the center line of a link that closes a
+// cycle: out of the right of its source, down and back along its lane
+// under the diagram, and up into the left of its target. It is drawn as a
+// stroke as wide as the link.
+func circularSankeyLink(l *sankeyLink) string {
+	sx, sy := l.source.x1, l.y0
+	tx, ty := l.target.x0, l.y1
+	r := math.Min(l.width/2+4, math.Min(l.laneY-sy, l.laneY-ty)/2)
+	r = math.Max(r, 0)
+	right := sx + l.laneX
+	left := tx - l.laneX
+
+	path := fmt.Sprintf("M %.2f %.2f ", sx, sy)
+	path += fmt.Sprintf("L %.2f %.2f ", right, sy)
+	path += fmt.Sprintf("A %.2f %.2f 0 0 1 %.2f %.2f ", r, r, right+r, sy+r)
+	path += fmt.Sprintf("L %.2f %.2f ", right+r, l.laneY-r)
+	path += fmt.Sprintf("A %.2f %.2f 0 0 1 %.2f %.2f ", r, r, right, l.laneY)
+	path += fmt.Sprintf("L %.2f %.2f ", left, l.laneY)
+	path += fmt.Sprintf("A %.2f %.2f 0 0 1 %.2f %.2f ", r, r, left-r, l.laneY-r)
+	path += fmt.Sprintf("L %.2f %.2f ", left-r, ty+r)
+	path += fmt.Sprintf("A %.2f %.2f 0 0 1 %.2f %.2f ", r, r, left, ty)
+	path += fmt.Sprintf("L %.2f %.2f", tx, ty)
+	return path
+}
+
+// Validate checks that links reference known nodes, have non-negative
+// values and, unless AllowCycles is set, do not form cycles
+func (s SankeySpec) Validate() error {
+	if err := validateSize("sankey", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Nodes) == 0 {
+		return &ValidationError{Chart: "sankey", Field: "Nodes", Err: ErrEmptyData}
+	}
+	if len(s.Links) == 0 {
+		return &ValidationError{Chart: "sankey", Field: "Links", Err: ErrEmptyData}
+	}
+
+	ids := make(map[string]bool, len(s.Nodes))
+	for i, n := range s.Nodes {
+		if ids[n.ID] {
+			return invalid("sankey", fmt.Sprintf("Nodes[%d].ID", i), ErrDuplicateID, "%q", n.ID)
+		}
+		ids[n.ID] = true
+	}
+
+	targets := make(map[string][]string)
+	for i, l := range s.Links {
+		field := fmt.Sprintf("Links[%d]", i)
+		if !ids[l.Source] {
+			return invalid("sankey", field+".Source", ErrUnknownReference, "no node %q", l.Source)
+		}
+		if !ids[l.Target] {
+			return invalid("sankey", field+".Target", ErrUnknownReference, "no node %q", l.Target)
+		}
+		if err := validateNonNegative("sankey", field+".Value", l.Value); err != nil {
+			return err
+		}
+		targets[l.Source] = append(targets[l.Source], l.Target)
+	}
+
+	switch s.Align {
+	case "", SankeyAlignJustify, SankeyAlignLeft, SankeyAlignRight, SankeyAlignCenter:
+	default:
+		return invalid("sankey", "Align", ErrUnsupported, "%q", s.Align)
+	}
+	switch s.LinkSort {
+	case "", SankeyLinkSortBreadth, SankeyLinkSortValue, SankeyLinkSortInput:
+	default:
+		return invalid("sankey", "LinkSort", ErrUnsupported, "%q", s.LinkSort)
+	}
+
+	if s.AllowCycles {
+		return nil
+	}
+	if cycle := findCycle(s.Nodes, targets); cycle != nil {
+		return invalid("sankey", "Links", ErrCycle, "%s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// findCycle returns the node IDs along a cycle in the link graph
+// (first node repeated at the end), or nil if the graph is acyclic
+func findCycle(nodes []SankeyNode, targets map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(nodes))
+	var path []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		path = append(path, id)
+		for _, next := range targets[id] {
+			switch state[next] {
+			case visiting:
+				for i, p := range path {
+					if p == next {
+						return append(append([]string{}, path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+		return nil
+	}
+
+	for _, n := range nodes {
+		if state[n.ID] == unvisited {
+			if cycle := visit(n.ID); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Render renders the Sankey diagram to SVG
+func (s SankeySpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "sankey", target, func() string {
+		return RenderSankey(s)
+	})
+}
@@ -0,0 +1,201 @@
+package charts
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// sankeyNodeAt returns the laid-out node with the given ID
+func sankeyNodeAt(t *testing.T, layout *sankeyLayout, id string) *sankeyNode {
+	t.Helper()
+	for _, n := range layout.nodes {
+		if n.node.ID == id {
+			return n
+		}
+	}
+	t.Fatalf("no node %q", id)
+	return nil
+}
+
+func TestSankeyAlign(t *testing.T) {
+	// a -> b -> c is the longest path; x feeds c directly and s is a sink
+	// hanging off a
+	nodes := []SankeyNode{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "x"}, {ID: "s"}}
+	links := []SankeyLink{
+		{Source: "a", Target: "b", Value: 2},
+		{Source: "b", Target: "c", Value: 2},
+		{Source: "x", Target: "c", Value: 1},
+		{Source: "a", Target: "s", Value: 1},
+	}
+
+	tests := []struct {
+		align  SankeyAlign
+		layers map[string]int
+	}{
+		{SankeyAlignLeft, map[string]int{"a": 0, "b": 1, "c": 2, "x": 0, "s": 1}},
+		{SankeyAlignRight, map[string]int{"a": 0, "b": 1, "c": 2, "x": 1, "s": 2}},
+		{SankeyAlignCenter, map[string]int{"a": 0, "b": 1, "c": 2, "x": 1, "s": 1}},
+		{SankeyAlignJustify, map[string]int{"a": 0, "b": 1, "c": 2, "x": 0, "s": 2}},
+		{"", map[string]int{"a": 0, "b": 1, "c": 2, "x": 0, "s": 2}},
+	}
+	for _, tt := range tests {
+		spec := SankeySpec{Nodes: nodes, Links: links, NodeWidth: 10, NodePadding: 10, Iterations: 6, Align: tt.align}
+		layout := layoutSankey(spec, 0, 0, 410, 300)
+		for id, layer := range tt.layers {
+			n := sankeyNodeAt(t, layout, id)
+			if n.layer != layer {
+				t.Errorf("%q: node %s in column %d, expected %d", tt.align, id, n.layer, layer)
+			}
+			if expected := float64(layer) * 200; n.x0 != expected {
+				t.Errorf("%q: node %s at x %v, expected %v", tt.align, id, n.x0, expected)
+			}
+		}
+	}
+}
+
+func TestSankeyRelaxation(t *testing.T) {
+	// Stacked in input order these links would cross; relaxation moves d
+	// above c
+	spec := SankeySpec{
+		Nodes:       []SankeyNode{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}},
+		Links:       []SankeyLink{{Source: "a", Target: "d", Value: 3}, {Source: "b", Target: "c", Value: 2}},
+		NodeWidth:   10,
+		NodePadding: 10,
+		Iterations:  6,
+	}
+	layout := layoutSankey(spec, 0, 0, 400, 300)
+	c, d := sankeyNodeAt(t, layout, "c"), sankeyNodeAt(t, layout, "d")
+	if d.y0 >= c.y0 {
+		t.Errorf("d at %v should be above c at %v", d.y0, c.y0)
+	}
+
+	// Nodes stay inside the box and do not overlap
+	for _, n := range layout.nodes {
+		if n.y0 < -1e-9 || n.y1 > 300+1e-9 {
+			t.Errorf("node %s spans %v-%v outside the box", n.node.ID, n.y0, n.y1)
+		}
+	}
+	for _, pair := range [][2]string{{"a", "b"}, {"d", "c"}} {
+		upper, lower := sankeyNodeAt(t, layout, pair[0]), sankeyNodeAt(t, layout, pair[1])
+		if upper.y1+spec.NodePadding > lower.y0+1e-9 {
+			t.Errorf("nodes %s and %s overlap", pair[0], pair[1])
+		}
+	}
+}
+
+func TestSankeyLinkSort(t *testing.T) {
+	spec := SankeySpec{
+		Nodes: []SankeyNode{{ID: "src"}, {ID: "top"}, {ID: "mid"}, {ID: "bottom"}},
+		Links: []SankeyLink{
+			{Source: "src", Target: "bottom", Value: 5},
+			{Source: "src", Target: "top", Value: 1},
+			{Source: "src", Target: "mid", Value: 3},
+		},
+		NodeWidth:   10,
+		NodePadding: 10,
+		Iterations:  6,
+	}
+
+	order := func(linkSort SankeyLinkSort) string {
+		spec.LinkSort = linkSort
+		layout := layoutSankey(spec, 0, 0, 400, 300)
+		var ids []string
+		for _, l := range sankeyNodeAt(t, layout, "src").sourceLinks {
+			ids = append(ids, l.target.node.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if got := order(SankeyLinkSortValue); got != "bottom,mid,top" {
+		t.Errorf("value order = %s", got)
+	}
+	if got := order(SankeyLinkSortInput); got != "bottom,top,mid" {
+		t.Errorf("input order = %s", got)
+	}
+
+	// By breadth, links leave in the vertical order of their targets
+	spec.LinkSort = SankeyLinkSortBreadth
+	layout := layoutSankey(spec, 0, 0, 400, 300)
+	links := sankeyNodeAt(t, layout, "src").sourceLinks
+	for i := 1; i < len(links); i++ {
+		if links[i-1].target.y0 > links[i].target.y0 || links[i-1].y0 >= links[i].y0 {
+			t.Errorf("links out of breadth order at %d", i)
+		}
+	}
+}
+
+func TestSankeyCycles(t *testing.T) {
+	spec := SankeySpec{
+		Nodes: []SankeyNode{{ID: "a", Color: "#ff0000"}, {ID: "b", Color: "#0000ff"}, {ID: "c"}},
+		Links: []SankeyLink{
+			{Source: "a", Target: "b", Value: 4},
+			{Source: "b", Target: "c", Value: 3},
+			{Source: "c", Target: "a", Value: 1},
+			{Source: "b", Target: "b", Value: 1},
+		},
+		Width:  600,
+		Height: 400,
+	}
+
+	if err := spec.Validate(); !errors.Is(err, ErrCycle) {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+	spec.AllowCycles = true
+	if err := spec.Validate(); err != nil {
+		t.Errorf("cycles should be allowed: %v", err)
+	}
+
+	layout := layoutSankey(spec, 0, 0, 600, 400)
+	var circular []string
+	for _, l := range layout.links {
+		if l.circular {
+			circular = append(circular, l.source.node.ID+"->"+l.target.node.ID)
+		}
+	}
+	if strings.Join(circular, ",") != "c->a,b->b" {
+		t.Errorf("circular links = %v", circular)
+	}
+
+	// Circular lanes run below every node
+	for _, l := range layout.links {
+		if !l.circular {
+			continue
+		}
+		for _, n := range layout.nodes {
+			if l.laneY-l.width/2 <= n.y1 {
+				t.Errorf("lane of %s->%s at %v overlaps node %s", l.source.node.ID, l.target.node.ID, l.laneY, n.node.ID)
+			}
+		}
+	}
+
+	svg := RenderSankey(spec)
+	if n := strings.Count(svg, `fill="none"`); n != 2 {
+		t.Errorf("got %d looped links, expected 2", n)
+	}
+}
+
+func TestSankeyLinkGradient(t *testing.T) {
+	spec := SankeySpec{
+		Nodes: []SankeyNode{{ID: "a", Color: "#ff0000"}, {ID: "b", Color: "#0000ff"}, {ID: "c", Color: "#0000ff"}},
+		Links: []SankeyLink{
+			{Source: "a", Target: "b", Value: 2},
+			{Source: "b", Target: "c", Value: 2},
+		},
+		Width:  600,
+		Height: 400,
+	}
+	if strings.Contains(RenderSankey(spec), "linearGradient") {
+		t.Error("gradients should be off by default")
+	}
+
+	spec.LinkGradient = true
+	svg := RenderSankey(spec)
+	// Only a -> b changes color along its length
+	if n := strings.Count(svg, "<linearGradient"); n != 1 {
+		t.Errorf("got %d gradients, expected 1", n)
+	}
+	if !strings.Contains(svg, `fill="url(#sankeyGradient-`) {
+		t.Error("link should be filled with its gradient")
+	}
+}
@@ -1,7 +1,9 @@
 package charts
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -42,6 +44,24 @@ func RenderScatterPlot(data ScatterPlotData, x, y int, width, height int, design
 		}
 	}
 
+	// Fit the trendline against times in Unix nanoseconds, widening the Y
+	// domain to fit its band
+	var trend *ConfidenceBand
+	if data.Trend != nil {
+		xs := make([]float64, len(data.Points))
+		ys := make([]float64, len(data.Points))
+		for i, point := range data.Points {
+			xs[i] = float64(point.Date.UnixNano())
+			ys[i] = float64(point.Value)
+		}
+		trend = data.Trend.fit(xs, ys, data.Color)
+		if trend != nil {
+			lo, hi := trend.extent()
+			minValue = min(minValue, int(math.Floor(lo)))
+			maxValue = max(maxValue, int(math.Ceil(hi)))
+		}
+	}
+
 	// Reserve space for Y-axis labels
 	labelAreaWidth := 2 * designTokens.Layout.CardPaddingRight
 	plotWidth := width - labelAreaWidth
@@ -77,6 +97,15 @@ func RenderScatterPlot(data ScatterPlotData, x, y int, width, height int, design
 
 	b.WriteString(yAxis.Render(axisOpts))
 
+	// Draw the trendline under the points
+	if trend != nil {
+		trendXScale := scales.NewLinearScale(
+			[2]float64{float64(minTime.UnixNano()), float64(maxTime.UnixNano())},
+			[2]units.Length{units.Px(0), units.Px(float64(plotWidth))},
+		)
+		b.WriteString(renderTrendline(trend, trendXScale, yScale))
+	}
+
 	markerSize := data.MarkerSize
 	if markerSize == 0 {
 		markerSize = 5 // Default size for scatter plots (larger than line graph)
@@ -186,3 +215,32 @@ func RenderScatterPlot(data ScatterPlotData, x, y int, width, height int, design
 
 	return b.String()
 }
+
+// ScatterPlotSpec renders scatter plot data through the Chart interface
+type ScatterPlotSpec struct {
+	Data   ScatterPlotData
+	Bounds Bounds
+	Config RenderConfig // A nil DesignTokens uses the default theme
+}
+
+// Validate checks that the scatter plot has points and a known trendline
+// model
+func (s ScatterPlotSpec) Validate() error {
+	if err := validateBounds("scatter-plot", s.Bounds); err != nil {
+		return err
+	}
+	if len(s.Data.Points) == 0 {
+		return &ValidationError{Chart: "scatter-plot", Field: "Data.Points", Err: ErrEmptyData}
+	}
+	if s.Data.Trend != nil {
+		return validateTrendline("scatter-plot", "Data.Trend", s.Data.Trend)
+	}
+	return nil
+}
+
+// Render renders the scatter plot to SVG or the terminal
+func (s ScatterPlotSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderWith(ctx, s, "scatter-plot", target, func(r Renderer) Output {
+		return r.RenderScatterPlot(s.Data, s.Bounds, s.Config.withDefaults())
+	})
+}
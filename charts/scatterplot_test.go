@@ -6,6 +6,7 @@ import (
 	"time"
 
 	design "github.com/SCKelemen/design-system"
+	"github.com/SCKelemen/dataviz/transforms"
 )
 
 func TestRenderScatterPlot(t *testing.T) {
@@ -221,3 +222,39 @@ func BenchmarkRenderScatterPlot(b *testing.B) {
 		_ = RenderScatterPlot(data, 0, 0, 800, 400, tokens)
 	}
 }
+
+func TestRenderScatterPlot_Trendline(t *testing.T) {
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var points []ScatterPoint
+	for i, v := range []int{100, 130, 115, 160, 150, 190} {
+		points = append(points, ScatterPoint{Date: startDate.AddDate(0, 0, i), Value: v})
+	}
+
+	tests := []struct {
+		name  string
+		trend *Trendline
+		band  bool
+		paths int
+	}{
+		{"band", &Trendline{}, true, 2},
+		{"prediction band", &Trendline{Regression: transforms.RegressionOptions{Method: "polynomial", Interval: "prediction"}}, true, 2},
+		{"line only", &Trendline{HideBand: true}, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := ScatterPlotData{Points: points, Color: "#F59E0B", Trend: tt.trend}
+			result := RenderScatterPlot(data, 0, 0, 400, 200, design.DefaultTheme())
+
+			if got := strings.Count(result, "<path"); got != tt.paths {
+				t.Errorf("Expected %d trendline paths, got %d", tt.paths, got)
+			}
+			if tt.band != strings.Contains(result, " Z") {
+				t.Errorf("Expected band %v", tt.band)
+			}
+			// The trendline is drawn under the points
+			if strings.Index(result, "<path") > strings.Index(result, "<circle") {
+				t.Error("Expected the trendline before the points")
+			}
+		})
+	}
+}
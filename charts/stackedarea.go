@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -347,3 +348,38 @@ func max2D(data [][]float64) float64 {
 	}
 	return maxVal
 }
+
+// Validate checks that every point has one finite, non-negative value per series
+func (s StackedAreaSpec) Validate() error {
+	if err := validateSize("stacked-area", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Points) == 0 {
+		return &ValidationError{Chart: "stacked-area", Field: "Points", Err: ErrEmptyData}
+	}
+	if len(s.Series) == 0 {
+		return &ValidationError{Chart: "stacked-area", Field: "Series", Err: ErrEmptyData}
+	}
+	for i, p := range s.Points {
+		field := fmt.Sprintf("Points[%d]", i)
+		if err := validateNumber("stacked-area", field+".X", p.X); err != nil {
+			return err
+		}
+		if len(p.Values) != len(s.Series) {
+			return invalid("stacked-area", field+".Values", ErrMismatchedLengths, "%d values for %d series", len(p.Values), len(s.Series))
+		}
+		for j, v := range p.Values {
+			if err := validateNonNegative("stacked-area", fmt.Sprintf("%s.Values[%d]", field, j), v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Render renders the stacked area chart to SVG
+func (s StackedAreaSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "stacked-area", target, func() string {
+		return RenderStackedArea(s)
+	})
+}
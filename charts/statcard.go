@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -206,3 +207,22 @@ func RenderStatCard(data StatCardData, x, y int, width, height int, designTokens
 	b.WriteString(`</g>`)
 	return b.String()
 }
+
+// StatCardSpec renders stat card data through the Chart interface
+type StatCardSpec struct {
+	Data   StatCardData
+	Bounds Bounds
+	Config RenderConfig // A nil DesignTokens uses the default theme
+}
+
+// Validate checks the stat card's bounds
+func (s StatCardSpec) Validate() error {
+	return validateBounds("stat-card", s.Bounds)
+}
+
+// Render renders the stat card to SVG or the terminal
+func (s StatCardSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderWith(ctx, s, "stat-card", target, func(r Renderer) Output {
+		return r.RenderStatCard(s.Data, s.Bounds, s.Config.withDefaults())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/SCKelemen/dataviz/kde"
 	"github.com/SCKelemen/dataviz/scales"
 	"github.com/SCKelemen/units"
 )
@@ -156,7 +157,7 @@ func TestCalculateViolinStats(t *testing.T) {
 
 func TestCalculateKDE(t *testing.T) {
 	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
-	density := calculateKDE(values, 1.0)
+	density := calculateKDE(values, kde.Options{Bandwidth: 1.0})
 
 	if len(density) == 0 {
 		t.Error("Expected density points")
@@ -173,6 +174,35 @@ func TestCalculateKDE(t *testing.T) {
 	}
 }
 
+func TestCalculateKDEOptions(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	lower := 0.0
+
+	tests := []struct {
+		name  string
+		opts  kde.Options
+		first float64
+		last  float64
+	}{
+		{"data range", kde.Options{Bandwidth: 1}, 1, 5},
+		{"cut", kde.Options{Bandwidth: 1, Cut: 3}, -2, 8},
+		{"bounded cut", kde.Options{Bandwidth: 1, Cut: 3, Lower: &lower}, 0, 8},
+	}
+	for _, tt := range tests {
+		density := calculateKDE(values, tt.opts)
+		lo, hi := densityExtent(1, 5, density)
+		if lo != tt.first || hi != tt.last {
+			t.Errorf("%s: expected the curve from %v to %v, got %v to %v", tt.name, tt.first, tt.last, lo, hi)
+		}
+	}
+
+	// Series weights and bandwidth override the chart's options
+	opts := seriesKDE(kde.Options{Bandwidth: 2, Kernel: kde.Epanechnikov}, 0.5, []float64{1, 1, 1, 1, 1})
+	if opts.Bandwidth != 0.5 || opts.Kernel != kde.Epanechnikov || len(opts.Weights) != 5 {
+		t.Errorf("Unexpected series options %+v", opts)
+	}
+}
+
 func TestRenderViolinPlot(t *testing.T) {
 	data := []*ViolinPlotData{
 		{
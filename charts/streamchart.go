@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -399,3 +400,43 @@ func StreamChartFromSeries(xValues []float64, seriesData [][]float64, seriesLabe
 		ShowLegend: true,
 	}
 }
+
+// Validate checks that every point has one finite, non-negative value per series
+func (s StreamChartSpec) Validate() error {
+	if err := validateSize("streamchart", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Points) == 0 {
+		return &ValidationError{Chart: "streamchart", Field: "Points", Err: ErrEmptyData}
+	}
+	if len(s.Series) == 0 {
+		return &ValidationError{Chart: "streamchart", Field: "Series", Err: ErrEmptyData}
+	}
+	for i, p := range s.Points {
+		field := fmt.Sprintf("Points[%d]", i)
+		if err := validateNumber("streamchart", field+".X", p.X); err != nil {
+			return err
+		}
+		if len(p.Values) != len(s.Series) {
+			return invalid("streamchart", field+".Values", ErrMismatchedLengths, "%d values for %d series", len(p.Values), len(s.Series))
+		}
+		for j, v := range p.Values {
+			if err := validateNonNegative("streamchart", fmt.Sprintf("%s.Values[%d]", field, j), v); err != nil {
+				return err
+			}
+		}
+	}
+	switch s.Layout {
+	case "", "center", "wiggle", "silhouette":
+	default:
+		return invalid("streamchart", "Layout", ErrInvalidValue, "unknown layout %q", s.Layout)
+	}
+	return nil
+}
+
+// Render renders the streamchart to SVG
+func (s StreamChartSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "streamchart", target, func() string {
+		return RenderStreamChart(s)
+	})
+}
@@ -1,9 +1,11 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 
+	"github.com/SCKelemen/dataviz/hierarchy"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
 )
@@ -46,25 +48,8 @@ func RenderSunburst(spec SunburstSpec) string {
 		return ""
 	}
 
-	// Convert start angle to radians (0 degrees = top = -π/2 radians)
-	startAngleRad := (spec.StartAngle - 90) * math.Pi / 180
-
-	// Calculate maximum depth for radius scaling
-	maxDepth := calculateMaxDepth(spec.Root, 0)
-	if maxDepth == 0 {
-		maxDepth = 1
-	}
-
 	// Compute arcs
-	arcs := sunburstLayout(
-		spec.Root,
-		0,
-		startAngleRad,
-		startAngleRad+2*math.Pi,
-		spec.InnerRadius,
-		maxRadius,
-		maxDepth,
-	)
+	arcs := sunburstLayout(spec.Root, spec.StartAngle*math.Pi/180, spec.InnerRadius, maxRadius)
 
 	// Render arcs
 	var result string
@@ -122,68 +107,36 @@ func RenderSunburst(spec SunburstSpec) string {
 	return result
 }
 
-// sunburstLayout computes arc positions for sunburst chart
-func sunburstLayout(node *TreeNode, depth int, startAngle, endAngle, innerRadius, outerRadius float64, maxDepth int) []SunburstArc {
-	if node == nil {
+// sunburstLayout partitions the hierarchy into one ring per depth between
+// the inner and outer radii, starting at startAngle radians clockwise from
+// 12 o'clock. Arc angles are returned in SVG terms, clockwise from 3
+// o'clock.
+func sunburstLayout(root *TreeNode, startAngle, innerRadius, outerRadius float64) []SunburstArc {
+	h := hierarchy.New(root)
+	if h == nil {
 		return nil
 	}
+	h.Sum(nil)
 
-	// Calculate radii for this depth
-	depthRange := outerRadius - innerRadius
-	radiusPerLevel := depthRange / float64(maxDepth)
-	arcInnerRadius := innerRadius + float64(depth)*radiusPerLevel
-	arcOuterRadius := arcInnerRadius + radiusPerLevel
-
+	partition := hierarchy.RadialPartition{
+		InnerRadius: innerRadius,
+		OuterRadius: outerRadius,
+		StartAngle:  startAngle - math.Pi/2,
+	}
 	var arcs []SunburstArc
-
-	// Leaf node or no children
-	if len(node.Children) == 0 {
+	for _, a := range partition.Layout(h) {
+		if a.EndAngle <= a.StartAngle {
+			continue
+		}
 		arcs = append(arcs, SunburstArc{
-			InnerRadius: arcInnerRadius,
-			OuterRadius: arcOuterRadius,
-			StartAngle:  startAngle,
-			EndAngle:    endAngle,
-			Node:        node,
-			Depth:       depth,
+			InnerRadius: a.InnerRadius,
+			OuterRadius: a.OuterRadius,
+			StartAngle:  a.StartAngle,
+			EndAngle:    a.EndAngle,
+			Node:        a.Node.Data,
+			Depth:       a.Node.Depth,
 		})
-		return arcs
 	}
-
-	// Add arc for current node
-	arcs = append(arcs, SunburstArc{
-		InnerRadius: arcInnerRadius,
-		OuterRadius: arcOuterRadius,
-		StartAngle:  startAngle,
-		EndAngle:    endAngle,
-		Node:        node,
-		Depth:       depth,
-	})
-
-	// Calculate total value of children
-	total := 0.0
-	for _, child := range node.Children {
-		total += calculateTreeValue(child)
-	}
-
-	if total == 0 {
-		return arcs
-	}
-
-	// Layout children
-	currentAngle := startAngle
-	angleRange := endAngle - startAngle
-
-	for _, child := range node.Children {
-		childValue := calculateTreeValue(child)
-		childAngleRange := (childValue / total) * angleRange
-		childEndAngle := currentAngle + childAngleRange
-
-		childArcs := sunburstLayout(child, depth+1, currentAngle, childEndAngle, innerRadius, outerRadius, maxDepth)
-		arcs = append(arcs, childArcs...)
-
-		currentAngle = childEndAngle
-	}
-
 	return arcs
 }
 
@@ -218,23 +171,20 @@ func buildArcPath(cx, cy, innerRadius, outerRadius, startAngle, endAngle float64
 	return path
 }
 
-// calculateMaxDepth calculates maximum depth of tree
-func calculateMaxDepth(node *TreeNode, currentDepth int) int {
-	if node == nil {
-		return currentDepth
+// Validate checks that the hierarchy is non-empty, acyclic and non-negative
+func (s SunburstSpec) Validate() error {
+	if err := validateSize("sunburst", s.Width, s.Height); err != nil {
+		return err
 	}
-
-	if len(node.Children) == 0 {
-		return currentDepth
-	}
-
-	maxChildDepth := currentDepth
-	for _, child := range node.Children {
-		childDepth := calculateMaxDepth(child, currentDepth+1)
-		if childDepth > maxChildDepth {
-			maxChildDepth = childDepth
-		}
+	if s.InnerRadius < 0 {
+		return invalid("sunburst", "InnerRadius", ErrNegativeValue, "%v", s.InnerRadius)
 	}
+	return validateTree("sunburst", s.Root)
+}
 
-	return maxChildDepth
+// Render renders the sunburst to SVG
+func (s SunburstSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "sunburst", target, func() string {
+		return RenderSunburst(s)
+	})
 }
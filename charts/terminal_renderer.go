@@ -252,18 +252,21 @@ func (r *TerminalRenderer) renderWeeksHeatmapTerminal(data HeatmapData, bounds B
 func (r *TerminalRenderer) renderLineGraphTerminal(data LineGraphData, bounds Bounds, config RenderConfig) Output {
 	var b strings.Builder
 
-	if len(data.Points) == 0 {
+	runs := timeSeriesRuns(data.Points, data.Gaps)
+	if len(runs) == 0 {
 		return TerminalOutput{Content: ""}
 	}
 
-	// Find min/max for scaling
-	minValue, maxValue := data.Points[0].Value, data.Points[0].Value
-	for _, point := range data.Points {
-		if point.Value < minValue {
-			minValue = point.Value
-		}
-		if point.Value > maxValue {
-			maxValue = point.Value
+	// Find min/max for scaling, skipping missing points
+	minValue, maxValue := runs[0][0].Value, runs[0][0].Value
+	for _, run := range runs {
+		for _, point := range run {
+			if point.Value < minValue {
+				minValue = point.Value
+			}
+			if point.Value > maxValue {
+				maxValue = point.Value
+			}
 		}
 	}
 
@@ -285,9 +288,18 @@ func (r *TerminalRenderer) renderLineGraphTerminal(data LineGraphData, bounds Bo
 	// Create braille canvas (each char is 2x4 pixels)
 	canvas := NewBrailleCanvas(width, height)
 
-	// Convert data points to canvas coordinates
+	// Convert data points to canvas coordinates, keeping missing points'
+	// places so the line breaks there or bridges them
 	braillePoints := make([]Point, 0, len(data.Points))
 	for i, point := range data.Points {
+		if point.Missing {
+			if data.Gaps != GapBridge {
+				canvas.DrawCurve(braillePoints)
+				braillePoints = braillePoints[:0]
+			}
+			continue
+		}
+
 		// X coordinate: scale to canvas width
 		x := float64(i) / float64(len(data.Points)-1) * float64(width*2-1)
 		if math.IsNaN(x) {
@@ -304,7 +316,7 @@ func (r *TerminalRenderer) renderLineGraphTerminal(data LineGraphData, bounds Bo
 		braillePoints = append(braillePoints, Point{X: x, Y: y})
 	}
 
-	// Draw the curve
+	// Draw the last curve
 	canvas.DrawCurve(braillePoints)
 
 	// Render canvas to string
@@ -524,18 +536,21 @@ func (r *TerminalRenderer) renderStatCardTerminal(data StatCardData, bounds Boun
 func (r *TerminalRenderer) renderAreaChartTerminal(data AreaChartData, bounds Bounds, config RenderConfig) Output {
 	var b strings.Builder
 
-	if len(data.Points) == 0 {
+	runs := timeSeriesRuns(data.Points, data.Gaps)
+	if len(runs) == 0 {
 		return TerminalOutput{Content: ""}
 	}
 
-	// Find min/max for scaling
-	minValue, maxValue := data.Points[0].Value, data.Points[0].Value
-	for _, point := range data.Points {
-		if point.Value < minValue {
-			minValue = point.Value
-		}
-		if point.Value > maxValue {
-			maxValue = point.Value
+	// Find min/max for scaling, skipping missing points
+	minValue, maxValue := runs[0][0].Value, runs[0][0].Value
+	for _, run := range runs {
+		for _, point := range run {
+			if point.Value < minValue {
+				minValue = point.Value
+			}
+			if point.Value > maxValue {
+				maxValue = point.Value
+			}
 		}
 	}
 
@@ -566,6 +581,9 @@ func (r *TerminalRenderer) renderAreaChartTerminal(data AreaChartData, bounds Bo
 	// Fill area under the curve
 	for i := 0; i < width && i < len(data.Points); i++ {
 		point := data.Points[i]
+		if point.Missing {
+			continue
+		}
 		y := float64(height-1) - (float64(point.Value-minValue)/float64(valueRange))*float64(height-1)
 		yInt := int(math.Round(y))
 
@@ -0,0 +1,305 @@
+package charts
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/SCKelemen/dataviz/hierarchy"
+	"github.com/SCKelemen/svg"
+	"github.com/SCKelemen/units"
+)
+
+// TreeLayout selects how a tree chart places its nodes
+type TreeLayout string
+
+const (
+	TreeLayoutTidy    TreeLayout = "tidy"    // Reingold–Tilford tidy tree: compact, parents centered over children (default)
+	TreeLayoutCluster TreeLayout = "cluster" // Dendrogram: all leaves at the same depth
+)
+
+// TreeOrientation selects the direction a tree chart grows in
+type TreeOrientation string
+
+const (
+	TreeHorizontal TreeOrientation = "horizontal" // Root at the left, growing right (default)
+	TreeVertical   TreeOrientation = "vertical"   // Root at the top, growing down
+	TreeRadial     TreeOrientation = "radial"     // Root at the center, growing outward
+)
+
+// TreeSpec configures a node-link tree chart, such as an org chart or a
+// file tree. Nodes are joined to their parents by curved links; leaves
+// are drawn hollow and nodes with children filled.
+type TreeSpec struct {
+	Root        *TreeNode // Hierarchy; values are not used
+	Width       float64
+	Height      float64
+	Layout      TreeLayout      // Node placement (default: tidy)
+	Orientation TreeOrientation // Growth direction (default: horizontal)
+	MaxDepth    int             // Deepest level shown; deeper subtrees collapse into a larger node (default: all levels)
+	ShowLabels  bool            // Show node names
+	NodeRadius  float64         // Node radius (default: 4)
+	NodeColor   string          // Node color; a TreeNode's Color applies to its whole subtree (default: blue)
+	LinkColor   string          // Link color (default: gray)
+	Title       string
+}
+
+// RenderTree generates an SVG node-link tree chart
+func RenderTree(spec TreeSpec) string {
+	root := hierarchy.New(spec.Root)
+	if root == nil {
+		return ""
+	}
+
+	// Set defaults
+	if spec.Layout == "" {
+		spec.Layout = TreeLayoutTidy
+	}
+	if spec.Orientation == "" {
+		spec.Orientation = TreeHorizontal
+	}
+	if spec.NodeRadius == 0 {
+		spec.NodeRadius = 4
+	}
+	if spec.NodeColor == "" {
+		spec.NodeColor = "#3b82f6"
+	}
+	if spec.LinkColor == "" {
+		spec.LinkColor = "#9ca3af"
+	}
+	if spec.MaxDepth > 0 {
+		root.Prune(spec.MaxDepth)
+	}
+
+	// Leaf labels sit beyond the outermost nodes and the root's label
+	// before it, so reserve room for them
+	var leafLabels []string
+	for _, leaf := range root.Leaves() {
+		leafLabels = append(leafLabels, leaf.Data.Name)
+	}
+	leafRoom, rootRoom := 0.0, 0.0
+	if spec.ShowLabels {
+		leafRoom = estimateLabelWidth(leafLabels, 10) + spec.NodeRadius + 3
+		if len(root.Children) > 0 {
+			rootRoom = estimateLabelWidth([]string{root.Data.Name}, 10) + spec.NodeRadius + 3
+		}
+	}
+	top := 10.0
+	if spec.Title != "" {
+		top = 36
+	}
+	r := spec.NodeRadius * 1.5
+	left, right, bottom := 10+r, 10+r, 10+r
+	top += r
+
+	var point func(p hierarchy.Point) (float64, float64)
+	var points []hierarchy.Point
+	switch spec.Orientation {
+	case TreeVertical:
+		bottom += leafRoom
+		points = treeLayout(root, spec.Layout, spec.Width-left-right, spec.Height-top-bottom, false)
+		point = func(p hierarchy.Point) (float64, float64) {
+			return left + p.X, top + p.Y
+		}
+	case TreeRadial:
+		centerX := spec.Width / 2
+		centerY := (top - r + spec.Height) / 2
+		radius := math.Max(math.Min(spec.Width, spec.Height-top+r)/2-10-r-leafRoom, 10)
+		points = treeLayout(root, spec.Layout, 2*math.Pi, radius, true)
+		point = func(p hierarchy.Point) (float64, float64) {
+			return centerX + p.Y*math.Sin(p.X), centerY - p.Y*math.Cos(p.X)
+		}
+	default:
+		left += rootRoom
+		right += leafRoom
+		points = treeLayout(root, spec.Layout, spec.Height-top-bottom, spec.Width-left-right, false)
+		point = func(p hierarchy.Point) (float64, float64) {
+			return left + p.Y, top + p.X
+		}
+	}
+
+	at := make(map[*hierarchy.Node]hierarchy.Point, len(points))
+	colors := make(map[*hierarchy.Node]string, len(points))
+	for _, p := range points {
+		at[p.Node] = p
+		colors[p.Node] = spec.NodeColor
+		if p.Node.Parent != nil {
+			colors[p.Node] = colors[p.Node.Parent]
+		}
+		if p.Node.Data.Color != "" {
+			colors[p.Node] = p.Node.Data.Color
+		}
+	}
+
+	var result string
+
+	// Draw title
+	if spec.Title != "" {
+		titleStyle := svg.Style{
+			FontSize:         units.Px(16),
+			FontFamily:       "sans-serif",
+			FontWeight:       "bold",
+			TextAnchor:       svg.TextAnchorMiddle,
+			DominantBaseline: svg.DominantBaselineHanging,
+		}
+		result += svg.Text(spec.Title, spec.Width/2, 10, titleStyle) + "\n"
+	}
+
+	// Draw links under the nodes
+	linkStyle := svg.Style{
+		Fill:          "none",
+		Stroke:        spec.LinkColor,
+		StrokeWidth:   1.5,
+		StrokeOpacity: 0.6,
+	}
+	for _, p := range points[1:] {
+		result += svg.Path(treeLink(at[p.Node.Parent], p, spec.Orientation, point), linkStyle) + "\n"
+	}
+
+	// Draw nodes: filled when they have children, larger when collapsed
+	for _, p := range points {
+		x, y := point(p)
+		nodeStyle := svg.Style{Fill: "#ffffff", Stroke: colors[p.Node], StrokeWidth: 1.5}
+		radius := spec.NodeRadius
+		if len(p.Node.Data.Children) > 0 {
+			nodeStyle.Fill = colors[p.Node]
+		}
+		if len(p.Node.Children) == 0 && len(p.Node.Data.Children) > 0 {
+			radius = r
+		}
+		result += svg.Circle(x, y, radius, nodeStyle) + "\n"
+	}
+
+	if spec.ShowLabels {
+		for _, p := range points {
+			result += treeLabel(p, spec, point)
+		}
+	}
+
+	return result
+}
+
+// treeLayout places the nodes with breadth along X and depth along Y. In
+// radial trees the breadth is an angle, so nodes far from the center are
+// given proportionally less of it.
+func treeLayout(root *hierarchy.Node, layout TreeLayout, breadth, depth float64, radial bool) []hierarchy.Point {
+	separation := hierarchy.DefaultSeparation
+	if radial {
+		separation = func(a, b *hierarchy.Node) float64 {
+			return hierarchy.DefaultSeparation(a, b) / float64(max(a.Depth, 1))
+		}
+	}
+	if layout == TreeLayoutCluster {
+		return hierarchy.Cluster{Width: breadth, Height: depth, Separation: separation}.Layout(root)
+	}
+	return hierarchy.Tree{Width: breadth, Height: depth, Separation: separation}.Layout(root)
+}
+
+// treeLink draws a curve from a parent to its child that leaves and
+// enters both along the direction of growth
+func treeLink(source, target hierarchy.Point, orientation TreeOrientation, point func(hierarchy.Point) (float64, float64)) string {
+	sx, sy := point(source)
+	tx, ty := point(target)
+	var c1x, c1y, c2x, c2y float64
+	switch orientation {
+	case TreeVertical:
+		c1x, c1y = sx, (sy+ty)/2
+		c2x, c2y = tx, (sy+ty)/2
+	case TreeRadial:
+		mid := (source.Y + target.Y) / 2
+		c1x, c1y = point(hierarchy.Point{X: source.X, Y: mid})
+		c2x, c2y = point(hierarchy.Point{X: target.X, Y: mid})
+	default:
+		c1x, c1y = (sx+tx)/2, sy
+		c2x, c2y = (sx+tx)/2, ty
+	}
+	return fmt.Sprintf("M %.2f %.2f C %.2f %.2f %.2f %.2f %.2f %.2f", sx, sy, c1x, c1y, c2x, c2y, tx, ty)
+}
+
+// treeLabel draws a node's name on a white halo. Nodes with visible
+// children are labeled on the side facing the root and leaves on the
+// side facing away; vertical leaf labels read downward and radial labels
+// follow their angle, flipped on the left half to read left to right.
+func treeLabel(p hierarchy.Point, spec TreeSpec, point func(hierarchy.Point) (float64, float64)) string {
+	x, y := point(p)
+	leaf := len(p.Node.Children) == 0
+	offset := spec.NodeRadius*1.5 + 3
+	style := svg.Style{
+		FontSize:         units.Px(10),
+		FontFamily:       "sans-serif",
+		Fill:             "#374151",
+		TextAnchor:       svg.TextAnchorStart,
+		DominantBaseline: svg.DominantBaselineMiddle,
+	}
+
+	var degrees float64
+	switch spec.Orientation {
+	case TreeVertical:
+		degrees = 90
+		if !leaf {
+			degrees = 0
+		}
+	case TreeRadial:
+		if p.Node.Parent == nil {
+			break
+		}
+		degrees = p.X*180/math.Pi - 90
+		if p.X > math.Pi {
+			degrees += 180
+			offset = -offset
+		}
+		if !leaf {
+			offset = -offset
+		}
+	default:
+		if !leaf {
+			offset = -offset
+		}
+	}
+	if offset < 0 {
+		style.TextAnchor = svg.TextAnchorEnd
+	}
+
+	halo := style
+	halo.Stroke = "#ffffff"
+	halo.StrokeWidth = 3
+	halo.Fill = "#ffffff"
+	label := svg.Text(p.Node.Data.Name, offset, 0, halo) + svg.Text(p.Node.Data.Name, offset, 0, style)
+	transform := fmt.Sprintf("translate(%.2f %.2f) rotate(%.2f)", x, y, degrees)
+	return svg.Group(label, transform, svg.Style{}) + "\n"
+}
+
+// Validate checks that the hierarchy is non-empty and acyclic and that the
+// options are known
+func (s TreeSpec) Validate() error {
+	if err := validateSize("tree", s.Width, s.Height); err != nil {
+		return err
+	}
+	err := walkTree("tree", s.Root, func(node *TreeNode, field string) error {
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	switch s.Layout {
+	case "", TreeLayoutTidy, TreeLayoutCluster:
+	default:
+		return invalid("tree", "Layout", ErrUnsupported, "%q", s.Layout)
+	}
+	switch s.Orientation {
+	case "", TreeHorizontal, TreeVertical, TreeRadial:
+	default:
+		return invalid("tree", "Orientation", ErrUnsupported, "%q", s.Orientation)
+	}
+	if s.MaxDepth < 0 {
+		return invalid("tree", "MaxDepth", ErrNegativeValue, "%d", s.MaxDepth)
+	}
+	return validateNonNegative("tree", "NodeRadius", s.NodeRadius)
+}
+
+// Render renders the tree chart to SVG
+func (s TreeSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "tree", target, func() string {
+		return RenderTree(s)
+	})
+}
@@ -0,0 +1,67 @@
+package charts
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/dataviz/hierarchy"
+)
+
+func TestRenderTree(t *testing.T) {
+	for _, orientation := range []TreeOrientation{TreeHorizontal, TreeVertical, TreeRadial} {
+		for _, layout := range []TreeLayout{TreeLayoutTidy, TreeLayoutCluster} {
+			spec := TreeSpec{
+				Root:        testBundleTree(),
+				Width:       500,
+				Height:      400,
+				Layout:      layout,
+				Orientation: orientation,
+				ShowLabels:  true,
+			}
+			svg := RenderTree(spec)
+			if n := strings.Count(svg, "<circle"); n != 9 {
+				t.Errorf("%s %s: got %d nodes, expected 9", orientation, layout, n)
+			}
+			if n := strings.Count(svg, "<path"); n != 8 {
+				t.Errorf("%s %s: got %d links, expected 8", orientation, layout, n)
+			}
+			// Each label is drawn twice, over its halo
+			if n := strings.Count(svg, ">planner<"); n != 2 {
+				t.Errorf("%s %s: got %d planner labels, expected 2", orientation, layout, n)
+			}
+		}
+	}
+}
+
+func TestRenderTreeMaxDepth(t *testing.T) {
+	spec := TreeSpec{Root: testBundleTree(), Width: 500, Height: 400, MaxDepth: 1, NodeRadius: 4}
+	svg := RenderTree(spec)
+	if n := strings.Count(svg, "<circle"); n != 3 {
+		t.Errorf("got %d nodes, expected the root and 2 collapsed children", n)
+	}
+	// Collapsed nodes are drawn larger
+	if n := strings.Count(svg, `r="6.00"`); n != 2 {
+		t.Errorf("got %d collapsed nodes, expected 2", n)
+	}
+}
+
+func TestTreeLayoutOrientation(t *testing.T) {
+	root := hierarchy.New(testBundleTree())
+	points := treeLayout(root, TreeLayoutCluster, 2*math.Pi, 100, true)
+
+	// Radial clusters put every leaf on the outer circle, in order around it
+	previous := -1.0
+	for _, p := range points {
+		if len(p.Node.Children) > 0 {
+			continue
+		}
+		if p.Y != 100 {
+			t.Errorf("%s at radius %v, expected 100", p.Node.Data.Name, p.Y)
+		}
+		if p.X <= previous || p.X >= 2*math.Pi {
+			t.Errorf("%s at angle %v, expected after %v", p.Node.Data.Name, p.X, previous)
+		}
+		previous = p.X
+	}
+}
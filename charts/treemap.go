@@ -1,20 +1,27 @@
 package charts
 
 import (
-	"sort"
+	"context"
 
+	"github.com/SCKelemen/dataviz/hierarchy"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
 )
 
 // TreeNode represents a node in a hierarchical tree structure
-type TreeNode struct {
-	Name     string
-	Value    float64      // Size/weight of this node
-	Children []*TreeNode  // Child nodes (nil for leaf nodes)
-	Color    string       // Optional custom color
-	Metadata map[string]interface{} // Optional metadata
-}
+type TreeNode = hierarchy.TreeNode
+
+// TreemapTiling controls how a treemap divides each rectangle among its
+// children
+type TreemapTiling string
+
+const (
+	TreemapSquarify  TreemapTiling = "squarify"   // Rows of rectangles close to the target aspect ratio (default)
+	TreemapBinary    TreemapTiling = "binary"     // Balanced binary splits along the longer side
+	TreemapSlice     TreemapTiling = "slice"      // Children stacked top to bottom
+	TreemapDice      TreemapTiling = "dice"       // Children side by side, left to right
+	TreemapSliceDice TreemapTiling = "slice-dice" // Dice and slice alternating by depth
+)
 
 // TreemapSpec configures treemap rendering
 type TreemapSpec struct {
@@ -25,6 +32,8 @@ type TreemapSpec struct {
 	ShowLabels bool
 	MinLabelSize float64 // Minimum rectangle size to show label
 	ColorScheme []string // Color palette
+	Tiling TreemapTiling // How children share their parent's rectangle (default: squarify)
+	Ratio float64 // Target aspect ratio for squarify tiling (default: the golden ratio)
 }
 
 // TreemapRect represents a positioned rectangle in the treemap
@@ -34,7 +43,7 @@ type TreemapRect struct {
 	Depth               int
 }
 
-// RenderTreemap renders a treemap visualization using squarified algorithm
+// RenderTreemap renders a treemap visualization
 func RenderTreemap(spec TreemapSpec) string {
 	if spec.Root == nil {
 		return ""
@@ -47,7 +56,7 @@ func RenderTreemap(spec TreemapSpec) string {
 	}
 
 	// Compute layout
-	rects := squarify(spec.Root, 0, 0, spec.Width, spec.Height, spec.Padding, 0)
+	rects := treemapLayout(spec)
 
 	// Render rectangles
 	var result string
@@ -112,149 +121,49 @@ func calculateTreeValue(node *TreeNode) float64 {
 	return total
 }
 
-// squarify implements the squarified treemap algorithm
-func squarify(node *TreeNode, x, y, width, height, padding float64, depth int) []TreemapRect {
-	if node == nil || width <= 0 || height <= 0 {
-		return nil
-	}
-
-	// Leaf node - return single rectangle
-	if len(node.Children) == 0 {
-		return []TreemapRect{{
-			X:      x + padding,
-			Y:      y + padding,
-			Width:  width - 2*padding,
-			Height: height - 2*padding,
-			Node:   node,
-			Depth:  depth,
-		}}
-	}
-
-	// Calculate total value of children
-	total := 0.0
-	for _, child := range node.Children {
-		total += calculateTreeValue(child)
-	}
-
-	if total == 0 {
-		return nil
+// treemapLayout tiles the hierarchy, largest children first, and returns
+// the leaf rectangles inset by the padding
+func treemapLayout(spec TreemapSpec) []TreemapRect {
+	root := hierarchy.New(spec.Root).Sum(nil).Sort(hierarchy.ValueDescending)
+	treemap := hierarchy.Treemap{
+		Width:  spec.Width,
+		Height: spec.Height,
+		Tile:   treemapTile(spec.Tiling, spec.Ratio),
 	}
 
-	// Sort children by value (descending)
-	children := make([]*TreeNode, len(node.Children))
-	copy(children, node.Children)
-	sort.Slice(children, func(i, j int) bool {
-		return calculateTreeValue(children[i]) > calculateTreeValue(children[j])
-	})
-
-	// Apply squarified algorithm
 	var rects []TreemapRect
-	currentX := x
-	currentY := y
-	remainingWidth := width
-	remainingHeight := height
-
-	for len(children) > 0 {
-		// Determine orientation
-		horizontal := remainingWidth >= remainingHeight
-
-		// Calculate how many children to place in current row/column
-		rowValue := 0.0
-		rowCount := 0
-
-		for i := 0; i < len(children); i++ {
-			childValue := calculateTreeValue(children[i])
-			newRowValue := rowValue + childValue
-
-			if i == 0 || improveAspectRatio(rowValue, newRowValue, remainingWidth, remainingHeight, total, horizontal) {
-				rowValue = newRowValue
-				rowCount++
-			} else {
-				break
-			}
-		}
-
-		if rowCount == 0 {
-			rowCount = 1
-			rowValue = calculateTreeValue(children[0])
-		}
-
-		// Layout children in row/column
-		if horizontal {
-			// Horizontal layout (left to right)
-			rowHeight := (rowValue / total) * remainingHeight
-			childX := currentX
-
-			for i := 0; i < rowCount && i < len(children); i++ {
-				child := children[i]
-				childValue := calculateTreeValue(child)
-				childWidth := (childValue / rowValue) * remainingWidth
-
-				childRects := squarify(child, childX, currentY, childWidth, rowHeight, padding, depth+1)
-				rects = append(rects, childRects...)
-
-				childX += childWidth
-			}
-
-			currentY += rowHeight
-			remainingHeight -= rowHeight
-		} else {
-			// Vertical layout (top to bottom)
-			rowWidth := (rowValue / total) * remainingWidth
-			childY := currentY
-
-			for i := 0; i < rowCount && i < len(children); i++ {
-				child := children[i]
-				childValue := calculateTreeValue(child)
-				childHeight := (childValue / rowValue) * remainingHeight
-
-				childRects := squarify(child, currentX, childY, rowWidth, childHeight, padding, depth+1)
-				rects = append(rects, childRects...)
-
-				childY += childHeight
-			}
-
-			currentX += rowWidth
-			remainingWidth -= rowWidth
+	for _, r := range treemap.Layout(root) {
+		if len(r.Node.Children) > 0 || r.Width() <= 0 || r.Height() <= 0 {
+			continue
 		}
-
-		// Remove processed children
-		children = children[rowCount:]
-		total -= rowValue
+		rects = append(rects, TreemapRect{
+			X:      r.X0 + spec.Padding,
+			Y:      r.Y0 + spec.Padding,
+			Width:  r.Width() - 2*spec.Padding,
+			Height: r.Height() - 2*spec.Padding,
+			Node:   r.Node.Data,
+			Depth:  r.Node.Depth,
+		})
 	}
-
 	return rects
 }
 
-// improveAspectRatio checks if adding another item improves the aspect ratio
-func improveAspectRatio(currentValue, newValue, width, height, total float64, horizontal bool) bool {
-	if horizontal {
-		currentRatio := aspectRatio(currentValue, width, height, total)
-		newRatio := aspectRatio(newValue, width, height, total)
-		return newRatio < currentRatio
-	} else {
-		currentRatio := aspectRatio(currentValue, height, width, total)
-		newRatio := aspectRatio(newValue, height, width, total)
-		return newRatio < currentRatio
-	}
-}
-
-// aspectRatio calculates the worst aspect ratio in a row/column
-func aspectRatio(value, length, breadth, total float64) float64 {
-	if value == 0 || total == 0 {
-		return 1e10
-	}
-
-	rowLength := (value / total) * breadth
-
-	// Worst aspect ratio is max(width/height, height/width)
-	ratio1 := length / rowLength
-	ratio2 := rowLength / length
-
-	if ratio1 > ratio2 {
-		return ratio1
-	}
-	return ratio2
+// treemapTile returns the hierarchy tiling for a treemap tiling option
+func treemapTile(tiling TreemapTiling, ratio float64) hierarchy.Tiling {
+	switch tiling {
+	case TreemapBinary:
+		return hierarchy.Binary
+	case TreemapSlice:
+		return hierarchy.Slice
+	case TreemapDice:
+		return hierarchy.Dice
+	case TreemapSliceDice:
+		return hierarchy.SliceDice
+	}
+	if ratio == 0 {
+		ratio = hierarchy.Phi
+	}
+	return hierarchy.Squarify(ratio)
 }
 
 // getDefaultTreemapColor returns default colors based on depth
@@ -291,21 +200,31 @@ func calculateFontSize(width, height float64) units.Length {
 
 // NewTreeNode creates a new tree node
 func NewTreeNode(name string, value float64) *TreeNode {
-	return &TreeNode{
-		Name:     name,
-		Value:    value,
-		Metadata: make(map[string]interface{}),
-	}
+	return hierarchy.NewTreeNode(name, value)
 }
 
-// AddChild adds a child node to this node
-func (n *TreeNode) AddChild(child *TreeNode) *TreeNode {
-	n.Children = append(n.Children, child)
-	return n
+// Validate checks that the hierarchy is non-empty, acyclic and non-negative
+func (s TreemapSpec) Validate() error {
+	if err := validateSize("treemap", s.Width, s.Height); err != nil {
+		return err
+	}
+	switch s.Tiling {
+	case "", TreemapSquarify, TreemapBinary, TreemapSlice, TreemapDice, TreemapSliceDice:
+	default:
+		return invalid("treemap", "Tiling", ErrUnsupported, "%q", s.Tiling)
+	}
+	if err := validateNumber("treemap", "Ratio", s.Ratio); err != nil {
+		return err
+	}
+	if s.Ratio != 0 && s.Ratio < 1 {
+		return invalid("treemap", "Ratio", ErrInvalidValue, "must be at least 1, got %v", s.Ratio)
+	}
+	return validateTree("treemap", s.Root)
 }
 
-// SetColor sets the color for this node
-func (n *TreeNode) SetColor(color string) *TreeNode {
-	n.Color = color
-	return n
+// Render renders the treemap to SVG
+func (s TreemapSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "treemap", target, func() string {
+		return RenderTreemap(s)
+	})
 }
@@ -0,0 +1,98 @@
+package charts
+
+import (
+	"math"
+
+	"github.com/SCKelemen/dataviz/scales"
+	"github.com/SCKelemen/dataviz/transforms"
+	"github.com/SCKelemen/svg"
+)
+
+// Trendline overlays a regression fit and its interval band on a scatter
+// plot. Trendlines are drawn in SVG output only.
+type Trendline struct {
+	Regression transforms.RegressionOptions // Model, confidence level and band
+	Color      string                       // Line and band color (default: the series color)
+	Opacity    float64                      // Band opacity (default: 0.2)
+	HideBand   bool                         // Draw only the fitted line
+}
+
+// fit fits the trendline to the points and returns the fitted line and
+// band, or nil when the points can't be fit. The band bounds are left
+// empty when there are too few points for an interval.
+func (t *Trendline) fit(xs, ys []float64, color string) *ConfidenceBand {
+	data := make([]transforms.DataPoint, len(xs))
+	for i := range xs {
+		data[i] = transforms.DataPoint{X: xs[i], Y: ys[i]}
+	}
+	trend := transforms.Regression(t.Regression)(data)
+	if len(trend) == 0 {
+		return nil
+	}
+
+	band := &ConfidenceBand{Color: t.Color, Opacity: t.Opacity}
+	if band.Color == "" {
+		band.Color = color
+	}
+	hasBounds := !t.HideBand
+	for _, d := range trend {
+		band.XValues = append(band.XValues, d.X.(float64))
+		band.YCenters = append(band.YCenters, d.Y)
+		band.YLowerBounds = append(band.YLowerBounds, d.Y0)
+		band.YUpperBounds = append(band.YUpperBounds, d.Y1)
+		hasBounds = hasBounds && !math.IsNaN(d.Y0) && !math.IsNaN(d.Y1)
+	}
+	if !hasBounds {
+		band.YLowerBounds, band.YUpperBounds = nil, nil
+	}
+	return band
+}
+
+// extent returns the smallest and largest Y the band covers
+func (band *ConfidenceBand) extent() (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, ys := range [][]float64{band.YCenters, band.YLowerBounds, band.YUpperBounds} {
+		for _, y := range ys {
+			lo = min(lo, y)
+			hi = max(hi, y)
+		}
+	}
+	return lo, hi
+}
+
+// renderTrendline draws the band with its fitted line, or the line alone
+// when the band has no bounds
+func renderTrendline(band *ConfidenceBand, xScale, yScale scales.Scale) string {
+	if len(band.YLowerBounds) > 0 {
+		return RenderConfidenceBands(ConfidenceBandSpec{Bands: []*ConfidenceBand{band}}, xScale, yScale)
+	}
+
+	points := make([]svg.Point, len(band.XValues))
+	for i := range band.XValues {
+		points[i] = svg.Point{
+			X: xScale.Apply(band.XValues[i]).Value,
+			Y: yScale.Apply(band.YCenters[i]).Value,
+		}
+	}
+	style := svg.Style{
+		Stroke:      band.Color,
+		StrokeWidth: 1.5,
+		Fill:        "none",
+	}
+	return svg.Path(svg.PolylinePath(points), style) + "\n"
+}
+
+// validateTrendline checks that the trendline's model and band are known
+func validateTrendline(chart, field string, t *Trendline) error {
+	switch t.Regression.Method {
+	case "", "linear", "polynomial", "exponential", "logarithmic", "power":
+	default:
+		return invalid(chart, field+".Regression.Method", ErrUnsupported, "%q", t.Regression.Method)
+	}
+	switch t.Regression.Interval {
+	case "", "confidence", "prediction":
+	default:
+		return invalid(chart, field+".Regression.Interval", ErrUnsupported, "%q", t.Regression.Interval)
+	}
+	return nil
+}
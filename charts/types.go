@@ -9,10 +9,19 @@ import (
 
 // TimeSeriesData represents data points over time
 type TimeSeriesData struct {
-	Date  time.Time
-	Value int
+	Date    time.Time
+	Value   int
+	Missing bool // No value was recorded at Date; Value is ignored
 }
 
+// GapMode selects how line and area charts draw missing points
+type GapMode string
+
+const (
+	GapBreak  GapMode = "break"  // End the line at a missing point and start again after it (default)
+	GapBridge GapMode = "bridge" // Join the points on either side of missing points
+)
+
 // ContributionDay represents a single day's contribution data
 type ContributionDay struct {
 	Date  time.Time
@@ -40,6 +49,7 @@ type LineGraphData struct {
 	Tension     float64 // Curve tension (0-1), only used if Smooth is true. 0.3 is recommended
 	MarkerType  string  // Marker type: "circle", "square", "diamond", "triangle", "dot", "" (none)
 	MarkerSize  float64 // Size of markers in pixels (default: 3)
+	Gaps        GapMode // How missing points are drawn (default: break)
 }
 
 // BarChartData represents data for a bar chart
@@ -85,6 +95,7 @@ type AreaChartData struct {
 	Tension     float64 // Curve tension (0-1), 0.3 recommended
 	BaselineY   int     // Y value for baseline (default: bottom of chart)
 	Stacked     bool    // For multiple series (future enhancement)
+	Gaps        GapMode // How missing points are drawn (default: break)
 }
 
 // ScatterPlotData represents data for a scatter plot
@@ -92,8 +103,9 @@ type ScatterPlotData struct {
 	Points     []ScatterPoint
 	Color      string
 	Label      string
-	MarkerType string  // Marker shape: "circle", "square", "diamond", "triangle", "cross", "x", "dot"
-	MarkerSize float64 // Size of markers in pixels
+	MarkerType string     // Marker shape: "circle", "square", "diamond", "triangle", "cross", "x", "dot"
+	MarkerSize float64    // Size of markers in pixels
+	Trend      *Trendline // Optional regression fit drawn under the points
 }
 
 // ScatterPoint represents a single point in a scatter plot
@@ -1,10 +1,12 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
 
+	"github.com/SCKelemen/dataviz/kde"
 	"github.com/SCKelemen/dataviz/scales"
 	"github.com/SCKelemen/svg"
 	"github.com/SCKelemen/units"
@@ -16,6 +18,7 @@ type ViolinPlotData struct {
 	Label       string    // Category label
 	Color       string    // Fill color
 	StrokeColor string    // Outline color
+	Weights     []float64 // Optional per-value weights for the density
 }
 
 // ViolinPlotSpec configures violin plot rendering
@@ -24,6 +27,7 @@ type ViolinPlotSpec struct {
 	Width     float64
 	Height    float64
 	Bandwidth float64 // KDE bandwidth (0 = auto)
+	KDE       kde.Options // Kernel, bandwidth selection, bounds and grid; Bandwidth takes precedence
 	ShowBox   bool    // If true, show box plot inside violin
 	ShowMedian bool   // If true, show median line
 	ShowMean  bool    // If true, show mean marker
@@ -51,6 +55,11 @@ type DensityPoint struct {
 
 // CalculateViolinStats calculates statistics and KDE for violin plot
 func CalculateViolinStats(values []float64, bandwidth float64) ViolinStats {
+	return violinStats(values, kde.Options{Bandwidth: bandwidth})
+}
+
+// violinStats calculates statistics and the density with the given options
+func violinStats(values []float64, opts kde.Options) ViolinStats {
 	if len(values) == 0 {
 		return ViolinStats{}
 	}
@@ -71,7 +80,7 @@ func CalculateViolinStats(values []float64, bandwidth float64) ViolinStats {
 	q3 := percentile(sorted, 75)
 
 	// Calculate KDE
-	density := calculateKDE(values, bandwidth)
+	density := calculateKDE(values, opts)
 
 	return ViolinStats{
 		Density: density,
@@ -82,75 +91,6 @@ func CalculateViolinStats(values []float64, bandwidth float64) ViolinStats {
 	}
 }
 
-// calculateKDE performs kernel density estimation using Gaussian kernel
-func calculateKDE(values []float64, bandwidth float64) []DensityPoint {
-	if len(values) == 0 {
-		return nil
-	}
-
-	// Find data range
-	minVal := values[0]
-	maxVal := values[0]
-	for _, v := range values {
-		if v < minVal {
-			minVal = v
-		}
-		if v > maxVal {
-			maxVal = v
-		}
-	}
-
-	// Auto-calculate bandwidth using Silverman's rule of thumb
-	if bandwidth == 0 {
-		// Calculate standard deviation
-		mean := 0.0
-		for _, v := range values {
-			mean += v
-		}
-		mean /= float64(len(values))
-
-		variance := 0.0
-		for _, v := range values {
-			diff := v - mean
-			variance += diff * diff
-		}
-		variance /= float64(len(values))
-		stdDev := math.Sqrt(variance)
-
-		// Silverman's rule: h = 0.9 * min(σ, IQR/1.34) * n^(-1/5)
-		sorted := make([]float64, len(values))
-		copy(sorted, values)
-		sort.Float64s(sorted)
-		iqr := percentile(sorted, 75) - percentile(sorted, 25)
-		bandwidth = 0.9 * math.Min(stdDev, iqr/1.34) * math.Pow(float64(len(values)), -0.2)
-	}
-
-	// Generate evaluation points
-	numPoints := 100
-	step := (maxVal - minVal) / float64(numPoints-1)
-	density := make([]DensityPoint, numPoints)
-
-	// Calculate density at each point using Gaussian kernel
-	for i := 0; i < numPoints; i++ {
-		x := minVal + float64(i)*step
-		d := 0.0
-
-		for _, value := range values {
-			// Gaussian kernel: (1/√(2π)) * exp(-0.5 * ((x-value)/h)²)
-			z := (x - value) / bandwidth
-			d += math.Exp(-0.5*z*z) / (bandwidth * math.Sqrt(2*math.Pi))
-		}
-		d /= float64(len(values))
-
-		density[i] = DensityPoint{
-			Value:   x,
-			Density: d,
-		}
-	}
-
-	return density
-}
-
 // RenderViolinPlot renders a violin plot
 func RenderViolinPlot(spec ViolinPlotSpec) string {
 	if len(spec.Data) == 0 {
@@ -164,7 +104,7 @@ func RenderViolinPlot(spec ViolinPlotSpec) string {
 	maxDensity := 0.0
 
 	for i, data := range spec.Data {
-		stats[i] = CalculateViolinStats(data.Values, spec.Bandwidth)
+		stats[i] = violinStats(data.Values, seriesKDE(spec.KDE, spec.Bandwidth, data.Weights))
 
 		// Find global min/max
 		for _, dp := range stats[i].Density {
@@ -302,3 +242,36 @@ func RenderViolinPlot(spec ViolinPlotSpec) string {
 
 	return result
 }
+
+// Validate checks that every violin has finite values
+func (s ViolinPlotSpec) Validate() error {
+	if err := validateSize("violin", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Data) == 0 {
+		return &ValidationError{Chart: "violin", Field: "Data", Err: ErrEmptyData}
+	}
+	for i, d := range s.Data {
+		field := fmt.Sprintf("Data[%d]", i)
+		if d == nil {
+			return invalid("violin", field, ErrMissingField, "nil violin")
+		}
+		if err := validateValues("violin", field+".Values", d.Values); err != nil {
+			return err
+		}
+		if err := validateWeights("violin", field+".Weights", d.Values, d.Weights); err != nil {
+			return err
+		}
+	}
+	if s.Bandwidth < 0 {
+		return invalid("violin", "Bandwidth", ErrNegativeValue, "%v", s.Bandwidth)
+	}
+	return validateKDE("violin", s.KDE)
+}
+
+// Render renders the violin plot to SVG
+func (s ViolinPlotSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "violin", target, func() string {
+		return RenderViolinPlot(s)
+	})
+}
@@ -1,11 +1,17 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"math/rand"
 	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/SCKelemen/svg"
+	"github.com/SCKelemen/text"
 	"github.com/SCKelemen/units"
 )
 
@@ -17,16 +23,46 @@ type WordCloudWord struct {
 	Angle      float64 // Optional rotation angle in degrees (0 = horizontal)
 }
 
-// WordCloudSpec configures wordcloud rendering
+// WordCloudSpiral is the path the spiral layout searches along for a free
+// spot for each word
+type WordCloudSpiral string
+
+const (
+	WordCloudSpiralArchimedean WordCloudSpiral = "archimedean" // Evenly spaced turns, giving a round cloud (default)
+	WordCloudSpiralRectangular WordCloudSpiral = "rectangular" // Square turns, giving a boxy cloud
+)
+
+// WordCloudShape is the region the spiral layout fills
+type WordCloudShape string
+
+const (
+	WordCloudShapeRectangle WordCloudShape = "rectangle" // The whole plot area (default)
+	WordCloudShapeCircle    WordCloudShape = "circle"    // The largest circle centered in the plot area
+	WordCloudShapePolygon   WordCloudShape = "polygon"   // The Mask polygon
+)
+
+// WordCloudSpec configures wordcloud rendering.
+//
+// The spiral layout places words largest first, each at the first spot
+// along a spiral from the center where it overlaps no placed word and lies
+// inside the shape. Overlap is tested on a pixel grid against each word's
+// rotated box, sized from its measured text width. Words that fit nowhere
+// are left out.
 type WordCloudSpec struct {
 	Words        []WordCloudWord
 	Width        float64
 	Height       float64
-	MinFontSize  float64   // Minimum font size (default: 12)
-	MaxFontSize  float64   // Maximum font size (default: 72)
-	FontFamily   string    // Font family (default: sans-serif)
-	DefaultColor string    // Default word color
-	Layout       string    // "spiral", "horizontal" (default: spiral)
+	MinFontSize  float64         // Minimum font size (default: 12)
+	MaxFontSize  float64         // Maximum font size (default: 72)
+	FontFamily   string          // Font family (default: sans-serif)
+	DefaultColor string          // Default word color
+	Layout       string          // "spiral", "horizontal" (default: spiral)
+	Spiral       WordCloudSpiral // Search path of the spiral layout (default: archimedean)
+	Rotations    []float64       // Angles in degrees chosen at random for words without an Angle (default: horizontal)
+	Shape        WordCloudShape  // Region the spiral layout fills (default: rectangle)
+	Mask         [][2]float64    // Polygon vertices for the polygon shape, as fractions (0-1) of the plot area
+	Padding      float64         // Space around each word in pixels (default: 1)
+	Seed         int64           // Seed for rotations and spiral directions; the layout is deterministic for a seed
 	Title        string
 }
 
@@ -52,6 +88,9 @@ func RenderWordCloud(spec WordCloudSpec) string {
 	if spec.Layout == "" {
 		spec.Layout = "spiral"
 	}
+	if spec.Padding == 0 {
+		spec.Padding = 1
+	}
 
 	// Find min/max frequency
 	minFreq := spec.Words[0].Frequency
@@ -73,11 +112,11 @@ func RenderWordCloud(spec WordCloudSpec) string {
 	// Sort words by frequency (descending) for better placement
 	sortedWords := make([]WordCloudWord, len(spec.Words))
 	copy(sortedWords, spec.Words)
-	sort.Slice(sortedWords, func(i, j int) bool {
+	sort.SliceStable(sortedWords, func(i, j int) bool {
 		return sortedWords[i].Frequency > sortedWords[j].Frequency
 	})
 
-	// Calculate font sizes
+	// Calculate font sizes and measure the words
 	words := make([]wordWithPosition, len(sortedWords))
 	for i, word := range sortedWords {
 		// Scale font size based on frequency
@@ -87,15 +126,21 @@ func RenderWordCloud(spec WordCloudSpec) string {
 		words[i] = wordWithPosition{
 			word:     word,
 			fontSize: fontSize,
+			width:    wordCloudText.Width(word.Text) * fontSize,
+			angle:    word.Angle,
 		}
 	}
 
-	// Layout words
+	// Layout words below the title
+	top := 0.0
+	if spec.Title != "" {
+		top = 36
+	}
 	switch spec.Layout {
 	case "horizontal":
 		layoutHorizontal(words, spec.Width, spec.Height)
 	default: // "spiral"
-		layoutSpiral(words, spec.Width, spec.Height)
+		layoutCloud(words, spec, 0, top, spec.Width, spec.Height-top)
 	}
 
 	var result string
@@ -117,6 +162,10 @@ func RenderWordCloud(spec WordCloudSpec) string {
 
 	// Draw words
 	for i, word := range words {
+		if !word.placed {
+			continue
+		}
+
 		// Get word color
 		wordColor := word.word.Color
 		if wordColor == "" {
@@ -139,9 +188,10 @@ func RenderWordCloud(spec WordCloudSpec) string {
 		}
 
 		// Apply rotation if specified
-		if word.word.Angle != 0 {
-			result += fmt.Sprintf(`<text x="%.2f" y="%.2f" font-size="%.0f" font-family="%s" font-weight="bold" text-anchor="middle" dominant-baseline="middle" fill="%s" transform="rotate(%.1f %.2f %.2f)">%s</text>`,
-				word.x, word.y, word.fontSize, spec.FontFamily, wordColor, word.word.Angle, word.x, word.y, word.word.Text) + "\n"
+		if word.angle != 0 {
+			label := svg.Text(word.word.Text, 0, 0, textStyle)
+			transform := fmt.Sprintf("translate(%.2f %.2f) rotate(%.1f)", word.x, word.y, word.angle)
+			result += svg.Group(label, transform, svg.Style{}) + "\n"
 		} else {
 			result += svg.Text(word.word.Text, word.x, word.y, textStyle) + "\n"
 		}
@@ -154,53 +204,243 @@ func RenderWordCloud(spec WordCloudSpec) string {
 type wordWithPosition struct {
 	word     WordCloudWord
 	fontSize float64
+	width    float64 // Measured text width in pixels
+	angle    float64 // Rotation in degrees
 	x        float64
 	y        float64
+	placed   bool
 }
 
-// layoutSpiral places words in a spiral pattern starting from center
-func layoutSpiral(words []wordWithPosition, width, height float64) {
-	centerX := width / 2
-	centerY := height / 2
+// wordCloudText measures words in ems of a bold sans-serif face
+var wordCloudText = text.New(text.Config{MeasureFunc: boldSansAdvance})
+
+// boldSansAdvance approximates the advance of a bold sans-serif glyph in
+// ems. Wide characters such as CJK ideographs take a full em.
+func boldSansAdvance(r rune) float64 {
+	switch {
+	case strings.ContainsRune("iljI|!.,:;'", r):
+		return 0.3
+	case strings.ContainsRune("frt()[]-\" ", r):
+		return 0.4
+	case strings.ContainsRune("mwMW", r):
+		return 0.9
+	case unicode.IsUpper(r):
+		return 0.72
+	case r < utf8.RuneSelf:
+		return 0.6
+	}
+	if w := text.TerminalMeasure(r); w < 2 {
+		return 0.6 * w
+	}
+	return 1
+}
 
-	// Simple spiral placement - place words along a spiral
-	angle := 0.0
-	radius := 0.0
-	radiusStep := 5.0
-	angleStep := 0.3
+// wordCloudCell is the side of a collision grid cell in pixels
+const wordCloudCell = 2.0
+
+// wordCloudGrid records which cells of the plot area are taken, one bit
+// per cell
+type wordCloudGrid struct {
+	cols, rows int
+	bits       [][]uint64
+}
+
+// wordSprite is the set of cells a word covers, as one span of columns
+// per row, relative to the cell holding the word's center
+type wordSprite []struct{ row, col0, col1 int }
+
+// layoutCloud places words along a spiral from the center of the plot
+// area, in the style of Wordle and d3-cloud: each word takes the first
+// spot where its sprite hits no taken cell, then takes those cells
+func layoutCloud(words []wordWithPosition, spec WordCloudSpec, x0, y0, width, height float64) {
+	grid := newWordCloudGrid(width, height)
+	grid.mask(spec, width, height)
+	rng := rand.New(rand.NewSource(spec.Seed))
+	maxDelta := math.Hypot(width, height)
 
 	for i := range words {
-		// Calculate position on spiral
-		x := centerX + radius*math.Cos(angle)
-		y := centerY + radius*math.Sin(angle)
+		w := &words[i]
+		if w.angle == 0 && len(spec.Rotations) > 0 {
+			w.angle = spec.Rotations[rng.Intn(len(spec.Rotations))]
+		}
+		sprite := newWordSprite(w.width, w.fontSize, w.angle, spec.Padding)
 
-		// Clamp to bounds
-		margin := words[i].fontSize
-		if x < margin {
-			x = margin
+		// Alternate spirals turn either way so the cloud does not swirl
+		direction := 1.0
+		if rng.Float64() < 0.5 {
+			direction = -1
 		}
-		if x > width-margin {
-			x = width - margin
+		next := newWordCloudSpiral(spec.Spiral, width, height, direction)
+		dx, dy := 0.0, 0.0
+		for math.Min(math.Abs(dx), math.Abs(dy)) < maxDelta {
+			col := int((width/2 + dx) / wordCloudCell)
+			row := int((height/2 + dy) / wordCloudCell)
+			if grid.fits(sprite, col, row) {
+				grid.take(sprite, col, row)
+				w.x = x0 + (float64(col)+0.5)*wordCloudCell
+				w.y = y0 + (float64(row)+0.5)*wordCloudCell
+				w.placed = true
+				break
+			}
+			dx, dy = next()
 		}
-		if y < margin {
-			y = margin
+	}
+}
+
+// newWordCloudSpiral returns a function giving successive offsets from the
+// center along the spiral, stretched to the plot's aspect ratio and turning
+// clockwise for a positive direction
+func newWordCloudSpiral(kind WordCloudSpiral, width, height, direction float64) func() (float64, float64) {
+	aspect := width / height
+	t := 0.0
+	if kind == WordCloudSpiralRectangular {
+		dy := 4.0
+		dx := dy * aspect
+		x, y := 0.0, 0.0
+		return func() (float64, float64) {
+			t += direction
+			switch int(math.Sqrt(1+4*direction*t)-direction) & 3 {
+			case 0:
+				x += dx
+			case 1:
+				y += dy
+			case 2:
+				x -= dx
+			default:
+				y -= dy
+			}
+			return x, y
 		}
-		if y > height-margin {
-			y = height - margin
+	}
+	return func() (float64, float64) {
+		t += direction
+		a := t * 0.1
+		return aspect * a * math.Cos(a), a * math.Sin(a)
+	}
+}
+
+// newWordSprite rasterizes a word's box, rotated by angle degrees and
+// grown by padding on every side, into grid cells. A cell is covered when
+// its center lies within half a cell of the box.
+func newWordSprite(width, fontSize, angle, padding float64) wordSprite {
+	hw := width/2 + padding + wordCloudCell/2
+	hh := fontSize/2 + padding + wordCloudCell/2
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	extentX := math.Abs(hw*cos) + math.Abs(hh*sin)
+	extentY := math.Abs(hw*sin) + math.Abs(hh*cos)
+	rx := int(math.Ceil(extentX / wordCloudCell))
+	ry := int(math.Ceil(extentY / wordCloudCell))
+
+	var sprite wordSprite
+	for row := -ry; row <= ry; row++ {
+		col0, col1 := 0, -1
+		for col := -rx; col <= rx; col++ {
+			x, y := float64(col)*wordCloudCell, float64(row)*wordCloudCell
+			// Back into the word's frame
+			u := x*cos + y*sin
+			v := -x*sin + y*cos
+			if math.Abs(u) > hw || math.Abs(v) > hh {
+				continue
+			}
+			if col1 < col0 {
+				col0 = col
+			}
+			col1 = col
+		}
+		if col1 >= col0 {
+			sprite = append(sprite, struct{ row, col0, col1 int }{row, col0, col1})
 		}
+	}
+	return sprite
+}
 
-		words[i].x = x
-		words[i].y = y
+// newWordCloudGrid creates an empty grid covering width by height pixels
+func newWordCloudGrid(width, height float64) *wordCloudGrid {
+	g := &wordCloudGrid{
+		cols: max(int(width/wordCloudCell), 1),
+		rows: max(int(height/wordCloudCell), 1),
+	}
+	g.bits = make([][]uint64, g.rows)
+	for i := range g.bits {
+		g.bits[i] = make([]uint64, (g.cols+63)/64)
+	}
+	return g
+}
 
-		// Update spiral parameters
-		angle += angleStep
-		radius += radiusStep
+// mask takes every cell whose center lies outside the spec's shape
+func (g *wordCloudGrid) mask(spec WordCloudSpec, width, height float64) {
+	var inside func(x, y float64) bool
+	switch spec.Shape {
+	case WordCloudShapeCircle:
+		r := math.Min(width, height) / 2
+		inside = func(x, y float64) bool {
+			return math.Hypot(x-width/2, y-height/2) <= r
+		}
+	case WordCloudShapePolygon:
+		inside = func(x, y float64) bool {
+			return pointInPolygon(x/width, y/height, spec.Mask)
+		}
+	default:
+		return
+	}
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.cols; col++ {
+			x := (float64(col) + 0.5) * wordCloudCell
+			y := (float64(row) + 0.5) * wordCloudCell
+			if !inside(x, y) {
+				g.bits[row][col/64] |= 1 << (col % 64)
+			}
+		}
+	}
+}
 
-		// Larger words increase the radius more
-		if words[i].fontSize > 40 {
-			radius += radiusStep * 2
+// fits reports whether the sprite centered on a cell lies within the grid
+// and covers no taken cell
+func (g *wordCloudGrid) fits(sprite wordSprite, col, row int) bool {
+	for _, span := range sprite {
+		r, c0, c1 := row+span.row, col+span.col0, col+span.col1
+		if r < 0 || r >= g.rows || c0 < 0 || c1 >= g.cols {
+			return false
+		}
+		bits := g.bits[r]
+		for w := c0 / 64; w <= c1/64; w++ {
+			if bits[w]&spanMask(w, c0, c1) != 0 {
+				return false
+			}
 		}
 	}
+	return true
+}
+
+// take marks the sprite's cells as taken
+func (g *wordCloudGrid) take(sprite wordSprite, col, row int) {
+	for _, span := range sprite {
+		r, c0, c1 := row+span.row, col+span.col0, col+span.col1
+		for w := c0 / 64; w <= c1/64; w++ {
+			g.bits[r][w] |= spanMask(w, c0, c1)
+		}
+	}
+}
+
+// spanMask returns the bits of word w covering columns c0 to c1 inclusive
+func spanMask(w, c0, c1 int) uint64 {
+	lo := max(c0-w*64, 0)
+	hi := min(c1-w*64, 63)
+	return (^uint64(0) >> (63 - hi)) &^ (1<<lo - 1)
+}
+
+// pointInPolygon reports whether a point lies inside a polygon, by the
+// even-odd rule
+func pointInPolygon(x, y float64, polygon [][2]float64) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		xi, yi := polygon[i][0], polygon[i][1]
+		xj, yj := polygon[j][0], polygon[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
 }
 
 // layoutHorizontal places words in rows from top to bottom
@@ -212,8 +452,7 @@ func layoutHorizontal(words []wordWithPosition, width, height float64) {
 	maxRowHeight := 0.0
 
 	for i := range words {
-		// Estimate word width (rough approximation)
-		wordWidth := float64(len(words[i].word.Text)) * words[i].fontSize * 0.6
+		wordWidth := words[i].width
 
 		// Check if word fits on current line
 		if x+wordWidth > width-margin && x > margin {
@@ -230,6 +469,7 @@ func layoutHorizontal(words []wordWithPosition, width, height float64) {
 
 		words[i].x = x + wordWidth/2
 		words[i].y = y + words[i].fontSize/2
+		words[i].placed = true
 
 		x += wordWidth + 15
 
@@ -323,3 +563,55 @@ func ColorfulWordCloud(words []string, frequencies []float64, colors []string, w
 
 	return RenderWordCloud(spec)
 }
+
+// Validate checks that the cloud has words with non-negative frequencies
+func (s WordCloudSpec) Validate() error {
+	if err := validateSize("wordcloud", s.Width, s.Height); err != nil {
+		return err
+	}
+	if len(s.Words) == 0 {
+		return &ValidationError{Chart: "wordcloud", Field: "Words", Err: ErrEmptyData}
+	}
+	for i, w := range s.Words {
+		if err := validateNonNegative("wordcloud", fmt.Sprintf("Words[%d].Frequency", i), w.Frequency); err != nil {
+			return err
+		}
+	}
+	if s.MinFontSize < 0 || s.MaxFontSize < 0 {
+		return invalid("wordcloud", "MinFontSize", ErrNegativeValue, "font sizes must not be negative")
+	}
+	switch s.Spiral {
+	case "", WordCloudSpiralArchimedean, WordCloudSpiralRectangular:
+	default:
+		return invalid("wordcloud", "Spiral", ErrUnsupported, "%q", s.Spiral)
+	}
+	for i, r := range s.Rotations {
+		if err := validateNumber("wordcloud", fmt.Sprintf("Rotations[%d]", i), r); err != nil {
+			return err
+		}
+	}
+	switch s.Shape {
+	case "", WordCloudShapeRectangle, WordCloudShapeCircle:
+	case WordCloudShapePolygon:
+		if len(s.Mask) < 3 {
+			return invalid("wordcloud", "Mask", ErrMissingField, "polygon shape needs at least 3 vertices, got %d", len(s.Mask))
+		}
+		for i, v := range s.Mask {
+			for j, c := range v {
+				if err := validateNumber("wordcloud", fmt.Sprintf("Mask[%d][%d]", i, j), c); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return invalid("wordcloud", "Shape", ErrUnsupported, "%q", s.Shape)
+	}
+	return validateNonNegative("wordcloud", "Padding", s.Padding)
+}
+
+// Render renders the word cloud to SVG
+func (s WordCloudSpec) Render(ctx context.Context, target Target) (Output, error) {
+	return renderSVG(ctx, s, "wordcloud", target, func() string {
+		return RenderWordCloud(s)
+	})
+}
@@ -0,0 +1,166 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// testCloudWords returns n words with falling frequencies
+func testCloudWords(n int) []WordCloudWord {
+	words := make([]WordCloudWord, n)
+	for i := range words {
+		words[i] = WordCloudWord{Text: fmt.Sprintf("word%d", i), Frequency: float64(n - i)}
+	}
+	return words
+}
+
+// layoutTestCloud measures and lays out a spec's words like RenderWordCloud
+func layoutTestCloud(spec WordCloudSpec) []wordWithPosition {
+	words := make([]wordWithPosition, len(spec.Words))
+	for i, w := range spec.Words {
+		fontSize := 12 + 24*w.Frequency/spec.Words[0].Frequency
+		words[i] = wordWithPosition{word: w, fontSize: fontSize, width: wordCloudText.Width(w.Text) * fontSize}
+	}
+	if spec.Padding == 0 {
+		spec.Padding = 1
+	}
+	layoutCloud(words, spec, 0, 0, spec.Width, spec.Height)
+	return words
+}
+
+// wordBox returns the axis-aligned box of a word rotated by 0 or 90 degrees
+func wordBox(w wordWithPosition) (x0, y0, x1, y1 float64) {
+	hw, hh := w.width/2, w.fontSize/2
+	if w.angle != 0 {
+		hw, hh = hh, hw
+	}
+	return w.x - hw, w.y - hh, w.x + hw, w.y + hh
+}
+
+func TestWordCloudNoOverlap(t *testing.T) {
+	for _, spiral := range []WordCloudSpiral{WordCloudSpiralArchimedean, WordCloudSpiralRectangular} {
+		spec := WordCloudSpec{Words: testCloudWords(60), Width: 600, Height: 400, Spiral: spiral, Rotations: []float64{0, 90}}
+		words := layoutTestCloud(spec)
+
+		placed := 0
+		for i, a := range words {
+			if !a.placed {
+				continue
+			}
+			placed++
+			ax0, ay0, ax1, ay1 := wordBox(a)
+			if ax0 < 0 || ay0 < 0 || ax1 > spec.Width || ay1 > spec.Height {
+				t.Errorf("%s: %s at (%v, %v) leaves the plot", spiral, a.word.Text, a.x, a.y)
+			}
+			for _, b := range words[:i] {
+				if !b.placed {
+					continue
+				}
+				bx0, by0, bx1, by1 := wordBox(b)
+				if ax0 < bx1 && bx0 < ax1 && ay0 < by1 && by0 < ay1 {
+					t.Errorf("%s: %s overlaps %s", spiral, a.word.Text, b.word.Text)
+				}
+			}
+		}
+		if placed < 40 {
+			t.Errorf("%s: placed %d of %d words", spiral, placed, len(words))
+		}
+	}
+}
+
+func TestWordCloudSeed(t *testing.T) {
+	spec := WordCloudSpec{Words: testCloudWords(30), Width: 600, Height: 400, Rotations: []float64{-45, 0, 45}, Seed: 7}
+	first := layoutTestCloud(spec)
+	second := layoutTestCloud(spec)
+	for i := range first {
+		if first[i].x != second[i].x || first[i].y != second[i].y || first[i].angle != second[i].angle {
+			t.Fatalf("%s placed differently for the same seed", first[i].word.Text)
+		}
+	}
+
+	spec.Seed = 8
+	other := layoutTestCloud(spec)
+	same := true
+	for i := range first {
+		same = same && first[i].x == other[i].x && first[i].y == other[i].y && first[i].angle == other[i].angle
+	}
+	if same {
+		t.Error("different seeds gave the same layout")
+	}
+}
+
+func TestWordCloudShape(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   WordCloudSpec
+		inside func(x, y float64) bool
+	}{
+		{"circle", WordCloudSpec{Shape: WordCloudShapeCircle}, func(x, y float64) bool {
+			return math.Hypot(x-300, y-200) <= 200
+		}},
+		{"polygon", WordCloudSpec{Shape: WordCloudShapePolygon, Mask: [][2]float64{{0.5, 0}, {1, 1}, {0, 1}}}, func(x, y float64) bool {
+			return pointInPolygon(x/600, y/400, [][2]float64{{0.5, 0}, {1, 1}, {0, 1}})
+		}},
+	}
+	for _, tt := range tests {
+		tt.spec.Words = testCloudWords(40)
+		tt.spec.Width, tt.spec.Height = 600, 400
+		for _, w := range layoutTestCloud(tt.spec) {
+			if !w.placed {
+				continue
+			}
+			x0, y0, x1, y1 := wordBox(w)
+			for _, corner := range [][2]float64{{x0, y0}, {x1, y0}, {x0, y1}, {x1, y1}} {
+				if !tt.inside(corner[0], corner[1]) {
+					t.Errorf("%s: %s corner %v outside the shape", tt.name, w.word.Text, corner)
+				}
+			}
+		}
+	}
+}
+
+func TestWordSprite(t *testing.T) {
+	// A horizontal 20x10 box with no padding covers 7 rows of 2px cells
+	horizontal := newWordSprite(20, 10, 0, 0)
+	if len(horizontal) != 7 {
+		t.Errorf("got %d rows, expected 7", len(horizontal))
+	}
+	// Turned upright it is taller than it is wide
+	upright := newWordSprite(20, 10, 90, 0)
+	if len(upright) <= len(horizontal) {
+		t.Errorf("upright sprite has %d rows, expected more than %d", len(upright), len(horizontal))
+	}
+	for _, span := range upright {
+		if span.col1-span.col0 > 6 {
+			t.Errorf("upright row %d spans %d cells", span.row, span.col1-span.col0+1)
+		}
+	}
+}
+
+func TestSpanMask(t *testing.T) {
+	tests := []struct {
+		w, c0, c1 int
+		expected  uint64
+	}{
+		{0, 0, 0, 1},
+		{0, 2, 4, 0b11100},
+		{0, 60, 70, 0xf000000000000000},
+		{1, 60, 70, 0b1111111},
+		{0, 0, 63, math.MaxUint64},
+	}
+	for _, tt := range tests {
+		if got := spanMask(tt.w, tt.c0, tt.c1); got != tt.expected {
+			t.Errorf("spanMask(%d, %d, %d) = %#x, expected %#x", tt.w, tt.c0, tt.c1, got, tt.expected)
+		}
+	}
+}
+
+func TestWordCloudMeasure(t *testing.T) {
+	if narrow, wide := wordCloudText.Width("iiii"), wordCloudText.Width("WWWW"); narrow >= wide {
+		t.Errorf("iiii measured %v, not narrower than WWWW at %v", narrow, wide)
+	}
+	if w := wordCloudText.Width("世界"); w != 2 {
+		t.Errorf("two ideographs measured %v ems, expected 2", w)
+	}
+}
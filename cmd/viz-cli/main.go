@@ -9,15 +9,17 @@ import (
 	"time"
 
 	"github.com/SCKelemen/dataviz/charts"
+	"github.com/SCKelemen/dataviz/grammar"
 	"github.com/SCKelemen/dataviz/scales"
 	"github.com/SCKelemen/units"
 	design "github.com/SCKelemen/design-system"
 )
 
-const usage = `viz-cli - Data visualization tool with SVG and terminal output
+const usage = `viz-cli - Data visualization tool with SVG, PNG, JPEG, PDF and terminal output
 
 Usage:
   viz-cli [options]
+  viz-cli -spec chart.json [-output chart.svg]
 
 Chart Types:
   Basic Charts:
@@ -36,6 +38,7 @@ Chart Types:
     circle-packing - Hierarchical circle packing
     icicle        - Icicle partition chart
     dendrogram    - Hierarchical clustering tree
+    tree          - Tidy, cluster or radial node-link tree
 
   Statistical Charts:
     boxplot       - Box and whisker plot
@@ -58,6 +61,9 @@ Chart Types:
   Network/Flow Charts:
     sankey        - Sankey flow diagram
     chord         - Chord diagram
+    network       - Force-directed network graph
+    arc           - Arc diagram
+    edge-bundling - Hierarchical edge bundling
 
   Circular Charts:
     circular-bar  - Circular bar plot
@@ -70,9 +76,14 @@ Options:
   -type string
         Chart type (default "heatmap")
   -format string
-        Output format: svg, terminal (default "terminal")
+        Output format: svg, terminal, png, jpeg, pdf
+        (default: inferred from the -output extension, else svg)
   -data string
         Path to JSON data file (or use stdin with -)
+  -spec string
+        Path to a grammar spec (JSON or YAML, or use stdin with -).
+        The spec sets the chart type, data, size and theme, so -type,
+        -data, -theme, -width, -height and -color are ignored
   -theme string
         Theme: default, midnight, nord, paper, wrapped (default "default")
   -width int
@@ -82,7 +93,14 @@ Options:
   -color string
         Primary color (hex format) (default "#3B82F6")
   -output string
-        Output file path (default: stdout)
+        Output file path (default: stdout). Binary formats (png, jpeg,
+        pdf) are not written to a terminal; redirect stdout or use -output
+  -scale float
+        Pixel scale for png and jpeg output, e.g. 2 for high-DPI (overrides -dpi)
+  -dpi int
+        Dots per inch for png and jpeg output (default 96, the chart's pixel size)
+  -quality int
+        JPEG quality, 1-100 (default 90)
 
 Examples:
   # SVG treemap from file
@@ -93,22 +111,59 @@ Examples:
 
   # Candlestick chart with custom theme
   viz-cli -type candlestick -data stocks.json -theme midnight -width 1200
+
+  # Chart from a declarative grammar spec
+  viz-cli -spec sales.yaml -output sales.svg
+
+  # Report images in CI: format inferred from the extension
+  viz-cli -spec sales.yaml -output sales.png -scale 2
+  viz-cli -type sankey -data flows.json -output flows.pdf
 `
 
 type Config struct {
 	vizType    string
 	format     string
 	dataFile   string
+	specFile   string
 	outputFile string
 	theme      string
 	width      int
 	height     int
 	color      string
+	scale      float64
+	dpi        int
+	quality    int
 }
 
 func main() {
 	cfg := parseFlags()
 
+	format, err := outputFormat(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Check before rendering so a mistyped command fails fast
+	if isBinary(format) && (cfg.outputFile == "" || cfg.outputFile == "-") && isTerminal(os.Stdout) {
+		fmt.Fprintf(os.Stderr, "Error: refusing to write binary %s output to a terminal; use -output or redirect stdout\n", format)
+		os.Exit(1)
+	}
+
+	if cfg.specFile != "" {
+		if format == "terminal" {
+			fmt.Fprintln(os.Stderr, "Error: terminal output is not supported with -spec")
+			os.Exit(1)
+		}
+		output, err := renderSpec(cfg.specFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error rendering spec: %v\n", err)
+			os.Exit(1)
+		}
+		writeEncoded(output, format, cfg)
+		return
+	}
+
 	if cfg.dataFile == "" || cfg.dataFile == "-" {
 		fmt.Fprintln(os.Stderr, "Reading from stdin...")
 	}
@@ -124,18 +179,24 @@ func main() {
 	tokens := getTheme(cfg.theme)
 
 	// Render visualization
-	var output string
-	switch cfg.format {
-	case "svg":
-		output = renderSVG(cfg.vizType, data, cfg, tokens)
-	case "terminal":
-		output = renderTerminal(cfg.vizType, data, cfg, tokens)
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown format: %s\n", cfg.format)
-		os.Exit(1)
+	if format == "terminal" {
+		output := renderTerminal(cfg.vizType, data, cfg, tokens)
+		if err := writeOutput(cfg.outputFile, []byte(output)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
+	writeEncoded(renderSVG(cfg.vizType, data, cfg, tokens), format, cfg)
+}
 
-	// Write output
+// writeEncoded converts an SVG document to the output format and writes it
+func writeEncoded(svg string, format string, cfg Config) {
+	output, err := encodeOutput(svg, format, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting %s: %v\n", format, err)
+		os.Exit(1)
+	}
 	if err := writeOutput(cfg.outputFile, output); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
 		os.Exit(1)
@@ -146,13 +207,17 @@ func parseFlags() Config {
 	cfg := Config{}
 
 	flag.StringVar(&cfg.vizType, "type", "", "Chart type")
-	flag.StringVar(&cfg.format, "format", "svg", "Output format")
+	flag.StringVar(&cfg.format, "format", "", "Output format")
 	flag.StringVar(&cfg.dataFile, "data", "-", "Data file path")
+	flag.StringVar(&cfg.specFile, "spec", "", "Grammar spec file path")
 	flag.StringVar(&cfg.outputFile, "output", "-", "Output file path")
 	flag.StringVar(&cfg.theme, "theme", "default", "Theme name")
 	flag.IntVar(&cfg.width, "width", 800, "Width in pixels")
 	flag.IntVar(&cfg.height, "height", 600, "Height in pixels")
 	flag.StringVar(&cfg.color, "color", "#3B82F6", "Primary color")
+	flag.Float64Var(&cfg.scale, "scale", 0, "Pixel scale for raster output")
+	flag.IntVar(&cfg.dpi, "dpi", 0, "Dots per inch for raster output")
+	flag.IntVar(&cfg.quality, "quality", 90, "JPEG quality")
 
 	flag.Usage = func() {
 		fmt.Fprint(os.Stderr, usage)
@@ -180,12 +245,26 @@ func readData(path string) ([]byte, error) {
 	return os.ReadFile(path)
 }
 
-func writeOutput(path string, content string) error {
+// renderSpec renders a grammar spec file with the same renderer used by the
+// library and the MCP server
+func renderSpec(path string) (string, error) {
+	data, err := readData(path)
+	if err != nil {
+		return "", err
+	}
+	spec, err := grammar.Parse(data)
+	if err != nil {
+		return "", err
+	}
+	return grammar.Render(spec)
+}
+
+func writeOutput(path string, content []byte) error {
 	if path == "" || path == "-" {
-		fmt.Print(content)
-		return nil
+		_, err := os.Stdout.Write(content)
+		return err
 	}
-	return os.WriteFile(path, []byte(content), 0644)
+	return os.WriteFile(path, content, 0644)
 }
 
 func getTheme(name string) *design.DesignTokens {
@@ -235,6 +314,8 @@ func renderVisualization(vizType string, data []byte, cfg Config, tokens *design
 		return renderIcicle(data, cfg)
 	case "dendrogram":
 		return renderDendrogram(data, cfg)
+	case "tree":
+		return renderTree(data, cfg)
 	// Statistical charts
 	case "boxplot":
 		return renderBoxplot(data, cfg)
@@ -267,6 +348,12 @@ func renderVisualization(vizType string, data []byte, cfg Config, tokens *design
 		return renderSankey(data, cfg)
 	case "chord":
 		return renderChord(data, cfg)
+	case "network":
+		return renderNetwork(data, cfg)
+	case "arc":
+		return renderArcDiagram(data, cfg)
+	case "edge-bundling":
+		return renderEdgeBundling(data, cfg)
 	// Circular charts
 	case "circular-bar":
 		return renderCircularBar(data, cfg)
@@ -280,11 +367,9 @@ func renderVisualization(vizType string, data []byte, cfg Config, tokens *design
 		return renderScatter(data, cfg)
 	case "pie":
 		return renderPie(data, cfg)
-	case "area-chart":
-		return renderArea(data, cfg)
 	case "lollipop":
 		return renderLollipop(data, cfg)
-	case "heatmap", "line-graph", "bar-chart", "stat-card":
+	case "heatmap", "line-graph", "area-chart", "bar-chart", "stat-card":
 		return renderLegacyChart(vizType, data, cfg, tokens)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown chart type: %s\n", vizType)
@@ -370,6 +455,32 @@ func renderIcicle(data []byte, cfg Config) string {
 	return charts.RenderIcicle(spec)
 }
 
+func renderTree(data []byte, cfg Config) string {
+	var input struct {
+		Root        charts.TreeNode `json:"root"`
+		Layout      string          `json:"layout,omitempty"`
+		Orientation string          `json:"orientation,omitempty"`
+		MaxDepth    int             `json:"maxDepth,omitempty"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing tree data: %v\n", err)
+		os.Exit(1)
+	}
+
+	spec := charts.TreeSpec{
+		Root:        &input.Root,
+		Width:       float64(cfg.width),
+		Height:      float64(cfg.height),
+		Layout:      charts.TreeLayout(input.Layout),
+		Orientation: charts.TreeOrientation(input.Orientation),
+		MaxDepth:    input.MaxDepth,
+		ShowLabels:  true,
+		NodeColor:   cfg.color,
+	}
+
+	return charts.RenderTree(spec)
+}
+
 // Statistical charts
 
 func renderBoxplot(data []byte, cfg Config) string {
@@ -666,12 +777,6 @@ func renderPie(data []byte, cfg Config) string {
 	return charts.RenderPieChart(pieData, 0, 0, cfg.width, cfg.height, "", input.Donut, true, true)
 }
 
-func renderArea(data []byte, cfg Config) string {
-	// Area implementation placeholder
-	return fmt.Sprintf(`<text x="%d" y="%d" text-anchor="middle">Area chart: Use MCP server for full support</text>`,
-		cfg.width/2, cfg.height/2)
-}
-
 func renderLegacyChart(vizType string, data []byte, cfg Config, tokens *design.DesignTokens) string {
 	// Legacy chart rendering using old renderer system
 	bounds := charts.Bounds{X: 0, Y: 0, Width: cfg.width, Height: cfg.height}
@@ -700,6 +805,14 @@ func renderLegacyChart(vizType string, data []byte, cfg Config, tokens *design.D
 		}
 		return renderer.RenderLineGraph(lineData, bounds, renderConfig).String()
 
+	case "area-chart":
+		var areaData charts.AreaChartData
+		if err := json.Unmarshal(data, &areaData); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing area chart data: %v\n", err)
+			os.Exit(1)
+		}
+		return renderer.RenderAreaChart(areaData, bounds, renderConfig).String()
+
 	case "bar-chart":
 		var barData charts.BarChartData
 		if err := json.Unmarshal(data, &barData); err != nil {
@@ -1067,7 +1180,12 @@ func renderWordCloud(data []byte, cfg Config) string {
 			Frequency float64 `json:"frequency"`
 			Color     string  `json:"color,omitempty"`
 		} `json:"words"`
-		Layout string `json:"layout,omitempty"`
+		Layout    string       `json:"layout,omitempty"`
+		Spiral    string       `json:"spiral,omitempty"`
+		Rotations []float64    `json:"rotations,omitempty"`
+		Shape     string       `json:"shape,omitempty"`
+		Mask      [][2]float64 `json:"mask,omitempty"`
+		Seed      int64        `json:"seed,omitempty"`
 	}
 	if err := json.Unmarshal(data, &input); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing wordcloud data: %v\n", err)
@@ -1084,10 +1202,15 @@ func renderWordCloud(data []byte, cfg Config) string {
 	}
 
 	spec := charts.WordCloudSpec{
-		Words:  words,
-		Width:  float64(cfg.width),
-		Height: float64(cfg.height),
-		Layout: input.Layout,
+		Words:     words,
+		Width:     float64(cfg.width),
+		Height:    float64(cfg.height),
+		Layout:    input.Layout,
+		Spiral:    charts.WordCloudSpiral(input.Spiral),
+		Rotations: input.Rotations,
+		Shape:     charts.WordCloudShape(input.Shape),
+		Mask:      input.Mask,
+		Seed:      input.Seed,
 	}
 
 	return charts.RenderWordCloud(spec)
@@ -1106,6 +1229,10 @@ func renderSankey(data []byte, cfg Config) string {
 			Value  float64 `json:"value"`
 			Color  string  `json:"color,omitempty"`
 		} `json:"links"`
+		Align        string `json:"align,omitempty"`
+		LinkSort     string `json:"linkSort,omitempty"`
+		LinkGradient bool   `json:"linkGradient,omitempty"`
+		AllowCycles  bool   `json:"allowCycles,omitempty"`
 	}
 	if err := json.Unmarshal(data, &input); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing sankey data: %v\n", err)
@@ -1132,11 +1259,15 @@ func renderSankey(data []byte, cfg Config) string {
 	}
 
 	spec := charts.SankeySpec{
-		Nodes:      nodes,
-		Links:      links,
-		Width:      float64(cfg.width),
-		Height:     float64(cfg.height),
-		ShowLabels: true,
+		Nodes:        nodes,
+		Links:        links,
+		Width:        float64(cfg.width),
+		Height:       float64(cfg.height),
+		Align:        charts.SankeyAlign(input.Align),
+		LinkSort:     charts.SankeyLinkSort(input.LinkSort),
+		LinkGradient: input.LinkGradient,
+		AllowCycles:  input.AllowCycles,
+		ShowLabels:   true,
 	}
 
 	return charts.RenderSankey(spec)
@@ -1154,6 +1285,10 @@ func renderChord(data []byte, cfg Config) string {
 			Target string  `json:"target"`
 			Value  float64 `json:"value"`
 		} `json:"relations"`
+		Directed      bool   `json:"directed,omitempty"`
+		SortGroups    string `json:"sortGroups,omitempty"`
+		SortSubgroups string `json:"sortSubgroups,omitempty"`
+		ShowTicks     bool   `json:"showTicks,omitempty"`
 	}
 	if err := json.Unmarshal(data, &input); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing chord data: %v\n", err)
@@ -1179,16 +1314,144 @@ func renderChord(data []byte, cfg Config) string {
 	}
 
 	spec := charts.ChordDiagramSpec{
-		Entities:   entities,
-		Relations:  relations,
-		Width:      float64(cfg.width),
-		Height:     float64(cfg.height),
-		ShowLabels: true,
+		Entities:      entities,
+		Relations:     relations,
+		Width:         float64(cfg.width),
+		Height:        float64(cfg.height),
+		Directed:      input.Directed,
+		SortGroups:    charts.ChordSort(input.SortGroups),
+		SortSubgroups: charts.ChordSort(input.SortSubgroups),
+		ShowTicks:     input.ShowTicks,
+		ShowLabels:    true,
 	}
 
 	return charts.RenderChordDiagram(spec)
 }
 
+// graphLink is a link between two nodes in CLI graph input
+type graphLink struct {
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Value  float64 `json:"value,omitempty"`
+	Color  string  `json:"color,omitempty"`
+}
+
+// graphInput is the CLI input for node-link charts
+type graphInput struct {
+	Nodes []struct {
+		ID    string  `json:"id"`
+		Label string  `json:"label,omitempty"`
+		Group string  `json:"group,omitempty"`
+		Value float64 `json:"value,omitempty"`
+		Color string  `json:"color,omitempty"`
+	} `json:"nodes"`
+	Links    []graphLink `json:"links"`
+	Directed bool        `json:"directed,omitempty"`
+}
+
+// networkNodes converts graph input nodes to chart nodes
+func (g graphInput) networkNodes() []charts.NetworkNode {
+	nodes := make([]charts.NetworkNode, len(g.Nodes))
+	for i, n := range g.Nodes {
+		nodes[i] = charts.NetworkNode{
+			ID:    n.ID,
+			Label: n.Label,
+			Group: n.Group,
+			Value: n.Value,
+			Color: n.Color,
+		}
+	}
+	return nodes
+}
+
+// networkLinks converts graph input links to chart links
+func networkLinks(input []graphLink) []charts.NetworkLink {
+	links := make([]charts.NetworkLink, len(input))
+	for i, l := range input {
+		links[i] = charts.NetworkLink{
+			Source: l.Source,
+			Target: l.Target,
+			Value:  l.Value,
+			Color:  l.Color,
+		}
+	}
+	return links
+}
+
+func renderNetwork(data []byte, cfg Config) string {
+	var input struct {
+		graphInput
+		Seed int64 `json:"seed,omitempty"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing network data: %v\n", err)
+		os.Exit(1)
+	}
+
+	spec := charts.NetworkSpec{
+		Nodes:        input.networkNodes(),
+		Links:        networkLinks(input.Links),
+		Width:        float64(cfg.width),
+		Height:       float64(cfg.height),
+		Directed:     input.Directed,
+		Seed:         input.Seed,
+		ShowLabels:   true,
+		ShowLegend:   true,
+		DefaultColor: cfg.color,
+	}
+
+	return charts.RenderNetwork(spec)
+}
+
+func renderArcDiagram(data []byte, cfg Config) string {
+	var input struct {
+		graphInput
+		Order string `json:"order,omitempty"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing arc diagram data: %v\n", err)
+		os.Exit(1)
+	}
+
+	spec := charts.ArcDiagramSpec{
+		Nodes:        input.networkNodes(),
+		Links:        networkLinks(input.Links),
+		Width:        float64(cfg.width),
+		Height:       float64(cfg.height),
+		Order:        charts.ArcOrder(input.Order),
+		Directed:     input.Directed,
+		ShowLabels:   true,
+		ShowLegend:   true,
+		DefaultColor: cfg.color,
+	}
+
+	return charts.RenderArcDiagram(spec)
+}
+
+func renderEdgeBundling(data []byte, cfg Config) string {
+	var input struct {
+		Root    charts.TreeNode `json:"root"`
+		Links   []graphLink     `json:"links"`
+		Tension *float64        `json:"tension,omitempty"`
+	}
+	if err := json.Unmarshal(data, &input); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing edge bundling data: %v\n", err)
+		os.Exit(1)
+	}
+
+	spec := charts.EdgeBundlingSpec{
+		Root:         &input.Root,
+		Links:        networkLinks(input.Links),
+		Width:        float64(cfg.width),
+		Height:       float64(cfg.height),
+		Tension:      input.Tension,
+		ShowLabels:   true,
+		DefaultColor: cfg.color,
+	}
+
+	return charts.RenderEdgeBundling(spec)
+}
+
 func renderCircularBar(data []byte, cfg Config) string {
 	var input struct {
 		Data []struct {
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderAreaChart(t *testing.T) {
+	data, err := readData("../../examples/line-graph/input.json")
+	if err != nil {
+		t.Fatalf("readData failed: %v", err)
+	}
+	cfg := Config{width: 400, height: 300, color: "#3B82F6", theme: "default"}
+
+	svg := renderSVG("area-chart", data, cfg, getTheme(cfg.theme))
+	if strings.Contains(svg, "Use MCP server") {
+		t.Fatal("area-chart rendered the placeholder")
+	}
+	if !strings.Contains(svg, "<path") {
+		t.Error("expected the area to be drawn as a path")
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SCKelemen/dataviz/mcp/export"
+)
+
+// outputFormat resolves the output format: the -format flag, then the
+// -output file extension, then svg
+func outputFormat(cfg Config) (string, error) {
+	format := strings.ToLower(cfg.format)
+	if format == "" {
+		ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(cfg.outputFile)), ".")
+		switch ext {
+		case "", "svg":
+			return "svg", nil
+		case "txt":
+			return "terminal", nil
+		}
+		format = ext
+	}
+
+	switch format {
+	case "svg", "terminal", "png", "pdf":
+		return format, nil
+	case "jpeg", "jpg":
+		return "jpeg", nil
+	}
+	return "", fmt.Errorf("unknown format: %s (expected svg, terminal, png, jpeg or pdf)", format)
+}
+
+// isBinary reports whether a format produces binary output
+func isBinary(format string) bool {
+	return format == "png" || format == "jpeg" || format == "pdf"
+}
+
+// encodeOutput converts an SVG document to the output format
+func encodeOutput(svg string, format string, cfg Config) ([]byte, error) {
+	if !isBinary(format) {
+		return []byte(svg), nil
+	}
+	exportFormat, err := export.ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	return export.Export(svg, export.ExportOptions{
+		Format:  exportFormat,
+		Quality: cfg.quality,
+		DPI:     cfg.dpi,
+		Scale:   cfg.scale,
+	})
+}
+
+// isTerminal reports whether a file is a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
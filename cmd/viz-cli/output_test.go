@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		output   string
+		expected string
+		wantErr  bool
+	}{
+		{"", "", "svg", false},
+		{"", "chart.svg", "svg", false},
+		{"", "chart.PNG", "png", false},
+		{"", "chart.jpg", "jpeg", false},
+		{"", "report.pdf", "pdf", false},
+		{"", "chart.txt", "terminal", false},
+		{"terminal", "", "terminal", false},
+		{"svg", "chart.png", "svg", false},
+		{"", "chart.gif", "", true},
+		{"bmp", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+"|"+tt.output, func(t *testing.T) {
+			format, err := outputFormat(Config{format: tt.format, outputFile: tt.output})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("outputFormat error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if format != tt.expected {
+				t.Errorf("outputFormat = %q, expected %q", format, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncodeOutput(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" width="40" height="20" viewBox="0 0 40 20"><rect width="40" height="20" fill="red"/></svg>`
+
+	tests := []struct {
+		format string
+		prefix string
+	}{
+		{"svg", "<svg"},
+		{"png", "\x89PNG"},
+		{"jpeg", "\xff\xd8"},
+		{"pdf", "%PDF-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			output, err := encodeOutput(svg, tt.format, Config{quality: 90, scale: 2})
+			if err != nil {
+				t.Fatalf("encodeOutput failed: %v", err)
+			}
+			if !bytes.HasPrefix(output, []byte(tt.prefix)) {
+				t.Errorf("output starts with %q, expected %q", output[:min(len(output), 8)], tt.prefix)
+			}
+		})
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	cfg := Config{width: 400, height: 300, color: "#3B82F6", theme: "default", quality: 90}
+
+	tests := []struct {
+		vizType string
+		data    string
+	}{
+		{"bar-chart", "../../examples/bar-chart/input.json"},
+		{"line-graph", "../../examples/line-graph/input.json"},
+		{"area-chart", "../../examples/line-graph/input.json"},
+		{"scatter", "../../examples/scatter/input.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.vizType, func(t *testing.T) {
+			data, err := readData(tt.data)
+			if err != nil {
+				t.Fatalf("readData failed: %v", err)
+			}
+			svg := renderSVG(tt.vizType, data, cfg, getTheme(cfg.theme))
+			output, err := encodeOutput(svg, "png", cfg)
+			if err != nil {
+				t.Fatalf("encodeOutput failed: %v", err)
+			}
+			path := filepath.Join(t.TempDir(), tt.vizType+".png")
+			if err := writeOutput(path, output); err != nil {
+				t.Fatalf("writeOutput failed: %v", err)
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			img, err := png.Decode(f)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if b := img.Bounds(); b.Dx() != cfg.width || b.Dy() != cfg.height {
+				t.Errorf("image is %dx%d, expected %dx%d", b.Dx(), b.Dy(), cfg.width, cfg.height)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ require (
 	github.com/modelcontextprotocol/go-sdk v1.2.0
 	github.com/srwiley/oksvg v0.0.0-20220731023508-a61f04f16b76
 	github.com/srwiley/rasterx v0.0.0-20220730225603-2ab79fcdd4ef
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
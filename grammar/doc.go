@@ -0,0 +1,56 @@
+// Package grammar provides a declarative, Vega-Lite-like chart specification
+// that compiles to the existing chart renderers.
+//
+// A Spec describes a chart as data, transforms, a mark, encodings, axes,
+// a legend and annotations. The same JSON or YAML document renders
+// identically through the library, viz-cli (-spec) and the MCP server
+// (render_spec tool).
+//
+// Pipeline:
+//   - Data: inline rows ("values"), each row a map of field name to value
+//   - Transforms: filter, aggregate, bin, sort, top, normalize, smooth and
+//     cumulative steps, executed with the transforms package
+//   - Encodings: x, y, color, size, theta, source, target and hierarchy
+//     channels; color and axis scales are built with the scales package
+//   - Marks: bar, line, point, area, arc, boxplot, violin, lollipop,
+//     density, histogram, treemap, sunburst, icicle, circle-packing,
+//     sankey and chord, each compiled to the matching charts renderer
+//   - Legends and annotations are drawn on top of the rendered mark
+//
+// Example:
+//
+//	spec, err := grammar.Parse([]byte(`{
+//	  "title": "Revenue by region",
+//	  "data": {"values": [
+//	    {"region": "EMEA", "revenue": 120},
+//	    {"region": "APAC", "revenue": 95},
+//	    {"region": "EMEA", "revenue": 40}
+//	  ]},
+//	  "transform": [
+//	    {"aggregate": [{"op": "sum", "field": "revenue", "as": "total"}], "groupby": ["region"]}
+//	  ],
+//	  "mark": "bar",
+//	  "encoding": {
+//	    "x": {"field": "region", "type": "nominal"},
+//	    "y": {"field": "total", "type": "quantitative"}
+//	  }
+//	}`))
+//	if err != nil {
+//	    return err
+//	}
+//	svg, err := grammar.Render(spec)
+//
+// Specs can also be written in YAML; this one counts rows per region with an
+// encoding aggregate instead of a transform:
+//
+//	title: Orders by region
+//	data:
+//	  values: [{region: EMEA}, {region: APAC}, {region: EMEA}]
+//	mark: bar
+//	encoding:
+//	  x: {field: region, type: nominal}
+//	  y: {aggregate: count}
+//
+// Line and area marks with a temporal x are drawn by the time-series
+// renderers, which plot integer values; y values are rounded.
+package grammar
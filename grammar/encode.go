@@ -0,0 +1,254 @@
+package grammar
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/dataviz/scales"
+	"github.com/SCKelemen/dataviz/theme"
+)
+
+// themeByName returns the named theme preset, or the default theme
+func themeByName(name string) (*theme.Theme, error) {
+	switch name {
+	case "", "default":
+		return theme.Default(), nil
+	case "midnight":
+		return theme.Midnight(), nil
+	case "nord":
+		return theme.Nord(), nil
+	case "paper":
+		return theme.Paper(), nil
+	case "wrapped":
+		return theme.Wrapped(), nil
+	}
+	return nil, fmt.Errorf("unknown theme %q", name)
+}
+
+// encodeAggregates applies the aggregates declared on encoding channels.
+// Rows are grouped by every encoded field without an aggregate, and each
+// aggregated channel is rewritten to read its result. A count without a
+// field writes to "count".
+func encodeAggregates(enc Encoding, rows []Row) (Encoding, []Row, error) {
+	var ops []AggregateOp
+	var groupby []string
+
+	channels := []**FieldDef{&enc.X, &enc.Y, &enc.Theta, &enc.Color, &enc.Size, &enc.Source, &enc.Target}
+	for _, ch := range channels {
+		def := *ch
+		if def == nil {
+			continue
+		}
+		if def.Aggregate == "" {
+			if def.Field != "" {
+				groupby = append(groupby, def.Field)
+			}
+			continue
+		}
+
+		rewritten := *def
+		rewritten.Aggregate = ""
+		if rewritten.Field == "" {
+			rewritten.Field = "count"
+		}
+		if rewritten.Title == "" {
+			rewritten.Title = def.title()
+		}
+		ops = append(ops, AggregateOp{Op: def.Aggregate, Field: def.Field, As: rewritten.Field})
+		*ch = &rewritten
+	}
+	for _, def := range enc.Hierarchy {
+		groupby = append(groupby, def.Field)
+	}
+
+	if len(ops) == 0 {
+		return enc, rows, nil
+	}
+
+	aggregated, err := aggregateRows(rows, ops, groupby)
+	if err != nil {
+		return enc, nil, err
+	}
+	return enc, aggregated, nil
+}
+
+// interpolator returns the color interpolator for a scale's scheme or range.
+// ok is false when the scale names neither.
+func interpolator(s *Scale) (scales.ColorInterpolatorFunc, bool, error) {
+	if s == nil {
+		return nil, false, nil
+	}
+
+	var fn scales.ColorInterpolatorFunc
+	switch {
+	case s.Scheme != "":
+		named, ok := scales.NamedInterpolator(s.Scheme)
+		if !ok {
+			return nil, false, fmt.Errorf("unknown color scheme %q", s.Scheme)
+		}
+		fn = named
+	case len(s.Range) >= 2:
+		stops, err := parseColors(s.Range)
+		if err != nil {
+			return nil, false, err
+		}
+		fn = scales.NewMultiStopInterpolator(stops, color.GradientOKLCH)
+	default:
+		return nil, false, nil
+	}
+
+	if s.Reverse {
+		fn = scales.ReverseInterpolator(fn)
+	}
+	return fn, true, nil
+}
+
+// parseColors parses a list of CSS color strings
+func parseColors(values []string) ([]color.Color, error) {
+	colors := make([]color.Color, len(values))
+	for i, v := range values {
+		c, err := color.ParseColor(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %q: %w", v, err)
+		}
+		colors[i] = c
+	}
+	return colors, nil
+}
+
+// palette returns n categorical colors for a color channel.
+// An explicit range is used as-is (cycled), a scheme is sampled evenly,
+// and otherwise the theme's categorical palette is used.
+func palette(def *FieldDef, th *theme.Theme, n int) ([]string, error) {
+	colors := make([]string, n)
+
+	if def != nil && def.Scale != nil && def.Scale.Scheme == "" && len(def.Scale.Range) > 0 {
+		if _, err := parseColors(def.Scale.Range); err != nil {
+			return nil, err
+		}
+		for i := range colors {
+			colors[i] = def.Scale.Range[i%len(def.Scale.Range)]
+		}
+		return colors, nil
+	}
+
+	if def != nil && def.Scale != nil && def.Scale.Scheme != "" {
+		fn, _, err := interpolator(def.Scale)
+		if err != nil {
+			return nil, err
+		}
+		for i, c := range scales.SampleInterpolator(fn, n) {
+			colors[i] = color.RGBToHex(c)
+		}
+		return colors, nil
+	}
+
+	for i := range colors {
+		colors[i] = th.GetColor(i)
+	}
+	return colors, nil
+}
+
+// categoryColors maps each category to a palette color
+func categoryColors(def *FieldDef, th *theme.Theme, categories []string) (map[string]string, error) {
+	colors, err := palette(def, th, len(categories))
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string, len(categories))
+	for i, c := range categories {
+		m[c] = colors[i]
+	}
+	return m, nil
+}
+
+// sequentialColors returns a function mapping a number to a color for a
+// quantitative color channel, using the scale's scheme (default: viridis)
+// over its domain (default: the data extent)
+func sequentialColors(def *FieldDef, rows []Row) (func(float64) string, error) {
+	fn, ok, err := interpolator(def.Scale)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		fn, _ = scales.NamedInterpolator("viridis")
+		if def.Scale != nil && def.Scale.Reverse {
+			fn = scales.ReverseInterpolator(fn)
+		}
+	}
+
+	domain, err := numericDomain(def, numbers(rows, def.Field))
+	if err != nil {
+		return nil, err
+	}
+
+	scale := scales.NewSequentialColorScaleWithInterpolator(domain, fn)
+	return func(v float64) string {
+		return color.RGBToHex(scale.ApplyColor(v))
+	}, nil
+}
+
+// numericDomain returns the channel's explicit scale domain, or the extent of values
+func numericDomain(def *FieldDef, values []float64) ([2]float64, error) {
+	if def != nil && def.Scale != nil && len(def.Scale.Domain) > 0 {
+		if len(def.Scale.Domain) != 2 {
+			return [2]float64{}, fmt.Errorf("encoding %s: a quantitative domain needs two values", def.Field)
+		}
+		lo, ok1 := toFloat(def.Scale.Domain[0])
+		hi, ok2 := toFloat(def.Scale.Domain[1])
+		if !ok1 || !ok2 {
+			return [2]float64{}, fmt.Errorf("encoding %s: domain values must be numbers", def.Field)
+		}
+		return [2]float64{lo, hi}, nil
+	}
+
+	if len(values) == 0 {
+		return [2]float64{0, 1}, nil
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return [2]float64{lo, hi}, nil
+}
+
+// isQuantitative reports whether a channel carries continuous numbers.
+// An untyped channel is quantitative when every value is numeric.
+func isQuantitative(def *FieldDef, rows []Row) bool {
+	if def == nil || def.Field == "" {
+		return false
+	}
+	switch def.Type {
+	case TypeQuantitative:
+		return true
+	case TypeNominal, TypeOrdinal, TypeTemporal:
+		return false
+	}
+	for _, row := range rows {
+		if _, ok := toFloat(row[def.Field]); !ok {
+			return false
+		}
+	}
+	return len(rows) > 0
+}
+
+// isTemporal reports whether a channel carries dates
+func isTemporal(def *FieldDef) bool {
+	return def != nil && def.Type == TypeTemporal
+}
+
+// markColor returns the single color for a mark without a color field:
+// a constant color value, the mark color, or the first theme color
+func markColor(spec *Spec, th *theme.Theme) string {
+	if c := spec.Encoding.Color; c != nil && c.Field == "" {
+		if s, ok := c.Value.(string); ok && s != "" {
+			return s
+		}
+	}
+	if spec.Mark.Color != "" {
+		return spec.Mark.Color
+	}
+	return th.GetColor(0)
+}
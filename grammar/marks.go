@@ -0,0 +1,844 @@
+package grammar
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/dataviz/axes"
+	"github.com/SCKelemen/dataviz/charts"
+	"github.com/SCKelemen/dataviz/charts/legends"
+	"github.com/SCKelemen/dataviz/scales"
+	"github.com/SCKelemen/dataviz/theme"
+	"github.com/SCKelemen/units"
+)
+
+// scatterMargin and scatterPadding mirror the layout of RenderConnectedScatter
+// so annotations can be placed in its data coordinates
+const (
+	scatterMargin  = 60.0
+	scatterPadding = 0.05
+)
+
+// markContext is the resolved state a mark is compiled from
+type markContext struct {
+	spec   *Spec
+	theme  *theme.Theme
+	enc    Encoding
+	rows   []Row
+	themed bool    // A theme was named; otherwise text and axes use neutral defaults
+	width  float64 // Width of the chart area
+	height float64 // Height of the chart area (below the title)
+	legend []legends.LegendItem
+}
+
+// frame is the plot area a mark was drawn into. xScale and yScale position
+// data values inside it; they are nil when the renderer's scales are not known.
+type frame struct {
+	x, y, width, height float64
+	xScale, yScale      scales.Scale
+}
+
+// renderMark compiles the spec's mark to the matching chart renderer
+func renderMark(ctx *markContext) (string, frame, error) {
+	switch ctx.spec.Mark.Type {
+	case MarkBar:
+		return renderBar(ctx)
+	case MarkHistogram:
+		return renderHistogram(ctx)
+	case MarkLine, MarkPoint:
+		return renderLine(ctx)
+	case MarkArea:
+		return renderArea(ctx)
+	case MarkArc:
+		return renderArc(ctx)
+	case MarkBoxplot, MarkViolin:
+		return renderDistribution(ctx)
+	case MarkLollipop:
+		return renderLollipop(ctx)
+	case MarkDensity:
+		return renderDensity(ctx)
+	case MarkTreemap, MarkSunburst, MarkIcicle, MarkCirclePacking:
+		return renderHierarchy(ctx)
+	case MarkSankey, MarkChord:
+		return renderFlow(ctx)
+	}
+	return "", frame{}, fmt.Errorf("unknown mark type %q", ctx.spec.Mark.Type)
+}
+
+// renderChart renders a chart spec to SVG, returning the chart's validation
+// error when the compiled data cannot be drawn
+func renderChart(c charts.Chart) (string, error) {
+	out, err := c.Render(context.Background(), charts.TargetSVG)
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// fullFrame is the whole chart area, without data scales
+func (ctx *markContext) fullFrame() frame {
+	return frame{width: ctx.width, height: ctx.height}
+}
+
+// renderBar draws a vertical bar per row with RenderBarChart and adds axes.
+// A binned x channel draws a histogram instead.
+func renderBar(ctx *markContext) (string, frame, error) {
+	x, y := ctx.enc.X, ctx.enc.Y
+	if x.Bin != nil {
+		return renderHistogram(ctx)
+	}
+	if ctx.enc.Color.hasField() {
+		return "", frame{}, fmt.Errorf("bar marks do not support a color field; filter or aggregate to a single series")
+	}
+	if ctx.spec.Mark.Orient == "horizontal" {
+		return "", frame{}, fmt.Errorf("horizontal bar marks are not supported; use a lollipop mark")
+	}
+
+	var categories []string
+	var values []float64
+	maxValue := 0.0
+	for _, row := range ctx.rows {
+		v, ok := toFloat(row[y.Field])
+		if !ok {
+			continue
+		}
+		if v < 0 {
+			return "", frame{}, fmt.Errorf("bar marks do not support negative values (%s = %v)", y.Field, v)
+		}
+		categories = append(categories, toString(row[x.Field]))
+		values = append(values, v)
+		maxValue = math.Max(maxValue, v)
+	}
+	if len(values) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with a numeric %q", y.Field)
+	}
+
+	f := frame{x: 60, y: 20, width: math.Floor(ctx.width - 80), height: math.Floor(ctx.height - 70)}
+
+	// RenderBarChart takes integer values; scale them by a power of ten so
+	// bar heights keep their precision relative to the nice y domain
+	factor := 1.0
+	for maxValue > 0 && maxValue*factor < 1e6 {
+		factor *= 10
+	}
+	bars := make([]charts.BarData, len(values))
+	for i, v := range values {
+		bars[i] = charts.BarData{Label: categories[i], Value: int(math.Round(v * factor))}
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	content, err := renderChart(charts.BarChartSpec{
+		Data:   charts.BarChartData{Bars: bars, Color: markColor(ctx.spec, ctx.theme)},
+		Bounds: charts.Bounds{X: int(f.x), Y: int(f.y), Width: int(f.width), Height: int(f.height)},
+		Config: charts.RenderConfig{DesignTokens: ctx.theme.Tokens},
+	})
+	if err != nil {
+		return "", frame{}, err
+	}
+
+	// Same scales as RenderBarChart, in chart coordinates
+	f.xScale = scales.NewBandScale(
+		categories,
+		[2]units.Length{units.Px(f.x), units.Px(f.x + f.width)},
+	).Padding(0.2)
+	f.yScale = scales.NewLinearScale(
+		[2]float64{0, maxValue},
+		[2]units.Length{units.Px(f.y + f.height), units.Px(f.y)},
+	).Nice(5)
+
+	return content + renderAxes(ctx, f, x, y), f, nil
+}
+
+// renderAxes draws bottom and left axes for a frame, in the theme's colors
+// when a theme is named
+func renderAxes(ctx *markContext, f frame, x, y *FieldDef) string {
+	opts := axes.DefaultRenderOptions()
+	if ctx.themed {
+		if c := ctx.theme.ColorScheme.TextColor; c != "" {
+			opts.Style.TextColor = c
+		}
+		if c := ctx.theme.ColorScheme.AxisColor; c != "" {
+			opts.Style.StrokeColor = c
+		}
+		if c := ctx.theme.ColorScheme.GridColor; c != "" {
+			opts.Style.GridStrokeColor = c
+		}
+	}
+
+	xAxis := axes.NewAxis(f.xScale, axes.AxisOrientationBottom).Title(x.title())
+	if x.Axis.grid() {
+		xAxis.Grid(units.Px(f.height))
+	}
+	opts.Position = units.Px(f.y + f.height)
+	result := xAxis.Render(opts)
+
+	yAxis := axes.NewAxis(f.yScale, axes.AxisOrientationLeft).Title(y.title()).TickCount(5)
+	if y.Axis.grid() {
+		yAxis.Grid(units.Px(f.width))
+	}
+	opts.Position = units.Px(f.x)
+	result += yAxis.Render(opts)
+
+	return result
+}
+
+// renderHistogram bins the x field with RenderHistogram
+func renderHistogram(ctx *markContext) (string, frame, error) {
+	x := ctx.enc.X
+	values := numbers(ctx.rows, x.Field)
+	if len(values) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with a numeric %q", x.Field)
+	}
+
+	spec := charts.HistogramSpec{
+		Data:       &charts.HistogramData{Values: values, Color: markColor(ctx.spec, ctx.theme)},
+		Width:      ctx.width,
+		Height:     ctx.height,
+		Nice:       true,
+		BarGap:     1,
+		XAxisLabel: x.title(),
+		YAxisLabel: "count",
+	}
+	if x.Bin != nil {
+		spec.BinCount = x.Bin.MaxBins
+		spec.BinSize = x.Bin.Step
+		spec.Nice = x.Bin.nice()
+	}
+	if t := ctx.enc.Y.title(); t != "" {
+		spec.YAxisLabel = t
+	}
+
+	content, err := renderChart(spec)
+	return content, ctx.fullFrame(), err
+}
+
+// renderLine draws line and point marks with RenderConnectedScatter, one
+// series per color category. A quantitative color field colors each point;
+// a temporal x field draws a time series instead.
+func renderLine(ctx *markContext) (string, frame, error) {
+	mark := ctx.spec.Mark
+	x, y, c := ctx.enc.X, ctx.enc.Y, ctx.enc.Color
+
+	if isTemporal(x) {
+		if mark.Type == MarkPoint {
+			return "", frame{}, fmt.Errorf("point marks require a quantitative x field")
+		}
+		return renderTimeSeries(ctx)
+	}
+	if !isQuantitative(x, ctx.rows) {
+		return "", frame{}, fmt.Errorf("%s marks require a quantitative or temporal x field", mark.Type)
+	}
+
+	groups := []string{""}
+	colors := map[string]string{"": markColor(ctx.spec, ctx.theme)}
+	var pointColor func(float64) string
+	if c.hasField() {
+		var err error
+		if isQuantitative(c, ctx.rows) {
+			if pointColor, err = sequentialColors(c, ctx.rows); err != nil {
+				return "", frame{}, err
+			}
+		} else {
+			groups = distinct(ctx.rows, c.Field)
+			if colors, err = categoryColors(c, ctx.theme, groups); err != nil {
+				return "", frame{}, err
+			}
+		}
+	}
+
+	series := make([]*charts.ConnectedScatterSeries, len(groups))
+	index := make(map[string]int, len(groups))
+	for i, g := range groups {
+		series[i] = &charts.ConnectedScatterSeries{
+			Label:      g,
+			Color:      colors[g],
+			MarkerType: mark.Shape,
+			MarkerSize: mark.Size,
+		}
+		index[g] = i
+	}
+
+	var xs, ys []float64
+	for _, row := range ctx.rows {
+		px, ok1 := toFloat(row[x.Field])
+		py, ok2 := toFloat(row[y.Field])
+		if !ok1 || !ok2 {
+			continue
+		}
+		point := charts.ConnectedScatterPoint{X: px, Y: py}
+		group := ""
+		if c.hasField() {
+			if pointColor != nil {
+				if v, ok := toFloat(row[c.Field]); ok {
+					point.Color = pointColor(v)
+				}
+			} else {
+				group = toString(row[c.Field])
+			}
+		}
+		s := series[index[group]]
+		s.Points = append(s.Points, point)
+		xs = append(xs, px)
+		ys = append(ys, py)
+	}
+	if len(xs) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with numeric %q and %q", x.Field, y.Field)
+	}
+
+	if mark.Type == MarkLine {
+		for _, s := range series {
+			sort.SliceStable(s.Points, func(i, j int) bool { return s.Points[i].X < s.Points[j].X })
+		}
+	}
+
+	spec := charts.ConnectedScatterSpec{
+		Series:      series,
+		Width:       ctx.width,
+		Height:      ctx.height,
+		ShowGrid:    x.Axis.grid() || y.Axis.grid(),
+		ShowLines:   mark.Type == MarkLine,
+		ShowMarkers: mark.Type == MarkPoint || mark.Point,
+		XAxisLabel:  x.title(),
+		YAxisLabel:  y.title(),
+	}
+
+	xDomain, err := numericDomain(x, xs)
+	if err != nil {
+		return "", frame{}, err
+	}
+	yDomain, err := numericDomain(y, ys)
+	if err != nil {
+		return "", frame{}, err
+	}
+	spec.XAxisMin, spec.XAxisMax = &xDomain[0], &xDomain[1]
+	spec.YAxisMin, spec.YAxisMax = &yDomain[0], &yDomain[1]
+
+	if c.hasField() && pointColor == nil {
+		for _, g := range groups {
+			swatch, _ := color.ParseColor(colors[g])
+			symbol := legends.Line(swatch)
+			if mark.Type == MarkPoint {
+				symbol = legends.Marker("circle", swatch)
+			}
+			ctx.legend = append(ctx.legend, legends.Item(g, symbol))
+		}
+	}
+
+	content, err := renderChart(spec)
+	return content, scatterFrame(ctx, xDomain, yDomain), err
+}
+
+// scatterFrame rebuilds the scales RenderConnectedScatter uses for a domain
+func scatterFrame(ctx *markContext, xDomain, yDomain [2]float64) frame {
+	pad := func(d [2]float64) [2]float64 {
+		span := d[1] - d[0]
+		if span == 0 {
+			span = 1
+		}
+		return [2]float64{d[0] - span*scatterPadding, d[1] + span*scatterPadding}
+	}
+
+	f := frame{
+		x:      scatterMargin,
+		y:      scatterMargin,
+		width:  ctx.width - 2*scatterMargin,
+		height: ctx.height - 2*scatterMargin,
+	}
+	f.xScale = scales.NewLinearScale(pad(xDomain), [2]units.Length{units.Px(f.x), units.Px(f.x + f.width)})
+	f.yScale = scales.NewLinearScale(pad(yDomain), [2]units.Length{units.Px(f.y + f.height), units.Px(f.y)})
+	return f
+}
+
+// renderTimeSeries draws a single temporal line or area series with
+// RenderLineGraph or RenderAreaChart. These renderers take integer values,
+// so y values are rounded. Rows with a date and a null y break the line.
+func renderTimeSeries(ctx *markContext) (string, frame, error) {
+	mark := ctx.spec.Mark
+	x, y := ctx.enc.X, ctx.enc.Y
+	if ctx.enc.Color.hasField() {
+		return "", frame{}, fmt.Errorf("temporal %s marks support a single series; remove the color field or use a quantitative x", mark.Type)
+	}
+
+	var points []charts.TimeSeriesData
+	values := 0
+	for _, row := range ctx.rows {
+		t, ok1 := toTime(row[x.Field])
+		v, ok2 := toFloat(row[y.Field])
+		if ok1 && row[y.Field] == nil {
+			points = append(points, charts.TimeSeriesData{Date: t, Missing: true})
+			continue
+		}
+		if !ok1 || !ok2 {
+			continue
+		}
+		points = append(points, charts.TimeSeriesData{Date: t, Value: int(math.Round(v))})
+		values++
+	}
+	if values == 0 {
+		return "", frame{}, fmt.Errorf("no rows with a date in %q and a number in %q", x.Field, y.Field)
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Date.Before(points[j].Date) })
+
+	c := markColor(ctx.spec, ctx.theme)
+	smooth := mark.Interpolate == "monotone"
+	f := frame{x: 20, y: 20, width: ctx.width - 40, height: ctx.height - 40}
+
+	bounds := charts.Bounds{X: int(f.x), Y: int(f.y), Width: int(f.width), Height: int(f.height)}
+	config := charts.RenderConfig{DesignTokens: ctx.theme.Tokens}
+
+	var chart charts.Chart = charts.LineGraphSpec{
+		Data:   charts.LineGraphData{Points: points, Color: c, Smooth: smooth},
+		Bounds: bounds,
+		Config: config,
+	}
+	if mark.Type == MarkArea {
+		chart = charts.AreaChartSpec{
+			Data:   charts.AreaChartData{Points: points, Color: c, FillColor: c, Smooth: smooth},
+			Bounds: bounds,
+			Config: config,
+		}
+	}
+	content, err := renderChart(chart)
+	return content, f, err
+}
+
+// renderArea stacks one area per color category with RenderStackedArea.
+// A temporal x field draws a single time series instead.
+func renderArea(ctx *markContext) (string, frame, error) {
+	x, y, c := ctx.enc.X, ctx.enc.Y, ctx.enc.Color
+	if isTemporal(x) {
+		return renderTimeSeries(ctx)
+	}
+	if !isQuantitative(x, ctx.rows) {
+		return "", frame{}, fmt.Errorf("area marks require a quantitative or temporal x field")
+	}
+
+	labels := []string{y.title()}
+	colors := map[string]string{labels[0]: markColor(ctx.spec, ctx.theme)}
+	if c.hasField() {
+		labels = distinct(ctx.rows, c.Field)
+		var err error
+		if colors, err = categoryColors(c, ctx.theme, labels); err != nil {
+			return "", frame{}, err
+		}
+	}
+	index := make(map[string]int, len(labels))
+	series := make([]charts.StackedAreaSeries, len(labels))
+	for i, l := range labels {
+		index[l] = i
+		series[i] = charts.StackedAreaSeries{Label: l, Color: colors[l]}
+	}
+
+	sums := make(map[float64][]float64)
+	for _, row := range ctx.rows {
+		px, ok1 := toFloat(row[x.Field])
+		py, ok2 := toFloat(row[y.Field])
+		if !ok1 || !ok2 {
+			continue
+		}
+		if sums[px] == nil {
+			sums[px] = make([]float64, len(labels))
+		}
+		i := 0
+		if c.hasField() {
+			i = index[toString(row[c.Field])]
+		}
+		sums[px][i] += py
+	}
+	if len(sums) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with numeric %q and %q", x.Field, y.Field)
+	}
+
+	points := make([]charts.StackedAreaPoint, 0, len(sums))
+	for px, values := range sums {
+		points = append(points, charts.StackedAreaPoint{X: px, Values: values})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].X < points[j].X })
+
+	spec := charts.StackedAreaSpec{
+		Points:     points,
+		Series:     series,
+		Width:      ctx.width,
+		Height:     ctx.height,
+		ShowGrid:   x.Axis.grid() || y.Axis.grid(),
+		Smooth:     ctx.spec.Mark.Interpolate == "monotone",
+		XAxisLabel: x.title(),
+		YAxisLabel: y.title(),
+	}
+	content, err := renderChart(spec)
+	return content, ctx.fullFrame(), err
+}
+
+// renderArc draws a pie (or donut) slice per color category
+func renderArc(ctx *markContext) (string, frame, error) {
+	theta, c := ctx.enc.Theta, ctx.enc.Color
+
+	var slices []charts.PieSlice
+	for _, row := range ctx.rows {
+		v, ok := toFloat(row[theta.Field])
+		if !ok {
+			continue
+		}
+		if v < 0 {
+			return "", frame{}, fmt.Errorf("arc marks do not support negative values (%s = %v)", theta.Field, v)
+		}
+		slices = append(slices, charts.PieSlice{Label: toString(row[c.Field]), Value: v})
+	}
+	if len(slices) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with a numeric %q", theta.Field)
+	}
+
+	colors, err := palette(c, ctx.theme, len(slices))
+	if err != nil {
+		return "", frame{}, err
+	}
+
+	showLegend := ctx.spec.Legend == nil || !ctx.spec.Legend.Disable
+	content, err := renderChart(charts.PieChartSpec{
+		Data:        charts.PieChartData{Slices: slices, Colors: colors},
+		Bounds:      charts.Bounds{Width: int(ctx.width), Height: int(ctx.height)},
+		Donut:       ctx.spec.Mark.InnerRadius > 0,
+		ShowLegend:  showLegend,
+		ShowPercent: ctx.spec.Mark.showLabels(),
+	})
+	return content, ctx.fullFrame(), err
+}
+
+// groupNumbers collects the numeric values of field per category of group,
+// in order of first appearance. Without a group field all values form one
+// group named label.
+func groupNumbers(rows []Row, group *FieldDef, field, label string) ([]string, map[string][]float64) {
+	var names []string
+	values := make(map[string][]float64)
+	for _, row := range rows {
+		v, ok := toFloat(row[field])
+		if !ok {
+			continue
+		}
+		name := label
+		if group.hasField() {
+			name = toString(row[group.Field])
+		}
+		if _, seen := values[name]; !seen {
+			names = append(names, name)
+		}
+		values[name] = append(values[name], v)
+	}
+	return names, values
+}
+
+// groupColors colors groups by the color channel, or uses the mark color
+// for every group when no color channel is encoded
+func groupColors(ctx *markContext, groups []string) (map[string]string, error) {
+	if ctx.enc.Color.hasField() || (ctx.enc.Color != nil && ctx.enc.Color.Scale != nil) {
+		return categoryColors(ctx.enc.Color, ctx.theme, groups)
+	}
+	colors := make(map[string]string, len(groups))
+	for _, g := range groups {
+		colors[g] = markColor(ctx.spec, ctx.theme)
+	}
+	return colors, nil
+}
+
+// renderDistribution draws boxplot and violin marks, one per x category
+func renderDistribution(ctx *markContext) (string, frame, error) {
+	x, y := ctx.enc.X, ctx.enc.Y
+	groups, values := groupNumbers(ctx.rows, x, y.Field, y.title())
+	if len(groups) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with a numeric %q", y.Field)
+	}
+	colors, err := groupColors(ctx, groups)
+	if err != nil {
+		return "", frame{}, err
+	}
+
+	if ctx.spec.Mark.Type == MarkViolin {
+		data := make([]*charts.ViolinPlotData, len(groups))
+		for i, g := range groups {
+			data[i] = &charts.ViolinPlotData{Values: values[g], Label: g, Color: colors[g]}
+		}
+		spec := charts.ViolinPlotSpec{
+			Data:       data,
+			Width:      ctx.width,
+			Height:     ctx.height,
+			ShowBox:    true,
+			ShowMedian: true,
+			XAxisLabel: x.title(),
+			YAxisLabel: y.title(),
+		}
+		content, err := renderChart(spec)
+		return content, ctx.fullFrame(), err
+	}
+
+	data := make([]*charts.BoxPlotData, len(groups))
+	for i, g := range groups {
+		data[i] = &charts.BoxPlotData{Values: values[g], Label: g, Color: colors[g]}
+	}
+	spec := charts.BoxPlotSpec{
+		Data:         data,
+		Width:        ctx.width,
+		Height:       ctx.height,
+		ShowOutliers: true,
+		XAxisLabel:   x.title(),
+		YAxisLabel:   y.title(),
+		ShowGrid:     y.Axis.grid(),
+	}
+	if ctx.spec.Mark.Orient == "horizontal" {
+		spec.Horizontal = true
+	}
+	content, err := renderChart(spec)
+	return content, ctx.fullFrame(), err
+}
+
+// renderLollipop draws a lollipop per row, colored by a nominal color field
+func renderLollipop(ctx *markContext) (string, frame, error) {
+	x, y, c := ctx.enc.X, ctx.enc.Y, ctx.enc.Color
+
+	var colors map[string]string
+	if c.hasField() {
+		var err error
+		if colors, err = categoryColors(c, ctx.theme, distinct(ctx.rows, c.Field)); err != nil {
+			return "", frame{}, err
+		}
+	}
+
+	var points []charts.LollipopPoint
+	for _, row := range ctx.rows {
+		v, ok := toFloat(row[y.Field])
+		if !ok {
+			continue
+		}
+		point := charts.LollipopPoint{Label: toString(row[x.Field]), Value: v}
+		if colors != nil {
+			point.Color = colors[toString(row[c.Field])]
+		}
+		points = append(points, point)
+	}
+	if len(points) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with a numeric %q", y.Field)
+	}
+
+	spec := charts.LollipopSpec{
+		Data:       &charts.LollipopData{Values: points, Color: markColor(ctx.spec, ctx.theme)},
+		Width:      ctx.width,
+		Height:     ctx.height,
+		Horizontal: ctx.spec.Mark.Orient == "horizontal",
+		ShowLabels: ctx.spec.Mark.showLabels(),
+		ShowGrid:   x.Axis.grid() || y.Axis.grid(),
+		XAxisLabel: x.title(),
+		YAxisLabel: y.title(),
+	}
+	content, err := renderChart(spec)
+	return content, ctx.fullFrame(), err
+}
+
+// renderDensity draws a kernel density curve per color category
+func renderDensity(ctx *markContext) (string, frame, error) {
+	x, c := ctx.enc.X, ctx.enc.Color
+	groups, values := groupNumbers(ctx.rows, c, x.Field, x.title())
+	if len(groups) == 0 {
+		return "", frame{}, fmt.Errorf("no rows with a numeric %q", x.Field)
+	}
+	colors, err := groupColors(ctx, groups)
+	if err != nil {
+		return "", frame{}, err
+	}
+
+	data := make([]*charts.SimpleDensityData, len(groups))
+	for i, g := range groups {
+		data[i] = &charts.SimpleDensityData{Values: values[g], Label: g, Color: colors[g]}
+	}
+	spec := charts.SimpleDensitySpec{
+		Data:       data,
+		Width:      ctx.width,
+		Height:     ctx.height,
+		ShowFill:   true,
+		XAxisLabel: x.title(),
+		YAxisLabel: "density",
+	}
+	if t := ctx.enc.Y.title(); t != "" {
+		spec.YAxisLabel = t
+	}
+	content, err := renderChart(spec)
+	return content, ctx.fullFrame(), err
+}
+
+// buildTree nests rows by the hierarchy fields, outermost first, summing
+// the size field into the leaves
+func buildTree(rows []Row, hierarchy []FieldDef, size, name string) (*charts.TreeNode, error) {
+	root := &charts.TreeNode{Name: name}
+	children := make(map[*charts.TreeNode]map[string]*charts.TreeNode)
+
+	for i, row := range rows {
+		v, ok := toFloat(row[size])
+		if !ok {
+			continue
+		}
+		if v < 0 {
+			return nil, fmt.Errorf("row %d: negative size %v", i, v)
+		}
+
+		node := root
+		for _, level := range hierarchy {
+			key := toString(row[level.Field])
+			if children[node] == nil {
+				children[node] = make(map[string]*charts.TreeNode)
+			}
+			child, ok := children[node][key]
+			if !ok {
+				child = &charts.TreeNode{Name: key}
+				children[node][key] = child
+				node.Children = append(node.Children, child)
+			}
+			node = child
+		}
+		node.Value += v
+	}
+
+	if len(root.Children) == 0 {
+		return nil, fmt.Errorf("no rows with a numeric %q", size)
+	}
+	return root, nil
+}
+
+// renderHierarchy draws treemap, sunburst, icicle and circle-packing marks
+func renderHierarchy(ctx *markContext) (string, frame, error) {
+	mark := ctx.spec.Mark
+	name := ctx.spec.Title
+	if name == "" {
+		name = "root"
+	}
+	root, err := buildTree(ctx.rows, ctx.enc.Hierarchy, ctx.enc.Size.Field, name)
+	if err != nil {
+		return "", frame{}, err
+	}
+	colors, err := palette(ctx.enc.Color, ctx.theme, len(root.Children))
+	if err != nil {
+		return "", frame{}, err
+	}
+
+	var chart charts.Chart
+	switch mark.Type {
+	case MarkTreemap:
+		chart = charts.TreemapSpec{
+			Root:         root,
+			Width:        ctx.width,
+			Height:       ctx.height,
+			Padding:      2,
+			ShowLabels:   mark.showLabels(),
+			MinLabelSize: 30,
+			ColorScheme:  colors,
+		}
+	case MarkSunburst:
+		chart = charts.SunburstSpec{
+			Root:        root,
+			Width:       ctx.width,
+			Height:      ctx.height,
+			InnerRadius: mark.InnerRadius,
+			ShowLabels:  mark.showLabels(),
+			ColorScheme: colors,
+		}
+	case MarkIcicle:
+		chart = charts.IcicleSpec{
+			Root:        root,
+			Width:       ctx.width,
+			Height:      ctx.height,
+			Padding:     2,
+			Orientation: mark.Orient,
+			ShowLabels:  mark.showLabels(),
+			ColorScheme: colors,
+		}
+	case MarkCirclePacking:
+		chart = charts.CirclePackingSpec{
+			Root:        root,
+			Width:       ctx.width,
+			Height:      ctx.height,
+			Padding:     2,
+			ShowLabels:  mark.showLabels(),
+			ColorScheme: colors,
+		}
+	}
+	content, err := renderChart(chart)
+	return content, ctx.fullFrame(), err
+}
+
+// renderFlow draws sankey and chord marks. Rows with the same source and
+// target are merged; each contributes its size value, or 1 without a size field.
+func renderFlow(ctx *markContext) (string, frame, error) {
+	source, target, size := ctx.enc.Source, ctx.enc.Target, ctx.enc.Size
+
+	var nodes []string
+	seen := make(map[string]bool)
+	addNode := func(n string) {
+		if !seen[n] {
+			seen[n] = true
+			nodes = append(nodes, n)
+		}
+	}
+
+	type pair struct{ source, target string }
+	var pairs []pair
+	weights := make(map[pair]float64)
+	for i, row := range ctx.rows {
+		v := 1.0
+		if size.hasField() {
+			var ok bool
+			if v, ok = toFloat(row[size.Field]); !ok {
+				continue
+			}
+			if v < 0 {
+				return "", frame{}, fmt.Errorf("row %d: negative size %v", i, v)
+			}
+		}
+		p := pair{toString(row[source.Field]), toString(row[target.Field])}
+		addNode(p.source)
+		addNode(p.target)
+		if _, ok := weights[p]; !ok {
+			pairs = append(pairs, p)
+		}
+		weights[p] += v
+	}
+	if len(pairs) == 0 {
+		return "", frame{}, fmt.Errorf("no flows between %q and %q", source.Field, target.Field)
+	}
+
+	colors, err := categoryColors(ctx.enc.Color, ctx.theme, nodes)
+	if err != nil {
+		return "", frame{}, err
+	}
+
+	if ctx.spec.Mark.Type == MarkChord {
+		spec := charts.ChordDiagramSpec{
+			Width:      ctx.width,
+			Height:     ctx.height,
+			ShowLabels: ctx.spec.Mark.showLabels(),
+		}
+		for _, n := range nodes {
+			spec.Entities = append(spec.Entities, charts.ChordEntity{ID: n, Label: n, Color: colors[n]})
+		}
+		for _, p := range pairs {
+			spec.Relations = append(spec.Relations, charts.ChordRelation{Source: p.source, Target: p.target, Value: weights[p]})
+		}
+		content, err := renderChart(spec)
+		return content, ctx.fullFrame(), err
+	}
+
+	spec := charts.SankeySpec{
+		Width:      ctx.width,
+		Height:     ctx.height,
+		ShowLabels: ctx.spec.Mark.showLabels(),
+	}
+	for _, n := range nodes {
+		spec.Nodes = append(spec.Nodes, charts.SankeyNode{ID: n, Label: n, Color: colors[n]})
+	}
+	for _, p := range pairs {
+		spec.Links = append(spec.Links, charts.SankeyLink{Source: p.source, Target: p.target, Value: weights[p]})
+	}
+	content, err := renderChart(spec)
+	return content, ctx.fullFrame(), err
+}
@@ -0,0 +1,240 @@
+package grammar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Parse decodes a spec from JSON or YAML.
+// Documents starting with "{" are decoded as JSON, anything else as YAML.
+func Parse(data []byte) (*Spec, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return ParseJSON(trimmed)
+	}
+	return ParseYAML(data)
+}
+
+// ParseJSON decodes and validates a JSON spec.
+// Unknown fields are rejected so that typos surface as errors.
+func ParseJSON(data []byte) (*Spec, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// ParseYAML decodes and validates a YAML spec.
+// The document is converted to JSON first, so YAML and JSON specs share
+// one set of field names and decoding rules.
+func ParseYAML(data []byte) (*Spec, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	jsonData, err := json.Marshal(jsonCompatible(doc))
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	return ParseJSON(jsonData)
+}
+
+// jsonCompatible converts YAML maps with non-string keys into
+// map[string]interface{} so the document can be encoded as JSON
+func jsonCompatible(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, item := range t {
+			t[k] = jsonCompatible(item)
+		}
+		return t
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			m[fmt.Sprint(k)] = jsonCompatible(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range t {
+			t[i] = jsonCompatible(item)
+		}
+		return t
+	}
+	return v
+}
+
+// Validate checks that the spec names a known mark, encodes the channels
+// that mark requires and uses known field, annotation and unit types
+func (s *Spec) Validate() error {
+	if s.Mark.Type == "" {
+		return fmt.Errorf("mark is required")
+	}
+	if s.Width < 0 || s.Height < 0 {
+		return fmt.Errorf("width and height must not be negative")
+	}
+
+	enc := s.Encoding
+	for _, name := range channelNames {
+		if err := enc.channel(name).validate(name); err != nil {
+			return err
+		}
+	}
+	for i := range enc.Hierarchy {
+		if err := enc.Hierarchy[i].validate(fmt.Sprintf("hierarchy[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	switch s.Mark.Type {
+	case MarkBar:
+		if enc.X.hasField() && enc.X.Bin != nil {
+			break
+		}
+		if err := requireFields(s.Mark.Type, map[string]*FieldDef{"x": enc.X, "y": enc.Y}); err != nil {
+			return err
+		}
+	case MarkLine, MarkPoint, MarkArea, MarkLollipop:
+		if err := requireFields(s.Mark.Type, map[string]*FieldDef{"x": enc.X, "y": enc.Y}); err != nil {
+			return err
+		}
+	case MarkHistogram, MarkDensity:
+		if err := requireFields(s.Mark.Type, map[string]*FieldDef{"x": enc.X}); err != nil {
+			return err
+		}
+	case MarkBoxplot, MarkViolin:
+		if err := requireFields(s.Mark.Type, map[string]*FieldDef{"y": enc.Y}); err != nil {
+			return err
+		}
+	case MarkArc:
+		if err := requireFields(s.Mark.Type, map[string]*FieldDef{"theta": enc.Theta, "color": enc.Color}); err != nil {
+			return err
+		}
+	case MarkTreemap, MarkSunburst, MarkIcicle, MarkCirclePacking:
+		if len(enc.Hierarchy) == 0 {
+			return fmt.Errorf("%s mark requires a hierarchy encoding", s.Mark.Type)
+		}
+		if err := requireFields(s.Mark.Type, map[string]*FieldDef{"size": enc.Size}); err != nil {
+			return err
+		}
+	case MarkSankey, MarkChord:
+		if err := requireFields(s.Mark.Type, map[string]*FieldDef{"source": enc.Source, "target": enc.Target}); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown mark type %q", s.Mark.Type)
+	}
+
+	for i, a := range s.Annotations {
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("annotation %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// channelNames lists the single-field channels in validation order
+var channelNames = []string{"x", "y", "theta", "color", "size", "source", "target"}
+
+// channel returns the field definition for a channel name
+func (e Encoding) channel(name string) *FieldDef {
+	switch name {
+	case "x":
+		return e.X
+	case "y":
+		return e.Y
+	case "theta":
+		return e.Theta
+	case "color":
+		return e.Color
+	case "size":
+		return e.Size
+	case "source":
+		return e.Source
+	case "target":
+		return e.Target
+	}
+	return nil
+}
+
+// hasField reports whether the channel is bound to a data field
+func (f *FieldDef) hasField() bool {
+	return f != nil && f.Field != ""
+}
+
+// validate checks the field type and aggregate of a channel
+func (f *FieldDef) validate(channel string) error {
+	if f == nil {
+		return nil
+	}
+	switch f.Type {
+	case "", TypeQuantitative, TypeTemporal, TypeNominal, TypeOrdinal:
+	default:
+		return fmt.Errorf("encoding %s: unknown field type %q", channel, f.Type)
+	}
+	if f.Aggregate != "" {
+		if _, err := aggregateFunc(f.Aggregate); err != nil {
+			return fmt.Errorf("encoding %s: %w", channel, err)
+		}
+	}
+	if f.Field == "" && f.Aggregate != "count" && f.Value == nil {
+		return fmt.Errorf("encoding %s: field or value is required", channel)
+	}
+	return nil
+}
+
+// requireFields returns an error naming the first channel without a field.
+// A count aggregate counts rows and needs no field.
+func requireFields(mark string, channels map[string]*FieldDef) error {
+	for _, name := range channelNames {
+		def, ok := channels[name]
+		if !ok {
+			continue
+		}
+		if !def.hasField() && (def == nil || def.Aggregate != "count") {
+			return fmt.Errorf("%s mark requires a field on the %s channel", mark, name)
+		}
+	}
+	return nil
+}
+
+// validate checks the annotation type, units and required coordinates
+func (a Annotation) validate() error {
+	switch a.Units {
+	case "", UnitsData, UnitsPixel, UnitsRelative:
+	default:
+		return fmt.Errorf("unknown units %q", a.Units)
+	}
+
+	switch a.Type {
+	case AnnotationText:
+		if a.X == nil || a.Y == nil {
+			return fmt.Errorf("text annotation requires x and y")
+		}
+	case AnnotationRule:
+		if (a.X == nil) == (a.Y == nil) {
+			return fmt.Errorf("rule annotation requires exactly one of x or y")
+		}
+	case AnnotationRegion:
+		if (a.X == nil) != (a.X2 == nil) || (a.Y == nil) != (a.Y2 == nil) || (a.X == nil && a.Y == nil) {
+			return fmt.Errorf("region annotation requires x and x2, y and y2, or both")
+		}
+	case AnnotationArrow:
+		if a.X == nil || a.Y == nil || a.X2 == nil || a.Y2 == nil {
+			return fmt.Errorf("arrow annotation requires x, y, x2 and y2")
+		}
+	default:
+		return fmt.Errorf("unknown annotation type %q", a.Type)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package grammar
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseJSON(t *testing.T) {
+	spec, err := Parse([]byte(`{
+		"title": "Sales",
+		"data": {"values": [{"region": "north", "sales": 10}]},
+		"mark": {"type": "bar", "color": "#3B82F6"},
+		"encoding": {
+			"x": {"field": "region", "type": "nominal"},
+			"y": {"field": "sales", "aggregate": "sum"}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if spec.Title != "Sales" {
+		t.Errorf("Title = %q, expected %q", spec.Title, "Sales")
+	}
+	if spec.Mark.Type != MarkBar || spec.Mark.Color != "#3B82F6" {
+		t.Errorf("Mark = %+v, expected bar with color", spec.Mark)
+	}
+	if spec.Encoding.Y.Aggregate != "sum" {
+		t.Errorf("Encoding.Y.Aggregate = %q, expected %q", spec.Encoding.Y.Aggregate, "sum")
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	spec, err := Parse([]byte(`
+mark: line
+data:
+  values:
+    - {x: 1, y: 2}
+    - {x: 2, y: 4}
+encoding:
+  x: {field: x}
+  y: {field: y}
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if spec.Mark.Type != MarkLine {
+		t.Errorf("Mark.Type = %q, expected %q", spec.Mark.Type, MarkLine)
+	}
+	if len(spec.Data.Values) != 2 {
+		t.Errorf("len(Data.Values) = %d, expected 2", len(spec.Data.Values))
+	}
+}
+
+func TestParseJSONAndYAMLMatch(t *testing.T) {
+	fromJSON, err := Parse([]byte(`{"mark": "point", "data": {"values": [{"a": 1, "b": 2}]}, "encoding": {"x": {"field": "a"}, "y": {"field": "b"}}}`))
+	if err != nil {
+		t.Fatalf("Parse(JSON) error = %v", err)
+	}
+	fromYAML, err := Parse([]byte("mark: point\ndata:\n  values: [{a: 1, b: 2}]\nencoding:\n  x: {field: a}\n  y: {field: b}\n"))
+	if err != nil {
+		t.Fatalf("Parse(YAML) error = %v", err)
+	}
+
+	a, err := Render(fromJSON)
+	if err != nil {
+		t.Fatalf("Render(JSON) error = %v", err)
+	}
+	b, err := Render(fromYAML)
+	if err != nil {
+		t.Fatalf("Render(YAML) error = %v", err)
+	}
+	if a != b {
+		t.Error("JSON and YAML specs rendered differently")
+	}
+}
+
+func TestParseUnknownField(t *testing.T) {
+	_, err := Parse([]byte(`{"mark": "bar", "encodings": {}}`))
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
+
+func TestBinUnmarshal(t *testing.T) {
+	spec, err := Parse([]byte(`{"mark": "bar", "encoding": {"x": {"field": "v", "bin": true}}}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if spec.Encoding.X.Bin == nil || !spec.Encoding.X.Bin.nice() {
+		t.Errorf("Bin = %+v, expected default bin", spec.Encoding.X.Bin)
+	}
+
+	spec, err = Parse([]byte(`{"mark": "bar", "encoding": {"x": {"field": "v", "bin": {"maxbins": 5, "nice": false}}}}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if bin := spec.Encoding.X.Bin; bin.MaxBins != 5 || bin.nice() {
+		t.Errorf("Bin = %+v, expected maxbins 5 without nice", bin)
+	}
+
+	// false turns binning off, on fields and transforms alike
+	spec, err = Parse([]byte(`{"mark": "bar", "transform": [{"bin": false, "sort": [{"field": "v"}]}],
+		"encoding": {"x": {"field": "k", "bin": false}, "y": {"field": "v"}}}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if spec.Encoding.X.Bin != nil || spec.Transform[0].Bin != nil {
+		t.Errorf("Bin = %+v, transform bin = %+v, expected no binning", spec.Encoding.X.Bin, spec.Transform[0].Bin)
+	}
+
+	// Field definitions still reject unknown fields
+	if _, err := Parse([]byte(`{"mark": "bar", "encoding": {"x": {"field": "k", "bins": true}, "y": {"field": "v"}}}`)); err == nil {
+		t.Error("expected an error for an unknown field definition field")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected string
+	}{
+		{"missing mark", `{"encoding": {}}`, "mark is required"},
+		{"unknown mark", `{"mark": "blob"}`, `unknown mark type "blob"`},
+		{"missing y", `{"mark": "line", "encoding": {"x": {"field": "a"}}}`, "requires a field on the y channel"},
+		{"unknown type", `{"mark": "bar", "encoding": {"x": {"field": "a", "type": "fuzzy"}}}`, `unknown field type "fuzzy"`},
+		{"unknown aggregate", `{"mark": "bar", "encoding": {"x": {"field": "a"}, "y": {"field": "b", "aggregate": "mode"}}}`, `unknown aggregate op "mode"`},
+		{"missing hierarchy", `{"mark": "treemap", "encoding": {"size": {"field": "v"}}}`, "requires a hierarchy encoding"},
+		{"rule with both axes", `{"mark": "bar", "encoding": {"x": {"field": "a"}, "y": {"field": "b"}}, "annotations": [{"type": "rule", "x": 1, "y": 2}]}`, "exactly one of x or y"},
+		{"unknown units", `{"mark": "bar", "encoding": {"x": {"field": "a"}, "y": {"field": "b"}}, "annotations": [{"type": "text", "x": 1, "y": 2, "units": "em"}]}`, `unknown units "em"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.spec))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Parse() error = %v, expected %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateCountWithoutField(t *testing.T) {
+	_, err := Parse([]byte(`{"mark": "bar", "encoding": {"x": {"field": "a"}, "y": {"aggregate": "count"}}}`))
+	if err != nil {
+		t.Errorf("Parse() error = %v, expected none", err)
+	}
+}
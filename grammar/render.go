@@ -0,0 +1,271 @@
+package grammar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SCKelemen/dataviz/annotations"
+	"github.com/SCKelemen/dataviz/charts/legends"
+	"github.com/SCKelemen/dataviz/scales"
+	"github.com/SCKelemen/svg"
+	"github.com/SCKelemen/units"
+)
+
+// Default chart size
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 500
+)
+
+// titleHeight is the space reserved above the chart for the title
+const titleHeight = 36.0
+
+// defaultTextColor is the title color when no theme is named
+const defaultTextColor = "#111827"
+
+// Render compiles a spec to a standalone SVG document.
+//
+// The spec is validated, its transforms and encoding aggregates are applied,
+// and the mark is drawn by the matching charts renderer. The title, legend,
+// background and annotations are drawn by the grammar around it.
+func Render(spec *Spec) (string, error) {
+	if spec == nil {
+		return "", fmt.Errorf("spec is nil")
+	}
+	if err := spec.Validate(); err != nil {
+		return "", err
+	}
+
+	th, err := themeByName(spec.Theme)
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := ApplyTransforms(spec.Data.Values, spec.Transform)
+	if err != nil {
+		return "", err
+	}
+	enc, rows, err := encodeAggregates(spec.Encoding, rows)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("no data to render")
+	}
+
+	width, height := float64(spec.Width), float64(spec.Height)
+	if width == 0 {
+		width = DefaultWidth
+	}
+	if height == 0 {
+		height = DefaultHeight
+	}
+	top := 0.0
+	if spec.Title != "" {
+		top = titleHeight
+	}
+
+	ctx := &markContext{
+		spec:   spec,
+		theme:  th,
+		themed: spec.Theme != "",
+		enc:    enc,
+		rows:   rows,
+		width:  width,
+		height: height - top,
+	}
+	content, f, err := renderMark(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	overlay, pixelOverlay, err := renderAnnotations(spec.Annotations, f, width, height)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		int(width), int(height), int(width), int(height)))
+	b.WriteString("\n")
+
+	if bg := background(spec, th.ColorScheme.BackgroundColor); bg != "" {
+		b.WriteString(svg.Rect(0, 0, width, height, svg.Style{Fill: bg}))
+		b.WriteString("\n")
+	}
+
+	if spec.Title != "" {
+		style := th.TitleStyle()
+		if !ctx.themed {
+			style.Fill = defaultTextColor
+		}
+		style.TextAnchor = svg.TextAnchorMiddle
+		style.DominantBaseline = svg.DominantBaselineMiddle
+		b.WriteString(svg.Text(spec.Title, width/2, top/2, style))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf(`<g class="mark mark-%s" transform="translate(0, %g)">`, spec.Mark.Type, top))
+	b.WriteString("\n")
+	b.WriteString(content)
+	b.WriteString("\n")
+	b.WriteString(overlay)
+	if legend := renderLegend(spec.Legend, ctx.legend, width, height-top); legend != "" {
+		b.WriteString(legend)
+	}
+	b.WriteString("</g>\n")
+
+	b.WriteString(pixelOverlay)
+	b.WriteString("</svg>")
+
+	return b.String(), nil
+}
+
+// background returns the fill behind the chart: the spec's background, or
+// the theme's background when a theme is named explicitly
+func background(spec *Spec, themeBackground string) string {
+	if spec.Background != "" {
+		return spec.Background
+	}
+	if spec.Theme != "" {
+		return themeBackground
+	}
+	return ""
+}
+
+// legendPositions maps spec legend positions to legend package positions
+var legendPositions = map[string]legends.Position{
+	"":             legends.PositionTopRight,
+	"top-left":     legends.PositionTopLeft,
+	"top-right":    legends.PositionTopRight,
+	"top":          legends.PositionTopCenter,
+	"bottom-left":  legends.PositionBottomLeft,
+	"bottom-right": legends.PositionBottomRight,
+	"bottom":       legends.PositionBottomCenter,
+	"left":         legends.PositionLeft,
+	"right":        legends.PositionRight,
+}
+
+// renderLegend draws the legend items collected by the mark
+func renderLegend(cfg *Legend, items []legends.LegendItem, width, height float64) string {
+	if len(items) == 0 || (cfg != nil && cfg.Disable) {
+		return ""
+	}
+	position := legends.PositionTopRight
+	if cfg != nil {
+		if p, ok := legendPositions[cfg.Position]; ok {
+			position = p
+		}
+	}
+	return legends.New(items, legends.WithPosition(position)).Render(int(width), int(height))
+}
+
+// renderAnnotations draws the spec's annotations. Data and relative
+// annotations are returned in overlay, drawn in the mark's coordinates;
+// pixel annotations are returned in pixelOverlay, drawn in document coordinates.
+func renderAnnotations(specs []Annotation, f frame, width, height float64) (overlay, pixelOverlay string, err error) {
+	if len(specs) == 0 {
+		return "", "", nil
+	}
+
+	// Relative coordinates run from the bottom-left (0, 0) to the top-right
+	// (1, 1) of the plot area; pixel coordinates from the document's top-left
+	relX := scales.NewLinearScale([2]float64{0, 1}, [2]units.Length{units.Px(f.x), units.Px(f.x + f.width)})
+	relY := scales.NewLinearScale([2]float64{0, 1}, [2]units.Length{units.Px(f.y + f.height), units.Px(f.y)})
+	pxX := scales.NewLinearScale([2]float64{0, width}, [2]units.Length{units.Px(0), units.Px(width)})
+	pxY := scales.NewLinearScale([2]float64{0, height}, [2]units.Length{units.Px(0), units.Px(height)})
+
+	data := annotations.NewAnnotationLayer()
+	relative := annotations.NewAnnotationLayer()
+	pixel := annotations.NewAnnotationLayer()
+
+	for i, a := range specs {
+		layer, xScale, yScale := data, f.xScale, f.yScale
+		switch a.Units {
+		case UnitsRelative:
+			layer, xScale, yScale = relative, relX, relY
+		case UnitsPixel:
+			layer, xScale, yScale = pixel, pxX, pxY
+		default:
+			if f.xScale == nil || f.yScale == nil {
+				return "", "", fmt.Errorf("annotation %d: data units are not supported for this mark; use relative or pixel units", i)
+			}
+		}
+
+		built, err := buildAnnotation(a, xScale, yScale)
+		if err != nil {
+			return "", "", fmt.Errorf("annotation %d: %w", i, err)
+		}
+		for _, ann := range built {
+			layer.Add(ann)
+		}
+	}
+
+	if len(data.Annotations) > 0 {
+		overlay += data.Render(f.xScale, f.yScale)
+	}
+	overlay += relative.Render(relX, relY)
+	pixelOverlay = pixel.Render(pxX, pxY)
+	return overlay, pixelOverlay, nil
+}
+
+// buildAnnotation converts an annotation spec to annotations package values
+func buildAnnotation(a Annotation, xScale, yScale scales.Scale) ([]annotations.Annotation, error) {
+	style := annotations.DefaultAnnotationStyle()
+	if a.Color != "" {
+		style.Stroke = a.Color
+		style.Fill = a.Color
+		style.Color = a.Color
+	}
+
+	x, x2 := coordinate(a.X, xScale), coordinate(a.X2, xScale)
+	y, y2 := coordinate(a.Y, yScale), coordinate(a.Y2, yScale)
+
+	switch a.Type {
+	case AnnotationText:
+		return []annotations.Annotation{annotations.NewTextLabel(a.Text, x, y).WithStyle(style)}, nil
+
+	case AnnotationRule:
+		var rule *annotations.ReferenceLine
+		if a.X != nil {
+			rule = annotations.NewVLine(x)
+		} else {
+			rule = annotations.NewHLine(y)
+		}
+		rule.WithStyle(style).WithLabel(a.Text)
+		if a.Dashed {
+			rule.WithDashed()
+		}
+		return []annotations.Annotation{rule}, nil
+
+	case AnnotationRegion:
+		style.Opacity = 0.15
+		region := annotations.NewReferenceRegion(x, y, x2, y2).WithStyle(style).WithLabel(a.Text)
+		return []annotations.Annotation{region}, nil
+
+	case AnnotationArrow:
+		result := []annotations.Annotation{annotations.NewArrow(x, y, x2, y2).WithStyle(style)}
+		if a.Text != "" {
+			label := annotations.NewTextLabel(a.Text, x, y).WithOffset(0, -8).WithStyle(style)
+			result = append(result, label)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unknown annotation type %q", a.Type)
+}
+
+// coordinate converts an annotation coordinate for a scale: categories stay
+// strings on categorical scales, everything else is converted to a number.
+// nil stays nil so regions can span the full range.
+func coordinate(v interface{}, scale scales.Scale) interface{} {
+	if v == nil {
+		return nil
+	}
+	if _, ok := scale.(scales.CategoricalScale); ok {
+		return toString(v)
+	}
+	if f, ok := toFloat(v); ok {
+		return f
+	}
+	return v
+}
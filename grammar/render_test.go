@@ -0,0 +1,191 @@
+package grammar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/dataviz/scales"
+	"github.com/SCKelemen/units"
+)
+
+func TestRenderMarks(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"bar", `{"mark": "bar", "data": {"values": [{"k": "a", "v": 1}, {"k": "b", "v": 2.5}]},
+			"encoding": {"x": {"field": "k"}, "y": {"field": "v"}}}`},
+		{"bar count", `{"mark": "bar", "data": {"values": [{"k": "a"}, {"k": "a"}, {"k": "b"}]},
+			"encoding": {"x": {"field": "k"}, "y": {"aggregate": "count"}}}`},
+		{"binned bar", `{"mark": "bar", "data": {"values": [{"v": 1}, {"v": 2}, {"v": 7}]},
+			"encoding": {"x": {"field": "v", "bin": {"maxbins": 3}}}}`},
+		{"line", `{"mark": "line", "data": {"values": [{"x": 1, "y": 2, "s": "a"}, {"x": 2, "y": 3, "s": "a"}, {"x": 1, "y": 1, "s": "b"}]},
+			"encoding": {"x": {"field": "x"}, "y": {"field": "y"}, "color": {"field": "s"}}}`},
+		{"temporal line", `{"mark": "line", "data": {"values": [{"d": "2024-01-01", "v": 3}, {"d": "2024-02-01", "v": 5}]},
+			"encoding": {"x": {"field": "d", "type": "temporal"}, "y": {"field": "v"}}}`},
+		{"point", `{"mark": "point", "data": {"values": [{"x": 1, "y": 2, "c": 0.5}, {"x": 2, "y": 3, "c": 1}]},
+			"encoding": {"x": {"field": "x"}, "y": {"field": "y"}, "color": {"field": "c", "scale": {"scheme": "magma"}}}}`},
+		{"area", `{"mark": "area", "data": {"values": [{"x": 1, "y": 2, "s": "a"}, {"x": 2, "y": 3, "s": "b"}]},
+			"encoding": {"x": {"field": "x"}, "y": {"field": "y"}, "color": {"field": "s"}}}`},
+		{"arc", `{"mark": {"type": "arc", "innerRadius": 0.5}, "data": {"values": [{"k": "a", "v": 1}, {"k": "b", "v": 2}]},
+			"encoding": {"theta": {"field": "v"}, "color": {"field": "k"}}}`},
+		{"boxplot", `{"mark": "boxplot", "data": {"values": [{"g": "a", "v": 1}, {"g": "a", "v": 5}, {"g": "b", "v": 2}]},
+			"encoding": {"x": {"field": "g"}, "y": {"field": "v"}}}`},
+		{"violin", `{"mark": "violin", "data": {"values": [{"v": 1}, {"v": 2}, {"v": 4}]},
+			"encoding": {"y": {"field": "v"}}}`},
+		{"lollipop", `{"mark": "lollipop", "data": {"values": [{"k": "a", "v": 1}, {"k": "b", "v": 2}]},
+			"encoding": {"x": {"field": "k"}, "y": {"field": "v"}}}`},
+		{"density", `{"mark": "density", "data": {"values": [{"v": 1}, {"v": 2}, {"v": 4}]},
+			"encoding": {"x": {"field": "v"}}}`},
+		{"histogram", `{"mark": "histogram", "data": {"values": [{"v": 1}, {"v": 2}, {"v": 4}]},
+			"encoding": {"x": {"field": "v"}}}`},
+		{"treemap", `{"mark": "treemap", "data": {"values": [{"a": "x", "b": "p", "v": 1}, {"a": "x", "b": "q", "v": 2}, {"a": "y", "b": "r", "v": 3}]},
+			"encoding": {"hierarchy": [{"field": "a"}, {"field": "b"}], "size": {"field": "v"}}}`},
+		{"sunburst", `{"mark": "sunburst", "data": {"values": [{"a": "x", "v": 1}, {"a": "y", "v": 2}]},
+			"encoding": {"hierarchy": [{"field": "a"}], "size": {"field": "v"}}}`},
+		{"sankey", `{"mark": "sankey", "data": {"values": [{"s": "a", "t": "b", "v": 2}, {"s": "b", "t": "c", "v": 1}]},
+			"encoding": {"source": {"field": "s"}, "target": {"field": "t"}, "size": {"field": "v"}}}`},
+		{"chord", `{"mark": "chord", "data": {"values": [{"s": "a", "t": "b"}, {"s": "b", "t": "a"}]},
+			"encoding": {"source": {"field": "s"}, "target": {"field": "t"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := Parse([]byte(tt.spec))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			out, err := Render(spec)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.HasPrefix(out, "<svg") || !strings.HasSuffix(out, "</svg>") {
+				t.Error("Render() did not produce an SVG document")
+			}
+			if !strings.Contains(out, `class="mark mark-`+spec.Mark.Type+`"`) {
+				t.Errorf("Render() output is missing the %s mark group", spec.Mark.Type)
+			}
+		})
+	}
+}
+
+func TestRenderBinFlag(t *testing.T) {
+	render := func(bin string) string {
+		spec, err := Parse([]byte(`{"mark": "bar", "data": {"values": [{"b": 1, "v": 3}, {"b": 2, "v": 5}]},
+			"encoding": {"x": {"field": "b", "bin": ` + bin + `}, "y": {"field": "v"}}}`))
+		if err != nil {
+			t.Fatalf("Parse(bin: %s) error = %v", bin, err)
+		}
+		out, err := Render(spec)
+		if err != nil {
+			t.Fatalf("Render(bin: %s) error = %v", bin, err)
+		}
+		return out
+	}
+
+	// Unbinned, a bar per row with the y field on the axis
+	if out := render("false"); strings.Count(out, "<rect") > 3 || !strings.Contains(out, ">v<") {
+		t.Errorf("bin: false should draw a bar chart, got:\n%s", out)
+	}
+	// Binned, a histogram of the x field
+	if out := render("true"); strings.Count(out, "<rect") < 10 {
+		t.Errorf("bin: true should draw a histogram, got:\n%s", out)
+	}
+}
+
+func TestRenderTitleAndBackground(t *testing.T) {
+	spec, err := Parse([]byte(`{"title": "Quarterly", "width": 400, "height": 300, "theme": "nord",
+		"mark": "bar", "data": {"values": [{"k": "a", "v": 1}]},
+		"encoding": {"x": {"field": "k"}, "y": {"field": "v"}}}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	out, err := Render(spec)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{`width="400" height="300"`, ">Quarterly</text>", "translate(0, 36)", "<rect x=\"0.00\" y=\"0.00\" width=\"400.00\""} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() output is missing %q", want)
+		}
+	}
+}
+
+func TestRenderAnnotations(t *testing.T) {
+	spec, err := Parse([]byte(`{"mark": "bar", "data": {"values": [{"k": "a", "v": 1}, {"k": "b", "v": 2}]},
+		"encoding": {"x": {"field": "k"}, "y": {"field": "v"}},
+		"annotations": [
+			{"type": "text", "text": "peak", "x": "b", "y": 2},
+			{"type": "rule", "y": 1.5, "text": "target", "dashed": true},
+			{"type": "region", "x": 0.1, "x2": 0.3, "units": "relative"},
+			{"type": "arrow", "x": 10, "y": 10, "x2": 50, "y2": 50, "units": "pixel", "text": "look"}
+		]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	out, err := Render(spec)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{">peak</text>", ">target</text>", ">look</text>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() output is missing %q", want)
+		}
+	}
+}
+
+func TestRenderAnnotationsCategoricalY(t *testing.T) {
+	// A nominal y field of years, placed on a band scale
+	yScale := scales.NewBandScale([]string{"2023", "2024"}, [2]units.Length{units.Px(100), units.Px(0)})
+	f := frame{
+		width:  200,
+		height: 100,
+		xScale: scales.NewLinearScale([2]float64{0, 10}, [2]units.Length{units.Px(0), units.Px(200)}),
+		yScale: yScale,
+	}
+
+	// Numeric coordinates are matched to categories by their text
+	overlay, _, err := renderAnnotations([]Annotation{{Type: AnnotationRule, Y: 2024.0}}, f, 200, 100)
+	if err != nil {
+		t.Fatalf("renderAnnotations() error = %v", err)
+	}
+	want := fmt.Sprintf(`y1="%.2f"`, yScale.Apply("2024").Value)
+	if !strings.Contains(overlay, want) {
+		t.Errorf("rule should be drawn at the 2024 band (%s), got:\n%s", want, overlay)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected string
+	}{
+		{"no data", `{"mark": "bar", "encoding": {"x": {"field": "k"}, "y": {"field": "v"}}}`, "no data"},
+		{"unknown theme", `{"theme": "neon", "mark": "bar", "data": {"values": [{"k": "a", "v": 1}]},
+			"encoding": {"x": {"field": "k"}, "y": {"field": "v"}}}`, `unknown theme "neon"`},
+		{"unknown scheme", `{"mark": "point", "data": {"values": [{"x": 1, "y": 2, "c": 1}]},
+			"encoding": {"x": {"field": "x"}, "y": {"field": "y"}, "color": {"field": "c", "type": "quantitative", "scale": {"scheme": "nope"}}}}`, `unknown color scheme "nope"`},
+		{"horizontal boxplot", `{"mark": {"type": "boxplot", "orient": "horizontal"}, "data": {"values": [{"v": 1}, {"v": 2}]},
+			"encoding": {"y": {"field": "v"}}}`, "boxplot: Horizontal: unsupported option"},
+		{"sankey cycle", `{"mark": "sankey", "data": {"values": [{"s": "a", "t": "b"}, {"s": "b", "t": "a"}]},
+			"encoding": {"source": {"field": "s"}, "target": {"field": "t"}}}`, "sankey: Links: cycle"},
+		{"data units on arc", `{"mark": "arc", "data": {"values": [{"k": "a", "v": 1}]},
+			"encoding": {"theta": {"field": "v"}, "color": {"field": "k"}},
+			"annotations": [{"type": "text", "text": "x", "x": 1, "y": 1}]}`, "data units are not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := Parse([]byte(tt.spec))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			_, err = Render(spec)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Render() error = %v, expected %q", err, tt.expected)
+			}
+		})
+	}
+}
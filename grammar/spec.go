@@ -0,0 +1,348 @@
+package grammar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Spec is a complete declarative chart specification
+type Spec struct {
+	Schema      string       `json:"$schema,omitempty"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Width       int          `json:"width,omitempty"`      // Default: 800
+	Height      int          `json:"height,omitempty"`     // Default: 500
+	Theme       string       `json:"theme,omitempty"`      // default, midnight, nord, paper, wrapped
+	Background  string       `json:"background,omitempty"` // Background fill (default: none)
+	Data        Data         `json:"data"`
+	Transform   []Transform  `json:"transform,omitempty"`
+	Mark        Mark         `json:"mark"`
+	Encoding    Encoding     `json:"encoding"`
+	Legend      *Legend      `json:"legend,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+// Data holds the inline rows a chart is built from
+type Data struct {
+	Values []map[string]interface{} `json:"values"`
+}
+
+// Mark types
+const (
+	MarkBar           = "bar"
+	MarkLine          = "line"
+	MarkPoint         = "point"
+	MarkArea          = "area"
+	MarkArc           = "arc"
+	MarkBoxplot       = "boxplot"
+	MarkViolin        = "violin"
+	MarkLollipop      = "lollipop"
+	MarkDensity       = "density"
+	MarkHistogram     = "histogram"
+	MarkTreemap       = "treemap"
+	MarkSunburst      = "sunburst"
+	MarkIcicle        = "icicle"
+	MarkCirclePacking = "circle-packing"
+	MarkSankey        = "sankey"
+	MarkChord         = "chord"
+)
+
+// Mark describes the graphical primitive used to draw the data.
+// It unmarshals from either a bare type name ("bar") or an object
+// ({"type": "bar", "color": "#3B82F6"}).
+type Mark struct {
+	Type        string  `json:"type"`
+	Color       string  `json:"color,omitempty"`       // Fill/stroke color when no color field is encoded
+	Orient      string  `json:"orient,omitempty"`      // "vertical" or "horizontal" (lollipop, icicle)
+	InnerRadius float64 `json:"innerRadius,omitempty"` // Arc and sunburst hole (arc: any value > 0 draws a donut)
+	Interpolate string  `json:"interpolate,omitempty"` // "linear" or "monotone" (smooth)
+	Point       bool    `json:"point,omitempty"`       // Draw markers on line marks
+	Shape       string  `json:"shape,omitempty"`       // Marker shape: circle, square, diamond, triangle
+	Size        float64 `json:"size,omitempty"`        // Marker size in pixels
+	Labels      *bool   `json:"labels,omitempty"`      // Show labels on marks that support them (default: true)
+}
+
+// UnmarshalJSON accepts a mark type string or a mark definition object
+func (m *Mark) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*m = Mark{Type: name}
+		return nil
+	}
+
+	type markDef Mark
+	var def markDef
+	if err := json.Unmarshal(data, &def); err != nil {
+		return fmt.Errorf("mark must be a string or an object: %w", err)
+	}
+	*m = Mark(def)
+	return nil
+}
+
+// showLabels reports whether mark labels are enabled
+func (m Mark) showLabels() bool {
+	return m.Labels == nil || *m.Labels
+}
+
+// Field types
+const (
+	TypeQuantitative = "quantitative"
+	TypeTemporal     = "temporal"
+	TypeNominal      = "nominal"
+	TypeOrdinal      = "ordinal"
+)
+
+// Encoding maps data fields to visual channels
+type Encoding struct {
+	X         *FieldDef  `json:"x,omitempty"`
+	Y         *FieldDef  `json:"y,omitempty"`
+	Color     *FieldDef  `json:"color,omitempty"`
+	Size      *FieldDef  `json:"size,omitempty"`
+	Theta     *FieldDef  `json:"theta,omitempty"`     // Arc angle (pie value)
+	Source    *FieldDef  `json:"source,omitempty"`    // Flow source node (sankey, chord)
+	Target    *FieldDef  `json:"target,omitempty"`    // Flow target node (sankey, chord)
+	Hierarchy []FieldDef `json:"hierarchy,omitempty"` // Nesting fields, outermost first (treemap, sunburst, ...)
+}
+
+// FieldDef encodes a single data field on a channel
+type FieldDef struct {
+	Field     string      `json:"field,omitempty"`
+	Type      string      `json:"type,omitempty"`      // quantitative, temporal, nominal, ordinal
+	Aggregate string      `json:"aggregate,omitempty"` // sum, mean, median, min, max, count
+	Bin       *Bin        `json:"bin,omitempty"`
+	Title     string      `json:"title,omitempty"`
+	Value     interface{} `json:"value,omitempty"` // Constant color instead of a field (color channel)
+	Scale     *Scale      `json:"scale,omitempty"`
+	Axis      *Axis       `json:"axis,omitempty"`
+}
+
+// UnmarshalJSON decodes a field definition, leaving Bin nil for "bin": false
+func (f *FieldDef) UnmarshalJSON(data []byte) error {
+	type fieldDef FieldDef
+	def := struct {
+		*fieldDef
+		Bin json.RawMessage `json:"bin,omitempty"`
+	}{fieldDef: (*fieldDef)(f)}
+	if err := decodeStrict(data, &def); err != nil {
+		return err
+	}
+	bin, err := decodeBin(def.Bin)
+	if err != nil {
+		return err
+	}
+	f.Bin = bin
+	return nil
+}
+
+// title returns the axis or legend title for the field
+func (f *FieldDef) title() string {
+	if f == nil {
+		return ""
+	}
+	if f.Axis != nil && f.Axis.Title != "" {
+		return f.Axis.Title
+	}
+	if f.Title != "" {
+		return f.Title
+	}
+	if f.Aggregate != "" && f.Field != "" {
+		return fmt.Sprintf("%s(%s)", f.Aggregate, f.Field)
+	}
+	return f.Field
+}
+
+// Bin configures binning of a quantitative field.
+// It unmarshals from true or from an object; false means no binning.
+type Bin struct {
+	MaxBins int     `json:"maxbins,omitempty"` // Approximate number of bins (default: 10)
+	Step    float64 `json:"step,omitempty"`    // Fixed bin width
+	Nice    *bool   `json:"nice,omitempty"`    // Round bin edges (default: true)
+}
+
+// decodeBin decodes a bin setting, returning nil when binning is off
+// (false, null or absent)
+func decodeBin(raw json.RawMessage) (*Bin, error) {
+	switch string(bytes.TrimSpace(raw)) {
+	case "", "false", "null":
+		return nil, nil
+	}
+	var bin Bin
+	if err := json.Unmarshal(raw, &bin); err != nil {
+		return nil, err
+	}
+	return &bin, nil
+}
+
+// decodeStrict decodes JSON into v, rejecting unknown fields as ParseJSON
+// does; nested decoders don't inherit that setting
+func decodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// UnmarshalJSON accepts true or a bin definition object. Fields and
+// transforms decode false as no binning before reaching it.
+func (b *Bin) UnmarshalJSON(data []byte) error {
+	var enabled bool
+	if err := json.Unmarshal(data, &enabled); err == nil {
+		*b = Bin{}
+		return nil
+	}
+
+	type binDef Bin
+	var def binDef
+	if err := json.Unmarshal(data, &def); err != nil {
+		return fmt.Errorf("bin must be a boolean or an object: %w", err)
+	}
+	*b = Bin(def)
+	return nil
+}
+
+// nice reports whether bin edges are rounded
+func (b *Bin) nice() bool {
+	return b.Nice == nil || *b.Nice
+}
+
+// Scale configures how a channel maps data values to visual values
+type Scale struct {
+	Domain  []interface{} `json:"domain,omitempty"`  // Explicit [min, max] for quantitative channels
+	Range   []string      `json:"range,omitempty"`   // Explicit colors for the color channel
+	Scheme  string        `json:"scheme,omitempty"`  // Named color interpolator (viridis, rdbu, ...)
+	Reverse bool          `json:"reverse,omitempty"` // Reverse the color scheme
+}
+
+// Axis configures the axis drawn for a positional channel
+type Axis struct {
+	Title string `json:"title,omitempty"`
+	Grid  bool   `json:"grid,omitempty"` // Draw grid lines
+}
+
+// grid reports whether grid lines are enabled for the axis
+func (a *Axis) grid() bool {
+	return a != nil && a.Grid
+}
+
+// Legend configures the color legend. The grammar draws legends for line
+// and point marks; arc marks honour Disable, and the remaining marks with a
+// color field draw their renderer's built-in legend.
+type Legend struct {
+	Position string `json:"position,omitempty"` // top-left, top-right (default), top, bottom-left, bottom-right, bottom, left, right
+	Disable  bool   `json:"disable,omitempty"`
+}
+
+// Annotation types
+const (
+	AnnotationText   = "text"
+	AnnotationRule   = "rule"
+	AnnotationRegion = "region"
+	AnnotationArrow  = "arrow"
+)
+
+// Annotation coordinate systems
+const (
+	UnitsData     = "data"
+	UnitsPixel    = "pixel"
+	UnitsRelative = "relative"
+)
+
+// Annotation is a label, reference line, shaded region or arrow drawn over the chart.
+//
+// Coordinates are interpreted according to Units:
+//   - "data" (default): domain values on the x and y channels
+//   - "relative": fractions (0-1) of the plot area
+//   - "pixel": absolute pixels
+//
+// A rule with only X is vertical, a rule with only Y is horizontal.
+type Annotation struct {
+	Type   string      `json:"type"`
+	Text   string      `json:"text,omitempty"`
+	X      interface{} `json:"x,omitempty"`
+	Y      interface{} `json:"y,omitempty"`
+	X2     interface{} `json:"x2,omitempty"`
+	Y2     interface{} `json:"y2,omitempty"`
+	Units  string      `json:"units,omitempty"`
+	Color  string      `json:"color,omitempty"`
+	Dashed bool        `json:"dashed,omitempty"`
+}
+
+// Transform is a single data transformation step. Exactly one of Filter,
+// Aggregate, Bin, Sort, Top, Normalize, Smooth or Cumulative is set.
+//
+// Examples:
+//
+//	{"filter": {"field": "value", "gt": 10}}
+//	{"aggregate": [{"op": "sum", "field": "value", "as": "total"}], "groupby": ["category"]}
+//	{"bin": {"maxbins": 20}, "field": "value", "as": "value_bin"}
+//	{"sort": [{"field": "total", "order": "descending"}]}
+//	{"top": 5, "field": "total"}
+//	{"normalize": "percentage", "field": "value"}
+//	{"smooth": {"method": "movingAverage", "window": 7}, "field": "value"}
+//	{"cumulative": true, "field": "value", "as": "running_total"}
+type Transform struct {
+	Filter     *Predicate    `json:"filter,omitempty"`
+	Aggregate  []AggregateOp `json:"aggregate,omitempty"`
+	GroupBy    []string      `json:"groupby,omitempty"`
+	Bin        *Bin          `json:"bin,omitempty"`
+	Sort       []SortField   `json:"sort,omitempty"`
+	Top        int           `json:"top,omitempty"`
+	Normalize  string        `json:"normalize,omitempty"` // percentage, fraction, zscore, minmax
+	Smooth     *Smooth       `json:"smooth,omitempty"`
+	Cumulative bool          `json:"cumulative,omitempty"`
+	Field      string        `json:"field,omitempty"`
+	As         string        `json:"as,omitempty"`
+}
+
+// UnmarshalJSON decodes a transform, leaving Bin nil for "bin": false
+func (t *Transform) UnmarshalJSON(data []byte) error {
+	type transform Transform
+	def := struct {
+		*transform
+		Bin json.RawMessage `json:"bin,omitempty"`
+	}{transform: (*transform)(t)}
+	if err := decodeStrict(data, &def); err != nil {
+		return err
+	}
+	bin, err := decodeBin(def.Bin)
+	if err != nil {
+		return err
+	}
+	t.Bin = bin
+	return nil
+}
+
+// Predicate is a field comparison used by filter transforms.
+// All set comparisons must hold for a row to be kept.
+type Predicate struct {
+	Field string        `json:"field"`
+	Equal interface{}   `json:"equal,omitempty"`
+	OneOf []interface{} `json:"oneOf,omitempty"`
+	GT    *float64      `json:"gt,omitempty"`
+	GTE   *float64      `json:"gte,omitempty"`
+	LT    *float64      `json:"lt,omitempty"`
+	LTE   *float64      `json:"lte,omitempty"`
+	Valid bool          `json:"valid,omitempty"` // Drop rows where the field is missing or not a number
+}
+
+// AggregateOp is one aggregation in an aggregate transform
+type AggregateOp struct {
+	Op    string `json:"op"` // sum, mean, average, median, min, max, count
+	Field string `json:"field,omitempty"`
+	As    string `json:"as,omitempty"`
+}
+
+// SortField orders rows by a field
+type SortField struct {
+	Field string `json:"field"`
+	Order string `json:"order,omitempty"` // ascending (default) or descending
+}
+
+// Smooth configures a smoothing transform
+type Smooth struct {
+	Method    string  `json:"method,omitempty"` // movingAverage (default), loess, exponential, savitzkyGolay
+	Window    int     `json:"window,omitempty"`
+	Bandwidth float64 `json:"bandwidth,omitempty"`
+	Alpha     float64 `json:"alpha,omitempty"`
+	PolyOrder int     `json:"polyOrder,omitempty"` // Savitzky-Golay polynomial degree
+}
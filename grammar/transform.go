@@ -0,0 +1,360 @@
+package grammar
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/SCKelemen/dataviz/transforms"
+)
+
+// ApplyTransforms runs the transform steps over rows in order and returns the
+// resulting rows. The input rows are not modified.
+func ApplyTransforms(rows []Row, steps []Transform) ([]Row, error) {
+	out := rows
+	for i, step := range steps {
+		var err error
+		out, err = applyTransform(out, step)
+		if err != nil {
+			return nil, fmt.Errorf("transform %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+// applyTransform runs a single transform step
+func applyTransform(rows []Row, t Transform) ([]Row, error) {
+	switch {
+	case t.Filter != nil:
+		return filterRows(rows, t.Filter)
+	case len(t.Aggregate) > 0:
+		return aggregateRows(rows, t.Aggregate, t.GroupBy)
+	case t.Bin != nil:
+		return binRows(rows, t.Bin, t.Field, t.As)
+	case len(t.Sort) > 0:
+		return sortRows(rows, t.Sort), nil
+	case t.Top > 0:
+		if t.Field == "" {
+			return nil, fmt.Errorf("top requires a field")
+		}
+		points, err := toPoints(rows, t.Field)
+		if err != nil {
+			return nil, err
+		}
+		return fromPoints(transforms.Top(t.Top)(points), ""), nil
+	case t.Normalize != "":
+		tr, err := normalizeTransform(t.Normalize)
+		if err != nil {
+			return nil, err
+		}
+		return mapField(rows, t.Field, t.As, tr)
+	case t.Smooth != nil:
+		tr := transforms.Smooth(transforms.SmoothOptions{
+			Method:     t.Smooth.Method,
+			WindowSize: t.Smooth.Window,
+			Bandwidth:  t.Smooth.Bandwidth,
+			Alpha:      t.Smooth.Alpha,
+			PolyOrder:  t.Smooth.PolyOrder,
+		})
+		return mapField(rows, t.Field, t.As, tr)
+	case t.Cumulative:
+		return mapField(rows, t.Field, t.As, transforms.Cumulative())
+	}
+	return nil, fmt.Errorf("no operation set")
+}
+
+// toPoints converts rows to data points with Y taken from field.
+// Each point keeps its row in Data and its position in Index. Rows where
+// the field is null or absent become missing values.
+func toPoints(rows []Row, field string) ([]transforms.DataPoint, error) {
+	points := make([]transforms.DataPoint, len(rows))
+	for i, row := range rows {
+		y, ok := toFloat(row[field])
+		if row[field] == nil {
+			y, ok = math.NaN(), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("field %q is not numeric in row %d", field, i)
+		}
+		points[i] = transforms.DataPoint{
+			X:     float64(i),
+			Y:     y,
+			Value: y,
+			Index: i,
+			Data:  row,
+		}
+	}
+	return points, nil
+}
+
+// fromPoints converts data points back to rows, storing Y in field
+// (if set) on a copy of each point's row, or null if it is missing
+func fromPoints(points []transforms.DataPoint, field string) []Row {
+	rows := make([]Row, len(points))
+	for i, p := range points {
+		row := cloneRow(p.Data.(Row))
+		if field != "" {
+			row[field] = p.Y
+			if p.IsMissing() {
+				row[field] = nil
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// mapField applies a numeric transform to field, writing the result to as
+// (default: field itself)
+func mapField(rows []Row, field, as string, tr transforms.Transform) ([]Row, error) {
+	if field == "" {
+		return nil, fmt.Errorf("a field is required")
+	}
+	if as == "" {
+		as = field
+	}
+	points, err := toPoints(rows, field)
+	if err != nil {
+		return nil, err
+	}
+	return fromPoints(tr(points), as), nil
+}
+
+// normalizeTransform returns the transform for a normalize method
+func normalizeTransform(method string) (transforms.Transform, error) {
+	switch method {
+	case "percentage":
+		return transforms.NormalizePercentage(), nil
+	case "fraction":
+		return transforms.NormalizeFraction(), nil
+	case "zscore":
+		return transforms.NormalizeZScore(), nil
+	case "minmax":
+		return transforms.NormalizeMinMax(0, 1), nil
+	}
+	return nil, fmt.Errorf("unknown normalize method %q", method)
+}
+
+// filterRows keeps the rows matching the predicate
+func filterRows(rows []Row, p *Predicate) ([]Row, error) {
+	if p.Field == "" {
+		return nil, fmt.Errorf("filter requires a field")
+	}
+
+	points := make([]transforms.DataPoint, len(rows))
+	for i, row := range rows {
+		points[i] = transforms.DataPoint{Index: i, Data: row}
+	}
+
+	kept := transforms.Filter(func(d transforms.DataPoint) bool {
+		return p.test(d.Data.(Row))
+	})(points)
+
+	return fromPoints(kept, ""), nil
+}
+
+// test reports whether a row satisfies every comparison in the predicate
+func (p *Predicate) test(row Row) bool {
+	v, present := row[p.Field]
+
+	if p.Equal != nil && toString(v) != toString(p.Equal) {
+		return false
+	}
+	if len(p.OneOf) > 0 {
+		found := false
+		for _, candidate := range p.OneOf {
+			if toString(v) == toString(candidate) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	f, numeric := toFloat(v)
+	if p.Valid && (!present || !numeric) {
+		return false
+	}
+	if p.GT != nil && (!numeric || f <= *p.GT) {
+		return false
+	}
+	if p.GTE != nil && (!numeric || f < *p.GTE) {
+		return false
+	}
+	if p.LT != nil && (!numeric || f >= *p.LT) {
+		return false
+	}
+	if p.LTE != nil && (!numeric || f > *p.LTE) {
+		return false
+	}
+	return true
+}
+
+// aggregateFunc returns the transforms aggregate for an op name
+func aggregateFunc(op string) (transforms.AggregateFunc, error) {
+	switch op {
+	case "sum":
+		return transforms.Sum, nil
+	case "mean", "average":
+		return transforms.Mean, nil
+	case "median":
+		return transforms.Median, nil
+	case "min":
+		return transforms.Min, nil
+	case "max":
+		return transforms.Max, nil
+	case "count":
+		return transforms.Count, nil
+	}
+	return nil, fmt.Errorf("unknown aggregate op %q", op)
+}
+
+// aggregateRows groups rows by the groupby fields and computes each op per group.
+// Output rows hold the groupby fields and one field per op, in order of first appearance.
+func aggregateRows(rows []Row, ops []AggregateOp, groupby []string) ([]Row, error) {
+	keys := make([]string, len(rows))
+	first := make(map[string]Row)
+	var order []string
+	for i, row := range rows {
+		parts := make([]string, len(groupby))
+		for j, field := range groupby {
+			parts[j] = toString(row[field])
+		}
+		keys[i] = strings.Join(parts, "\x00")
+		if _, ok := first[keys[i]]; !ok {
+			first[keys[i]] = row
+			order = append(order, keys[i])
+		}
+	}
+
+	out := make(map[string]Row, len(order))
+	for _, key := range order {
+		row := make(Row, len(groupby)+len(ops))
+		for _, field := range groupby {
+			row[field] = first[key][field]
+		}
+		out[key] = row
+	}
+
+	for _, op := range ops {
+		fn, err := aggregateFunc(op.Op)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op != "count" && op.Field == "" {
+			return nil, fmt.Errorf("aggregate %q requires a field", op.Op)
+		}
+
+		as := op.As
+		if as == "" {
+			as = op.Op
+			if op.Field != "" {
+				as += "_" + op.Field
+			}
+		}
+
+		// Non-numeric values are skipped, except by count which counts rows
+		points := make([]transforms.DataPoint, 0, len(rows))
+		for i, row := range rows {
+			y, ok := toFloat(row[op.Field])
+			if !ok && op.Op != "count" {
+				continue
+			}
+			points = append(points, transforms.DataPoint{Label: keys[i], Y: y})
+		}
+
+		for _, p := range transforms.GroupBy(transforms.GroupOptions{By: "Label", Aggregate: fn})(points) {
+			out[p.Label][as] = p.Y
+		}
+	}
+
+	result := make([]Row, len(order))
+	for i, key := range order {
+		result[i] = out[key]
+	}
+	return result, nil
+}
+
+// binRows bins a numeric field and returns one row per bin with the bin
+// start in as, the bin end in as+"_end" and the number of rows in "count"
+func binRows(rows []Row, bin *Bin, field, as string) ([]Row, error) {
+	if field == "" {
+		return nil, fmt.Errorf("bin requires a field")
+	}
+	if as == "" {
+		as = "bin_" + field
+	}
+
+	points := make([]transforms.DataPoint, 0, len(rows))
+	for _, row := range rows {
+		if y, ok := toFloat(row[field]); ok {
+			points = append(points, transforms.DataPoint{Y: y})
+		}
+	}
+
+	bins := transforms.Bin(binOptions(bin, points))(points)
+
+	result := make([]Row, len(bins))
+	for i, b := range bins {
+		result[i] = Row{
+			as:          b.Y0,
+			as + "_end": b.Y1,
+			"count":     float64(b.Count),
+		}
+	}
+	return result, nil
+}
+
+// binOptions converts a bin definition to transforms options.
+// A fixed step is turned into explicit thresholds over the data extent.
+func binOptions(bin *Bin, points []transforms.DataPoint) transforms.BinOptions {
+	opts := transforms.BinOptions{Count: bin.MaxBins, Nice: bin.nice()}
+	if bin.Step <= 0 || len(points) == 0 {
+		return opts
+	}
+
+	lo, hi := points[0].Y, points[0].Y
+	for _, p := range points[1:] {
+		if p.Y < lo {
+			lo = p.Y
+		}
+		if p.Y > hi {
+			hi = p.Y
+		}
+	}
+
+	start := bin.Step * math.Floor(lo/bin.Step)
+	for edge := start; ; edge += bin.Step {
+		opts.Thresholds = append(opts.Thresholds, edge)
+		if edge >= hi {
+			break
+		}
+	}
+	if len(opts.Thresholds) < 2 {
+		opts.Thresholds = append(opts.Thresholds, start+bin.Step)
+	}
+	return opts
+}
+
+// sortRows stably sorts rows by the sort fields in priority order
+func sortRows(rows []Row, fields []SortField) []Row {
+	out := make([]Row, len(rows))
+	copy(out, rows)
+	sort.SliceStable(out, func(i, j int) bool {
+		for _, f := range fields {
+			c := compareValues(out[i][f.Field], out[j][f.Field])
+			if c == 0 {
+				continue
+			}
+			if f.Order == "descending" {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return out
+}
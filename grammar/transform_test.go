@@ -0,0 +1,197 @@
+package grammar
+
+import (
+	"math"
+	"testing"
+)
+
+func testRows() []Row {
+	return []Row{
+		{"category": "a", "value": 1.0},
+		{"category": "b", "value": 4.0},
+		{"category": "a", "value": 3.0},
+		{"category": "c", "value": "n/a"},
+		{"category": "b", "value": 2.0},
+	}
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func TestFilterTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		predicate Predicate
+		expected  int
+	}{
+		{"equal", Predicate{Field: "category", Equal: "a"}, 2},
+		{"oneOf", Predicate{Field: "category", OneOf: []interface{}{"a", "c"}}, 3},
+		{"gt", Predicate{Field: "value", GT: ptr(2)}, 2},
+		{"range", Predicate{Field: "value", GTE: ptr(2), LTE: ptr(3)}, 2},
+		{"valid", Predicate{Field: "value", Valid: true}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.predicate
+			rows, err := ApplyTransforms(testRows(), []Transform{{Filter: &p}})
+			if err != nil {
+				t.Fatalf("ApplyTransforms() error = %v", err)
+			}
+			if len(rows) != tt.expected {
+				t.Errorf("len(rows) = %d, expected %d", len(rows), tt.expected)
+			}
+		})
+	}
+}
+
+func TestAggregateTransform(t *testing.T) {
+	rows, err := ApplyTransforms(testRows(), []Transform{{
+		Aggregate: []AggregateOp{{Op: "sum", Field: "value", As: "total"}, {Op: "count"}},
+		GroupBy:   []string{"category"},
+	}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+
+	expected := []struct {
+		category string
+		total    interface{}
+		count    float64
+	}{
+		{"a", 4.0, 2},
+		{"b", 6.0, 2},
+		{"c", nil, 1},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("len(rows) = %d, expected %d", len(rows), len(expected))
+	}
+	for i, e := range expected {
+		if rows[i]["category"] != e.category || rows[i]["total"] != e.total || rows[i]["count"] != e.count {
+			t.Errorf("rows[%d] = %v, expected %v", i, rows[i], e)
+		}
+	}
+}
+
+func TestAggregateTransformUnknownOp(t *testing.T) {
+	_, err := ApplyTransforms(testRows(), []Transform{{Aggregate: []AggregateOp{{Op: "mode", Field: "value"}}}})
+	if err == nil {
+		t.Error("expected an error for an unknown aggregate op")
+	}
+}
+
+func TestBinTransform(t *testing.T) {
+	rows := []Row{{"v": 0.5}, {"v": 1.5}, {"v": 1.7}, {"v": 3.2}}
+	binned, err := ApplyTransforms(rows, []Transform{{Bin: &Bin{Step: 1}, Field: "v"}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+
+	total := 0.0
+	for _, row := range binned {
+		total += row["count"].(float64)
+		if row["bin_v_end"].(float64)-row["bin_v"].(float64) != 1 {
+			t.Errorf("bin %v has width != 1", row)
+		}
+	}
+	if total != 4 {
+		t.Errorf("total count = %v, expected 4", total)
+	}
+}
+
+func TestSortTransform(t *testing.T) {
+	rows, err := ApplyTransforms(testRows(), []Transform{{Sort: []SortField{
+		{Field: "category"},
+		{Field: "value", Order: "descending"},
+	}}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+
+	expected := []interface{}{3.0, 1.0, 4.0, 2.0, "n/a"}
+	for i, e := range expected {
+		if rows[i]["value"] != e {
+			t.Errorf("rows[%d][value] = %v, expected %v", i, rows[i]["value"], e)
+		}
+	}
+}
+
+func TestTopTransform(t *testing.T) {
+	rows := []Row{{"v": 1.0}, {"v": 5.0}, {"v": 3.0}}
+	top, err := ApplyTransforms(rows, []Transform{{Top: 2, Field: "v"}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+	if len(top) != 2 || top[0]["v"] != 5.0 || top[1]["v"] != 3.0 {
+		t.Errorf("Top(2) = %v, expected values 5 and 3", top)
+	}
+}
+
+func TestNormalizeAndCumulativeTransforms(t *testing.T) {
+	rows := []Row{{"v": 1.0}, {"v": 3.0}}
+
+	normalized, err := ApplyTransforms(rows, []Transform{{Normalize: "percentage", Field: "v", As: "pct"}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+	if math.Abs(normalized[1]["pct"].(float64)-75) > 1e-9 {
+		t.Errorf("pct = %v, expected 75", normalized[1]["pct"])
+	}
+	if rows[1]["pct"] != nil {
+		t.Error("input rows were modified")
+	}
+
+	cumulative, err := ApplyTransforms(rows, []Transform{{Cumulative: true, Field: "v"}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+	if cumulative[1]["v"] != 4.0 {
+		t.Errorf("cumulative = %v, expected 4", cumulative[1]["v"])
+	}
+}
+
+func TestTransformMissingValues(t *testing.T) {
+	rows := []Row{{"v": 1.0}, {"v": nil}, {}, {"v": 3.0}}
+
+	// Missing values add nothing and stay null
+	cumulative, err := ApplyTransforms(rows, []Transform{{Cumulative: true, Field: "v", As: "c"}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+	if cumulative[1]["c"] != nil || cumulative[2]["c"] != nil || cumulative[3]["c"] != 4.0 {
+		t.Errorf("cumulative = %v, expected 1, null, null, 4", cumulative)
+	}
+}
+
+func TestSmoothTransform(t *testing.T) {
+	rows := []Row{{"v": 1.0}, {"v": 4.0}, {"v": 9.0}, {"v": 16.0}, {"v": 25.0}}
+
+	// A quadratic fit leaves squares unchanged
+	smoothed, err := ApplyTransforms(rows, []Transform{{Smooth: &Smooth{Method: "savitzkyGolay", Window: 5, PolyOrder: 2}, Field: "v", As: "s"}})
+	if err != nil {
+		t.Fatalf("ApplyTransforms() error = %v", err)
+	}
+	for i, row := range smoothed {
+		if math.Abs(row["s"].(float64)-row["v"].(float64)) > 1e-9 {
+			t.Errorf("row %d: s = %v, expected %v", i, row["s"], row["v"])
+		}
+	}
+}
+
+func TestTransformErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		step Transform
+	}{
+		{"empty", Transform{}},
+		{"non-numeric", Transform{Cumulative: true, Field: "value"}},
+		{"unknown normalize", Transform{Normalize: "log", Field: "value"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ApplyTransforms(testRows(), []Transform{tt.step}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package grammar
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Row is a single data record, a map of field name to value
+type Row = map[string]interface{}
+
+// timeLayouts are the string formats accepted for temporal fields
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// toFloat converts a field value to a number.
+// Strings are parsed; nil, booleans and other types are not numeric.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toString converts a field value to its display string
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	case time.Time:
+		return s.Format("2006-01-02")
+	}
+	return fmt.Sprint(v)
+}
+
+// toTime converts a field value to a time.
+// Numbers are interpreted as Unix milliseconds, as in Vega-Lite.
+func toTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		for _, layout := range timeLayouts {
+			if parsed, err := time.Parse(layout, strings.TrimSpace(t)); err == nil {
+				return parsed, true
+			}
+		}
+		return time.Time{}, false
+	}
+	if ms, ok := toFloat(v); ok {
+		return time.UnixMilli(int64(ms)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// compareValues orders two field values: numbers numerically,
+// times chronologically, everything else by string
+func compareValues(a, b interface{}) int {
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Compare(tb)
+		}
+	}
+	return strings.Compare(toString(a), toString(b))
+}
+
+// cloneRow returns a shallow copy of a row
+func cloneRow(row Row) Row {
+	out := make(Row, len(row))
+	for k, v := range row {
+		out[k] = v
+	}
+	return out
+}
+
+// distinct returns the distinct string values of a field in first-appearance order
+func distinct(rows []Row, field string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, row := range rows {
+		s := toString(row[field])
+		if !seen[s] {
+			seen[s] = true
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// numbers returns the numeric values of a field, skipping rows where it is not a number
+func numbers(rows []Row, field string) []float64 {
+	values := make([]float64, 0, len(rows))
+	for _, row := range rows {
+		if f, ok := toFloat(row[field]); ok {
+			values = append(values, f)
+		}
+	}
+	return values
+}
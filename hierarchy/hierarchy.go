@@ -0,0 +1,218 @@
+// Package hierarchy computes layouts for tree-structured data, after
+// d3-hierarchy.
+//
+// New wraps a TreeNode tree in Nodes that carry depth, height and a parent
+// link; Sum and Sort then set values and child order once for every layout.
+// The layouts return plain geometry that any renderer can consume:
+//   - Treemap tiles rectangles with a choice of tilings: Squarify,
+//     Resquarify, Binary, Slice, Dice and SliceDice
+//   - Partition stacks rectangles one row per depth (icicle charts)
+//   - RadialPartition stacks arcs one ring per depth (sunburst charts)
+//   - Pack nests circles (circle packing charts)
+//   - Tree and Cluster place nodes for node-link diagrams: tidy trees and
+//     dendrograms
+//
+// Example:
+//
+//	root := hierarchy.New(tree).Sum(nil).Sort(hierarchy.ValueDescending)
+//	for _, r := range (hierarchy.Treemap{Width: 800, Height: 600, Tile: hierarchy.Binary}).Layout(root) {
+//		fmt.Println(r.Node.Data.Name, r.X0, r.Y0, r.Width(), r.Height())
+//	}
+package hierarchy
+
+import (
+	"math"
+	"sort"
+)
+
+// TreeNode represents a node in a hierarchical tree structure
+type TreeNode struct {
+	Name     string
+	Value    float64                // Size/weight of this node
+	Children []*TreeNode            // Child nodes (nil for leaf nodes)
+	Color    string                 // Optional custom color
+	Metadata map[string]interface{} // Optional metadata
+}
+
+// NewTreeNode creates a new tree node
+func NewTreeNode(name string, value float64) *TreeNode {
+	return &TreeNode{
+		Name:     name,
+		Value:    value,
+		Metadata: make(map[string]interface{}),
+	}
+}
+
+// AddChild adds a child node to this node
+func (n *TreeNode) AddChild(child *TreeNode) *TreeNode {
+	n.Children = append(n.Children, child)
+	return n
+}
+
+// SetColor sets the color for this node
+func (n *TreeNode) SetColor(color string) *TreeNode {
+	n.Color = color
+	return n
+}
+
+// Node is a TreeNode placed in a hierarchy. Treemap and Partition layouts
+// store each node's rectangle in X0, Y0, X1 and Y1, which is where custom
+// tilings write their results.
+type Node struct {
+	Data     *TreeNode
+	Parent   *Node
+	Children []*Node
+	Depth    int     // Distance from the root
+	Height   int     // Greatest distance to a descendant leaf
+	Value    float64 // Set by Sum
+
+	X0, Y0, X1, Y1 float64
+
+	rows *squarifyRows // Row layout kept by Resquarify
+}
+
+// New builds the hierarchy rooted at root, computing every node's depth
+// and height. A TreeNode that is its own ancestor is left out rather than
+// followed forever. New returns nil for a nil root.
+func New(root *TreeNode) *Node {
+	if root == nil {
+		return nil
+	}
+	onPath := make(map[*TreeNode]bool)
+	var build func(data *TreeNode, parent *Node) *Node
+	build = func(data *TreeNode, parent *Node) *Node {
+		n := &Node{Data: data, Parent: parent}
+		if parent != nil {
+			n.Depth = parent.Depth + 1
+		}
+		onPath[data] = true
+		for _, child := range data.Children {
+			if child == nil || onPath[child] {
+				continue
+			}
+			c := build(child, n)
+			n.Children = append(n.Children, c)
+			n.Height = max(n.Height, c.Height+1)
+		}
+		onPath[data] = false
+		return n
+	}
+	return build(root, nil)
+}
+
+// LeafValue returns a leaf's Value and zero for internal nodes, so sums
+// count each leaf once whatever values parents carry
+func LeafValue(n *TreeNode) float64 {
+	if len(n.Children) == 0 {
+		return n.Value
+	}
+	return 0
+}
+
+// Sum sets each node's Value to value(node) plus the values of its
+// children. A nil value function uses LeafValue. Sum can be called again
+// after the data changes; it returns n for chaining.
+func (n *Node) Sum(value func(*TreeNode) float64) *Node {
+	if value == nil {
+		value = LeafValue
+	}
+	n.EachAfter(func(node *Node) {
+		node.Value = value(node.Data)
+		for _, c := range node.Children {
+			node.Value += c.Value
+		}
+	})
+	return n
+}
+
+// Sort orders every node's children by less, keeping the input order of
+// equal children. It returns n for chaining.
+func (n *Node) Sort(less func(a, b *Node) bool) *Node {
+	n.EachBefore(func(node *Node) {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return less(node.Children[i], node.Children[j])
+		})
+	})
+	return n
+}
+
+// ValueDescending orders nodes largest value first, the usual order for
+// treemaps and packings
+func ValueDescending(a, b *Node) bool {
+	return a.Value > b.Value
+}
+
+// EachBefore calls fn for n and its descendants, parents before children
+func (n *Node) EachBefore(fn func(*Node)) {
+	fn(n)
+	for _, c := range n.Children {
+		c.EachBefore(fn)
+	}
+}
+
+// EachAfter calls fn for n and its descendants, children before parents
+func (n *Node) EachAfter(fn func(*Node)) {
+	for _, c := range n.Children {
+		c.EachAfter(fn)
+	}
+	fn(n)
+}
+
+// Descendants returns n and its descendants, parents before children
+func (n *Node) Descendants() []*Node {
+	var nodes []*Node
+	n.EachBefore(func(node *Node) {
+		nodes = append(nodes, node)
+	})
+	return nodes
+}
+
+// Leaves returns the leaves under n in order
+func (n *Node) Leaves() []*Node {
+	var leaves []*Node
+	n.EachBefore(func(node *Node) {
+		if len(node.Children) == 0 {
+			leaves = append(leaves, node)
+		}
+	})
+	return leaves
+}
+
+// Ancestors returns n, its parent and so on up to the root
+func (n *Node) Ancestors() []*Node {
+	var nodes []*Node
+	for node := n; node != nil; node = node.Parent {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// Rect is a node's rectangle from a Treemap or Partition layout
+type Rect struct {
+	X0, Y0, X1, Y1 float64
+	Node           *Node
+}
+
+// Width returns the rectangle's width
+func (r Rect) Width() float64 {
+	return r.X1 - r.X0
+}
+
+// Height returns the rectangle's height
+func (r Rect) Height() float64 {
+	return r.Y1 - r.Y0
+}
+
+// rects collects the rectangles of n and its descendants, rounding them to
+// whole pixels if asked
+func rects(n *Node, round bool) []Rect {
+	var result []Rect
+	n.EachBefore(func(node *Node) {
+		if round {
+			node.X0, node.Y0 = math.Round(node.X0), math.Round(node.Y0)
+			node.X1, node.Y1 = math.Round(node.X1), math.Round(node.Y1)
+		}
+		result = append(result, Rect{X0: node.X0, Y0: node.Y0, X1: node.X1, Y1: node.Y1, Node: node})
+	})
+	return result
+}
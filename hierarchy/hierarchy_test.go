@@ -0,0 +1,134 @@
+package hierarchy
+
+import "testing"
+
+// testTree returns a two-level tree with leaf values 10 to 30
+func testTree() *TreeNode {
+	a := NewTreeNode("A", 0).
+		AddChild(NewTreeNode("A1", 10)).
+		AddChild(NewTreeNode("A2", 20)).
+		AddChild(NewTreeNode("A3", 15))
+	b := NewTreeNode("B", 0).
+		AddChild(NewTreeNode("B1", 25)).
+		AddChild(NewTreeNode("B2", 30))
+	return NewTreeNode("Root", 0).AddChild(a).AddChild(b)
+}
+
+// find returns the node for a name
+func find(root *Node, name string) *Node {
+	for _, n := range root.Descendants() {
+		if n.Data.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	deep := NewTreeNode("Root", 0)
+	for i, current := 0, deep; i < 5; i++ {
+		child := NewTreeNode("Level", 1)
+		current.AddChild(child)
+		current = child
+	}
+
+	tests := []struct {
+		name   string
+		tree   *TreeNode
+		height int
+		nodes  int
+	}{
+		{"leaf", NewTreeNode("Leaf", 10), 0, 1},
+		{"nested", testTree(), 2, 8},
+		{"deep", deep, 5, 6},
+	}
+	for _, tt := range tests {
+		root := New(tt.tree)
+		if root.Height != tt.height {
+			t.Errorf("%s: height %d, expected %d", tt.name, root.Height, tt.height)
+		}
+		nodes := root.Descendants()
+		if len(nodes) != tt.nodes {
+			t.Errorf("%s: %d nodes, expected %d", tt.name, len(nodes), tt.nodes)
+		}
+		for _, n := range nodes {
+			if n.Parent != nil && n.Depth != n.Parent.Depth+1 {
+				t.Errorf("%s: %s at depth %d under depth %d", tt.name, n.Data.Name, n.Depth, n.Parent.Depth)
+			}
+		}
+	}
+
+	if New(nil) != nil {
+		t.Error("expected nil for a nil root")
+	}
+}
+
+func TestNewCycle(t *testing.T) {
+	a := NewTreeNode("A", 0)
+	b := NewTreeNode("B", 1)
+	a.AddChild(b)
+	b.AddChild(a)
+
+	root := New(a)
+	if n := len(root.Descendants()); n != 2 {
+		t.Errorf("got %d nodes, expected the cycle cut after 2", n)
+	}
+}
+
+func TestSum(t *testing.T) {
+	tree := testTree()
+	tree.Value = 1000 // Ignored by the default sum
+	root := New(tree).Sum(nil)
+	if root.Value != 100 {
+		t.Errorf("root value %v, expected 100", root.Value)
+	}
+	if a := find(root, "A"); a.Value != 45 {
+		t.Errorf("A value %v, expected 45", a.Value)
+	}
+
+	// Counting leaves instead
+	root.Sum(func(n *TreeNode) float64 {
+		if len(n.Children) == 0 {
+			return 1
+		}
+		return 0
+	})
+	if root.Value != 5 {
+		t.Errorf("root counts %v leaves, expected 5", root.Value)
+	}
+}
+
+func TestSort(t *testing.T) {
+	root := New(testTree()).Sum(nil).Sort(ValueDescending)
+	var names []string
+	for _, leaf := range root.Leaves() {
+		names = append(names, leaf.Data.Name)
+	}
+	expected := []string{"B2", "B1", "A2", "A3", "A1"}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("leaves %v, expected %v", names, expected)
+		}
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	root := New(testTree())
+	path := find(root, "B1").Ancestors()
+	if len(path) != 3 || path[0].Data.Name != "B1" || path[1].Data.Name != "B" || path[2] != root {
+		t.Errorf("unexpected ancestors of B1: %d nodes", len(path))
+	}
+}
+
+func TestEachAfter(t *testing.T) {
+	root := New(testTree())
+	seen := make(map[*Node]bool)
+	root.EachAfter(func(n *Node) {
+		for _, c := range n.Children {
+			if !seen[c] {
+				t.Errorf("%s visited before its child %s", n.Data.Name, c.Data.Name)
+			}
+		}
+		seen[n] = true
+	})
+}
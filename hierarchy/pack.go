@@ -0,0 +1,382 @@
+package hierarchy
+
+import "math"
+
+// Circle is a node's circle from a Pack layout
+type Circle struct {
+	X, Y, R float64
+	Node    *Node
+}
+
+// Pack lays out a hierarchy as nested circles, following d3-hierarchy's
+// pack layout. Leaf areas are proportional to their values; siblings are
+// packed tightly in order with the front-chain algorithm of Wang et al.
+// and each parent is the smallest circle enclosing its children. The root
+// fills the largest circle centered in a width by height area. Values must
+// be set with Sum first, and sorting children largest first packs best.
+type Pack struct {
+	Width   float64
+	Height  float64
+	Padding float64 // Pixels between siblings and between each circle and its parent
+}
+
+// Layout positions root and its descendants and returns their circles,
+// parents before children
+func (p Pack) Layout(root *Node) []Circle {
+	if root == nil {
+		return nil
+	}
+
+	var build func(node *Node) *packNode
+	build = func(node *Node) *packNode {
+		pn := &packNode{node: node}
+		if len(node.Children) == 0 {
+			pn.r = math.Sqrt(math.Max(0, node.Value))
+		}
+		for _, child := range node.Children {
+			pn.children = append(pn.children, build(child))
+		}
+		return pn
+	}
+	tree := build(root)
+
+	// Pack without padding to find the scale, then with the padding
+	// converted to layout units. Padding grows the root and so shrinks the
+	// scale, so repeat until the padding converges to padding pixels.
+	random := newPackRandom()
+	tree.packChildren(0, random)
+	size := math.Min(p.Width, p.Height)
+	if p.Padding > 0 && tree.r > 0 {
+		pad := 0.0
+		for i := 0; i < 10; i++ {
+			next := p.Padding * tree.r / size
+			if math.Abs(next-pad) <= 1e-12*next {
+				break
+			}
+			pad = next
+			tree.packChildren(pad, random)
+		}
+	}
+
+	k := 0.0
+	if tree.r > 0 {
+		k = size / (2 * tree.r)
+	}
+	var circles []Circle
+	var place func(pn *packNode, x, y float64)
+	place = func(pn *packNode, x, y float64) {
+		circles = append(circles, Circle{X: x, Y: y, R: pn.r * k, Node: pn.node})
+		for _, child := range pn.children {
+			place(child, x+child.x*k, y+child.y*k)
+		}
+	}
+	place(tree, p.Width/2, p.Height/2)
+	return circles
+}
+
+// packCircle is a circle in layout units
+type packCircle struct {
+	x, y, r float64
+}
+
+// packNode is a hierarchy node being packed. Its position is relative to
+// its parent's center.
+type packNode struct {
+	packCircle
+	node     *Node
+	children []*packNode
+}
+
+// packChildren packs each node's children, deepest first, and sets the
+// node's radius to enclose them with the given padding
+func (p *packNode) packChildren(padding float64, random func() float64) {
+	if len(p.children) == 0 {
+		return
+	}
+	circles := make([]*packCircle, len(p.children))
+	for i, child := range p.children {
+		child.packChildren(padding, random)
+		child.r += padding
+		circles[i] = &child.packCircle
+	}
+	e := packSiblings(circles, random)
+	for _, child := range p.children {
+		child.r -= padding
+	}
+	p.r = e + padding
+}
+
+// newPackRandom returns d3's linear congruential generator, so packings
+// are deterministic
+func newPackRandom() func() float64 {
+	s := uint64(1)
+	return func() float64 {
+		s = (1664525*s + 1013904223) % 4294967296
+		return float64(s) / 4294967296
+	}
+}
+
+// packSiblings places circles tangent to one another around the origin
+// using a front chain: each circle is placed tangent to the pair on the
+// chain closest to the centroid, and the chain is cut back past any circle
+// it would overlap. The circles are then centered on their smallest
+// enclosing circle, whose radius is returned.
+func packSiblings(circles []*packCircle, random func() float64) float64 {
+	n := len(circles)
+	if n == 0 {
+		return 0
+	}
+
+	// Place the first circle
+	a := circles[0]
+	a.x, a.y = 0, 0
+	if n == 1 {
+		return a.r
+	}
+
+	// Place the second circle
+	b := circles[1]
+	a.x, b.x, b.y = -b.r, a.r, 0
+	if n == 2 {
+		return a.r + b.r
+	}
+
+	// Place the third circle
+	placeTangent(b, a, circles[2])
+
+	// Initialize the front chain using the first three circles
+	type chainNode struct {
+		c              *packCircle
+		next, previous *chainNode
+	}
+	na, nb, nc := &chainNode{c: a}, &chainNode{c: b}, &chainNode{c: circles[2]}
+	na.next, nc.previous = nb, nb
+	nb.next, na.previous = nc, nc
+	nc.next, nb.previous = na, na
+
+	score := func(node *chainNode) float64 {
+		a, b := node.c, node.next.c
+		ab := a.r + b.r
+		dx := (a.x*b.r + b.x*a.r) / ab
+		dy := (a.y*b.r + b.y*a.r) / ab
+		return dx*dx + dy*dy
+	}
+
+pack:
+	for i := 3; i < n; i++ {
+		c := circles[i]
+		placeTangent(na.c, nb.c, c)
+		node := &chainNode{c: c}
+
+		// Find the closest intersecting circle on the front chain, if any,
+		// by distance along the chain in either direction
+		j, k := nb.next, na.previous
+		sj, sk := nb.c.r, na.c.r
+		for {
+			if sj <= sk {
+				if circlesIntersect(j.c, c) {
+					nb = j
+					na.next, nb.previous = nb, na
+					i--
+					continue pack
+				}
+				sj += j.c.r
+				j = j.next
+			} else {
+				if circlesIntersect(k.c, c) {
+					na = k
+					na.next, nb.previous = nb, na
+					i--
+					continue pack
+				}
+				sk += k.c.r
+				k = k.previous
+			}
+			if j == k.next {
+				break
+			}
+		}
+
+		// Insert the new circle between a and b
+		node.previous, node.next = na, nb
+		na.next, nb.previous = node, node
+		nb = node
+
+		// Find the chain pair closest to the centroid
+		best := score(na)
+		for node = node.next; node != nb; node = node.next {
+			if s := score(node); s < best {
+				na, best = node, s
+			}
+		}
+		nb = na.next
+	}
+
+	// Enclose the front chain and center the circles on it
+	chain := []*packCircle{nb.c}
+	for node := nb.next; node != nb; node = node.next {
+		chain = append(chain, node.c)
+	}
+	e := encloseCircles(chain, random)
+	for _, c := range circles {
+		c.x -= e.x
+		c.y -= e.y
+	}
+	return e.r
+}
+
+// placeTangent places c tangent to both a and b
+func placeTangent(b, a, c *packCircle) {
+	dx, dy := b.x-a.x, b.y-a.y
+	d2 := dx*dx + dy*dy
+	if d2 == 0 {
+		c.x, c.y = a.x+c.r, a.y
+		return
+	}
+	a2 := (a.r + c.r) * (a.r + c.r)
+	b2 := (b.r + c.r) * (b.r + c.r)
+	if a2 > b2 {
+		x := (d2 + b2 - a2) / (2 * d2)
+		y := math.Sqrt(math.Max(0, b2/d2-x*x))
+		c.x = b.x - x*dx - y*dy
+		c.y = b.y - x*dy + y*dx
+	} else {
+		x := (d2 + a2 - b2) / (2 * d2)
+		y := math.Sqrt(math.Max(0, a2/d2-x*x))
+		c.x = a.x + x*dx - y*dy
+		c.y = a.y + x*dy + y*dx
+	}
+}
+
+// circlesIntersect reports whether two circles overlap by more than a
+// rounding error
+func circlesIntersect(a, b *packCircle) bool {
+	dr := a.r + b.r - 1e-6
+	dx, dy := b.x-a.x, b.y-a.y
+	return dr > 0 && dr*dr > dx*dx+dy*dy
+}
+
+// encloseCircles returns the smallest circle enclosing the given circles,
+// using Welzl's algorithm over a shuffled copy
+func encloseCircles(circles []*packCircle, random func() float64) packCircle {
+	shuffled := append([]*packCircle(nil), circles...)
+	for m := len(shuffled); m > 0; {
+		i := int(random() * float64(m))
+		m--
+		shuffled[m], shuffled[i] = shuffled[i], shuffled[m]
+	}
+
+	var basis []packCircle
+	var e packCircle
+	found := false
+	for i := 0; i < len(shuffled); {
+		p := *shuffled[i]
+		if found && enclosesWeak(e, p) {
+			i++
+			continue
+		}
+		basis = extendBasis(basis, p)
+		e = encloseBasis(basis)
+		found = true
+		i = 0
+	}
+	return e
+}
+
+// extendBasis returns the smallest set of circles, from the basis plus p,
+// whose enclosing circle encloses the basis and p
+func extendBasis(basis []packCircle, p packCircle) []packCircle {
+	if enclosesWeakAll(p, basis) {
+		return []packCircle{p}
+	}
+	for _, b := range basis {
+		if enclosesNot(p, b) && enclosesWeakAll(encloseBasis2(b, p), basis) {
+			return []packCircle{b, p}
+		}
+	}
+	for i := 0; i < len(basis)-1; i++ {
+		for j := i + 1; j < len(basis); j++ {
+			bi, bj := basis[i], basis[j]
+			if enclosesNot(encloseBasis2(bi, bj), p) &&
+				enclosesNot(encloseBasis2(bi, p), bj) &&
+				enclosesNot(encloseBasis2(bj, p), bi) &&
+				enclosesWeakAll(encloseBasis3(bi, bj, p), basis) {
+				return []packCircle{bi, bj, p}
+			}
+		}
+	}
+	// Unreachable for exact arithmetic; fall back to the new circle
+	return []packCircle{p}
+}
+
+// enclosesNot reports whether a fails to enclose b
+func enclosesNot(a, b packCircle) bool {
+	dr := a.r - b.r
+	dx, dy := b.x-a.x, b.y-a.y
+	return dr < 0 || dr*dr < dx*dx+dy*dy
+}
+
+// enclosesWeak reports whether a encloses b, allowing for rounding error
+func enclosesWeak(a, b packCircle) bool {
+	dr := a.r - b.r + math.Max(math.Max(a.r, b.r), 1)*1e-9
+	dx, dy := b.x-a.x, b.y-a.y
+	return dr > 0 && dr*dr > dx*dx+dy*dy
+}
+
+// enclosesWeakAll reports whether a encloses every circle of the basis
+func enclosesWeakAll(a packCircle, basis []packCircle) bool {
+	for _, b := range basis {
+		if !enclosesWeak(a, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// encloseBasis returns the circle enclosing one, two or three circles and
+// tangent to each
+func encloseBasis(basis []packCircle) packCircle {
+	switch len(basis) {
+	case 1:
+		return basis[0]
+	case 2:
+		return encloseBasis2(basis[0], basis[1])
+	}
+	return encloseBasis3(basis[0], basis[1], basis[2])
+}
+
+// encloseBasis2 returns the smallest circle enclosing two circles
+func encloseBasis2(a, b packCircle) packCircle {
+	x21, y21, r21 := b.x-a.x, b.y-a.y, b.r-a.r
+	l := math.Hypot(x21, y21)
+	return packCircle{
+		x: (a.x + b.x + x21/l*r21) / 2,
+		y: (a.y + b.y + y21/l*r21) / 2,
+		r: (l + a.r + b.r) / 2,
+	}
+}
+
+// encloseBasis3 returns the circle internally tangent to three circles
+func encloseBasis3(a, b, c packCircle) packCircle {
+	a2, a3 := a.x-b.x, a.x-c.x
+	b2, b3 := a.y-b.y, a.y-c.y
+	c2, c3 := b.r-a.r, c.r-a.r
+	d1 := a.x*a.x + a.y*a.y - a.r*a.r
+	d2 := d1 - b.x*b.x - b.y*b.y + b.r*b.r
+	d3 := d1 - c.x*c.x - c.y*c.y + c.r*c.r
+	ab := a3*b2 - a2*b3
+	xa := (b2*d3-b3*d2)/(ab*2) - a.x
+	xb := (b3*c2 - b2*c3) / ab
+	ya := (a3*d2-a2*d3)/(ab*2) - a.y
+	yb := (a2*c3 - a3*c2) / ab
+	A := xb*xb + yb*yb - 1
+	B := 2 * (a.r + xa*xb + ya*yb)
+	C := xa*xa + ya*ya - a.r*a.r
+	var r float64
+	if math.Abs(A) > 1e-6 {
+		r = -(B + math.Sqrt(B*B-4*A*C)) / (2 * A)
+	} else {
+		r = -C / B
+	}
+	return packCircle{x: a.x + xa + xb*r, y: a.y + ya + yb*r, r: r}
+}
@@ -0,0 +1,71 @@
+package hierarchy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPack(t *testing.T) {
+	root := New(testTree()).Sum(nil).Sort(ValueDescending)
+	circles := Pack{Width: 400, Height: 300, Padding: 2}.Layout(root)
+	if len(circles) != 8 {
+		t.Fatalf("got %d circles, expected 8", len(circles))
+	}
+	if c := circles[0]; c.X != 200 || c.Y != 150 || math.Abs(c.R-150) > eps {
+		t.Errorf("root circle %+v, expected radius 150 at the center", c)
+	}
+	at := make(map[*Node]Circle)
+	for _, c := range circles {
+		at[c.Node] = c
+	}
+	for _, c := range circles[1:] {
+		p := at[c.Node.Parent]
+		if d := math.Hypot(c.X-p.X, c.Y-p.Y); d+c.R+2 > p.R+1e-6 {
+			t.Errorf("%s pokes out of %s", c.Node.Data.Name, p.Node.Data.Name)
+		}
+	}
+}
+
+func TestPackSiblings(t *testing.T) {
+	tests := []struct {
+		name     string
+		radii    []float64
+		expected float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{5}, 5},
+		{"pair", []float64{3, 2}, 5},
+		{"three equal", []float64{1, 1, 1}, 1 + 2/math.Sqrt(3)},
+		{"four equal", []float64{1, 1, 1, 1}, 1 + math.Sqrt(3)}, // A rhombus
+	}
+	for _, tt := range tests {
+		circles := make([]*packCircle, len(tt.radii))
+		for i, r := range tt.radii {
+			circles[i] = &packCircle{r: r}
+		}
+		r := packSiblings(circles, newPackRandom())
+		if math.Abs(r-tt.expected) > 1e-9 {
+			t.Errorf("%s: enclosing radius %v, expected %v", tt.name, r, tt.expected)
+		}
+		for i, a := range circles {
+			// Every circle lies inside the enclosing circle at the origin
+			if d := math.Hypot(a.x, a.y) + a.r; d > r+1e-9 {
+				t.Errorf("%s: circle %d reaches %v, outside %v", tt.name, i, d, r)
+			}
+			for _, b := range circles[:i] {
+				if math.Hypot(a.x-b.x, a.y-b.y) < a.r+b.r-1e-9 {
+					t.Errorf("%s: circles overlap", tt.name)
+				}
+			}
+		}
+	}
+}
+
+func TestEncloseCircles(t *testing.T) {
+	// Two circles side by side, and a small one inside their enclosure
+	circles := []*packCircle{{x: -2, y: 0, r: 1}, {x: 3, y: 0, r: 2}, {x: 1, y: 1, r: 0.5}}
+	e := encloseCircles(circles, newPackRandom())
+	if math.Abs(e.x-1) > 1e-9 || math.Abs(e.y) > 1e-9 || math.Abs(e.r-4) > 1e-9 {
+		t.Errorf("Expected circle at (1, 0) with radius 4, got %+v", e)
+	}
+}
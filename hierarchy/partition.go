@@ -0,0 +1,71 @@
+package hierarchy
+
+import "math"
+
+// Partition lays out a hierarchy as adjacent rectangles, one row per
+// depth from the top of a width by height area, each node spanning the
+// width of its descendants (an icicle chart). Swap the axes of the result
+// for rows that run left to right. Values must be set with Sum first.
+type Partition struct {
+	Width   float64
+	Height  float64
+	Padding float64 // Gap after each rectangle, to the right and below
+	Round   bool    // Round coordinates to whole pixels
+}
+
+// Layout positions root and its descendants and returns their rectangles,
+// parents before children
+func (p Partition) Layout(root *Node) []Rect {
+	if root == nil {
+		return nil
+	}
+	levels := float64(root.Height + 1)
+	root.X0, root.Y0, root.X1, root.Y1 = 0, 0, p.Width, p.Height/levels
+
+	root.EachBefore(func(node *Node) {
+		// Place the children below the unpadded rectangle, then pad it
+		if len(node.Children) > 0 {
+			y0 := p.Height * float64(node.Depth+1) / levels
+			y1 := p.Height * float64(node.Depth+2) / levels
+			Dice(node, node.X0, y0, node.X1, y1)
+		}
+		node.X0, node.Y0, node.X1, node.Y1 = clampRect(node.X0, node.Y0, node.X1-p.Padding, node.Y1-p.Padding)
+	})
+	return rects(root, p.Round)
+}
+
+// Arc is a node's ring segment from a RadialPartition layout. Angles are
+// in radians clockwise from 12 o'clock.
+type Arc struct {
+	StartAngle  float64
+	EndAngle    float64
+	InnerRadius float64
+	OuterRadius float64
+	Node        *Node
+}
+
+// RadialPartition lays out a hierarchy as rings of arcs, one ring per
+// depth from the inside out, each node spanning the angle of its
+// descendants (a sunburst chart). Values must be set with Sum first.
+type RadialPartition struct {
+	InnerRadius float64 // Inner radius of the root's ring
+	OuterRadius float64 // Outer radius of the deepest ring
+	StartAngle  float64 // Where the root starts, in radians clockwise from 12 o'clock
+}
+
+// Layout positions root and its descendants and returns their arcs,
+// parents before children
+func (r RadialPartition) Layout(root *Node) []Arc {
+	rects := Partition{Width: 2 * math.Pi, Height: r.OuterRadius - r.InnerRadius}.Layout(root)
+	arcs := make([]Arc, len(rects))
+	for i, rect := range rects {
+		arcs[i] = Arc{
+			StartAngle:  r.StartAngle + rect.X0,
+			EndAngle:    r.StartAngle + rect.X1,
+			InnerRadius: r.InnerRadius + rect.Y0,
+			OuterRadius: r.InnerRadius + rect.Y1,
+			Node:        rect.Node,
+		}
+	}
+	return arcs
+}
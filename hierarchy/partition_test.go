@@ -0,0 +1,42 @@
+package hierarchy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	root := New(testTree()).Sum(nil)
+	rects := Partition{Width: 300, Height: 300, Padding: 1}.Layout(root)
+	if len(rects) != 8 {
+		t.Fatalf("got %d rectangles, expected 8", len(rects))
+	}
+	for _, r := range rects {
+		// One 100px row per depth, less the padding
+		if r.Y0 != float64(r.Node.Depth)*100 || r.Y1 != r.Y0+99 {
+			t.Errorf("%s spans rows %v-%v", r.Node.Data.Name, r.Y0, r.Y1)
+		}
+		if width := r.Width() + 1; math.Abs(width-r.Node.Value*3) > eps {
+			t.Errorf("%s is %v wide, expected %v", r.Node.Data.Name, width, r.Node.Value*3)
+		}
+	}
+	if b := find(root, "B"); b.X0 != 135 {
+		t.Errorf("B starts at %v, expected after A at 135", b.X0)
+	}
+}
+
+func TestRadialPartition(t *testing.T) {
+	root := New(testTree()).Sum(nil)
+	arcs := RadialPartition{InnerRadius: 10, OuterRadius: 160, StartAngle: math.Pi}.Layout(root)
+	for _, a := range arcs {
+		if a.InnerRadius != 10+float64(a.Node.Depth)*50 || a.OuterRadius != a.InnerRadius+50 {
+			t.Errorf("%s spans radii %v-%v", a.Node.Data.Name, a.InnerRadius, a.OuterRadius)
+		}
+		if sweep := a.EndAngle - a.StartAngle; math.Abs(sweep-2*math.Pi*a.Node.Value/100) > eps {
+			t.Errorf("%s sweeps %v", a.Node.Data.Name, sweep)
+		}
+	}
+	if arcs[0].StartAngle != math.Pi {
+		t.Errorf("root starts at %v, expected π", arcs[0].StartAngle)
+	}
+}
@@ -0,0 +1,312 @@
+package hierarchy
+
+// Point is a node's position from a Tree or Cluster layout
+type Point struct {
+	X, Y float64
+	Node *Node
+}
+
+// DefaultSeparation spaces siblings one unit apart and cousins two, the
+// default for Tree and Cluster
+func DefaultSeparation(a, b *Node) float64 {
+	if a.Parent == b.Parent {
+		return 1
+	}
+	return 2
+}
+
+// Tree lays out a hierarchy as a tidy node-link tree with the
+// Reingold–Tilford algorithm, in the linear-time form of Buchheim et al.:
+// parents are centered over their children, subtrees are packed as close
+// as the separation allows and identical subtrees get identical shapes.
+// Breadth runs along X from 0 to Width and depth along Y, from the root at
+// 0 to the deepest nodes at Height.
+type Tree struct {
+	Width      float64
+	Height     float64
+	Separation func(a, b *Node) float64 // Gap between adjacent nodes, in units of the narrowest (default: DefaultSeparation)
+}
+
+// tidyNode carries the Buchheim algorithm's state for one node
+type tidyNode struct {
+	node     *Node
+	parent   *tidyNode
+	children []*tidyNode
+	index    int // Position among its siblings
+
+	ancestor        *tidyNode // Greatest distinct ancestor, for apportioning shifts
+	defaultAncestor *tidyNode // Set on parents while their children are walked
+	thread          *tidyNode // Next node on the contour when it has no children
+	prelim          float64
+	mod             float64
+	change, shift   float64
+	x               float64
+}
+
+// Layout positions root and its descendants and returns their points,
+// parents before children
+func (t Tree) Layout(root *Node) []Point {
+	if root == nil {
+		return nil
+	}
+	separation := t.Separation
+	if separation == nil {
+		separation = DefaultSeparation
+	}
+	sep := func(a, b *tidyNode) float64 {
+		return separation(a.node, b.node)
+	}
+
+	var build func(node *Node, parent *tidyNode, index int) *tidyNode
+	build = func(node *Node, parent *tidyNode, index int) *tidyNode {
+		v := &tidyNode{node: node, parent: parent, index: index}
+		v.ancestor = v
+		for i, child := range node.Children {
+			v.children = append(v.children, build(child, v, i))
+		}
+		return v
+	}
+	// The root hangs from a placeholder so every node has a parent
+	top := &tidyNode{}
+	tree := build(root, top, 0)
+	top.children = []*tidyNode{tree}
+
+	// Walk up the tree computing preliminary positions, then down adding
+	// each node's accumulated modifiers
+	var firstWalk func(v *tidyNode)
+	firstWalk = func(v *tidyNode) {
+		for _, c := range v.children {
+			firstWalk(c)
+		}
+		siblings := v.parent.children
+		var w *tidyNode
+		if v.index > 0 {
+			w = siblings[v.index-1]
+		}
+		if len(v.children) > 0 {
+			executeShifts(v)
+			midpoint := (v.children[0].prelim + v.children[len(v.children)-1].prelim) / 2
+			if w != nil {
+				v.prelim = w.prelim + sep(v, w)
+				v.mod = v.prelim - midpoint
+			} else {
+				v.prelim = midpoint
+			}
+		} else if w != nil {
+			v.prelim = w.prelim + sep(v, w)
+		}
+		ancestor := v.parent.defaultAncestor
+		if ancestor == nil {
+			ancestor = siblings[0]
+		}
+		v.parent.defaultAncestor = apportion(v, w, ancestor, sep)
+	}
+	firstWalk(tree)
+	top.mod = -tree.prelim
+
+	var secondWalk func(v *tidyNode)
+	secondWalk = func(v *tidyNode) {
+		v.x = v.prelim + v.parent.mod
+		v.mod += v.parent.mod
+		for _, c := range v.children {
+			secondWalk(c)
+		}
+	}
+	secondWalk(tree)
+
+	// Scale the extent, plus half a separation at each end, to the width
+	var nodes []*tidyNode
+	var collect func(v *tidyNode)
+	collect = func(v *tidyNode) {
+		nodes = append(nodes, v)
+		for _, c := range v.children {
+			collect(c)
+		}
+	}
+	collect(tree)
+	left, right := tree, tree
+	for _, v := range nodes {
+		if v.x < left.x {
+			left = v
+		}
+		if v.x > right.x {
+			right = v
+		}
+	}
+	s := 1.0
+	if left != right {
+		s = sep(left, right) / 2
+	}
+	tx := s - left.x
+	kx := t.Width / (right.x + s + tx)
+	ky := t.Height / float64(max(root.Height, 1))
+
+	points := make([]Point, len(nodes))
+	for i, v := range nodes {
+		points[i] = Point{X: (v.x + tx) * kx, Y: float64(v.node.Depth-root.Depth) * ky, Node: v.node}
+	}
+	return points
+}
+
+// nextLeft returns the next node on the left contour of v's subtree
+func nextLeft(v *tidyNode) *tidyNode {
+	if len(v.children) > 0 {
+		return v.children[0]
+	}
+	return v.thread
+}
+
+// nextRight returns the next node on the right contour of v's subtree
+func nextRight(v *tidyNode) *tidyNode {
+	if len(v.children) > 0 {
+		return v.children[len(v.children)-1]
+	}
+	return v.thread
+}
+
+// moveSubtree shifts the subtree at wp right, spreading the shift over
+// the subtrees between wm and wp
+func moveSubtree(wm, wp *tidyNode, shift float64) {
+	change := shift / float64(wp.index-wm.index)
+	wp.change -= change
+	wp.shift += shift
+	wm.change += change
+	wp.prelim += shift
+	wp.mod += shift
+}
+
+// executeShifts applies the spread shifts recorded by moveSubtree to v's
+// children
+func executeShifts(v *tidyNode) {
+	shift, change := 0.0, 0.0
+	for i := len(v.children) - 1; i >= 0; i-- {
+		w := v.children[i]
+		w.prelim += shift
+		w.mod += shift
+		change += w.change
+		shift += w.shift + change
+	}
+}
+
+// apportion pushes v's subtree right until its left contour clears the
+// right contours of its left siblings' subtrees, and returns the new
+// default ancestor
+func apportion(v, w, ancestor *tidyNode, sep func(a, b *tidyNode) float64) *tidyNode {
+	if w == nil {
+		return ancestor
+	}
+	vip, vop := v, v
+	vim, vom := w, v.parent.children[0]
+	sip, sop := vip.mod, vop.mod
+	sim, som := vim.mod, vom.mod
+	for {
+		vim, vip = nextRight(vim), nextLeft(vip)
+		if vim == nil || vip == nil {
+			break
+		}
+		vom = nextLeft(vom)
+		vop = nextRight(vop)
+		vop.ancestor = v
+		shift := vim.prelim + sim - vip.prelim - sip + sep(vim, vip)
+		if shift > 0 {
+			a := ancestor
+			if vim.ancestor.parent == v.parent {
+				a = vim.ancestor
+			}
+			moveSubtree(a, v, shift)
+			sip += shift
+			sop += shift
+		}
+		sim += vim.mod
+		sip += vip.mod
+		som += vom.mod
+		sop += vop.mod
+	}
+	if vim != nil && nextRight(vop) == nil {
+		vop.thread = vim
+		vop.mod += sim - sop
+	}
+	if vip != nil && nextLeft(vom) == nil {
+		vom.thread = vip
+		vom.mod += sip - som
+		ancestor = v
+	}
+	return ancestor
+}
+
+// Cluster lays out a hierarchy as a dendrogram: leaves are spaced evenly
+// along X from 0 to Width, each parent is centered over its children and
+// all leaves sit at the same depth, Y = Height, with the root at Y = 0.
+type Cluster struct {
+	Width      float64
+	Height     float64
+	Separation func(a, b *Node) float64 // Gap between adjacent leaves, in units of the narrowest (default: DefaultSeparation)
+}
+
+// Layout positions root and its descendants and returns their points,
+// parents before children
+func (c Cluster) Layout(root *Node) []Point {
+	if root == nil {
+		return nil
+	}
+	separation := c.Separation
+	if separation == nil {
+		separation = DefaultSeparation
+	}
+
+	// Place leaves left to right and parents at the mean of their
+	// children, with heights counted up from the leaves
+	x := make(map[*Node]float64)
+	y := make(map[*Node]float64)
+	var previous *Node
+	next := 0.0
+	root.EachAfter(func(node *Node) {
+		if len(node.Children) == 0 {
+			if previous != nil {
+				next += separation(node, previous)
+			}
+			x[node] = next
+			previous = node
+			return
+		}
+		for _, child := range node.Children {
+			x[node] += x[child]
+			y[node] = max(y[node], y[child]+1)
+		}
+		x[node] /= float64(len(node.Children))
+	})
+
+	leaves := root.Leaves()
+	left, right := leaves[0], leaves[len(leaves)-1]
+	x0 := x[left] - separation(left, right)/2
+	x1 := x[right] + separation(right, left)/2
+
+	var points []Point
+	root.EachBefore(func(node *Node) {
+		depth := 1.0
+		if y[root] > 0 {
+			depth = 1 - y[node]/y[root]
+		}
+		points = append(points, Point{X: (x[node] - x0) / (x1 - x0) * c.Width, Y: depth * c.Height, Node: node})
+	})
+	return points
+}
+
+// Prune hides the descendants of nodes more than depth levels below n, so
+// they lay out as leaves, and recomputes heights. The TreeNodes are left
+// untouched, so a pruned Node whose Data has children is a collapsed
+// subtree. It returns n for chaining.
+func (n *Node) Prune(depth int) *Node {
+	n.EachBefore(func(node *Node) {
+		if node.Depth-n.Depth >= depth {
+			node.Children = nil
+		}
+	})
+	n.EachAfter(func(node *Node) {
+		node.Height = 0
+		for _, c := range node.Children {
+			node.Height = max(node.Height, c.Height+1)
+		}
+	})
+	return n
+}
@@ -0,0 +1,106 @@
+package hierarchy
+
+import (
+	"math"
+	"testing"
+)
+
+// unevenTree returns a tree whose subtrees have different widths, so the
+// tidy layout must shift them apart
+func unevenTree() *TreeNode {
+	wide := NewTreeNode("wide", 0)
+	for _, name := range []string{"w1", "w2", "w3", "w4"} {
+		wide.AddChild(NewTreeNode(name, 1))
+	}
+	deep := NewTreeNode("deep", 0).AddChild(
+		NewTreeNode("d1", 0).AddChild(NewTreeNode("d11", 1)).AddChild(NewTreeNode("d12", 1)).AddChild(NewTreeNode("d13", 1)))
+	return NewTreeNode("root", 0).AddChild(NewTreeNode("leaf", 1)).AddChild(wide).AddChild(deep).AddChild(NewTreeNode("last", 1))
+}
+
+func TestTree(t *testing.T) {
+	root := New(unevenTree())
+	points := Tree{Width: 300, Height: 300}.Layout(root)
+	at := make(map[*Node]Point)
+	for _, p := range points {
+		at[p.Node] = p
+		if p.X < 0 || p.X > 300 {
+			t.Errorf("%s at x = %v, outside the width", p.Node.Data.Name, p.X)
+		}
+		if p.Y != float64(p.Node.Depth)*100 {
+			t.Errorf("%s at y = %v, expected %v", p.Node.Data.Name, p.Y, float64(p.Node.Depth)*100)
+		}
+	}
+
+	// Parents are centered over their first and last children
+	for _, p := range points {
+		if c := p.Node.Children; len(c) > 0 {
+			if mid := (at[c[0]].X + at[c[len(c)-1]].X) / 2; math.Abs(p.X-mid) > eps {
+				t.Errorf("%s at %v, expected centered at %v", p.Node.Data.Name, p.X, mid)
+			}
+		}
+	}
+
+	// Nodes on each level keep at least the sibling separation apart,
+	// measured by the narrowest gap
+	levels := make(map[int][]float64)
+	for _, p := range points {
+		levels[p.Node.Depth] = append(levels[p.Node.Depth], p.X)
+	}
+	unit := at[find(root, "w2")].X - at[find(root, "w1")].X
+	for depth, xs := range levels {
+		for i := 1; i < len(xs); i++ {
+			if xs[i]-xs[i-1] < unit-eps {
+				t.Errorf("depth %d: nodes %v apart, closer than %v", depth, xs[i]-xs[i-1], unit)
+			}
+		}
+	}
+}
+
+func TestTreeSingleNode(t *testing.T) {
+	points := Tree{Width: 200, Height: 100}.Layout(New(NewTreeNode("only", 1)))
+	if len(points) != 1 || points[0].X != 100 || points[0].Y != 0 {
+		t.Errorf("got %+v, expected one point at (100, 0)", points)
+	}
+}
+
+func TestCluster(t *testing.T) {
+	root := New(unevenTree())
+	points := Cluster{Width: 800, Height: 300}.Layout(root)
+	at := make(map[*Node]Point)
+	for _, p := range points {
+		at[p.Node] = p
+	}
+	for _, p := range points {
+		c := p.Node.Children
+		if len(c) == 0 {
+			if p.Y != 300 {
+				t.Errorf("leaf %s at y = %v, expected 300", p.Node.Data.Name, p.Y)
+			}
+			continue
+		}
+		mean := 0.0
+		for _, child := range c {
+			mean += at[child].X
+		}
+		if mean /= float64(len(c)); math.Abs(p.X-mean) > eps {
+			t.Errorf("%s at %v, expected the mean %v", p.Node.Data.Name, p.X, mean)
+		}
+	}
+	if at[root].Y != 0 {
+		t.Errorf("root at y = %v, expected 0", at[root].Y)
+	}
+	// Nine leaves with three cousin gaps and half a gap at each end span 12 units
+	if x := at[find(root, "leaf")].X; math.Abs(x-800.0/12/2) > eps {
+		t.Errorf("first leaf at %v, expected %v", x, 800.0/12/2)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	root := New(unevenTree()).Prune(1)
+	if root.Height != 1 || len(root.Descendants()) != 5 {
+		t.Errorf("pruned to height %d with %d nodes, expected 1 and 5", root.Height, len(root.Descendants()))
+	}
+	if wide := find(root, "wide"); len(wide.Children) != 0 || len(wide.Data.Children) != 4 {
+		t.Error("expected wide collapsed with its data intact")
+	}
+}
@@ -0,0 +1,285 @@
+package hierarchy
+
+import "math"
+
+// Phi is the golden ratio, the aspect ratio Squarify aims for by default
+var Phi = (1 + math.Sqrt(5)) / 2
+
+// Tiling divides the rectangle x0, y0, x1, y1 among a parent's children in
+// proportion to their values, setting each child's X0, Y0, X1 and Y1
+type Tiling func(parent *Node, x0, y0, x1, y1 float64)
+
+// Treemap lays out a hierarchy as nested rectangles filling a width by
+// height area. Children of each node are tiled within the node's
+// rectangle, so values must be set with Sum first.
+type Treemap struct {
+	Width        float64
+	Height       float64
+	Tile         Tiling  // How children share their parent (default: Squarify(Phi))
+	PaddingInner float64 // Gap between adjacent siblings
+	PaddingOuter float64 // Gap between a parent's edges and its children
+	Round        bool    // Round coordinates to whole pixels
+}
+
+// Layout positions root and its descendants and returns their rectangles,
+// parents before children
+func (t Treemap) Layout(root *Node) []Rect {
+	if root == nil {
+		return nil
+	}
+	tile := t.Tile
+	if tile == nil {
+		tile = Squarify(Phi)
+	}
+
+	root.X0, root.Y0, root.X1, root.Y1 = 0, 0, t.Width, t.Height
+
+	// Each node is inset by half the inner padding on every side, so
+	// siblings end up the full padding apart; the parent's tiling area
+	// makes up the difference to the outer padding
+	inset := make(map[int]float64)
+	root.EachBefore(func(node *Node) {
+		p := inset[node.Depth]
+		x0, y0, x1, y1 := clampRect(node.X0+p, node.Y0+p, node.X1-p, node.Y1-p)
+		node.X0, node.Y0, node.X1, node.Y1 = x0, y0, x1, y1
+		if len(node.Children) == 0 {
+			return
+		}
+		p = t.PaddingInner / 2
+		inset[node.Depth+1] = p
+		d := t.PaddingOuter - p
+		x0, y0, x1, y1 = clampRect(x0+d, y0+d, x1-d, y1-d)
+		tile(node, x0, y0, x1, y1)
+	})
+	return rects(root, t.Round)
+}
+
+// clampRect collapses an inverted rectangle to its midline
+func clampRect(x0, y0, x1, y1 float64) (float64, float64, float64, float64) {
+	if x1 < x0 {
+		x0 = (x0 + x1) / 2
+		x1 = x0
+	}
+	if y1 < y0 {
+		y0 = (y0 + y1) / 2
+		y1 = y0
+	}
+	return x0, y0, x1, y1
+}
+
+// Dice places children side by side from left to right, each spanning the
+// full height
+func Dice(parent *Node, x0, y0, x1, y1 float64) {
+	dice(parent.Children, parent.Value, x0, y0, x1, y1)
+}
+
+// Slice stacks children from top to bottom, each spanning the full width
+func Slice(parent *Node, x0, y0, x1, y1 float64) {
+	slice(parent.Children, parent.Value, x0, y0, x1, y1)
+}
+
+// SliceDice alternates Dice and Slice by depth, starting with Dice at the
+// root
+func SliceDice(parent *Node, x0, y0, x1, y1 float64) {
+	if parent.Depth%2 == 1 {
+		Slice(parent, x0, y0, x1, y1)
+	} else {
+		Dice(parent, x0, y0, x1, y1)
+	}
+}
+
+// dice divides x0..x1 among nodes whose values sum to value
+func dice(nodes []*Node, value, x0, y0, x1, y1 float64) {
+	k := 0.0
+	if value != 0 {
+		k = (x1 - x0) / value
+	}
+	for _, node := range nodes {
+		node.Y0, node.Y1 = y0, y1
+		node.X0 = x0
+		x0 += node.Value * k
+		node.X1 = x0
+	}
+}
+
+// slice divides y0..y1 among nodes whose values sum to value
+func slice(nodes []*Node, value, x0, y0, x1, y1 float64) {
+	k := 0.0
+	if value != 0 {
+		k = (y1 - y0) / value
+	}
+	for _, node := range nodes {
+		node.X0, node.X1 = x0, x1
+		node.Y0 = y0
+		y0 += node.Value * k
+		node.Y1 = y0
+	}
+}
+
+// Binary splits the children into two groups of about equal value, divides
+// the rectangle between them along its longer side and recurses, giving a
+// balanced layout that keeps the input order
+func Binary(parent *Node, x0, y0, x1, y1 float64) {
+	nodes := parent.Children
+	if len(nodes) == 0 {
+		return
+	}
+	sums := make([]float64, len(nodes)+1)
+	for i, node := range nodes {
+		sums[i+1] = sums[i] + node.Value
+	}
+
+	var partition func(i, j int, value, x0, y0, x1, y1 float64)
+	partition = func(i, j int, value, x0, y0, x1, y1 float64) {
+		if i >= j-1 {
+			node := nodes[i]
+			node.X0, node.Y0, node.X1, node.Y1 = x0, y0, x1, y1
+			return
+		}
+
+		// Find the split closest to half the value
+		offset := sums[i]
+		target := value/2 + offset
+		k, hi := i+1, j-1
+		for k < hi {
+			mid := (k + hi) / 2
+			if sums[mid] < target {
+				k = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		if target-sums[k-1] < sums[k]-target && i+1 < k {
+			k--
+		}
+
+		left := sums[k] - offset
+		right := value - left
+		if x1-x0 > y1-y0 {
+			xk := x1
+			if value != 0 {
+				xk = (x0*right + x1*left) / value
+			}
+			partition(i, k, left, x0, y0, xk, y1)
+			partition(k, j, right, xk, y0, x1, y1)
+		} else {
+			yk := y1
+			if value != 0 {
+				yk = (y0*right + y1*left) / value
+			}
+			partition(i, k, left, x0, y0, x1, yk)
+			partition(k, j, right, x0, yk, x1, y1)
+		}
+	}
+	partition(0, len(nodes), parent.Value, x0, y0, x1, y1)
+}
+
+// squarifyRow is a run of children laid out together along one side
+type squarifyRow struct {
+	nodes []*Node
+	value float64
+	dice  bool // Laid out left to right rather than top to bottom
+}
+
+// squarifyRows is a parent's row layout, kept by Resquarify
+type squarifyRows struct {
+	ratio float64
+	rows  []squarifyRow
+}
+
+// Squarify tiles children in rows whose rectangles have aspect ratios as
+// close as possible to ratio, using the algorithm of Bruls et al. Ratios
+// below 1 are treated as 1. Children are placed in order, so sort them
+// largest first for the best results.
+func Squarify(ratio float64) Tiling {
+	ratio = math.Max(ratio, 1)
+	return func(parent *Node, x0, y0, x1, y1 float64) {
+		squarify(ratio, parent, x0, y0, x1, y1)
+	}
+}
+
+// Resquarify is Squarify for animated or repeated layouts: the first
+// layout of a parent squarifies it, and later layouts of the same Node
+// keep its rows and only resize them, so rectangles change size without
+// moving around. Call Sum again to update values between layouts.
+func Resquarify(ratio float64) Tiling {
+	ratio = math.Max(ratio, 1)
+	return func(parent *Node, x0, y0, x1, y1 float64) {
+		r := parent.rows
+		if r == nil || r.ratio != ratio {
+			parent.rows = &squarifyRows{ratio: ratio, rows: squarify(ratio, parent, x0, y0, x1, y1)}
+			return
+		}
+		value := parent.Value
+		for i := range r.rows {
+			row := &r.rows[i]
+			row.value = 0
+			for _, node := range row.nodes {
+				row.value += node.Value
+			}
+			x0, y0 = placeRow(row, value, x0, y0, x1, y1)
+			value -= row.value
+		}
+	}
+}
+
+// squarify lays out the parent's children in rows and returns the rows
+func squarify(ratio float64, parent *Node, x0, y0, x1, y1 float64) []squarifyRow {
+	var rows []squarifyRow
+	nodes := parent.Children
+	value := parent.Value
+	for i0, i1, n := 0, 0, len(nodes); i0 < n; i0 = i1 {
+		dx, dy := x1-x0, y1-y0
+
+		// Start the row with the next non-empty node
+		sum := nodes[i1].Value
+		for i1++; sum == 0 && i1 < n; i1++ {
+			sum = nodes[i1].Value
+		}
+		minValue, maxValue := sum, sum
+		alpha := math.Max(dy/dx, dx/dy) / (value * ratio)
+		beta := sum * sum * alpha
+		minRatio := math.Max(maxValue/beta, beta/minValue)
+
+		// Keep adding nodes while the worst aspect ratio holds or improves
+		for ; i1 < n; i1++ {
+			v := nodes[i1].Value
+			sum += v
+			minValue = math.Min(minValue, v)
+			maxValue = math.Max(maxValue, v)
+			beta = sum * sum * alpha
+			newRatio := math.Max(maxValue/beta, beta/minValue)
+			if newRatio > minRatio {
+				sum -= v
+				break
+			}
+			minRatio = newRatio
+		}
+
+		row := squarifyRow{nodes: nodes[i0:i1], value: sum, dice: dx < dy}
+		x0, y0 = placeRow(&row, value, x0, y0, x1, y1)
+		rows = append(rows, row)
+		value -= sum
+	}
+	return rows
+}
+
+// placeRow lays out a row along the top or left of the remaining
+// rectangle, whose children sum to value, and returns the new top-left
+// corner of what remains
+func placeRow(row *squarifyRow, value, x0, y0, x1, y1 float64) (float64, float64) {
+	if row.dice {
+		y := y1
+		if value != 0 {
+			y = y0 + (y1-y0)*row.value/value
+		}
+		dice(row.nodes, row.value, x0, y0, x1, y)
+		return x0, y
+	}
+	x := x1
+	if value != 0 {
+		x = x0 + (x1-x0)*row.value/value
+	}
+	slice(row.nodes, row.value, x0, y0, x, y1)
+	return x, y0
+}
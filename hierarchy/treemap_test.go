@@ -0,0 +1,131 @@
+package hierarchy
+
+import (
+	"math"
+	"testing"
+)
+
+const eps = 1e-9
+
+func TestTreemapTilings(t *testing.T) {
+	tests := []struct {
+		name string
+		tile Tiling
+	}{
+		{"squarify", Squarify(Phi)},
+		{"squarify 1", Squarify(1)},
+		{"resquarify", Resquarify(Phi)},
+		{"binary", Binary},
+		{"slice", Slice},
+		{"dice", Dice},
+		{"slice-dice", SliceDice},
+	}
+	for _, tt := range tests {
+		root := New(testTree()).Sum(nil).Sort(ValueDescending)
+		rects := Treemap{Width: 400, Height: 300, Tile: tt.tile}.Layout(root)
+		if len(rects) != 8 {
+			t.Fatalf("%s: got %d rectangles, expected 8", tt.name, len(rects))
+		}
+		for _, r := range rects {
+			// Areas are proportional to values
+			if area := r.Width() * r.Height(); math.Abs(area-r.Node.Value*1200) > 1e-6 {
+				t.Errorf("%s: %s has area %v, expected %v", tt.name, r.Node.Data.Name, area, r.Node.Value*1200)
+			}
+			// Children lie inside their parent
+			if p := r.Node.Parent; p != nil {
+				if r.X0 < p.X0-eps || r.Y0 < p.Y0-eps || r.X1 > p.X1+eps || r.Y1 > p.Y1+eps {
+					t.Errorf("%s: %s leaves its parent", tt.name, r.Node.Data.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestSliceDice(t *testing.T) {
+	root := New(testTree()).Sum(nil)
+	Treemap{Width: 100, Height: 100, Tile: SliceDice}.Layout(root)
+
+	// The root is diced into columns, its children sliced into rows
+	a, a1 := find(root, "A"), find(root, "A1")
+	if a.Y0 != 0 || a.Y1 != 100 || a.X1 != 45 {
+		t.Errorf("A at %v,%v-%v,%v, expected a 45 wide column", a.X0, a.Y0, a.X1, a.Y1)
+	}
+	if a1.X0 != 0 || a1.X1 != 45 || math.Abs(a1.Y1-100.0/45*10) > eps {
+		t.Errorf("A1 at %v,%v-%v,%v, expected a row across A", a1.X0, a1.Y0, a1.X1, a1.Y1)
+	}
+}
+
+func TestSquarifyAspectRatio(t *testing.T) {
+	tree := NewTreeNode("Root", 0)
+	for i := 0; i < 12; i++ {
+		tree.AddChild(NewTreeNode("Leaf", 1))
+	}
+	worst := func(ratio float64) float64 {
+		root := New(tree).Sum(nil)
+		w := 0.0
+		for _, r := range (Treemap{Width: 600, Height: 400, Tile: Squarify(ratio)}).Layout(root)[1:] {
+			w = math.Max(w, math.Max(r.Width()/r.Height(), r.Height()/r.Width()))
+		}
+		return w
+	}
+	// Columns of three 150x133 rectangles are the closest to square
+	if w := worst(1); math.Abs(w-1.125) > eps {
+		t.Errorf("worst aspect ratio %v, expected 1.125", w)
+	}
+	if w := worst(3); w <= 1.125 {
+		t.Errorf("worst aspect ratio %v for ratio 3, expected longer rectangles", w)
+	}
+}
+
+func TestResquarify(t *testing.T) {
+	tree := testTree()
+	root := New(tree).Sum(nil).Sort(ValueDescending)
+	treemap := Treemap{Width: 400, Height: 300, Tile: Resquarify(Phi)}
+	treemap.Layout(root)
+	before := make(map[string]Rect)
+	for _, r := range treemap.Layout(root) {
+		before[r.Node.Data.Name] = r
+	}
+
+	// Growing a leaf resizes the rectangles but keeps their arrangement
+	tree.Children[0].Children[0].Value = 40
+	root.Sum(nil)
+	for _, r := range treemap.Layout(root) {
+		b := before[r.Node.Data.Name]
+		if (b.X0 == 0) != (r.X0 == 0) || (b.Y0 == 0) != (r.Y0 == 0) {
+			t.Errorf("%s moved from %v,%v to %v,%v", r.Node.Data.Name, b.X0, b.Y0, r.X0, r.Y0)
+		}
+		if area := r.Width() * r.Height(); math.Abs(area-r.Node.Value*120000/root.Value) > 1e-6 {
+			t.Errorf("%s has area %v after the update", r.Node.Data.Name, area)
+		}
+	}
+}
+
+func TestTreemapPadding(t *testing.T) {
+	root := New(testTree()).Sum(nil).Sort(ValueDescending)
+	rects := Treemap{Width: 400, Height: 300, PaddingInner: 4, PaddingOuter: 6, Round: true}.Layout(root)
+	for _, r := range rects {
+		if r.X0 != math.Round(r.X0) || r.Y1 != math.Round(r.Y1) {
+			t.Errorf("%s not rounded", r.Node.Data.Name)
+		}
+		p := r.Node.Parent
+		if p == nil {
+			continue
+		}
+		// Children keep the outer padding from their parent's edges
+		if r.X0-p.X0 < 6-1 || p.X1-r.X1 < 6-1 || r.Y0-p.Y0 < 6-1 || p.Y1-r.Y1 < 6-1 {
+			t.Errorf("%s is within the outer padding of %s", r.Node.Data.Name, p.Data.Name)
+		}
+		// and the inner padding from each other
+		for _, s := range p.Children {
+			if s == r.Node {
+				continue
+			}
+			gapX := math.Max(s.X0-r.X1, r.X0-s.X1)
+			gapY := math.Max(s.Y0-r.Y1, r.Y0-s.Y1)
+			if math.Max(gapX, gapY) < 4-1 {
+				t.Errorf("%s and %s are closer than the inner padding", r.Node.Data.Name, s.Data.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package kde
+
+import "math"
+
+// BandwidthMethod is a bandwidth selector
+type BandwidthMethod string
+
+const (
+	// Silverman is Silverman's rule of thumb, 0.9 min(σ, IQR/1.34) n^(-1/5),
+	// which suits unimodal data (default)
+	Silverman BandwidthMethod = "silverman"
+
+	// Scott is Scott's normal reference rule, 1.06 σ n^(-1/5), which
+	// smooths more than Silverman's rule
+	Scott BandwidthMethod = "scott"
+
+	// SheatherJones solves the Sheather-Jones plug-in equation, which
+	// adapts to multimodal and skewed data
+	SheatherJones BandwidthMethod = "sheather-jones"
+)
+
+// Bandwidth selects a bandwidth for the finite values with the given
+// method. Weights, when they match the values in length, weigh the
+// samples and set the effective sample size.
+func Bandwidth(values, weights []float64, method BandwidthMethod) float64 {
+	xs, ws := samples(values, weights, math.Inf(-1), math.Inf(1))
+	if len(xs) == 0 {
+		return 0
+	}
+	return selectBandwidth(method, xs, ws)
+}
+
+// selectBandwidth selects a bandwidth for sorted samples with normalized
+// weights
+func selectBandwidth(method BandwidthMethod, xs, ws []float64) float64 {
+	n := effectiveSize(ws)
+	sd, iqr := spread(xs, ws)
+	switch method {
+	case Scott:
+		return 1.06 * fallbackScale(sd, sd, iqr, xs) * math.Pow(n, -0.2)
+	case SheatherJones:
+		if h := sheatherJones(xs, ws, n, fallbackScale(math.Min(sd, iqr/1.349), sd, iqr, xs)); h > 0 {
+			return h
+		}
+	}
+	return 0.9 * fallbackScale(math.Min(sd, iqr/1.34), sd, iqr, xs) * math.Pow(n, -0.2)
+}
+
+// fallbackScale returns scale, or for data without spread the standard
+// deviation, the IQR-based scale, the size of the first value or 1,
+// whichever is first positive
+func fallbackScale(scale, sd, iqr float64, xs []float64) float64 {
+	for _, s := range []float64{scale, sd, iqr / 1.34, math.Abs(xs[0])} {
+		if s > 0 {
+			return s
+		}
+	}
+	return 1
+}
+
+// effectiveSize returns Kish's effective sample size for normalized
+// weights, which is the count for equal weights
+func effectiveSize(ws []float64) float64 {
+	sum := 0.0
+	for _, w := range ws {
+		sum += w * w
+	}
+	return 1 / sum
+}
+
+// spread returns the weighted standard deviation and interquartile range
+// of sorted samples
+func spread(xs, ws []float64) (sd, iqr float64) {
+	mean := 0.0
+	for i, x := range xs {
+		mean += ws[i] * x
+	}
+	variance := 0.0
+	for i, x := range xs {
+		variance += ws[i] * (x - mean) * (x - mean)
+	}
+	if n := effectiveSize(ws); n > 1 {
+		sd = math.Sqrt(variance * n / (n - 1))
+	}
+	return sd, quantile(xs, ws, 0.75) - quantile(xs, ws, 0.25)
+}
+
+// quantile returns the weighted p-quantile of sorted samples, placing each
+// sample at the midpoint of its share of the weight and interpolating
+// linearly between them
+func quantile(xs, ws []float64, p float64) float64 {
+	before := 0.0
+	prev := 0.0
+	for i, x := range xs {
+		pos := before + ws[i]/2
+		if p <= pos {
+			if i == 0 {
+				return x
+			}
+			return xs[i-1] + (x-xs[i-1])*(p-prev)/(pos-prev)
+		}
+		before += ws[i]
+		prev = pos
+	}
+	return xs[len(xs)-1]
+}
+
+// sheatherJones solves the Sheather-Jones equation for the bandwidth by
+// bisection, with the density functionals estimated from binned pairwise
+// distances as in R's bw.SJ. It returns 0 when there is no solution.
+func sheatherJones(xs, ws []float64, n, scale float64) float64 {
+	const bins = 1000
+	lo, hi := xs[0], xs[len(xs)-1]
+	if n < 2 || hi == lo {
+		return 0
+	}
+
+	// Weighted counts of pairs by the number of bins between them, with
+	// weights scaled to count as samples
+	d := (hi - lo) * 1.01 / bins
+	counts := make([]float64, bins)
+	squares := make([]float64, bins)
+	for i, x := range xs {
+		b := int((x - lo) / d)
+		counts[b] += ws[i] * n
+		squares[b] += ws[i] * n * ws[i] * n
+	}
+	pairs := make([]float64, bins)
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		pairs[0] += (c*c - squares[i]) / 2
+		for j := 0; j < i; j++ {
+			pairs[i-j] += c * counts[j]
+		}
+	}
+
+	// Estimates of the integrated squared fourth and sixth derivatives of
+	// the density, with Gaussian kernels of bandwidth h
+	functional := func(h float64, order int) float64 {
+		sum := 0.0
+		for k, count := range pairs {
+			delta := float64(k) * d / h
+			delta *= delta
+			if delta >= 1000 {
+				break
+			}
+			if order == 4 {
+				sum += math.Exp(-delta/2) * (delta*delta - 6*delta + 3) * count
+			} else {
+				sum += math.Exp(-delta/2) * (delta*delta*delta - 15*delta*delta + 45*delta - 15) * count
+			}
+		}
+		if order == 4 {
+			sum = 2*sum + 3*n
+		} else {
+			sum = 2*sum - 15*n
+		}
+		return sum / (n * (n - 1) * math.Pow(h, float64(order+1)) * math.Sqrt(2*math.Pi))
+	}
+
+	a := 1.24 * scale * math.Pow(n, -1.0/7)
+	b := 1.23 * scale * math.Pow(n, -1.0/9)
+	td := -functional(b, 6)
+	if !(td > 0) || math.IsInf(td, 0) {
+		return 0
+	}
+	alpha2 := 1.357 * math.Pow(functional(a, 4)/td, 1.0/7)
+	c1 := 1 / (2 * math.Sqrt(math.Pi) * n)
+	f := func(h float64) float64 {
+		return math.Pow(c1/functional(alpha2*math.Pow(h, 5.0/7), 4), 0.2) - h
+	}
+
+	// Widen the search until it brackets the root
+	hmax := 1.144 * scale * math.Pow(n, -0.2)
+	lower, upper := 0.1*hmax, hmax
+	for i := 0; f(lower)*f(upper) > 0; i++ {
+		if i >= 100 {
+			return 0
+		}
+		if i%2 == 0 {
+			upper *= 1.2
+		} else {
+			lower /= 1.2
+		}
+	}
+	if math.IsNaN(f(lower) * f(upper)) {
+		return 0
+	}
+
+	for range 100 {
+		mid := (lower + upper) / 2
+		if f(lower)*f(mid) <= 0 {
+			upper = mid
+		} else {
+			lower = mid
+		}
+	}
+	return (lower + upper) / 2
+}
@@ -0,0 +1,70 @@
+package kde
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBandwidthRules(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	sd := math.Sqrt(55.0 / 6) // Sample standard deviation; the IQR is 5
+
+	tests := []struct {
+		method BandwidthMethod
+		want   float64
+	}{
+		{"", 0.9 * sd * math.Pow(10, -0.2)},
+		{Silverman, 0.9 * sd * math.Pow(10, -0.2)},
+		{Scott, 1.06 * sd * math.Pow(10, -0.2)},
+	}
+	for _, tt := range tests {
+		if got := Bandwidth(values, nil, tt.method); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Bandwidth(%q) = %f, expected %f", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestBandwidthWithoutSpread(t *testing.T) {
+	for _, method := range []BandwidthMethod{Silverman, Scott, SheatherJones} {
+		if got := Bandwidth([]float64{5, 5, 5}, nil, method); !(got > 0) {
+			t.Errorf("%s: expected a positive bandwidth for identical values, got %f", method, got)
+		}
+	}
+}
+
+func TestSheatherJones(t *testing.T) {
+	// Close to Scott's rule, which is optimal for normal data
+	normal := normalSample(500, 3)
+	sj := Bandwidth(normal, nil, SheatherJones)
+	scott := Bandwidth(normal, nil, Scott)
+	if sj < 0.8*scott || sj > 1.2*scott {
+		t.Errorf("Expected Sheather-Jones near Scott for normal data: %f vs %f", sj, scott)
+	}
+
+	// Narrower than Silverman's rule, which oversmooths, for well
+	// separated modes
+	bimodal := append(normalSample(250, 4), normalSample(250, 5)...)
+	for i := 250; i < len(bimodal); i++ {
+		bimodal[i] += 8
+	}
+	sj = Bandwidth(bimodal, nil, SheatherJones)
+	silverman := Bandwidth(bimodal, nil, Silverman)
+	if sj >= silverman {
+		t.Errorf("Expected Sheather-Jones below Silverman for bimodal data: %f vs %f", sj, silverman)
+	}
+}
+
+func TestWeightedQuantile(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5}
+	equal := []float64{0.2, 0.2, 0.2, 0.2, 0.2}
+	for _, tt := range []struct{ p, want float64 }{{0, 1}, {0.25, 1.75}, {0.5, 3}, {0.6, 3.5}, {1, 5}} {
+		if got := quantile(xs, equal, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("quantile(%v) = %f, expected %f", tt.p, got, tt.want)
+		}
+	}
+
+	// Weight pulls the median toward the heavier sample
+	if got := quantile([]float64{0, 10}, []float64{0.9, 0.1}, 0.5); got >= 5 {
+		t.Errorf("Expected the weighted median below 5, got %f", got)
+	}
+}
@@ -0,0 +1,244 @@
+// Package kde estimates probability densities from samples by kernel
+// density estimation.
+//
+// An estimate places a kernel on every sample and sums them. The choices
+// are all in Options:
+//   - Kernel picks the kernel shape: Gaussian, Epanechnikov, Triangular or
+//     Uniform
+//   - Bandwidth sets the kernel's standard deviation, or Method picks a
+//     selector when it is zero: Silverman, Scott or SheatherJones
+//   - Lower and Upper bound the support, reflecting the kernels that would
+//     spill past a bound back inside it
+//   - Weights weighs the samples
+//   - Points, Cut and Grid set where the density is evaluated
+//
+// Bandwidths are kernel standard deviations for every kernel, so the same
+// bandwidth smooths about as much whichever kernel is used.
+//
+// Example:
+//
+//	for _, p := range kde.Estimate(latencies, kde.Options{Kernel: kde.Epanechnikov, Method: kde.SheatherJones}) {
+//		fmt.Println(p.X, p.Density)
+//	}
+package kde
+
+import (
+	"math"
+	"sort"
+)
+
+// Kernel is a kernel shape
+type Kernel string
+
+const (
+	Gaussian     Kernel = "gaussian"     // Normal density (default)
+	Epanechnikov Kernel = "epanechnikov" // Parabola, the most efficient kernel
+	Triangular   Kernel = "triangular"   // Triangle
+	Uniform      Kernel = "uniform"      // Rectangle
+)
+
+// Eval returns the kernel's density at u standard deviations from its
+// center
+func (k Kernel) Eval(u float64) float64 {
+	a := k.Support()
+	if math.Abs(u) >= a {
+		return 0
+	}
+	switch k {
+	case Epanechnikov:
+		return 3 / (4 * a) * (1 - u*u/(a*a))
+	case Triangular:
+		return (1 - math.Abs(u)/a) / a
+	case Uniform:
+		return 1 / (2 * a)
+	default:
+		return math.Exp(-u*u/2) / math.Sqrt(2*math.Pi)
+	}
+}
+
+// Support returns how many standard deviations from its center the kernel
+// reaches, which is infinite for the Gaussian kernel
+func (k Kernel) Support() float64 {
+	switch k {
+	case Epanechnikov:
+		return math.Sqrt(5)
+	case Triangular:
+		return math.Sqrt(6)
+	case Uniform:
+		return math.Sqrt(3)
+	default:
+		return math.Inf(1)
+	}
+}
+
+// Options configures a density estimate
+type Options struct {
+	Kernel Kernel // Kernel shape (default: Gaussian)
+
+	// Bandwidth is the kernel standard deviation; zero selects one with
+	// Method
+	Bandwidth float64
+
+	// Method selects the bandwidth when Bandwidth is zero (default: Silverman)
+	Method BandwidthMethod
+
+	// Weights are per-sample weights, ignored unless they match the
+	// values in length; samples with non-positive weights are left out
+	// (default: equal weights)
+	Weights []float64
+
+	// Lower and Upper optionally bound the support. Kernel mass that
+	// would fall past a bound is reflected back inside it, and the density
+	// is zero beyond it.
+	Lower *float64
+	Upper *float64
+
+	// Points is the number of evenly spaced grid points (default: 100)
+	Points int
+
+	// Cut is how many bandwidths the grid extends past the smallest and
+	// largest samples, within the bounds (default: 0, the sample range)
+	Cut float64
+
+	// Grid lists the evaluation points, overriding Points and Cut
+	Grid []float64
+}
+
+// Point is the estimated density at X
+type Point struct {
+	X       float64
+	Density float64
+}
+
+// Estimator is a density estimate fit to samples
+type Estimator struct {
+	Kernel    Kernel
+	Bandwidth float64
+	Lower     float64 // -Inf when unbounded
+	Upper     float64 // +Inf when unbounded
+	Min, Max  float64 // Sample range
+
+	values  []float64
+	weights []float64 // Normalized to sum to 1
+}
+
+// New fits an estimator to the finite values within the bounds, or
+// returns nil when there are none
+func New(values []float64, opts Options) *Estimator {
+	e := &Estimator{
+		Kernel:    opts.Kernel,
+		Bandwidth: opts.Bandwidth,
+		Lower:     math.Inf(-1),
+		Upper:     math.Inf(1),
+	}
+	if e.Kernel == "" {
+		e.Kernel = Gaussian
+	}
+	if opts.Lower != nil {
+		e.Lower = *opts.Lower
+	}
+	if opts.Upper != nil {
+		e.Upper = *opts.Upper
+	}
+
+	xs, ws := samples(values, opts.Weights, e.Lower, e.Upper)
+	if len(xs) == 0 {
+		return nil
+	}
+	e.values, e.weights = xs, ws
+	e.Min, e.Max = xs[0], xs[len(xs)-1]
+	if e.Bandwidth <= 0 {
+		e.Bandwidth = selectBandwidth(opts.Method, xs, ws)
+	}
+	return e
+}
+
+// Density returns the estimated density at x
+func (e *Estimator) Density(x float64) float64 {
+	if x < e.Lower || x > e.Upper {
+		return 0
+	}
+
+	// Only samples within the kernel's reach contribute
+	reach := e.Kernel.Support() * e.Bandwidth
+	if math.IsInf(reach, 1) {
+		reach = 40 * e.Bandwidth
+	}
+	density := 0.0
+	for _, center := range []float64{x, 2*e.Lower - x, 2*e.Upper - x} {
+		if math.IsInf(center, 0) {
+			continue
+		}
+		start := sort.SearchFloat64s(e.values, center-reach)
+		for i := start; i < len(e.values) && e.values[i] <= center+reach; i++ {
+			density += e.weights[i] * e.Kernel.Eval((center-e.values[i])/e.Bandwidth)
+		}
+	}
+	return density / e.Bandwidth
+}
+
+// Evaluate returns the density at the grid points set by opts: Grid if
+// given, or Points points evenly spaced from Cut bandwidths below the
+// smallest sample to Cut bandwidths above the largest, within the bounds
+func (e *Estimator) Evaluate(opts Options) []Point {
+	grid := opts.Grid
+	if grid == nil {
+		n := opts.Points
+		if n <= 0 {
+			n = 100
+		}
+		lo := math.Max(e.Min-opts.Cut*e.Bandwidth, e.Lower)
+		hi := math.Min(e.Max+opts.Cut*e.Bandwidth, e.Upper)
+		grid = make([]float64, n)
+		for i := range grid {
+			grid[i] = lo
+			if n > 1 {
+				grid[i] += (hi - lo) * float64(i) / float64(n-1)
+			}
+		}
+	}
+
+	points := make([]Point, len(grid))
+	for i, x := range grid {
+		points[i] = Point{X: x, Density: e.Density(x)}
+	}
+	return points
+}
+
+// Estimate fits an estimator to the values and evaluates it on the grid
+// set by opts, returning nil when there are no finite values
+func Estimate(values []float64, opts Options) []Point {
+	e := New(values, opts)
+	if e == nil {
+		return nil
+	}
+	return e.Evaluate(opts)
+}
+
+// samples returns the finite values within the bounds that have positive
+// weights, in ascending order with their weights normalized to sum to 1
+func samples(values, weights []float64, lower, upper float64) (xs, ws []float64) {
+	type sample struct{ x, w float64 }
+	list := make([]sample, 0, len(values))
+	total := 0.0
+	for i, v := range values {
+		w := 1.0
+		if len(weights) == len(values) {
+			w = weights[i]
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < lower || v > upper || !(w > 0) || math.IsInf(w, 1) {
+			continue
+		}
+		list = append(list, sample{v, w})
+		total += w
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].x < list[j].x })
+
+	xs = make([]float64, len(list))
+	ws = make([]float64, len(list))
+	for i, s := range list {
+		xs[i] = s.x
+		ws[i] = s.w / total
+	}
+	return xs, ws
+}
@@ -0,0 +1,125 @@
+package kde
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// integrate integrates f over [a, b] with the trapezoid rule
+func integrate(f func(float64) float64, a, b float64) float64 {
+	const steps = 20000
+	h := (b - a) / steps
+	sum := (f(a) + f(b)) / 2
+	for i := 1; i < steps; i++ {
+		sum += f(a + float64(i)*h)
+	}
+	return sum * h
+}
+
+// normalSample returns n deterministic standard normal draws
+func normalSample(n int, seed int64) []float64 {
+	r := rand.New(rand.NewSource(seed))
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = r.NormFloat64()
+	}
+	return values
+}
+
+func TestKernels(t *testing.T) {
+	for _, k := range []Kernel{Gaussian, Epanechnikov, Triangular, Uniform} {
+		mass := integrate(k.Eval, -10, 10)
+		variance := integrate(func(u float64) float64 { return u * u * k.Eval(u) }, -10, 10)
+		if math.Abs(mass-1) > 1e-3 {
+			t.Errorf("%s: integrates to %f, expected 1", k, mass)
+		}
+		if math.Abs(variance-1) > 1e-3 {
+			t.Errorf("%s: variance %f, expected 1", k, variance)
+		}
+		if k.Eval(0.5) != k.Eval(-0.5) {
+			t.Errorf("%s: expected a symmetric kernel", k)
+		}
+	}
+}
+
+func TestEstimatorIntegratesToOne(t *testing.T) {
+	values := normalSample(200, 1)
+	for _, k := range []Kernel{Gaussian, Epanechnikov, Triangular, Uniform} {
+		e := New(values, Options{Kernel: k})
+		if mass := integrate(e.Density, e.Min-10, e.Max+10); math.Abs(mass-1) > 1e-3 {
+			t.Errorf("%s: density integrates to %f, expected 1", k, mass)
+		}
+	}
+}
+
+func TestEstimatorReflection(t *testing.T) {
+	// Exponential samples pile up against zero
+	r := rand.New(rand.NewSource(2))
+	values := make([]float64, 300)
+	for i := range values {
+		values[i] = r.ExpFloat64()
+	}
+	lower := 0.0
+
+	bounded := New(values, Options{Lower: &lower})
+	unbounded := New(values, Options{Bandwidth: bounded.Bandwidth})
+
+	if mass := integrate(bounded.Density, 0, 20); math.Abs(mass-1) > 1e-3 {
+		t.Errorf("Bounded density integrates to %f over its support, expected 1", mass)
+	}
+	if got := bounded.Density(-0.1); got != 0 {
+		t.Errorf("Expected zero density below the bound, got %f", got)
+	}
+	if bounded.Density(0) <= unbounded.Density(0) {
+		t.Errorf("Expected reflection to raise the density at the bound: %f <= %f", bounded.Density(0), unbounded.Density(0))
+	}
+}
+
+func TestEstimatorWeights(t *testing.T) {
+	values := []float64{0, 10, 20}
+	e := New(values, Options{Weights: []float64{1, 3, 0}, Bandwidth: 1})
+
+	if got := e.Density(10) / e.Density(0); math.Abs(got-3) > 1e-9 {
+		t.Errorf("Expected three times the density at the heavier sample, got %f", got)
+	}
+	if e.Max != 10 {
+		t.Errorf("Expected the zero-weight sample to be left out, got max %f", e.Max)
+	}
+}
+
+func TestEvaluateGrid(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name   string
+		opts   Options
+		points int
+		first  float64
+		last   float64
+	}{
+		{"default", Options{Bandwidth: 1}, 100, 1, 5},
+		{"points", Options{Bandwidth: 1, Points: 5}, 5, 1, 5},
+		{"cut", Options{Bandwidth: 1, Points: 9, Cut: 2}, 9, -1, 7},
+		{"cut within bounds", Options{Bandwidth: 1, Points: 9, Cut: 2, Lower: new(float64)}, 9, 0, 7},
+		{"grid", Options{Bandwidth: 1, Grid: []float64{0, 3}}, 2, 0, 3},
+	}
+	for _, tt := range tests {
+		got := Estimate(values, tt.opts)
+		if len(got) != tt.points {
+			t.Errorf("%s: expected %d points, got %d", tt.name, tt.points, len(got))
+			continue
+		}
+		if got[0].X != tt.first || got[len(got)-1].X != tt.last {
+			t.Errorf("%s: expected the grid from %v to %v, got %v to %v", tt.name, tt.first, tt.last, got[0].X, got[len(got)-1].X)
+		}
+	}
+}
+
+func TestEstimateEmpty(t *testing.T) {
+	for _, values := range [][]float64{nil, {math.NaN(), math.Inf(1)}} {
+		if got := Estimate(values, Options{}); got != nil {
+			t.Errorf("Estimate(%v) = %v, expected nil", values, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -15,6 +16,16 @@ import (
 	"github.com/SCKelemen/units"
 )
 
+// render renders a main library chart to SVG, returning its validation
+// error when the input cannot be drawn
+func render(chart maincharts.Chart) (string, error) {
+	out, err := chart.Render(context.Background(), maincharts.TargetSVG)
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
 // CreateBarChart generates a bar chart SVG by calling the main library
 func CreateBarChart(config types.BarChartConfig) (string, error) {
 	// Convert MCP types to main library types
@@ -31,13 +42,12 @@ func CreateBarChart(config types.BarChartConfig) (string, error) {
 		Color: config.Color,
 	}
 
-	// Use default theme
-	tokens := design.DefaultTheme()
-
-	// Call main library function
-	svg := maincharts.RenderBarChart(data, 0, 0, config.Width, config.Height, tokens)
-
-	return svg, nil
+	// Call main library with the default theme
+	return render(maincharts.BarChartSpec{
+		Data:   data,
+		Bounds: maincharts.Bounds{Width: config.Width, Height: config.Height},
+		Config: maincharts.RenderConfig{DesignTokens: design.DefaultTheme()},
+	})
 }
 
 // CreatePieChart generates a pie chart SVG by calling the main library
@@ -56,11 +66,15 @@ func CreatePieChart(config types.PieChartConfig) (string, error) {
 	}
 
 	// Call main library function
-	// Note: config.Donut maps to donutMode parameter
 	// showLegend=true, showPercent=true for MCP compatibility
-	svg := maincharts.RenderPieChart(data, 0, 0, config.Width, config.Height, config.Title, config.Donut, true, true)
-
-	return svg, nil
+	return render(maincharts.PieChartSpec{
+		Data:        data,
+		Bounds:      maincharts.Bounds{Width: config.Width, Height: config.Height},
+		Title:       config.Title,
+		Donut:       config.Donut,
+		ShowLegend:  true,
+		ShowPercent: true,
+	})
 }
 
 // CreateLineChart generates a line chart SVG using SCKelemen libraries
@@ -414,9 +428,11 @@ func CreateTreemap(config types.TreemapConfig) (string, error) {
 		Padding:      2,
 		ShowLabels:   config.ShowLabels,
 		MinLabelSize: 30,
+		Tiling:       maincharts.TreemapTiling(config.Tiling),
+		Ratio:        config.Ratio,
 	}
 
-	return maincharts.RenderTreemap(spec), nil
+	return render(spec)
 }
 
 // CreateSunburst generates a sunburst chart SVG
@@ -431,7 +447,7 @@ func CreateSunburst(config types.SunburstConfig) (string, error) {
 		ShowLabels:  config.ShowLabels,
 	}
 
-	return maincharts.RenderSunburst(spec), nil
+	return render(spec)
 }
 
 // CreateCirclePacking generates a circle packing chart SVG
@@ -445,7 +461,7 @@ func CreateCirclePacking(config types.CirclePackingConfig) (string, error) {
 		ShowLabels: config.ShowLabels,
 	}
 
-	return maincharts.RenderCirclePacking(spec), nil
+	return render(spec)
 }
 
 // CreateIcicle generates an icicle partition chart SVG
@@ -465,7 +481,7 @@ func CreateIcicle(config types.IcicleConfig) (string, error) {
 		ShowLabels:  config.ShowLabels,
 	}
 
-	return maincharts.RenderIcicle(spec), nil
+	return render(spec)
 }
 
 // CreateBoxPlot generates a box plot SVG
@@ -489,7 +505,7 @@ func CreateBoxPlot(config types.BoxPlotConfig) (string, error) {
 		WhiskerMultiplier: 1.5,
 	}
 
-	return maincharts.RenderVerticalBoxPlot(spec), nil
+	return render(spec)
 }
 
 // CreateViolinPlot generates a violin plot SVG
@@ -513,7 +529,7 @@ func CreateViolinPlot(config types.ViolinPlotConfig) (string, error) {
 		ShowMean:   false,
 	}
 
-	return maincharts.RenderViolinPlot(spec), nil
+	return render(spec)
 }
 
 // CreateHistogram generates a histogram SVG
@@ -534,7 +550,7 @@ func CreateHistogram(config types.HistogramConfig) (string, error) {
 		Nice:     true,
 	}
 
-	return maincharts.RenderHistogram(spec), nil
+	return render(spec)
 }
 
 // CreateRidgeline generates a ridgeline plot SVG
@@ -561,7 +577,7 @@ func CreateRidgeline(config types.RidgelineConfig) (string, error) {
 		ShowLabels: config.ShowLabels,
 	}
 
-	return maincharts.RenderRidgeline(spec), nil
+	return render(spec)
 }
 
 // CreateCandlestick generates a candlestick chart SVG
@@ -614,7 +630,7 @@ func CreateCandlestick(config types.CandlestickConfig) (string, error) {
 		VolumeHeight: 100,
 	}
 
-	return maincharts.RenderCandlestick(spec), nil
+	return render(spec)
 }
 
 // CreateOHLC generates an OHLC bar chart SVG
@@ -664,7 +680,7 @@ func CreateOHLC(config types.OHLCConfig) (string, error) {
 		YScale: yScale,
 	}
 
-	return maincharts.RenderOHLC(spec), nil
+	return render(spec)
 }
 
 // convertTreeNode converts MCP TreeNode to charts.TreeNode recursively
@@ -715,7 +731,7 @@ func CreateLollipop(config types.LollipopConfig) (string, error) {
 		Title:      config.Title,
 	}
 
-	return maincharts.RenderLollipop(spec), nil
+	return render(spec)
 }
 
 // CreateDensity creates a density plot
@@ -738,7 +754,7 @@ func CreateDensity(config types.DensityConfig) (string, error) {
 		Title:    config.Title,
 	}
 
-	return maincharts.RenderSimpleDensity(spec), nil
+	return render(spec)
 }
 
 // CreateConnectedScatter creates a connected scatter plot
@@ -771,7 +787,7 @@ func CreateConnectedScatter(config types.ConnectedScatterConfig) (string, error)
 		Title:       config.Title,
 	}
 
-	return maincharts.RenderConnectedScatter(spec), nil
+	return render(spec)
 }
 
 // CreateStackedArea creates a stacked area chart
@@ -801,7 +817,7 @@ func CreateStackedArea(config types.StackedAreaConfig) (string, error) {
 		Title:    config.Title,
 	}
 
-	return maincharts.RenderStackedArea(spec), nil
+	return render(spec)
 }
 
 // CreateStreamChart creates a stream chart
@@ -832,7 +848,7 @@ func CreateStreamChart(config types.StreamChartConfig) (string, error) {
 		Title:      config.Title,
 	}
 
-	return maincharts.RenderStreamChart(spec), nil
+	return render(spec)
 }
 
 // CreateCorrelogram creates a correlogram
@@ -853,7 +869,7 @@ func CreateCorrelogram(config types.CorrelogramConfig) (string, error) {
 		Title:        config.Title,
 	}
 
-	return maincharts.RenderCorrelogram(spec), nil
+	return render(spec)
 }
 
 // CreateRadar creates a radar chart
@@ -887,7 +903,7 @@ func CreateRadar(config types.RadarConfig) (string, error) {
 		Title:      config.Title,
 	}
 
-	return maincharts.RenderRadarChart(spec), nil
+	return render(spec)
 }
 
 // CreateParallel creates a parallel coordinates chart
@@ -919,7 +935,7 @@ func CreateParallel(config types.ParallelConfig) (string, error) {
 		Title:          config.Title,
 	}
 
-	return maincharts.RenderParallelCoordinates(spec), nil
+	return render(spec)
 }
 
 // CreateWordCloud creates a word cloud
@@ -934,14 +950,19 @@ func CreateWordCloud(config types.WordCloudConfig) (string, error) {
 	}
 
 	spec := maincharts.WordCloudSpec{
-		Words:  words,
-		Width:  float64(config.Width),
-		Height: float64(config.Height),
-		Layout: config.Layout,
-		Title:  config.Title,
-	}
-
-	return maincharts.RenderWordCloud(spec), nil
+		Words:     words,
+		Width:     float64(config.Width),
+		Height:    float64(config.Height),
+		Layout:    config.Layout,
+		Spiral:    maincharts.WordCloudSpiral(config.Spiral),
+		Rotations: config.Rotations,
+		Shape:     maincharts.WordCloudShape(config.Shape),
+		Mask:      config.Mask,
+		Seed:      config.Seed,
+		Title:     config.Title,
+	}
+
+	return render(spec)
 }
 
 // CreateSankey creates a Sankey diagram
@@ -966,15 +987,19 @@ func CreateSankey(config types.SankeyConfig) (string, error) {
 	}
 
 	spec := maincharts.SankeySpec{
-		Nodes:      nodes,
-		Links:      links,
-		Width:      float64(config.Width),
-		Height:     float64(config.Height),
-		ShowLabels: true,
-		Title:      config.Title,
+		Nodes:        nodes,
+		Links:        links,
+		Width:        float64(config.Width),
+		Height:       float64(config.Height),
+		Align:        maincharts.SankeyAlign(config.Align),
+		LinkSort:     maincharts.SankeyLinkSort(config.LinkSort),
+		LinkGradient: config.LinkGradient,
+		AllowCycles:  config.AllowCycles,
+		ShowLabels:   true,
+		Title:        config.Title,
 	}
 
-	return maincharts.RenderSankey(spec), nil
+	return render(spec)
 }
 
 // CreateChord creates a chord diagram
@@ -998,15 +1023,57 @@ func CreateChord(config types.ChordConfig) (string, error) {
 	}
 
 	spec := maincharts.ChordDiagramSpec{
-		Entities:   entities,
-		Relations:  relations,
+		Entities:      entities,
+		Relations:     relations,
+		Width:         float64(config.Width),
+		Height:        float64(config.Height),
+		Directed:      config.Directed,
+		SortGroups:    maincharts.ChordSort(config.SortGroups),
+		SortSubgroups: maincharts.ChordSort(config.SortSubgroups),
+		ShowTicks:     config.ShowTicks,
+		ShowLabels:    true,
+		Title:         config.Title,
+	}
+
+	return render(spec)
+}
+
+// CreateNetwork creates a force-directed network graph
+func CreateNetwork(config types.NetworkConfig) (string, error) {
+	nodes := make([]maincharts.NetworkNode, len(config.Nodes))
+	for i, n := range config.Nodes {
+		nodes[i] = maincharts.NetworkNode{
+			ID:    n.ID,
+			Label: n.Label,
+			Group: n.Group,
+			Value: n.Value,
+			Color: n.Color,
+		}
+	}
+
+	links := make([]maincharts.NetworkLink, len(config.Links))
+	for i, l := range config.Links {
+		links[i] = maincharts.NetworkLink{
+			Source: l.Source,
+			Target: l.Target,
+			Value:  l.Value,
+			Color:  l.Color,
+		}
+	}
+
+	spec := maincharts.NetworkSpec{
+		Nodes:      nodes,
+		Links:      links,
 		Width:      float64(config.Width),
 		Height:     float64(config.Height),
+		Directed:   config.Directed,
 		ShowLabels: true,
+		ShowLegend: true,
+		Seed:       config.Seed,
 		Title:      config.Title,
 	}
 
-	return maincharts.RenderChordDiagram(spec), nil
+	return render(spec)
 }
 
 // CreateCircularBar creates a circular bar plot
@@ -1030,7 +1097,7 @@ func CreateCircularBar(config types.CircularBarConfig) (string, error) {
 		Title:          config.Title,
 	}
 
-	return maincharts.RenderCircularBarPlot(spec), nil
+	return render(spec)
 }
 
 // CreateDendrogram creates a dendrogram
@@ -1069,7 +1136,7 @@ func CreateDendrogram(config types.DendrogramConfig) (string, error) {
 		Title:       config.Title,
 	}
 
-	return maincharts.RenderDendrogram(spec), nil
+	return render(spec)
 }
 
 // Keep unused imports to avoid compiler errors
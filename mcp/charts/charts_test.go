@@ -1,9 +1,11 @@
 package charts
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
+	maincharts "github.com/SCKelemen/dataviz/charts"
 	"github.com/SCKelemen/dataviz/mcp/types"
 )
 
@@ -510,6 +512,40 @@ func TestCreateOHLC(t *testing.T) {
 	}
 }
 
+// TestCreateNetwork tests network graph generation
+func TestCreateNetwork(t *testing.T) {
+	config := types.NetworkConfig{
+		ChartConfig: types.ChartConfig{
+			Title:  "Test Network",
+			Width:  800,
+			Height: 600,
+		},
+		Nodes: []types.NetworkNode{
+			{ID: "a", Group: "x"},
+			{ID: "b", Group: "x"},
+			{ID: "c", Group: "y", Value: 4},
+		},
+		Links: []types.NetworkLink{
+			{Source: "a", Target: "b"},
+			{Source: "b", Target: "c", Value: 2},
+		},
+		Directed: true,
+	}
+
+	svg, err := CreateNetwork(config)
+	if err != nil {
+		t.Fatalf("CreateNetwork failed: %v", err)
+	}
+
+	if strings.Count(svg, "<circle") < 3 {
+		t.Error("Network should contain a circle per node")
+	}
+
+	if !strings.Contains(svg, "<polygon") {
+		t.Error("Directed network should contain arrowheads")
+	}
+}
+
 // TestConvertTreeNode tests tree node conversion
 func TestConvertTreeNode(t *testing.T) {
 	input := &types.TreeNode{
@@ -637,6 +673,44 @@ func TestEmptyData(t *testing.T) {
 	})
 }
 
+// TestInvalidData tests that validation errors from the main library are returned
+func TestInvalidData(t *testing.T) {
+	t.Run("negative pie value", func(t *testing.T) {
+		config := types.PieChartConfig{
+			ChartConfig: types.ChartConfig{Width: 600, Height: 600},
+			Data:        []types.DataPoint{{Label: "A", Value: 10}, {Label: "B", Value: -5}},
+		}
+		_, err := CreatePieChart(config)
+		if !errors.Is(err, maincharts.ErrNegativeValue) {
+			t.Errorf("Expected negative value error, got %v", err)
+		}
+	})
+
+	t.Run("sankey cycle", func(t *testing.T) {
+		config := types.SankeyConfig{
+			ChartConfig: types.ChartConfig{Width: 800, Height: 600},
+			Nodes:       []types.SankeyNode{{ID: "a"}, {ID: "b"}},
+			Links:       []types.SankeyLink{{Source: "a", Target: "b", Value: 1}, {Source: "b", Target: "a", Value: 1}},
+		}
+		_, err := CreateSankey(config)
+		if !errors.Is(err, maincharts.ErrCycle) {
+			t.Errorf("Expected cycle error, got %v", err)
+		}
+	})
+
+	t.Run("network unknown node", func(t *testing.T) {
+		config := types.NetworkConfig{
+			ChartConfig: types.ChartConfig{Width: 800, Height: 600},
+			Nodes:       []types.NetworkNode{{ID: "a"}},
+			Links:       []types.NetworkLink{{Source: "a", Target: "b"}},
+		}
+		_, err := CreateNetwork(config)
+		if !errors.Is(err, maincharts.ErrUnknownReference) {
+			t.Errorf("Expected unknown reference error, got %v", err)
+		}
+	})
+}
+
 // TestNonZeroDimensions tests charts with explicit dimensions
 func TestNonZeroDimensions(t *testing.T) {
 	config := types.TreemapConfig{
@@ -19,7 +19,7 @@
 //	3. Call DataViz MCP → Generate bar chart
 //	4. Return SVG to user
 //
-// MCP Tools Provided (29 total):
+// MCP Tools Provided (30 total):
 //
 // Chart Generation:
 //   - bar_chart: Vertical/horizontal bar charts
@@ -48,9 +48,23 @@
 //   - wordcloud: Word cloud visualizations
 //   - sankey: Sankey flow diagrams
 //   - chord: Chord diagrams for relationships
+//   - network: Force-directed network graphs
 //   - circular_bar: Circular bar plots
 //   - dendrogram: Hierarchical clustering trees
 //   - generate_gallery: Generate comparison galleries of chart variants
+//   - render_spec: Render a declarative grammar spec (see package grammar)
+//
+// Output Formats:
+//
+// Every tool accepts the same output options alongside its chart options:
+//   - format: "svg" (default) returns SVG text; "png" or "jpeg" return an
+//     image content block with the matching MIME type
+//   - scale: pixel scale for raster output, e.g. 2 for high-DPI displays
+//   - dpi: alternative to scale; 96 renders at the chart's pixel size
+//   - quality: JPEG quality, 1-100
+//
+// Raster output is produced by the export package, which does not draw
+// text elements.
 //
 // Gallery Tool:
 //
@@ -90,6 +104,6 @@
 //
 // This package uses:
 //   - charts/ - For chart generation
-//   - export/ - For PNG/JPEG conversion
+//   - export/ - For PNG/JPEG/PDF conversion
 //   - MCP SDK - For protocol implementation
 package mcp
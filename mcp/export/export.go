@@ -4,10 +4,16 @@ import (
 	"bytes"
 	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
 	"image/jpeg"
 	"image/png"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/SCKelemen/units"
 	"github.com/srwiley/oksvg"
 	"github.com/srwiley/rasterx"
 )
@@ -20,15 +26,35 @@ const (
 	FormatPNG  Format = "png"
 	FormatJPEG Format = "jpeg"
 	FormatJPG  Format = "jpg"
+	FormatPDF  Format = "pdf"
 )
 
 // ExportOptions configures export settings
 type ExportOptions struct {
 	Format  Format
-	Width   int // For raster formats, 0 = use SVG dimensions
-	Height  int // For raster formats, 0 = use SVG dimensions
-	Quality int // For JPEG, 0-100 (default 90)
-	DPI     int // Dots per inch (default 96)
+	Width   int     // For raster formats and PDF, 0 = use SVG dimensions
+	Height  int     // For raster formats and PDF, 0 = use SVG dimensions
+	Quality int     // For JPEG, 0-100 (default 90)
+	DPI     int     // Dots per inch (default 96); 192 renders at twice the SVG size
+	Scale   float64 // Pixel scale for SVG dimensions, 0 = DPI/96; takes precedence over DPI
+}
+
+// MaxScale is the largest raster scale factor accepted by Export
+const MaxScale = 10
+
+// scale returns the factor applied to SVG dimensions for raster output
+func (opts ExportOptions) scale() (float64, error) {
+	scale := opts.Scale
+	if scale == 0 && opts.DPI != 0 {
+		scale = float64(opts.DPI) / 96
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	if !(scale > 0) || scale > MaxScale {
+		return 0, fmt.Errorf("scale must be between 0 and %d, got %g", MaxScale, scale)
+	}
+	return scale, nil
 }
 
 // DefaultOptions returns sensible defaults
@@ -47,6 +73,18 @@ func Export(svgData string, opts ExportOptions) ([]byte, error) {
 		return []byte(svgData), nil
 	}
 
+	// PDF is a single vector page, sized in CSS pixels
+	if opts.Format == FormatPDF {
+		page := Page{SVG: svgData}
+		if opts.Width > 0 {
+			page.Width = units.Px(float64(opts.Width))
+		}
+		if opts.Height > 0 {
+			page.Height = units.Px(float64(opts.Height))
+		}
+		return ExportPDF([]Page{page})
+	}
+
 	// For raster formats, we need to rasterize
 	return rasterize(svgData, opts)
 }
@@ -54,19 +92,27 @@ func Export(svgData string, opts ExportOptions) ([]byte, error) {
 // rasterize converts SVG to a raster image format
 func rasterize(svgData string, opts ExportOptions) ([]byte, error) {
 	// Parse SVG
-	icon, err := oksvg.ReadIconStream(strings.NewReader(svgData))
+	icon, err := oksvg.ReadIconStream(strings.NewReader(splitAlpha(svgData)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse SVG: %w", err)
 	}
 
+	scale, err := opts.scale()
+	if err != nil {
+		return nil, err
+	}
+
 	// Determine output dimensions
 	width := opts.Width
 	height := opts.Height
 
 	if width == 0 || height == 0 {
 		// Use SVG dimensions
-		w := int(icon.ViewBox.W)
-		h := int(icon.ViewBox.H)
+		w := int(icon.ViewBox.W * scale)
+		h := int(icon.ViewBox.H * scale)
+		if w <= 0 || h <= 0 {
+			return nil, fmt.Errorf("failed to parse SVG: missing viewBox dimensions")
+		}
 
 		if width == 0 && height == 0 {
 			// Use original dimensions
@@ -87,6 +133,11 @@ func rasterize(svgData string, opts ExportOptions) ([]byte, error) {
 	// Create image
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
 
+	// JPEG has no alpha channel, so transparent areas would encode as black
+	if opts.Format == FormatJPEG || opts.Format == FormatJPG {
+		draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	}
+
 	// Create scanner
 	scanner := rasterx.NewScannerGV(width, height, img, img.Bounds())
 
@@ -124,6 +175,86 @@ func rasterize(svgData string, opts ExportOptions) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// rgbaPaint matches an rgba() fill, stroke or stop color, as an attribute
+// or a style declaration
+var rgbaPaint = regexp.MustCompile(`\b(fill|stroke|stop-color)(\s*=\s*"|\s*:\s*)rgba\(([^,()]+),([^,()]+),([^,()]+),([^,()]+)\)`)
+
+// svgTag matches a start or empty-element tag with its attributes
+var svgTag = regexp.MustCompile(`<[a-zA-Z][^>]*>`)
+
+// opacityDecls match an opacity property's value, as an attribute or a
+// style declaration
+var opacityDecls = map[string]*regexp.Regexp{
+	"fill-opacity":   regexp.MustCompile(`\bfill-opacity(\s*=\s*"|\s*:\s*)([^";]*)`),
+	"stroke-opacity": regexp.MustCompile(`\bstroke-opacity(\s*=\s*"|\s*:\s*)([^";]*)`),
+	"stop-opacity":   regexp.MustCompile(`\bstop-opacity(\s*=\s*"|\s*:\s*)([^";]*)`),
+}
+
+// splitAlpha rewrites rgba() colors, which oksvg can't parse, as rgb()
+// colors with the alpha moved into the matching opacity property
+func splitAlpha(svgData string) string {
+	return svgTag.ReplaceAllStringFunc(svgData, splitTagAlpha)
+}
+
+// splitTagAlpha rewrites the rgba() colors of one tag. The alpha multiplies
+// an opacity the tag already sets, or is added as an attribute, so the tag
+// never sets the same property twice.
+func splitTagAlpha(tag string) string {
+	alphas := make(map[string]float64)
+	var props []string
+	tag = rgbaPaint.ReplaceAllStringFunc(tag, func(m string) string {
+		parts := rgbaPaint.FindStringSubmatch(m)
+		alpha, ok := parseOpacity(parts[6])
+		if !ok {
+			return m
+		}
+		prop := parts[1] + "-opacity"
+		if parts[1] == "stop-color" {
+			prop = "stop-opacity"
+		}
+		if _, seen := alphas[prop]; !seen {
+			props = append(props, prop)
+		}
+		alphas[prop] = alpha
+		return fmt.Sprintf("%s%srgb(%s,%s,%s)", parts[1], parts[2], strings.TrimSpace(parts[3]), strings.TrimSpace(parts[4]), strings.TrimSpace(parts[5]))
+	})
+
+	for _, prop := range props {
+		alpha := alphas[prop]
+		if loc := opacityDecls[prop].FindStringSubmatchIndex(tag); loc != nil {
+			if existing, ok := parseOpacity(tag[loc[4]:loc[5]]); ok {
+				tag = tag[:loc[4]] + formatOpacity(existing*alpha) + tag[loc[5]:]
+			}
+			continue
+		}
+		end := len(tag) - 1
+		if strings.HasSuffix(tag, "/>") {
+			end--
+		}
+		tag = fmt.Sprintf(`%s %s="%s"%s`, strings.TrimRight(tag[:end], " "), prop, formatOpacity(alpha), tag[end:])
+	}
+	return tag
+}
+
+// parseOpacity parses an opacity or alpha value, as a number or percentage
+func parseOpacity(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	scale := 1.0
+	if pct, ok := strings.CutSuffix(s, "%"); ok {
+		s, scale = pct, 0.01
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * scale, true
+}
+
+// formatOpacity formats an opacity to four decimal places
+func formatOpacity(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e4)/1e4, 'g', -1, 64)
+}
+
 // GetMimeType returns the MIME type for a format
 func GetMimeType(format Format) string {
 	switch format {
@@ -133,6 +264,8 @@ func GetMimeType(format Format) string {
 		return "image/png"
 	case FormatJPEG, FormatJPG:
 		return "image/jpeg"
+	case FormatPDF:
+		return "application/pdf"
 	default:
 		return "application/octet-stream"
 	}
@@ -147,6 +280,8 @@ func GetFileExtension(format Format) string {
 		return ".png"
 	case FormatJPEG, FormatJPG:
 		return ".jpg"
+	case FormatPDF:
+		return ".pdf"
 	default:
 		return ".bin"
 	}
@@ -162,6 +297,8 @@ func ParseFormat(s string) (Format, error) {
 		return FormatPNG, nil
 	case "jpeg", "jpg":
 		return FormatJPEG, nil
+	case "pdf":
+		return FormatPDF, nil
 	default:
 		return "", fmt.Errorf("unknown format: %s", s)
 	}
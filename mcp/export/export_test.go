@@ -1,6 +1,10 @@
 package export
 
 import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
 	"strings"
 	"testing"
 )
@@ -216,3 +220,97 @@ func TestUnsupportedFormat(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 }
+
+func TestExportScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		scale    float64
+		dpi      int
+		expected image.Point
+	}{
+		{"default", 0, 0, image.Pt(200, 100)},
+		{"96 dpi", 0, 96, image.Pt(200, 100)},
+		{"192 dpi", 0, 192, image.Pt(400, 200)},
+		{"scale", 1.5, 0, image.Pt(300, 150)},
+		{"scale overrides dpi", 3, 96, image.Pt(600, 300)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Export(testSVG, ExportOptions{Format: FormatPNG, Scale: tt.scale, DPI: tt.dpi})
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(result))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if size := img.Bounds().Size(); size != tt.expected {
+				t.Errorf("size = %v, expected %v", size, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Export(testSVG, ExportOptions{Format: FormatPNG, Scale: MaxScale + 1}); err == nil {
+		t.Error("Export should fail with a scale above MaxScale")
+	}
+}
+
+func TestExportJPEGBackground(t *testing.T) {
+	const transparent = `<svg xmlns="http://www.w3.org/2000/svg" width="20" height="20" viewBox="0 0 20 20"></svg>`
+
+	result, err := Export(transparent, ExportOptions{Format: FormatJPEG})
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(result))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if r, g, b, _ := img.At(10, 10).RGBA(); r>>8 < 250 || g>>8 < 250 || b>>8 < 250 {
+		t.Errorf("transparent pixel encoded as (%d, %d, %d), expected white", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestSplitAlpha(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`<line stroke="rgba(255,255,255,0.1)"/>`, `<line stroke="rgb(255,255,255)" stroke-opacity="0.1"/>`},
+		{`<rect fill="rgba(0, 0, 0, 50%)"/>`, `<rect fill="rgb(0,0,0)" fill-opacity="0.5"/>`},
+		{`<stop offset="1" stop-color="rgba(0,0,0,0)"/>`, `<stop offset="1" stop-color="rgb(0,0,0)" stop-opacity="0"/>`},
+		{`<path style="fill: rgba(1,2,3,0.4); stroke:#000"/>`, `<path style="fill: rgb(1,2,3); stroke:#000" fill-opacity="0.4"/>`},
+		{`<g stroke="rgba(0,0,0,0.2)">`, `<g stroke="rgb(0,0,0)" stroke-opacity="0.2">`},
+
+		// An opacity the element already sets is multiplied, not repeated
+		{`<rect fill="rgba(255,0,0,0.5)" fill-opacity="0.5"/>`, `<rect fill="rgb(255,0,0)" fill-opacity="0.25"/>`},
+		{`<rect fill-opacity="0.5" fill="rgba(255,0,0,0.5)"/>`, `<rect fill-opacity="0.25" fill="rgb(255,0,0)"/>`},
+		{`<path style="stroke: rgba(0,0,0,0.5); stroke-opacity: 0.8"/>`, `<path style="stroke: rgb(0,0,0); stroke-opacity: 0.4"/>`},
+		{`<stop stop-color="rgba(0,0,0,0.5)" stop-opacity="0.5"/>`, `<stop stop-color="rgb(0,0,0)" stop-opacity="0.25"/>`},
+		{`<rect fill="#3b82f6"/>`, `<rect fill="#3b82f6"/>`},
+	}
+	for _, tt := range tests {
+		if got := splitAlpha(tt.in); got != tt.want {
+			t.Errorf("splitAlpha(%s) = %s, expected %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExportRGBA(t *testing.T) {
+	const translucent = `<svg xmlns="http://www.w3.org/2000/svg" width="20" height="20" viewBox="0 0 20 20">
+  <rect width="20" height="20" fill="rgba(255,0,0,0.5)"/>
+</svg>`
+
+	result, err := Export(translucent, ExportOptions{Format: FormatPNG})
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(result))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if _, _, _, a := img.At(10, 10).RGBA(); a>>8 < 100 || a>>8 > 155 {
+		t.Errorf("expected a half-transparent pixel, got alpha %d", a>>8)
+	}
+}
@@ -0,0 +1,267 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SCKelemen/units"
+)
+
+// Page is one page of a PDF document
+type Page struct {
+	SVG    string       // SVG document drawn on the page
+	Width  units.Length // Page width; zero uses the SVG's width, then its viewBox width in px
+	Height units.Length // Page height; zero uses the SVG's height, then its viewBox height in px
+}
+
+// ExportPDF renders SVG documents to a vector PDF with one page per
+// document, e.g. one dashboard per page of a report.
+//
+// Shapes are drawn as PDF paths and text as real text in the standard
+// Helvetica and Courier fonts, so the output stays searchable and no fonts
+// are embedded. The SVG is scaled to fit the page, centered, keeping its
+// aspect ratio. Gradients are approximated with flat fills, and images,
+// clip paths, masks and markers are not drawn.
+func ExportPDF(pages []Page) ([]byte, error) {
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("PDF needs at least one page")
+	}
+
+	doc := &pdfDocument{fonts: make(map[*pdfFont]string)}
+	for i, page := range pages {
+		if err := doc.addPage(page); err != nil {
+			if len(pages) == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("page %d: %w", i+1, err)
+		}
+	}
+	return doc.bytes()
+}
+
+// pdfDocument collects the pages and fonts of a PDF
+type pdfDocument struct {
+	pages []*pdfPage
+	fonts map[*pdfFont]string // Font resource names
+}
+
+// pdfPage is a rendered page
+type pdfPage struct {
+	width, height float64 // In points
+	content       string
+	alphas        map[[2]float64]string // ExtGState names by fill and stroke alpha
+}
+
+// fontResource returns the resource name of a font, adding it to the document
+func (d *pdfDocument) fontResource(f *pdfFont) string {
+	name, ok := d.fonts[f]
+	if !ok {
+		name = fmt.Sprintf("F%d", len(d.fonts)+1)
+		d.fonts[f] = name
+	}
+	return name
+}
+
+// alphaState returns the name of a graphics state with the given fill and
+// stroke alpha, adding it to the page
+func (p *pdfPage) alphaState(fill, stroke float64) string {
+	key := [2]float64{fill, stroke}
+	name, ok := p.alphas[key]
+	if !ok {
+		name = fmt.Sprintf("GS%d", len(p.alphas)+1)
+		p.alphas[key] = name
+	}
+	return name
+}
+
+// addPage renders an SVG document to a new page
+func (d *pdfDocument) addPage(page Page) error {
+	root, err := parseSVGTree(page.SVG)
+	if err != nil {
+		return fmt.Errorf("failed to parse SVG: %w", err)
+	}
+	vx, vy, vw, vh, ok := root.viewBox()
+	if !ok {
+		// Fragments without a size are laid out on the page in pixels
+		w, werr := page.Width.ToPx()
+		h, herr := page.Height.ToPx()
+		if werr != nil || herr != nil || !(w.Value > 0) || !(h.Value > 0) {
+			return fmt.Errorf("failed to parse SVG: missing viewBox dimensions")
+		}
+		vw, vh = w.Value, h.Value
+	}
+
+	width, height, err := pageSize(root, page, vw, vh)
+	if err != nil {
+		return err
+	}
+
+	p := &pdfPage{width: width, height: height, alphas: make(map[[2]float64]string)}
+	r := newRenderer(root, d, p)
+
+	// Flip the page to SVG's y-down coordinates and fit the viewBox,
+	// centered, keeping its aspect ratio
+	scale := math.Min(width/vw, height/vh)
+	r.out.WriteString(matrix{1, 0, 0, -1, 0, height}.cm())
+	r.out.WriteString(matrix{scale, 0, 0, scale, (width-vw*scale)/2 - vx*scale, (height-vh*scale)/2 - vy*scale}.cm())
+
+	style := defaultStyle
+	if root.name == "svg" {
+		// The root's position is the page itself
+		delete(root.attrs, "x")
+		delete(root.attrs, "y")
+	}
+	if err := r.render(root, style); err != nil {
+		return err
+	}
+
+	p.content = r.out.String()
+	d.pages = append(d.pages, p)
+	return nil
+}
+
+// pageSize returns the page size in points: the page's explicit size, then
+// the SVG's width and height, then the viewBox size in pixels. When only
+// one dimension is known, the other keeps the viewBox aspect ratio.
+func pageSize(root *svgNode, page Page, vw, vh float64) (float64, float64, error) {
+	size := func(l units.Length, attr string) (float64, bool, error) {
+		if l.Value != 0 {
+			pt, err := l.To(units.Point)
+			if err != nil {
+				return 0, false, fmt.Errorf("invalid page size %s: %w", l, err)
+			}
+			if !(pt.Value > 0) {
+				return 0, false, fmt.Errorf("invalid page size %s: must be positive", l)
+			}
+			return pt.Value, true, nil
+		}
+		if px, ok := pixels(root.attrs[attr]); ok && px > 0 && root.name == "svg" {
+			return px * 72 / 96, true, nil
+		}
+		return 0, false, nil
+	}
+
+	width, wok, err := size(page.Width, "width")
+	if err != nil {
+		return 0, 0, err
+	}
+	height, hok, err := size(page.Height, "height")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	switch {
+	case wok && hok:
+	case wok:
+		height = width * vh / vw
+	case hok:
+		width = height * vw / vh
+	default:
+		width, height = vw*72/96, vh*72/96
+	}
+	return width, height, nil
+}
+
+// parseLength parses an absolute CSS length such as "400", "400px" or
+// "210mm"; unitless values are pixels
+func parseLength(s string) (units.Length, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return units.Length{}, false
+	}
+	end := len(s)
+	for end > 0 && (s[end-1] >= 'a' && s[end-1] <= 'z' || s[end-1] == 'Q') {
+		end--
+	}
+	v, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil {
+		return units.Length{}, false
+	}
+	unit := units.LengthUnit(s[end:])
+	if unit == "" {
+		unit = units.PX
+	}
+	l := units.Length{Value: v, Unit: unit}
+	if !l.IsAbsolute() {
+		return units.Length{}, false
+	}
+	return l, true
+}
+
+// bytes writes the document as a PDF file
+func (d *pdfDocument) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Object numbers: catalog, page tree, fonts, then a page and its
+	// content stream for each page
+	fonts := make([]*pdfFont, 0, len(d.fonts))
+	for f := range d.fonts {
+		fonts = append(fonts, f)
+	}
+	sort.Slice(fonts, func(i, j int) bool { return d.fonts[fonts[i]] < d.fonts[fonts[j]] })
+	firstFont := 3
+	firstPage := firstFont + len(fonts)
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	var fontRefs strings.Builder
+	for i, f := range fonts {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.name))
+		fmt.Fprintf(&fontRefs, " /%s %d 0 R", d.fonts[f], firstFont+i)
+	}
+
+	for i, p := range d.pages {
+		var states strings.Builder
+		keys := make([][2]float64, 0, len(p.alphas))
+		for key := range p.alphas {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return p.alphas[keys[i]] < p.alphas[keys[j]] })
+		for _, key := range keys {
+			fmt.Fprintf(&states, " /%s << /Type /ExtGState /ca %s /CA %s >>", p.alphas[key], num(key[0]), num(key[1]))
+		}
+
+		resources := fmt.Sprintf("/Font <<%s >>", fontRefs.String())
+		if states.Len() > 0 {
+			resources += fmt.Sprintf(" /ExtGState <<%s >>", states.String())
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents %d 0 R >>",
+			num(p.width), num(p.height), resources, firstPage+2*i+1))
+
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		if _, err := zw.Write([]byte(p.content)); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,158 @@
+package export
+
+import (
+	"strings"
+)
+
+// PDF text is drawn with the standard Type 1 fonts every PDF reader
+// provides, so no font data is embedded. Text is encoded with
+// WinAnsiEncoding; characters outside it are replaced with '?'.
+
+// pdfFont is a standard PDF font
+type pdfFont struct {
+	name   string // Base font name, e.g. "Helvetica-Bold"
+	widths *[95]int
+	fixed  int // Advance width of every glyph for fixed-pitch fonts
+}
+
+// Glyph widths of the printable ASCII characters (32-126) in 1/1000 em,
+// from the Adobe font metrics of the standard fonts
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// Standard fonts by family, weight and style
+var (
+	helvetica            = &pdfFont{name: "Helvetica", widths: &helveticaWidths}
+	helveticaBold        = &pdfFont{name: "Helvetica-Bold", widths: &helveticaBoldWidths}
+	helveticaOblique     = &pdfFont{name: "Helvetica-Oblique", widths: &helveticaWidths}
+	helveticaBoldOblique = &pdfFont{name: "Helvetica-BoldOblique", widths: &helveticaBoldWidths}
+	courier              = &pdfFont{name: "Courier", fixed: 600}
+	courierBold          = &pdfFont{name: "Courier-Bold", fixed: 600}
+	courierOblique       = &pdfFont{name: "Courier-Oblique", fixed: 600}
+	courierBoldOblique   = &pdfFont{name: "Courier-BoldOblique", fixed: 600}
+)
+
+// selectFont picks the standard font closest to a CSS font family, weight
+// and style. Monospace families map to Courier, everything else to Helvetica.
+func selectFont(family, weight, style string) *pdfFont {
+	bold := isBold(weight)
+	italic := style == "italic" || style == "oblique"
+
+	family = strings.ToLower(family)
+	if strings.Contains(family, "mono") || strings.Contains(family, "courier") || strings.Contains(family, "consolas") || strings.Contains(family, "menlo") {
+		switch {
+		case bold && italic:
+			return courierBoldOblique
+		case bold:
+			return courierBold
+		case italic:
+			return courierOblique
+		}
+		return courier
+	}
+
+	switch {
+	case bold && italic:
+		return helveticaBoldOblique
+	case bold:
+		return helveticaBold
+	case italic:
+		return helveticaOblique
+	}
+	return helvetica
+}
+
+// isBold reports whether a CSS font weight renders as bold
+func isBold(weight string) bool {
+	switch weight {
+	case "bold", "bolder", "600", "700", "800", "900":
+		return true
+	}
+	return false
+}
+
+// width returns the advance width of WinAnsi-encoded text in 1/1000 em
+func (f *pdfFont) width(encoded []byte) int {
+	total := 0
+	for _, c := range encoded {
+		switch {
+		case f.fixed > 0:
+			total += f.fixed
+		case c >= 32 && c <= 126:
+			total += f.widths[c-32]
+		case c == 0x95: // bullet
+			total += 350
+		case c == 0x85 || c == 0x97 || c == 0x89: // ellipsis, em dash, per mille
+			total += 1000
+		default:
+			total += 556
+		}
+	}
+	return total
+}
+
+// winAnsi maps the characters of WinAnsiEncoding's 0x80-0x9F range
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encodeWinAnsi encodes text in WinAnsiEncoding
+func encodeWinAnsi(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		case r == '−': // minus sign, common in axis labels
+			encoded = append(encoded, '-')
+		default:
+			if c, ok := winAnsi[r]; ok {
+				encoded = append(encoded, c)
+			} else {
+				encoded = append(encoded, '?')
+			}
+		}
+	}
+	return encoded
+}
+
+// pdfString formats encoded text as a PDF literal string
+func pdfString(encoded []byte) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range encoded {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			b.WriteString("\\")
+			b.WriteByte('0' + c>>6)
+			b.WriteByte('0' + (c>>3)&7)
+			b.WriteByte('0' + c&7)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
@@ -0,0 +1,481 @@
+package export
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// matrix is a 2D affine transform [a b c d e f], mapping (x, y) to
+// (a*x + c*y + e, b*x + d*y + f) as in SVG and PDF
+type matrix [6]float64
+
+// identity is the identity transform
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// then returns the transform that applies n first and m second, so that
+// a transform list "m n" maps p to m(n(p))
+func (m matrix) then(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+// cm returns the PDF operator concatenating the transform to the CTM
+func (m matrix) cm() string {
+	return fmt.Sprintf("%s %s %s %s %s %s cm\n", num(m[0]), num(m[1]), num(m[2]), num(m[3]), num(m[4]), num(m[5]))
+}
+
+// parseTransform parses an SVG transform list such as
+// "translate(10, 20) rotate(-45)"
+func parseTransform(s string) (matrix, error) {
+	m := identity
+	s = strings.TrimSpace(s)
+	for s != "" {
+		open := strings.IndexByte(s, '(')
+		closing := strings.IndexByte(s, ')')
+		if open < 0 || closing < open {
+			return identity, fmt.Errorf("invalid transform %q", s)
+		}
+		name := strings.TrimSpace(strings.Trim(s[:open], ", \t\n"))
+		args, err := parseNumbers(s[open+1 : closing])
+		if err != nil {
+			return identity, fmt.Errorf("invalid transform %q: %w", s, err)
+		}
+		s = strings.TrimSpace(strings.TrimLeft(s[closing+1:], ", \t\n"))
+
+		arg := func(i int, def float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return def
+		}
+
+		var t matrix
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				return identity, fmt.Errorf("matrix needs 6 values, got %d", len(args))
+			}
+			copy(t[:], args)
+		case "translate":
+			t = matrix{1, 0, 0, 1, arg(0, 0), arg(1, 0)}
+		case "scale":
+			sx := arg(0, 1)
+			t = matrix{sx, 0, 0, arg(1, sx), 0, 0}
+		case "rotate":
+			a := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			sin, cos := math.Sincos(a)
+			t = matrix{1, 0, 0, 1, cx, cy}.
+				then(matrix{cos, sin, -sin, cos, 0, 0}).
+				then(matrix{1, 0, 0, 1, -cx, -cy})
+		case "skewX":
+			t = matrix{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = matrix{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return identity, fmt.Errorf("unknown transform %q", name)
+		}
+		m = m.then(t)
+	}
+	return m, nil
+}
+
+// parseNumbers parses a list of numbers separated by commas or whitespace
+func parseNumbers(s string) ([]float64, error) {
+	sc := pathScanner{s: s}
+	var values []float64
+	for {
+		sc.skipSeparators()
+		if sc.done() {
+			return values, nil
+		}
+		v, ok := sc.number()
+		if !ok {
+			return nil, fmt.Errorf("invalid number at %q", s[sc.i:])
+		}
+		values = append(values, v)
+	}
+}
+
+// num formats a number for a PDF content stream
+func num(v float64) string {
+	if math.Abs(v) < 1e-6 {
+		return "0"
+	}
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// pathBuilder writes PDF path construction operators
+type pathBuilder struct {
+	b strings.Builder
+}
+
+func (p *pathBuilder) moveTo(x, y float64) {
+	fmt.Fprintf(&p.b, "%s %s m\n", num(x), num(y))
+}
+
+func (p *pathBuilder) lineTo(x, y float64) {
+	fmt.Fprintf(&p.b, "%s %s l\n", num(x), num(y))
+}
+
+func (p *pathBuilder) curveTo(x1, y1, x2, y2, x, y float64) {
+	fmt.Fprintf(&p.b, "%s %s %s %s %s %s c\n", num(x1), num(y1), num(x2), num(y2), num(x), num(y))
+}
+
+func (p *pathBuilder) close() {
+	p.b.WriteString("h\n")
+}
+
+func (p *pathBuilder) String() string {
+	return p.b.String()
+}
+
+// kappa is the control point distance for approximating a quarter circle
+// with a cubic Bézier curve
+const kappa = 0.5522847498
+
+// ellipse adds a closed ellipse
+func (p *pathBuilder) ellipse(cx, cy, rx, ry float64) {
+	kx, ky := rx*kappa, ry*kappa
+	p.moveTo(cx+rx, cy)
+	p.curveTo(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	p.curveTo(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	p.curveTo(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	p.curveTo(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	p.close()
+}
+
+// rect adds a closed rectangle with optional rounded corners
+func (p *pathBuilder) rect(x, y, w, h, rx, ry float64) {
+	if rx <= 0 || ry <= 0 {
+		p.moveTo(x, y)
+		p.lineTo(x+w, y)
+		p.lineTo(x+w, y+h)
+		p.lineTo(x, y+h)
+		p.close()
+		return
+	}
+	rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+	kx, ky := rx*kappa, ry*kappa
+	p.moveTo(x+rx, y)
+	p.lineTo(x+w-rx, y)
+	p.curveTo(x+w-rx+kx, y, x+w, y+ry-ky, x+w, y+ry)
+	p.lineTo(x+w, y+h-ry)
+	p.curveTo(x+w, y+h-ry+ky, x+w-rx+kx, y+h, x+w-rx, y+h)
+	p.lineTo(x+rx, y+h)
+	p.curveTo(x+rx-kx, y+h, x, y+h-ry+ky, x, y+h-ry)
+	p.lineTo(x, y+ry)
+	p.curveTo(x, y+ry-ky, x+rx-kx, y, x+rx, y)
+	p.close()
+}
+
+// pathScanner tokenizes SVG path data and number lists
+type pathScanner struct {
+	s string
+	i int
+}
+
+func (sc *pathScanner) done() bool {
+	return sc.i >= len(sc.s)
+}
+
+func (sc *pathScanner) skipSeparators() {
+	for sc.i < len(sc.s) {
+		switch sc.s[sc.i] {
+		case ' ', ',', '\t', '\n', '\r', '\f':
+			sc.i++
+		default:
+			return
+		}
+	}
+}
+
+// number scans a number such as "-1.5e3"; "1.5.5" scans as 1.5 and .5
+func (sc *pathScanner) number() (float64, bool) {
+	sc.skipSeparators()
+	start := sc.i
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+	}
+	digits, dot := false, false
+	for sc.i < len(sc.s) {
+		c := sc.s[sc.i]
+		if c >= '0' && c <= '9' {
+			digits = true
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		sc.i++
+	}
+	if !digits {
+		sc.i = start
+		return 0, false
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		j := sc.i + 1
+		if j < len(sc.s) && (sc.s[j] == '+' || sc.s[j] == '-') {
+			j++
+		}
+		if j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+			for j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+				j++
+			}
+			sc.i = j
+		}
+	}
+	v, err := strconv.ParseFloat(sc.s[start:sc.i], 64)
+	if err != nil {
+		sc.i = start
+		return 0, false
+	}
+	return v, true
+}
+
+// flag scans an arc flag, which may be written without a separator
+func (sc *pathScanner) flag() (bool, bool) {
+	sc.skipSeparators()
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return sc.s[sc.i-1] == '1', true
+	}
+	return false, false
+}
+
+// parsePath converts SVG path data to PDF path operators
+func parsePath(d string, p *pathBuilder) error {
+	sc := pathScanner{s: d}
+	var cmd byte
+	var x, y, startX, startY float64 // Current point and subpath start
+	var ctrlX, ctrlY float64         // Last control point, for smooth curves
+	var prev byte                    // Previous command, lowercased
+
+	numbers := func(n int) ([]float64, error) {
+		values := make([]float64, n)
+		for i := range values {
+			v, ok := sc.number()
+			if !ok {
+				return nil, fmt.Errorf("invalid path data at %q", d[sc.i:])
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+
+	for {
+		sc.skipSeparators()
+		if sc.done() {
+			return nil
+		}
+		c := sc.s[sc.i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+			cmd = c
+			sc.i++
+		} else if cmd == 0 {
+			return fmt.Errorf("expected a path command at %q", d[sc.i:])
+		}
+
+		relative := cmd >= 'a'
+		dx, dy := 0.0, 0.0
+		if relative {
+			dx, dy = x, y
+		}
+		lower := cmd | 0x20
+
+		switch lower {
+		case 'z':
+			p.close()
+			x, y = startX, startY
+			prev = lower
+			cmd = 0 // closepath takes no arguments to repeat
+			continue
+
+		case 'm':
+			v, err := numbers(2)
+			if err != nil {
+				return err
+			}
+			x, y = v[0]+dx, v[1]+dy
+			startX, startY = x, y
+			p.moveTo(x, y)
+			// Subsequent pairs are implicit lineto commands
+			if relative {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+
+		case 'l':
+			v, err := numbers(2)
+			if err != nil {
+				return err
+			}
+			x, y = v[0]+dx, v[1]+dy
+			p.lineTo(x, y)
+
+		case 'h':
+			v, err := numbers(1)
+			if err != nil {
+				return err
+			}
+			x = v[0] + dx
+			p.lineTo(x, y)
+
+		case 'v':
+			v, err := numbers(1)
+			if err != nil {
+				return err
+			}
+			y = v[0] + dy
+			p.lineTo(x, y)
+
+		case 'c', 's':
+			var x1, y1 float64
+			var rest []float64
+			if lower == 'c' {
+				v, err := numbers(6)
+				if err != nil {
+					return err
+				}
+				x1, y1, rest = v[0]+dx, v[1]+dy, v[2:]
+			} else {
+				v, err := numbers(4)
+				if err != nil {
+					return err
+				}
+				x1, y1, rest = x, y, v
+				if prev == 'c' || prev == 's' {
+					x1, y1 = 2*x-ctrlX, 2*y-ctrlY
+				}
+			}
+			x2, y2 := rest[0]+dx, rest[1]+dy
+			x, y = rest[2]+dx, rest[3]+dy
+			p.curveTo(x1, y1, x2, y2, x, y)
+			ctrlX, ctrlY = x2, y2
+
+		case 'q', 't':
+			var qx, qy, ex, ey float64
+			if lower == 'q' {
+				v, err := numbers(4)
+				if err != nil {
+					return err
+				}
+				qx, qy, ex, ey = v[0]+dx, v[1]+dy, v[2]+dx, v[3]+dy
+			} else {
+				v, err := numbers(2)
+				if err != nil {
+					return err
+				}
+				qx, qy, ex, ey = x, y, v[0]+dx, v[1]+dy
+				if prev == 'q' || prev == 't' {
+					qx, qy = 2*x-ctrlX, 2*y-ctrlY
+				}
+			}
+			// Elevate the quadratic curve to a cubic one
+			p.curveTo(x+2.0/3*(qx-x), y+2.0/3*(qy-y), ex+2.0/3*(qx-ex), ey+2.0/3*(qy-ey), ex, ey)
+			x, y = ex, ey
+			ctrlX, ctrlY = qx, qy
+
+		case 'a':
+			v, err := numbers(3)
+			if err != nil {
+				return err
+			}
+			large, ok1 := sc.flag()
+			sweep, ok2 := sc.flag()
+			if !ok1 || !ok2 {
+				return fmt.Errorf("invalid arc flags at %q", d[sc.i:])
+			}
+			end, err := numbers(2)
+			if err != nil {
+				return err
+			}
+			ex, ey := end[0]+dx, end[1]+dy
+			arcTo(p, x, y, v[0], v[1], v[2], large, sweep, ex, ey)
+			x, y = ex, ey
+
+		default:
+			return fmt.Errorf("unknown path command %q", cmd)
+		}
+		prev = lower
+	}
+}
+
+// arcTo adds an SVG elliptical arc from (x1, y1) to (x2, y2) as cubic
+// Bézier curves, following the endpoint to center parameterization in
+// the SVG specification's implementation notes
+func arcTo(p *pathBuilder, x1, y1, rx, ry, angle float64, large, sweep bool, x2, y2 float64) {
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.lineTo(x2, y2)
+		return
+	}
+
+	sinPhi, cosPhi := math.Sincos(angle * math.Pi / 180)
+	mx, my := (x1-x2)/2, (y1-y2)/2
+	x1p := cosPhi*mx + sinPhi*my
+	y1p := -sinPhi*mx + cosPhi*my
+
+	// Scale up radii that are too small to span the endpoints
+	if lambda := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); lambda > 1 {
+		s := math.Sqrt(lambda)
+		rx, ry = rx*s, ry*s
+	}
+
+	numerator := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	denominator := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coef := math.Sqrt(math.Max(0, numerator/denominator))
+	if large == sweep {
+		coef = -coef
+	}
+	cxp, cyp := coef*rx*y1p/ry, -coef*ry*x1p/rx
+	cx := cosPhi*cxp - sinPhi*cyp + (x1+x2)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (y1+y2)/2
+
+	vectorAngle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := vectorAngle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	delta := vectorAngle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	// Split into segments of at most 90 degrees
+	segments := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(segments)
+	k := 4.0 / 3 * math.Tan(step/4)
+	point := func(t float64) (float64, float64, float64, float64) {
+		sin, cos := math.Sincos(t)
+		// Point and derivative on the unit circle, mapped onto the ellipse
+		px, py := rx*cos, ry*sin
+		tx, ty := -rx*sin, ry*cos
+		return cx + cosPhi*px - sinPhi*py, cy + sinPhi*px + cosPhi*py,
+			cosPhi*tx - sinPhi*ty, sinPhi*tx + cosPhi*ty
+	}
+	sx, sy, stx, sty := point(theta)
+	for i := 1; i <= segments; i++ {
+		ex, ey, etx, ety := point(theta + step*float64(i))
+		if i == segments {
+			ex, ey = x2, y2
+		}
+		p.curveTo(sx+k*stx, sy+k*sty, ex-k*etx, ey-k*ety, ex, ey)
+		sx, sy, stx, sty = ex, ey, etx, ety
+	}
+}
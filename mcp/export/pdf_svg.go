@@ -0,0 +1,667 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/SCKelemen/color"
+)
+
+// svgNode is an element or character data in a parsed SVG document
+type svgNode struct {
+	name     string // Element name, or "" for character data
+	attrs    map[string]string
+	children []*svgNode
+	text     string // Character data
+}
+
+// parseSVGTree parses an SVG document into a tree of nodes. A fragment with
+// several top-level elements is returned under a synthetic "g" root.
+func parseSVGTree(data string) (*svgNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(data))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	root := &svgNode{name: "g", attrs: map[string]string{}}
+	stack := []*svgNode{root}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &svgNode{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, attr := range t.Attr {
+				node.attrs[attr.Name.Local] = attr.Value
+			}
+			// Inline style declarations override presentation attributes
+			for _, decl := range strings.Split(node.attrs["style"], ";") {
+				if name, value, ok := strings.Cut(decl, ":"); ok {
+					node.attrs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+				}
+			}
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			parent.children = append(parent.children, &svgNode{text: string(t)})
+		}
+	}
+
+	var elements []*svgNode
+	for _, child := range root.children {
+		if child.name != "" {
+			elements = append(elements, child)
+		}
+	}
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("no SVG elements")
+	}
+	if len(elements) == 1 && elements[0].name == "svg" {
+		return elements[0], nil
+	}
+	return root, nil
+}
+
+// viewBox returns the root element's viewBox, falling back to its width
+// and height in pixels
+func (n *svgNode) viewBox() (x, y, w, h float64, ok bool) {
+	if v, err := parseNumbers(n.attrs["viewBox"]); err == nil && len(v) == 4 && v[2] > 0 && v[3] > 0 {
+		return v[0], v[1], v[2], v[3], true
+	}
+	w, wok := pixels(n.attrs["width"])
+	h, hok := pixels(n.attrs["height"])
+	if wok && hok && w > 0 && h > 0 {
+		return 0, 0, w, h, true
+	}
+	return 0, 0, 0, 0, false
+}
+
+// rgb is an sRGB color with components in [0, 1]
+type rgb [3]float64
+
+// paint is a resolved fill or stroke
+type paint struct {
+	color rgb
+	alpha float64
+}
+
+// svgStyle holds the inherited presentation properties of an element
+type svgStyle struct {
+	fill, stroke      string
+	color             string // currentColor
+	fillOpacity       float64
+	strokeOpacity     float64
+	opacity           float64 // Group opacity, approximated by multiplying into descendants
+	strokeWidth       float64
+	dashArray         string
+	lineCap, lineJoin string
+	fillRule          string
+	fontFamily        string
+	fontSize          float64
+	fontWeight        string
+	fontStyle         string
+	textAnchor        string
+	baseline          string
+}
+
+// defaultStyle is the initial value of every property
+var defaultStyle = svgStyle{
+	fill:          "black",
+	stroke:        "none",
+	color:         "black",
+	fillOpacity:   1,
+	strokeOpacity: 1,
+	opacity:       1,
+	strokeWidth:   1,
+	fontSize:      16,
+	fontWeight:    "normal",
+	fontStyle:     "normal",
+	textAnchor:    "start",
+}
+
+// inherit returns the style of a child element with the given attributes
+func (s svgStyle) inherit(attrs map[string]string) svgStyle {
+	set := func(name string, target *string) {
+		if v, ok := attrs[name]; ok && v != "inherit" {
+			*target = v
+		}
+	}
+	setNumber := func(name string, target *float64) {
+		if v, ok := attrs[name]; ok {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				*target = f
+			}
+		}
+	}
+
+	set("fill", &s.fill)
+	set("stroke", &s.stroke)
+	set("color", &s.color)
+	set("stroke-dasharray", &s.dashArray)
+	set("stroke-linecap", &s.lineCap)
+	set("stroke-linejoin", &s.lineJoin)
+	set("fill-rule", &s.fillRule)
+	set("font-family", &s.fontFamily)
+	set("font-weight", &s.fontWeight)
+	set("font-style", &s.fontStyle)
+	set("text-anchor", &s.textAnchor)
+	set("dominant-baseline", &s.baseline)
+	set("alignment-baseline", &s.baseline)
+	setNumber("fill-opacity", &s.fillOpacity)
+	setNumber("stroke-opacity", &s.strokeOpacity)
+
+	if v, ok := attrs["stroke-width"]; ok {
+		if w, ok := length(v, s.fontSize); ok {
+			s.strokeWidth = w
+		}
+	}
+	if v, ok := attrs["font-size"]; ok {
+		if size, ok := length(v, s.fontSize); ok {
+			s.fontSize = size
+		}
+	}
+
+	opacity := 1.0
+	setNumber("opacity", &opacity)
+	s.opacity *= clamp(opacity)
+	return s
+}
+
+// clamp limits an opacity to [0, 1]
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// pixels parses an absolute SVG length such as "400", "400px" or "10mm"
+// in pixels
+func pixels(s string) (float64, bool) {
+	l, ok := parseLength(s)
+	if !ok {
+		return 0, false
+	}
+	px, err := l.ToPx()
+	if err != nil {
+		return 0, false
+	}
+	return px.Value, true
+}
+
+// length parses an SVG length in pixels, resolving em against fontSize
+func length(s string, fontSize float64) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if v, ok := strings.CutSuffix(s, "em"); ok && !strings.HasSuffix(v, "r") {
+		f, err := strconv.ParseFloat(v, 64)
+		return f * fontSize, err == nil
+	}
+	return pixels(s)
+}
+
+// renderer draws SVG elements to a PDF content stream
+type renderer struct {
+	out       strings.Builder
+	ids       map[string]*svgNode
+	gradients map[string]paint // Flat approximations of gradients
+	doc       *pdfDocument
+	page      *pdfPage
+	depth     int // <use> nesting depth
+}
+
+// newRenderer creates a renderer for a document, indexing its ids and
+// gradients
+func newRenderer(root *svgNode, doc *pdfDocument, page *pdfPage) *renderer {
+	r := &renderer{
+		ids:       make(map[string]*svgNode),
+		gradients: make(map[string]paint),
+		doc:       doc,
+		page:      page,
+	}
+	var index func(n *svgNode)
+	index = func(n *svgNode) {
+		if id := n.attrs["id"]; id != "" {
+			r.ids[id] = n
+		}
+		for _, child := range n.children {
+			index(child)
+		}
+	}
+	index(root)
+
+	for id, n := range r.ids {
+		if n.name == "linearGradient" || n.name == "radialGradient" {
+			if p, ok := r.gradientPaint(n, 0); ok {
+				r.gradients[id] = p
+			}
+		}
+	}
+	return r
+}
+
+// gradientPaint approximates a gradient with a flat paint: the first
+// stop's color at the gradient's mean opacity
+func (r *renderer) gradientPaint(n *svgNode, depth int) (paint, bool) {
+	var stops []*svgNode
+	for _, child := range n.children {
+		if child.name == "stop" {
+			stops = append(stops, child)
+		}
+	}
+	if len(stops) == 0 {
+		// Gradients can inherit their stops from another gradient
+		href := strings.TrimPrefix(n.attrs["href"], "#")
+		if ref, ok := r.ids[href]; ok && depth < 8 {
+			return r.gradientPaint(ref, depth+1)
+		}
+		return paint{}, false
+	}
+
+	var result paint
+	for i, stop := range stops {
+		c, err := color.ParseColor(stop.attrs["stop-color"])
+		if err != nil {
+			c = color.RGB(0, 0, 0)
+		}
+		red, green, blue, alpha := c.RGBA()
+		if v, err := strconv.ParseFloat(stop.attrs["stop-opacity"], 64); err == nil {
+			alpha *= clamp(v)
+		}
+		if i == 0 {
+			result.color = rgb{red, green, blue}
+		}
+		result.alpha += alpha / float64(len(stops))
+	}
+	return result, true
+}
+
+// resolvePaint resolves a fill or stroke value
+func (r *renderer) resolvePaint(value, current string, opacity float64) (paint, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "none" || value == "transparent" {
+		return paint{}, false
+	}
+	if value == "currentColor" {
+		value = current
+	}
+	if strings.HasPrefix(value, "url(") {
+		end := strings.IndexByte(value, ')')
+		if end < 0 {
+			return paint{}, false
+		}
+		id := strings.TrimPrefix(strings.TrimSpace(value[4:end]), "#")
+		if p, ok := r.gradients[id]; ok {
+			p.alpha *= opacity
+			return p, p.alpha > 0
+		}
+		// Fall back to the color after the reference, if any
+		return r.resolvePaint(value[end+1:], current, opacity)
+	}
+
+	c, err := color.ParseColor(value)
+	if err != nil {
+		return paint{}, false
+	}
+	red, green, blue, alpha := c.RGBA()
+	alpha *= opacity
+	return paint{color: rgb{red, green, blue}, alpha: alpha}, alpha > 0
+}
+
+// skipped lists elements that are not drawn directly
+var skipped = map[string]bool{
+	"defs": true, "clipPath": true, "mask": true, "marker": true, "pattern": true,
+	"symbol": true, "style": true, "title": true, "desc": true, "metadata": true,
+	"script": true, "linearGradient": true, "radialGradient": true,
+	"image": true, "foreignObject": true,
+}
+
+// render draws an element and its descendants
+func (r *renderer) render(n *svgNode, parent svgStyle) error {
+	if n.name == "" || skipped[n.name] || n.attrs["display"] == "none" {
+		return nil
+	}
+	style := parent.inherit(n.attrs)
+
+	transform := identity
+	if t, ok := n.attrs["transform"]; ok {
+		var err error
+		if transform, err = parseTransform(t); err != nil {
+			return err
+		}
+	}
+	if n.name == "svg" || n.name == "use" {
+		x, _ := length(n.attrs["x"], style.fontSize)
+		y, _ := length(n.attrs["y"], style.fontSize)
+		transform = transform.then(matrix{1, 0, 0, 1, x, y})
+	}
+
+	r.out.WriteString("q\n")
+	if transform != identity {
+		r.out.WriteString(transform.cm())
+	}
+	defer r.out.WriteString("Q\n")
+
+	if n.attrs["visibility"] == "hidden" && n.name != "g" {
+		return nil
+	}
+
+	switch n.name {
+	case "svg", "g", "a", "switch":
+		for _, child := range n.children {
+			if err := r.render(child, style); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "use":
+		ref, ok := r.ids[strings.TrimPrefix(n.attrs["href"], "#")]
+		if !ok || r.depth > 8 {
+			return nil
+		}
+		r.depth++
+		defer func() { r.depth-- }()
+		if ref.name == "symbol" {
+			ref = &svgNode{name: "g", attrs: ref.attrs, children: ref.children}
+		}
+		return r.render(ref, style)
+
+	case "text":
+		return r.text(n, style)
+	}
+
+	var p pathBuilder
+	closed := true
+	number := func(name string) float64 {
+		v, _ := length(n.attrs[name], style.fontSize)
+		return v
+	}
+
+	switch n.name {
+	case "rect":
+		w, h := number("width"), number("height")
+		if w <= 0 || h <= 0 {
+			return nil
+		}
+		rx, rxSet := length(n.attrs["rx"], style.fontSize)
+		ry, rySet := length(n.attrs["ry"], style.fontSize)
+		if !rySet {
+			ry = rx
+		}
+		if !rxSet {
+			rx = ry
+		}
+		p.rect(number("x"), number("y"), w, h, rx, ry)
+	case "circle":
+		radius := number("r")
+		if radius <= 0 {
+			return nil
+		}
+		p.ellipse(number("cx"), number("cy"), radius, radius)
+	case "ellipse":
+		rx, ry := number("rx"), number("ry")
+		if rx <= 0 || ry <= 0 {
+			return nil
+		}
+		p.ellipse(number("cx"), number("cy"), rx, ry)
+	case "line":
+		p.moveTo(number("x1"), number("y1"))
+		p.lineTo(number("x2"), number("y2"))
+		closed = false
+	case "polyline", "polygon":
+		points, err := parseNumbers(n.attrs["points"])
+		if err != nil {
+			return fmt.Errorf("invalid %s points: %w", n.name, err)
+		}
+		if len(points) < 4 {
+			return nil
+		}
+		p.moveTo(points[0], points[1])
+		for i := 2; i+1 < len(points); i += 2 {
+			p.lineTo(points[i], points[i+1])
+		}
+		if n.name == "polygon" {
+			p.close()
+		}
+	case "path":
+		if err := parsePath(n.attrs["d"], &p); err != nil {
+			return err
+		}
+	default:
+		// Unknown elements are ignored, as in browsers
+		return nil
+	}
+
+	r.paintPath(p.String(), style, closed)
+	return nil
+}
+
+// paintPath fills and strokes a constructed path
+func (r *renderer) paintPath(path string, style svgStyle, fillable bool) {
+	fill, hasFill := r.resolvePaint(style.fill, style.color, style.fillOpacity*style.opacity)
+	stroke, hasStroke := r.resolvePaint(style.stroke, style.color, style.strokeOpacity*style.opacity)
+	hasFill = hasFill && fillable
+	hasStroke = hasStroke && style.strokeWidth > 0
+	if !hasFill && !hasStroke {
+		return
+	}
+
+	fillAlpha, strokeAlpha := 1.0, 1.0
+	if hasFill {
+		fillAlpha = fill.alpha
+		fmt.Fprintf(&r.out, "%s %s %s rg\n", num(fill.color[0]), num(fill.color[1]), num(fill.color[2]))
+	}
+	if hasStroke {
+		strokeAlpha = stroke.alpha
+		fmt.Fprintf(&r.out, "%s %s %s RG\n", num(stroke.color[0]), num(stroke.color[1]), num(stroke.color[2]))
+		r.strokeState(style)
+	}
+	if fillAlpha < 1 || strokeAlpha < 1 {
+		fmt.Fprintf(&r.out, "/%s gs\n", r.page.alphaState(fillAlpha, strokeAlpha))
+	}
+
+	r.out.WriteString(path)
+	evenOdd := style.fillRule == "evenodd"
+	switch {
+	case hasFill && hasStroke && evenOdd:
+		r.out.WriteString("B*\n")
+	case hasFill && hasStroke:
+		r.out.WriteString("B\n")
+	case hasFill && evenOdd:
+		r.out.WriteString("f*\n")
+	case hasFill:
+		r.out.WriteString("f\n")
+	default:
+		r.out.WriteString("S\n")
+	}
+}
+
+// strokeState writes the line width, dash, cap and join operators
+func (r *renderer) strokeState(style svgStyle) {
+	fmt.Fprintf(&r.out, "%s w\n", num(style.strokeWidth))
+
+	if style.dashArray != "" && style.dashArray != "none" {
+		if dashes, err := parseNumbers(style.dashArray); err == nil && len(dashes) > 0 {
+			if len(dashes)%2 == 1 {
+				dashes = append(dashes, dashes...)
+			}
+			parts := make([]string, len(dashes))
+			for i, d := range dashes {
+				parts[i] = num(d)
+			}
+			fmt.Fprintf(&r.out, "[%s] 0 d\n", strings.Join(parts, " "))
+		}
+	}
+
+	switch style.lineCap {
+	case "round":
+		r.out.WriteString("1 J\n")
+	case "square":
+		r.out.WriteString("2 J\n")
+	}
+	switch style.lineJoin {
+	case "round":
+		r.out.WriteString("1 j\n")
+	case "bevel":
+		r.out.WriteString("2 j\n")
+	}
+}
+
+// textRun is a span of text drawn with one style
+type textRun struct {
+	text   string
+	style  svgStyle
+	x, y   *float64 // Absolute position, if set
+	dx, dy float64
+}
+
+// text draws a text element and its tspans as PDF text
+func (r *renderer) text(n *svgNode, style svgStyle) error {
+	var runs []textRun
+	var collect func(n *svgNode, style svgStyle)
+	collect = func(n *svgNode, style svgStyle) {
+		// An empty run carries the element's position to the runs after it
+		run := textRun{style: style}
+		position := func(name string, target **float64, offset *float64) {
+			if v, err := parseNumbers(n.attrs[name]); err == nil && len(v) > 0 {
+				*target = &v[0]
+			}
+			if d, ok := length(n.attrs["d"+name], style.fontSize); ok {
+				*offset = d
+			}
+		}
+		position("x", &run.x, &run.dx)
+		position("y", &run.y, &run.dy)
+		runs = append(runs, run)
+
+		for _, child := range n.children {
+			if child.name == "" {
+				runs = append(runs, textRun{text: child.text, style: style})
+			} else if child.name == "tspan" && child.attrs["display"] != "none" {
+				collect(child, style.inherit(child.attrs))
+			}
+		}
+	}
+	collect(n, style)
+	collapseWhitespace(runs)
+
+	// Lay out runs into chunks that start at each absolute x position, so
+	// the text anchor applies to each chunk
+	type placed struct {
+		run     textRun
+		font    *pdfFont
+		encoded []byte
+		x, y    float64
+		width   float64
+	}
+	var chunks [][]placed
+	x, y := 0.0, 0.0
+	for i, run := range runs {
+		if run.x != nil {
+			x = *run.x
+		}
+		if run.y != nil {
+			y = *run.y
+		}
+		x += run.dx
+		y += run.dy
+		if i == 0 || run.x != nil {
+			chunks = append(chunks, nil)
+		}
+		if run.text == "" {
+			continue
+		}
+		font := selectFont(run.style.fontFamily, run.style.fontWeight, run.style.fontStyle)
+		encoded := encodeWinAnsi(run.text)
+		width := float64(font.width(encoded)) / 1000 * run.style.fontSize
+		chunks[len(chunks)-1] = append(chunks[len(chunks)-1], placed{run: run, font: font, encoded: encoded, x: x, y: y, width: width})
+		x += width
+	}
+
+	for _, chunk := range chunks {
+		if len(chunk) == 0 {
+			continue
+		}
+		total := chunk[len(chunk)-1].x + chunk[len(chunk)-1].width - chunk[0].x
+		shift := 0.0
+		switch chunk[0].run.style.textAnchor {
+		case "middle":
+			shift = -total / 2
+		case "end":
+			shift = -total
+		}
+		for _, p := range chunk {
+			fill, ok := r.resolvePaint(p.run.style.fill, p.run.style.color, p.run.style.fillOpacity*p.run.style.opacity)
+			if !ok {
+				continue
+			}
+			size := p.run.style.fontSize
+			r.out.WriteString("q\n")
+			if fill.alpha < 1 {
+				fmt.Fprintf(&r.out, "/%s gs\n", r.page.alphaState(fill.alpha, 1))
+			}
+			fmt.Fprintf(&r.out, "BT\n/%s %s Tf\n%s %s %s rg\n", r.doc.fontResource(p.font), num(size),
+				num(fill.color[0]), num(fill.color[1]), num(fill.color[2]))
+			// The page is flipped to SVG's y-down coordinates, so flip glyphs back
+			fmt.Fprintf(&r.out, "1 0 0 -1 %s %s Tm\n", num(p.x+shift), num(p.y+baselineShift(p.run.style.baseline)*size))
+			fmt.Fprintf(&r.out, "%s Tj\nET\nQ\n", pdfString(p.encoded))
+		}
+	}
+	return nil
+}
+
+// collapseWhitespace collapses runs of whitespace to single spaces across
+// text runs and trims the ends, as SVG does by default
+func collapseWhitespace(runs []textRun) {
+	space := true // Drop leading whitespace
+	last := -1
+	for i := range runs {
+		var b strings.Builder
+		for _, c := range runs[i].text {
+			if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+				if !space {
+					b.WriteByte(' ')
+				}
+				space = true
+				continue
+			}
+			b.WriteRune(c)
+			space = false
+		}
+		runs[i].text = b.String()
+		if runs[i].text != "" {
+			last = i
+		}
+	}
+	if last >= 0 {
+		runs[last].text = strings.TrimRight(runs[last].text, " ")
+	}
+}
+
+// baselineShift returns the offset from the alphabetic baseline to a
+// dominant baseline, in em
+func baselineShift(baseline string) float64 {
+	switch baseline {
+	case "middle", "central":
+		return 0.35
+	case "hanging":
+		return 0.72
+	case "text-top", "text-before-edge", "before-edge":
+		return 0.77
+	case "text-bottom", "text-after-edge", "after-edge", "ideographic":
+		return -0.23
+	}
+	return 0
+}
@@ -0,0 +1,234 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/units"
+)
+
+const textSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="400" height="300" viewBox="0 0 400 300">
+  <rect x="10" y="10" width="100" height="50" fill="#3b82f6" fill-opacity="0.5"/>
+  <g transform="translate(200, 150)">
+    <path d="M0,0 L50,0 A50,50 0 0,1 0,50 Z" style="fill: red; stroke: black; stroke-width: 2"/>
+    <text x="0" y="-20" font-size="14px" font-weight="bold" text-anchor="middle">Revenue (Q1)</text>
+  </g>
+</svg>`
+
+// readPDF checks the file structure and returns the decompressed content
+// streams
+func readPDF(t *testing.T, pdf []byte) []string {
+	t.Helper()
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", pdf[:min(len(pdf), 16)])
+	}
+	if !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("missing EOF trailer")
+	}
+
+	// Every xref entry must point at its object
+	start := bytes.LastIndex(pdf, []byte("startxref\n"))
+	xref, err := strconv.Atoi(strings.Fields(string(pdf[start+len("startxref\n"):]))[0])
+	if err != nil || !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(pdf[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")) {
+			t.Errorf("xref entry %d does not point at its object", i+1)
+		}
+	}
+
+	var streams []string
+	for _, m := range regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`).FindAllSubmatchIndex(pdf, -1) {
+		n, _ := strconv.Atoi(string(pdf[m[2]:m[3]]))
+		zr, err := zlib.NewReader(bytes.NewReader(pdf[m[1] : m[1]+n]))
+		if err != nil {
+			t.Fatalf("invalid content stream: %v", err)
+		}
+		content, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatalf("invalid content stream: %v", err)
+		}
+		streams = append(streams, string(content))
+	}
+	return streams
+}
+
+func TestExportPDF(t *testing.T) {
+	pdf, err := ExportPDF([]Page{{SVG: textSVG}})
+	if err != nil {
+		t.Fatalf("ExportPDF failed: %v", err)
+	}
+	streams := readPDF(t, pdf)
+	if len(streams) != 1 {
+		t.Fatalf("got %d content streams, expected 1", len(streams))
+	}
+	content := streams[0]
+
+	// 400x300 CSS pixels is 300x225 points
+	if !bytes.Contains(pdf, []byte("/MediaBox [0 0 300 225]")) {
+		t.Error("page size is not derived from the SVG size")
+	}
+
+	// Text stays text, in the standard bold font
+	if !strings.Contains(content, `(Revenue \(Q1\)) Tj`) {
+		t.Errorf("content does not draw the text as text:\n%s", content)
+	}
+	if !bytes.Contains(pdf, []byte("/BaseFont /Helvetica-Bold")) {
+		t.Error("bold text does not use Helvetica-Bold")
+	}
+
+	// Shapes are vector paths with their colors and opacity
+	for _, op := range []string{"0.2314 0.5098 0.9647 rg", "1 0 0 rg", "2 w", " c\n", "B\n", "/GS1 gs"} {
+		if !strings.Contains(content, op) {
+			t.Errorf("content is missing %q", op)
+		}
+	}
+	if !bytes.Contains(pdf, []byte("/ca 0.5 /CA 1")) {
+		t.Error("fill opacity is not exported as a graphics state")
+	}
+}
+
+func TestExportPDFPages(t *testing.T) {
+	dashboard := `<svg viewBox="0 0 800 600" xmlns="http://www.w3.org/2000/svg"><circle cx="400" cy="300" r="100"/></svg>`
+
+	pdf, err := ExportPDF([]Page{
+		{SVG: textSVG},
+		{SVG: dashboard, Width: units.Mm(297), Height: units.Mm(210)},
+		{SVG: dashboard, Width: units.In(8)},
+	})
+	if err != nil {
+		t.Fatalf("ExportPDF failed: %v", err)
+	}
+	if streams := readPDF(t, pdf); len(streams) != 3 {
+		t.Fatalf("got %d content streams, expected 3", len(streams))
+	}
+	if !bytes.Contains(pdf, []byte("/Count 3")) {
+		t.Error("page tree does not count 3 pages")
+	}
+
+	tests := []string{
+		"/MediaBox [0 0 300 225]",
+		"/MediaBox [0 0 841.8898 595.2756]", // A4 landscape
+		"/MediaBox [0 0 576 432]",           // 8in wide, keeping the 4:3 viewBox
+	}
+	for _, expected := range tests {
+		if !bytes.Contains(pdf, []byte(expected)) {
+			t.Errorf("missing %s", expected)
+		}
+	}
+}
+
+func TestExportPDFErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages []Page
+	}{
+		{"no pages", nil},
+		{"invalid SVG", []Page{{SVG: "not valid svg"}}},
+		{"no size", []Page{{SVG: `<svg xmlns="http://www.w3.org/2000/svg"><rect width="1" height="1"/></svg>`}}},
+		{"relative page size", []Page{{SVG: textSVG, Width: units.Em(10)}}},
+		{"invalid path", []Page{{SVG: `<svg viewBox="0 0 10 10"><path d="M 0 0 L x"/></svg>`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExportPDF(tt.pages); err == nil {
+				t.Error("ExportPDF should fail")
+			}
+		})
+	}
+}
+
+func TestExportFormatPDF(t *testing.T) {
+	// Chart renderers produce fragments; Width and Height size the page
+	fragment := `<rect x="0" y="0" width="100" height="40" fill="steelblue"/><text x="50" y="20">A</text>`
+
+	pdf, err := Export(fragment, ExportOptions{Format: FormatPDF, Width: 200, Height: 80})
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	readPDF(t, pdf)
+	if !bytes.Contains(pdf, []byte("/MediaBox [0 0 150 60]")) {
+		t.Error("page size is not derived from Width and Height")
+	}
+
+	if format, err := ParseFormat("PDF"); err != nil || format != FormatPDF {
+		t.Errorf("ParseFormat(PDF) = %v, %v", format, err)
+	}
+	if GetMimeType(FormatPDF) != "application/pdf" || GetFileExtension(FormatPDF) != ".pdf" {
+		t.Error("wrong MIME type or extension for PDF")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		d        string
+		expected string
+	}{
+		{"M10,20 L30 40 h10 v-10 z", "10 20 m\n30 40 l\n40 40 l\n40 30 l\nh\n"},
+		{"m10 20 10 0 0 10", "10 20 m\n20 20 l\n20 30 l\n"},
+		{"M0 0Q10 10 20 0", "0 0 m\n6.6667 6.6667 13.3333 6.6667 20 0 c\n"},
+		{"M-1.5.5e1", "-1.5 5 m\n"},
+		// A half circle arc from (0,0) to (20,0) ends exactly at its endpoint
+		{"M0 0A10 10 0 0 1 20 0", "0 0 m\n0 -5.5228 4.4772 -10 10 -10 c\n15.5228 -10 20 -5.5228 20 0 c\n"},
+		// Packed arc flags
+		{"M0 0a10 10 0 0120 0", "0 0 m\n0 -5.5228 4.4772 -10 10 -10 c\n15.5228 -10 20 -5.5228 20 0 c\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d, func(t *testing.T) {
+			var p pathBuilder
+			if err := parsePath(tt.d, &p); err != nil {
+				t.Fatalf("parsePath failed: %v", err)
+			}
+			if p.String() != tt.expected {
+				t.Errorf("parsePath(%q) =\n%s\nexpected\n%s", tt.d, p.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTransform(t *testing.T) {
+	tests := []struct {
+		transform string
+		expected  matrix
+	}{
+		{"translate(10, 20)", matrix{1, 0, 0, 1, 10, 20}},
+		{"translate(10) scale(2)", matrix{2, 0, 0, 2, 10, 0}},
+		{"rotate(90)", matrix{0, 1, -1, 0, 0, 0}},
+		{"rotate(180, 5, 5)", matrix{-1, 0, 0, -1, 10, 10}},
+		{"matrix(1 2 3 4 5 6)", matrix{1, 2, 3, 4, 5, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transform, func(t *testing.T) {
+			m, err := parseTransform(tt.transform)
+			if err != nil {
+				t.Fatalf("parseTransform failed: %v", err)
+			}
+			for i := range m {
+				if diff := m[i] - tt.expected[i]; diff > 1e-9 || diff < -1e-9 {
+					t.Fatalf("parseTransform(%q) = %v, expected %v", tt.transform, m, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWinAnsi(t *testing.T) {
+	encoded := encodeWinAnsi("€5 − café ✓")
+	if string(encoded) != "\x805 - caf\xe9 ?" {
+		t.Errorf("encodeWinAnsi = %q", encoded)
+	}
+	if s := pdfString(encoded); s != `(\2005 - caf\351 ?)` {
+		t.Errorf("pdfString = %s", s)
+	}
+}
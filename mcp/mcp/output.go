@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SCKelemen/dataviz/mcp/export"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// outputOptions are the output settings accepted by every chart tool
+type outputOptions struct {
+	Format  string  `json:"format"`
+	Scale   float64 `json:"scale"`
+	DPI     int     `json:"dpi"`
+	Quality int     `json:"quality"`
+}
+
+// outputProperties returns the input schema properties for outputOptions
+func outputProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"format": map[string]interface{}{
+			"type":        "string",
+			"description": "Output format: 'svg' returns SVG text, 'png' or 'jpeg' return an image",
+			"enum":        []string{"svg", "png", "jpeg", "jpg"},
+			"default":     "svg",
+		},
+		"scale": map[string]interface{}{
+			"type":        "number",
+			"description": "Pixel scale for raster output, e.g. 2 for high-DPI displays (overrides dpi)",
+			"default":     1,
+		},
+		"dpi": map[string]interface{}{
+			"type":        "number",
+			"description": "Dots per inch for raster output; 96 renders at the chart's pixel size",
+			"default":     96,
+		},
+		"quality": map[string]interface{}{
+			"type":        "number",
+			"description": "JPEG quality, 1-100",
+			"default":     90,
+		},
+	}
+}
+
+// addTool registers a chart tool, adding the output options to its schema
+func (s *Server) addTool(tool *mcp.Tool, handler mcp.ToolHandler) {
+	if schema, ok := tool.InputSchema.(map[string]interface{}); ok {
+		properties, ok := schema["properties"].(map[string]interface{})
+		if !ok {
+			properties = make(map[string]interface{})
+			schema["properties"] = properties
+		}
+		for name, property := range outputProperties() {
+			if _, exists := properties[name]; !exists {
+				properties[name] = property
+			}
+		}
+	}
+	s.server.AddTool(tool, handler)
+	s.tools++
+}
+
+// chartResult returns a rendered chart in the output format requested by
+// the tool call: SVG text by default, or an image content block for raster
+// formats. width and height size the document that wraps SVG fragments
+// before rasterizing; they are ignored for complete SVG documents.
+func chartResult(request *mcp.CallToolRequest, svg string, width, height int) (*mcp.CallToolResult, error) {
+	var opts outputOptions
+	if err := parseArguments(request.Params.Arguments, &opts); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	format := export.FormatSVG
+	if opts.Format != "" {
+		var err error
+		if format, err = export.ParseFormat(opts.Format); err != nil {
+			return nil, err
+		}
+	}
+	if format == export.FormatPDF {
+		return nil, fmt.Errorf("pdf output is not supported by chart tools; use svg, png or jpeg")
+	}
+
+	if format == export.FormatSVG {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("```svg\n%s\n```", svg),
+				},
+			},
+		}, nil
+	}
+
+	data, err := export.Export(document(svg, width, height), export.ExportOptions{
+		Format:  format,
+		Quality: opts.Quality,
+		DPI:     opts.DPI,
+		Scale:   opts.Scale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", format, err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.ImageContent{
+				Data:     data,
+				MIMEType: export.GetMimeType(format),
+			},
+		},
+	}, nil
+}
+
+// document wraps an SVG fragment in a standalone SVG document of the given
+// size, returning complete documents unchanged
+func document(svg string, width, height int) string {
+	trimmed := strings.TrimSpace(svg)
+	if strings.HasPrefix(trimmed, "<svg") || strings.HasPrefix(trimmed, "<?xml") {
+		return svg
+	}
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">%s</svg>`,
+		width, height, width, height, svg)
+}
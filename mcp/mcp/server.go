@@ -10,6 +10,7 @@ import (
 // Server represents the DataViz MCP server
 type Server struct {
 	server *mcp.Server
+	tools  int // Number of registered tools
 }
 
 // NewServer creates a new DataViz MCP server
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/SCKelemen/dataviz/grammar"
 	"github.com/SCKelemen/dataviz/internal/gallery"
 	"github.com/SCKelemen/dataviz/mcp/charts"
 	"github.com/SCKelemen/dataviz/mcp/types"
@@ -14,7 +15,7 @@ import (
 // RegisterTools registers all chart generation tools
 func (s *Server) RegisterTools() {
 	// Tool: bar_chart
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "bar_chart",
 			Description: "Generate a bar chart (vertical or horizontal) from labeled data points",
@@ -64,7 +65,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: pie_chart
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "pie_chart",
 			Description: "Generate a pie or donut chart from labeled data points",
@@ -108,7 +109,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: line_chart
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "line_chart",
 			Description: "Generate a line chart with one or more data series",
@@ -171,7 +172,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: scatter_plot
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "scatter_plot",
 			Description: "Generate a scatter plot for showing correlation between two variables",
@@ -220,7 +221,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: heatmap
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "heatmap",
 			Description: "Generate a heatmap for matrix data showing intensity with colors",
@@ -273,7 +274,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: treemap
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "treemap",
 			Description: "Generate a treemap visualization for hierarchical data",
@@ -291,8 +292,14 @@ func (s *Server) RegisterTools() {
 						},
 					},
 					"show_labels": map[string]interface{}{"type": "boolean", "default": true},
-					"width":       map[string]interface{}{"type": "number", "default": 800},
-					"height":      map[string]interface{}{"type": "number", "default": 600},
+					"tiling": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"squarify", "binary", "slice", "dice", "slice-dice"},
+						"description": "How each rectangle is divided among its children",
+					},
+					"ratio":  map[string]interface{}{"type": "number", "description": "Target aspect ratio of squarified rectangles, at least 1 (default: the golden ratio)"},
+					"width":  map[string]interface{}{"type": "number", "default": 800},
+					"height": map[string]interface{}{"type": "number", "default": 600},
 				},
 				"required": []string{"data"},
 			},
@@ -301,7 +308,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: sunburst
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "sunburst",
 			Description: "Generate a sunburst (radial partition) chart for hierarchical data",
@@ -321,7 +328,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: circle_packing
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "circle_packing",
 			Description: "Generate a circle packing visualization for hierarchical data",
@@ -341,7 +348,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: icicle
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "icicle",
 			Description: "Generate an icicle partition chart for hierarchical data",
@@ -362,7 +369,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: boxplot
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "boxplot",
 			Description: "Generate a box plot for showing statistical distribution",
@@ -393,7 +400,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: violin
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "violin",
 			Description: "Generate a violin plot with kernel density estimation",
@@ -424,7 +431,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: histogram
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "histogram",
 			Description: "Generate a histogram with automatic binning",
@@ -444,7 +451,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: ridgeline
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "ridgeline",
 			Description: "Generate a ridgeline (joy) plot for comparing distributions",
@@ -475,7 +482,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: candlestick
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "candlestick",
 			Description: "Generate a candlestick chart for financial OHLC data",
@@ -509,7 +516,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: ohlc
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "ohlc",
 			Description: "Generate an OHLC bar chart for financial data",
@@ -541,7 +548,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: lollipop
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "lollipop",
 			Description: "Generate a lollipop chart with stems and circles",
@@ -574,7 +581,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: density
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "density",
 			Description: "Generate a kernel density estimation plot",
@@ -607,7 +614,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: connected_scatter
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "connected_scatter",
 			Description: "Generate a connected scatter plot with lines between points",
@@ -650,7 +657,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: stacked_area
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "stacked_area",
 			Description: "Generate a stacked area chart",
@@ -692,7 +699,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: streamchart
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "streamchart",
 			Description: "Generate a streamchart (flowing stacked area)",
@@ -735,7 +742,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: correlogram
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "correlogram",
 			Description: "Generate a correlogram (correlation matrix visualization)",
@@ -762,7 +769,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: radar
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "radar",
 			Description: "Generate a radar (spider) chart",
@@ -806,7 +813,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: parallel
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "parallel",
 			Description: "Generate a parallel coordinates plot",
@@ -849,7 +856,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: wordcloud
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "wordcloud",
 			Description: "Generate a word cloud visualization",
@@ -871,6 +878,34 @@ func (s *Server) RegisterTools() {
 						},
 					},
 					"layout": map[string]interface{}{"type": "string", "description": "Layout algorithm", "default": "spiral"},
+					"spiral": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"archimedean", "rectangular"},
+						"description": "Path the spiral layout searches for a free spot for each word",
+						"default":     "archimedean",
+					},
+					"rotations": map[string]interface{}{
+						"type":        "array",
+						"description": "Angles in degrees chosen at random for each word, e.g. [0, 90]",
+						"items":       map[string]string{"type": "number"},
+					},
+					"shape": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"rectangle", "circle", "polygon"},
+						"description": "Region the words fill",
+						"default":     "rectangle",
+					},
+					"mask": map[string]interface{}{
+						"type":        "array",
+						"description": "Polygon vertices [x, y] for the polygon shape, as fractions (0-1) of the plot area",
+						"items": map[string]interface{}{
+							"type":     "array",
+							"items":    map[string]string{"type": "number"},
+							"minItems": 2,
+							"maxItems": 2,
+						},
+					},
+					"seed":   map[string]interface{}{"type": "integer", "description": "Layout seed; the same seed always gives the same layout", "default": 0},
 					"width":  map[string]interface{}{"type": "number", "default": 800},
 					"height": map[string]interface{}{"type": "number", "default": 600},
 				},
@@ -881,7 +916,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: sankey
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "sankey",
 			Description: "Generate a Sankey diagram for flow visualization",
@@ -916,8 +951,22 @@ func (s *Server) RegisterTools() {
 							"required": []string{"source", "target", "value"},
 						},
 					},
-					"width":  map[string]interface{}{"type": "number", "default": 1000},
-					"height": map[string]interface{}{"type": "number", "default": 600},
+					"align": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"justify", "left", "right", "center"},
+						"description": "Column assignment: justify moves sinks to the last column, left and right align by distance from sources or sinks, center moves sources next to their targets",
+						"default":     "justify",
+					},
+					"link_sort": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"breadth", "value", "input"},
+						"description": "Order of links at each node",
+						"default":     "breadth",
+					},
+					"link_gradient": map[string]interface{}{"type": "boolean", "description": "Fade each link from its source color to its target color", "default": false},
+					"allow_cycles":  map[string]interface{}{"type": "boolean", "description": "Accept cyclic flows, drawing cycle-closing links as loops under the diagram", "default": false},
+					"width":         map[string]interface{}{"type": "number", "default": 1000},
+					"height":        map[string]interface{}{"type": "number", "default": 600},
 				},
 				"required": []string{"nodes", "links"},
 			},
@@ -926,7 +975,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: chord
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "chord",
 			Description: "Generate a chord diagram for relationships",
@@ -960,8 +1009,20 @@ func (s *Server) RegisterTools() {
 							"required": []string{"source", "target", "value"},
 						},
 					},
-					"width":  map[string]interface{}{"type": "number", "default": 800},
-					"height": map[string]interface{}{"type": "number", "default": 800},
+					"directed": map[string]interface{}{"type": "boolean", "description": "Draw one arrow-tipped ribbon per relation instead of one ribbon per pair", "default": false},
+					"sort_groups": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"input", "ascending", "descending"},
+						"description": "Order of entities around the circle by total flow",
+					},
+					"sort_subgroups": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"input", "ascending", "descending"},
+						"description": "Order of flows within each entity's arc by value",
+					},
+					"show_ticks": map[string]interface{}{"type": "boolean", "description": "Ring each arc with ticks labeled with cumulative totals", "default": false},
+					"width":      map[string]interface{}{"type": "number", "default": 800},
+					"height":     map[string]interface{}{"type": "number", "default": 800},
 				},
 				"required": []string{"entities", "relations"},
 			},
@@ -969,8 +1030,57 @@ func (s *Server) RegisterTools() {
 		s.handleChord,
 	)
 
+	// Tool: network
+	s.addTool(
+		&mcp.Tool{
+			Name:        "network",
+			Description: "Generate a force-directed network graph of nodes and links",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"title": map[string]interface{}{"type": "string", "description": "Chart title"},
+					"nodes": map[string]interface{}{
+						"type":        "array",
+						"description": "Array of {id, label?, group?, value?, color?} nodes; groups share a color and value sets the node size",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"id":    map[string]string{"type": "string"},
+								"label": map[string]string{"type": "string"},
+								"group": map[string]string{"type": "string"},
+								"value": map[string]string{"type": "number"},
+								"color": map[string]string{"type": "string"},
+							},
+							"required": []string{"id"},
+						},
+					},
+					"links": map[string]interface{}{
+						"type":        "array",
+						"description": "Array of {source, target, value?, color?} links; value sets the line width",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"source": map[string]string{"type": "string"},
+								"target": map[string]string{"type": "string"},
+								"value":  map[string]string{"type": "number"},
+								"color":  map[string]string{"type": "string"},
+							},
+							"required": []string{"source", "target"},
+						},
+					},
+					"directed": map[string]interface{}{"type": "boolean", "description": "Draw arrowheads at link targets", "default": false},
+					"seed":     map[string]interface{}{"type": "integer", "description": "Layout seed; the same seed always gives the same layout", "default": 0},
+					"width":    map[string]interface{}{"type": "number", "default": 800},
+					"height":   map[string]interface{}{"type": "number", "default": 600},
+				},
+				"required": []string{"nodes"},
+			},
+		},
+		s.handleNetwork,
+	)
+
 	// Tool: circular_bar
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "circular_bar",
 			Description: "Generate a circular bar plot",
@@ -1002,7 +1112,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: dendrogram
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "dendrogram",
 			Description: "Generate a dendrogram (hierarchical clustering tree)",
@@ -1031,7 +1141,7 @@ func (s *Server) RegisterTools() {
 	)
 
 	// Tool: generate_gallery
-	s.server.AddTool(
+	s.addTool(
 		&mcp.Tool{
 			Name:        "generate_gallery",
 			Description: "Generate a gallery SVG showcasing multiple variations of a chart type side-by-side for comparison and demonstration purposes",
@@ -1056,7 +1166,26 @@ func (s *Server) RegisterTools() {
 		s.handleGallery,
 	)
 
-	fmt.Println("Registered 29 chart generation tools")
+	// Tool: render_spec
+	s.addTool(
+		&mcp.Tool{
+			Name:        "render_spec",
+			Description: "Render a declarative grammar spec (data, transforms, mark, encoding, legend, annotations) to SVG. The same spec renders identically in the library and viz-cli",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"type":        []string{"object", "string"},
+						"description": "Grammar spec as a JSON object, or as a JSON or YAML document string",
+					},
+				},
+				"required": []string{"spec"},
+			},
+		},
+		s.handleRenderSpec,
+	)
+
+	fmt.Printf("Registered %d chart generation tools\n", s.tools)
 }
 
 // handleBarChart handles the bar_chart tool
@@ -1083,13 +1212,7 @@ func (s *Server) handleBarChart(ctx context.Context, request *mcp.CallToolReques
 		return nil, fmt.Errorf("failed to create bar chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handlePieChart handles the pie_chart tool
@@ -1112,13 +1235,7 @@ func (s *Server) handlePieChart(ctx context.Context, request *mcp.CallToolReques
 		return nil, fmt.Errorf("failed to create pie chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleLineChart handles the line_chart tool
@@ -1141,13 +1258,7 @@ func (s *Server) handleLineChart(ctx context.Context, request *mcp.CallToolReque
 		return nil, fmt.Errorf("failed to create line chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleScatterPlot handles the scatter_plot tool
@@ -1170,13 +1281,7 @@ func (s *Server) handleScatterPlot(ctx context.Context, request *mcp.CallToolReq
 		return nil, fmt.Errorf("failed to create scatter plot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleHeatmap handles the heatmap tool
@@ -1202,13 +1307,7 @@ func (s *Server) handleHeatmap(ctx context.Context, request *mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to create heatmap: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleTreemap handles the treemap tool
@@ -1230,13 +1329,7 @@ func (s *Server) handleTreemap(ctx context.Context, request *mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to create treemap: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleSunburst handles the sunburst tool
@@ -1258,13 +1351,7 @@ func (s *Server) handleSunburst(ctx context.Context, request *mcp.CallToolReques
 		return nil, fmt.Errorf("failed to create sunburst: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleCirclePacking handles the circle_packing tool
@@ -1286,13 +1373,7 @@ func (s *Server) handleCirclePacking(ctx context.Context, request *mcp.CallToolR
 		return nil, fmt.Errorf("failed to create circle packing: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleIcicle handles the icicle tool
@@ -1314,13 +1395,7 @@ func (s *Server) handleIcicle(ctx context.Context, request *mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to create icicle: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleBoxplot handles the boxplot tool
@@ -1342,13 +1417,7 @@ func (s *Server) handleBoxplot(ctx context.Context, request *mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to create boxplot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleViolin handles the violin tool
@@ -1370,13 +1439,7 @@ func (s *Server) handleViolin(ctx context.Context, request *mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to create violin plot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleHistogram handles the histogram tool
@@ -1398,13 +1461,7 @@ func (s *Server) handleHistogram(ctx context.Context, request *mcp.CallToolReque
 		return nil, fmt.Errorf("failed to create histogram: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleRidgeline handles the ridgeline tool
@@ -1426,13 +1483,7 @@ func (s *Server) handleRidgeline(ctx context.Context, request *mcp.CallToolReque
 		return nil, fmt.Errorf("failed to create ridgeline plot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleCandlestick handles the candlestick tool
@@ -1454,13 +1505,7 @@ func (s *Server) handleCandlestick(ctx context.Context, request *mcp.CallToolReq
 		return nil, fmt.Errorf("failed to create candlestick chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleOHLC handles the ohlc tool
@@ -1482,13 +1527,7 @@ func (s *Server) handleOHLC(ctx context.Context, request *mcp.CallToolRequest) (
 		return nil, fmt.Errorf("failed to create OHLC chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleLollipop handles the lollipop tool
@@ -1510,13 +1549,7 @@ func (s *Server) handleLollipop(ctx context.Context, request *mcp.CallToolReques
 		return nil, fmt.Errorf("failed to create lollipop chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleDensity handles the density tool
@@ -1538,13 +1571,7 @@ func (s *Server) handleDensity(ctx context.Context, request *mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to create density plot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleConnectedScatter handles the connected_scatter tool
@@ -1566,13 +1593,7 @@ func (s *Server) handleConnectedScatter(ctx context.Context, request *mcp.CallTo
 		return nil, fmt.Errorf("failed to create connected scatter plot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleStackedArea handles the stacked_area tool
@@ -1594,13 +1615,7 @@ func (s *Server) handleStackedArea(ctx context.Context, request *mcp.CallToolReq
 		return nil, fmt.Errorf("failed to create stacked area chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleStreamChart handles the streamchart tool
@@ -1622,13 +1637,7 @@ func (s *Server) handleStreamChart(ctx context.Context, request *mcp.CallToolReq
 		return nil, fmt.Errorf("failed to create streamchart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleCorrelogram handles the correlogram tool
@@ -1650,13 +1659,7 @@ func (s *Server) handleCorrelogram(ctx context.Context, request *mcp.CallToolReq
 		return nil, fmt.Errorf("failed to create correlogram: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleRadar handles the radar tool
@@ -1678,13 +1681,7 @@ func (s *Server) handleRadar(ctx context.Context, request *mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to create radar chart: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleParallel handles the parallel tool
@@ -1706,13 +1703,7 @@ func (s *Server) handleParallel(ctx context.Context, request *mcp.CallToolReques
 		return nil, fmt.Errorf("failed to create parallel coordinates plot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleWordCloud handles the wordcloud tool
@@ -1734,13 +1725,7 @@ func (s *Server) handleWordCloud(ctx context.Context, request *mcp.CallToolReque
 		return nil, fmt.Errorf("failed to create word cloud: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleSankey handles the sankey tool
@@ -1762,13 +1747,7 @@ func (s *Server) handleSankey(ctx context.Context, request *mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to create Sankey diagram: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleChord handles the chord tool
@@ -1790,13 +1769,29 @@ func (s *Server) handleChord(ctx context.Context, request *mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to create chord diagram: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
+}
+
+// handleNetwork handles the network tool
+func (s *Server) handleNetwork(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var config types.NetworkConfig
+	if err := parseArguments(request.Params.Arguments, &config); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	if config.Width == 0 {
+		config.Width = 800
+	}
+	if config.Height == 0 {
+		config.Height = 600
+	}
+
+	svg, err := charts.CreateNetwork(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network graph: %w", err)
+	}
+
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleCircularBar handles the circular_bar tool
@@ -1818,13 +1813,7 @@ func (s *Server) handleCircularBar(ctx context.Context, request *mcp.CallToolReq
 		return nil, fmt.Errorf("failed to create circular bar plot: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleDendrogram handles the dendrogram tool
@@ -1846,13 +1835,7 @@ func (s *Server) handleDendrogram(ctx context.Context, request *mcp.CallToolRequ
 		return nil, fmt.Errorf("failed to create dendrogram: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, config.Width, config.Height)
 }
 
 // handleGallery handles the generate_gallery tool
@@ -1876,13 +1859,40 @@ func (s *Server) handleGallery(ctx context.Context, request *mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to generate gallery: %w", err)
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: fmt.Sprintf("```svg\n%s\n```", svg),
-			},
-		},
-	}, nil
+	return chartResult(request, svg, 0, 0)
+}
+
+// handleRenderSpec handles the render_spec tool
+func (s *Server) handleRenderSpec(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var input struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := parseArguments(request.Params.Arguments, &input); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if len(input.Spec) == 0 {
+		return nil, fmt.Errorf("spec is required")
+	}
+
+	// A string holds a JSON or YAML document; anything else is the spec itself
+	var spec *grammar.Spec
+	var document string
+	var err error
+	if json.Unmarshal(input.Spec, &document) == nil {
+		spec, err = grammar.Parse([]byte(document))
+	} else {
+		spec, err = grammar.ParseJSON(input.Spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+
+	svg, err := grammar.Render(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render spec: %w", err)
+	}
+
+	return chartResult(request, svg, 0, 0)
 }
 
 // parseArguments helper to parse tool arguments from map[string]any
@@ -1,8 +1,12 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
 	"strings"
 	"testing"
 
@@ -42,6 +46,10 @@ func TestServerCreation(t *testing.T) {
 	if server == nil {
 		t.Fatal("Server is nil")
 	}
+	// The README lists the tool count
+	if server.tools != 31 {
+		t.Errorf("Registered %d tools, expected 31", server.tools)
+	}
 }
 
 // TestBarChartTool tests the bar_chart tool
@@ -567,3 +575,158 @@ func roundtripJSON(v interface{}) (interface{}, error) {
 	err = json.Unmarshal(data, &result)
 	return result, err
 }
+
+// TestRenderSpecTool tests the render_spec tool with object and YAML specs
+func TestRenderSpecTool(t *testing.T) {
+	server, err := NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	specs := map[string]interface{}{
+		"object": map[string]interface{}{
+			"mark": "bar",
+			"data": map[string]interface{}{
+				"values": []map[string]interface{}{
+					{"category": "A", "value": 10.0},
+					{"category": "B", "value": 20.0},
+				},
+			},
+			"encoding": map[string]interface{}{
+				"x": map[string]interface{}{"field": "category"},
+				"y": map[string]interface{}{"field": "value"},
+			},
+		},
+		"yaml": "mark: bar\ndata:\n  values: [{category: A, value: 10}, {category: B, value: 20}]\nencoding:\n  x: {field: category}\n  y: {field: value}\n",
+	}
+
+	var outputs []string
+	for name, spec := range specs {
+		request := createTestRequest(t, "render_spec", map[string]interface{}{"spec": spec})
+
+		result, err := server.handleRenderSpec(context.Background(), request)
+		if err != nil {
+			t.Fatalf("handleRenderSpec(%s) failed: %v", name, err)
+		}
+
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		if !ok {
+			t.Fatal("Result content is not TextContent")
+		}
+		if !strings.Contains(textContent.Text, "<svg") {
+			t.Errorf("Result does not contain SVG. Got: %s", truncate(textContent.Text, 200))
+		}
+		outputs = append(outputs, textContent.Text)
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Error("Object and YAML specs rendered differently")
+	}
+
+	request := createTestRequest(t, "render_spec", map[string]interface{}{"spec": map[string]interface{}{"mark": "blob"}})
+	if _, err := server.handleRenderSpec(context.Background(), request); err == nil {
+		t.Error("Expected an error for an invalid spec")
+	}
+}
+
+// TestRasterOutput tests that chart tools return images for raster formats
+func TestRasterOutput(t *testing.T) {
+	server, err := NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		options  map[string]interface{}
+		mimeType string
+		width    int
+	}{
+		{"png", map[string]interface{}{"format": "png"}, "image/png", 400},
+		{"png scale", map[string]interface{}{"format": "png", "scale": 2.0}, "image/png", 800},
+		{"png dpi", map[string]interface{}{"format": "PNG", "dpi": 144.0}, "image/png", 600},
+		{"jpeg", map[string]interface{}{"format": "jpeg", "quality": 80.0}, "image/jpeg", 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]interface{}{
+				"data": []map[string]interface{}{
+					{"label": "A", "value": 10.0},
+					{"label": "B", "value": 20.0},
+				},
+				"width":  400.0,
+				"height": 200.0,
+			}
+			for k, v := range tt.options {
+				args[k] = v
+			}
+
+			result, err := server.handleBarChart(context.Background(), createTestRequest(t, "bar_chart", args))
+			if err != nil {
+				t.Fatalf("handleBarChart failed: %v", err)
+			}
+
+			imageContent, ok := result.Content[0].(*mcp.ImageContent)
+			if !ok {
+				t.Fatalf("Result content is %T, expected ImageContent", result.Content[0])
+			}
+			if imageContent.MIMEType != tt.mimeType {
+				t.Errorf("MIMEType = %q, expected %q", imageContent.MIMEType, tt.mimeType)
+			}
+
+			config, _, err := image.DecodeConfig(bytes.NewReader(imageContent.Data))
+			if err != nil {
+				t.Fatalf("Failed to decode image: %v", err)
+			}
+			if config.Width != tt.width {
+				t.Errorf("image width = %d, expected %d", config.Width, tt.width)
+			}
+		})
+	}
+
+	for _, format := range []string{"gif", "pdf"} {
+		request := createTestRequest(t, "bar_chart", map[string]interface{}{
+			"data":   []map[string]interface{}{{"label": "A", "value": 10.0}},
+			"format": format,
+		})
+		if _, err := server.handleBarChart(context.Background(), request); err == nil {
+			t.Errorf("handleBarChart should fail with format %q", format)
+		}
+	}
+}
+
+// TestOutputSchema tests that every tool accepts the output options
+func TestOutputSchema(t *testing.T) {
+	server, err := NewServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.GetMCPServer().Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer session.Close()
+
+	tools, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	for _, tool := range tools.Tools {
+		schema, err := json.Marshal(tool.InputSchema)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s schema: %v", tool.Name, err)
+		}
+		for _, option := range []string{`"format"`, `"scale"`, `"dpi"`} {
+			if !strings.Contains(string(schema), option) {
+				t.Errorf("%s schema is missing %s", tool.Name, option)
+			}
+		}
+	}
+}
@@ -102,6 +102,8 @@ type TreemapConfig struct {
 	ChartConfig
 	Data       TreeNode `json:"data"`
 	ShowLabels bool     `json:"show_labels,omitempty"`
+	Tiling     string   `json:"tiling,omitempty"` // "squarify", "binary", "slice", "dice" or "slice-dice"
+	Ratio      float64  `json:"ratio,omitempty"`  // Target aspect ratio for squarify tiling
 }
 
 // SunburstConfig configuration for sunburst charts
@@ -330,8 +332,13 @@ type WordCloudWord struct {
 // WordCloudConfig configuration for word clouds
 type WordCloudConfig struct {
 	ChartConfig
-	Words  []WordCloudWord `json:"words"`
-	Layout string          `json:"layout,omitempty"`
+	Words     []WordCloudWord `json:"words"`
+	Layout    string          `json:"layout,omitempty"`
+	Spiral    string          `json:"spiral,omitempty"`
+	Rotations []float64       `json:"rotations,omitempty"`
+	Shape     string          `json:"shape,omitempty"`
+	Mask      [][2]float64    `json:"mask,omitempty"`
+	Seed      int64           `json:"seed,omitempty"`
 }
 
 // SankeyNode represents a node in Sankey diagram
@@ -352,8 +359,12 @@ type SankeyLink struct {
 // SankeyConfig configuration for Sankey diagrams
 type SankeyConfig struct {
 	ChartConfig
-	Nodes []SankeyNode `json:"nodes"`
-	Links []SankeyLink `json:"links"`
+	Nodes        []SankeyNode `json:"nodes"`
+	Links        []SankeyLink `json:"links"`
+	Align        string       `json:"align,omitempty"`
+	LinkSort     string       `json:"link_sort,omitempty"`
+	LinkGradient bool         `json:"link_gradient,omitempty"`
+	AllowCycles  bool         `json:"allow_cycles,omitempty"`
 }
 
 // ChordEntity represents an entity in chord diagram
@@ -373,8 +384,38 @@ type ChordRelation struct {
 // ChordConfig configuration for chord diagrams
 type ChordConfig struct {
 	ChartConfig
-	Entities  []ChordEntity   `json:"entities"`
-	Relations []ChordRelation `json:"relations"`
+	Entities      []ChordEntity   `json:"entities"`
+	Relations     []ChordRelation `json:"relations"`
+	Directed      bool            `json:"directed,omitempty"`
+	SortGroups    string          `json:"sort_groups,omitempty"`
+	SortSubgroups string          `json:"sort_subgroups,omitempty"`
+	ShowTicks     bool            `json:"show_ticks,omitempty"`
+}
+
+// NetworkNode represents a node in a network graph
+type NetworkNode struct {
+	ID    string  `json:"id"`
+	Label string  `json:"label,omitempty"`
+	Group string  `json:"group,omitempty"`
+	Value float64 `json:"value,omitempty"`
+	Color string  `json:"color,omitempty"`
+}
+
+// NetworkLink represents an edge in a network graph
+type NetworkLink struct {
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Value  float64 `json:"value,omitempty"`
+	Color  string  `json:"color,omitempty"`
+}
+
+// NetworkConfig configuration for force-directed network graphs
+type NetworkConfig struct {
+	ChartConfig
+	Nodes    []NetworkNode `json:"nodes"`
+	Links    []NetworkLink `json:"links"`
+	Directed bool          `json:"directed,omitempty"`
+	Seed     int64         `json:"seed,omitempty"`
 }
 
 // CircularBarPoint represents a point in circular bar plot
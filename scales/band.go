@@ -130,6 +130,62 @@ func (s *BandScale) Index(value string) int {
 	return -1
 }
 
+// InvertCategory maps a range value back to the band that contains it.
+// Returns false if the value falls in the padding between or around bands.
+//
+// Example:
+//   scale := NewBandScale([]string{"A", "B", "C"}, [2]units.Length{units.Px(0), units.Px(300)})
+//   scale.InvertCategory(units.Px(150)) // Returns "B", true
+func (s *BandScale) InvertCategory(value units.Length) (string, bool) {
+	index := s.invertIndex(value.Value)
+	if index < 0 {
+		return "", false
+	}
+	return s.domain[index], true
+}
+
+// InvertRange returns all categories whose bands overlap the range [start, end].
+// Useful for brushing; the order of start and end does not matter.
+func (s *BandScale) InvertRange(start, end units.Length) []string {
+	lo, hi := start.Value, end.Value
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	var categories []string
+	for i, category := range s.domain {
+		bandStart := s.start + float64(i)*s.step
+		if bandStart <= hi && bandStart+s.bandwidth >= lo {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// invertIndex returns the index of the band containing the raw position v, or -1
+func (s *BandScale) invertIndex(v float64) int {
+	if len(s.domain) == 0 || s.step == 0 {
+		return -1
+	}
+
+	// Candidate band from the step, then confirm v is inside its bandwidth
+	index := int(math.Floor((v - s.start) / s.step))
+	if s.step < 0 {
+		// Bands are laid out in descending positions but each band
+		// still extends forward from its start
+		index = int(math.Ceil((v - s.start) / s.step))
+	}
+	if index < 0 || index >= len(s.domain) {
+		return -1
+	}
+
+	bandStart := s.start + float64(index)*s.step
+	if v < bandStart || v > bandStart+s.bandwidth {
+		return -1
+	}
+	return index
+}
+
 // Bandwidth returns the width of each band
 func (s *BandScale) Bandwidth() units.Length {
 	return units.Length{Value: s.bandwidth, Unit: s.range_[0].Unit}
@@ -323,3 +323,114 @@ func BenchmarkBandScale_Rescale(b *testing.B) {
 		scale.Padding(0.1)
 	}
 }
+
+func TestBandScale_InvertCategory(t *testing.T) {
+	scale := NewBandScale(
+		[]string{"A", "B", "C"},
+		[2]units.Length{units.Px(0), units.Px(300)},
+	)
+
+	tests := []struct {
+		input    float64
+		expected string
+		ok       bool
+	}{
+		{0, "A", true},
+		{50, "A", true},
+		{150, "B", true},
+		{299, "C", true},
+		{-10, "", false},
+		{310, "", false},
+	}
+
+	for _, tt := range tests {
+		result, ok := scale.InvertCategory(units.Px(tt.input))
+		if result != tt.expected || ok != tt.ok {
+			t.Errorf("InvertCategory(%v) = %q, %v, expected %q, %v", tt.input, result, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestBandScale_InvertCategory_Padding(t *testing.T) {
+	scale := NewBandScale(
+		[]string{"A", "B", "C"},
+		[2]units.Length{units.Px(0), units.Px(300)},
+	)
+	scale.Padding(0.2)
+
+	// Every band's start and center map back to its category
+	for _, category := range scale.Values() {
+		start := scale.Apply(category)
+		center := units.Px(start.Value + scale.Bandwidth().Value/2)
+
+		if result, ok := scale.InvertCategory(start); !ok || result != category {
+			t.Errorf("InvertCategory(start of %q) = %q, %v", category, result, ok)
+		}
+		if result, ok := scale.InvertCategory(center); !ok || result != category {
+			t.Errorf("InvertCategory(center of %q) = %q, %v", category, result, ok)
+		}
+	}
+
+	// Gap between A and B is padding
+	gap := scale.Apply("A").Value + scale.Bandwidth().Value + 1
+	if result, ok := scale.InvertCategory(units.Px(gap)); ok {
+		t.Errorf("InvertCategory(%v) in padding = %q, expected no match", gap, result)
+	}
+}
+
+func TestBandScale_InvertCategory_AlignRound(t *testing.T) {
+	scale := NewBandScale(
+		[]string{"A", "B", "C", "D"},
+		[2]units.Length{units.Px(0), units.Px(103)},
+	)
+	scale.PaddingInner(0.1).PaddingOuter(0.3).Align(0).Round(true)
+
+	for _, category := range scale.Values() {
+		center := units.Px(scale.Apply(category).Value + scale.Bandwidth().Value/2)
+		if result, ok := scale.InvertCategory(center); !ok || result != category {
+			t.Errorf("InvertCategory(center of %q) = %q, %v", category, result, ok)
+		}
+	}
+}
+
+func TestBandScale_InvertCategory_ReverseRange(t *testing.T) {
+	scale := NewBandScale(
+		[]string{"A", "B", "C"},
+		[2]units.Length{units.Px(300), units.Px(0)},
+	)
+
+	for _, category := range scale.Values() {
+		center := units.Px(scale.Apply(category).Value + scale.Bandwidth().Value/2)
+		if result, ok := scale.InvertCategory(center); !ok || result != category {
+			t.Errorf("InvertCategory(center of %q) = %q, %v", category, result, ok)
+		}
+	}
+}
+
+func TestBandScale_InvertCategory_EmptyDomain(t *testing.T) {
+	scale := NewBandScale([]string{}, [2]units.Length{units.Px(0), units.Px(300)})
+
+	if _, ok := scale.InvertCategory(units.Px(10)); ok {
+		t.Error("InvertCategory on empty domain should fail")
+	}
+}
+
+func TestBandScale_InvertRange(t *testing.T) {
+	scale := NewBandScale(
+		[]string{"A", "B", "C", "D"},
+		[2]units.Length{units.Px(0), units.Px(400)},
+	)
+	scale.Padding(0.1)
+
+	result := scale.InvertRange(units.Px(250), units.Px(120))
+	expected := []string{"B", "C"}
+
+	if len(result) != len(expected) {
+		t.Fatalf("InvertRange(120, 250) = %v, expected %v", result, expected)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("InvertRange(120, 250)[%d] = %q, expected %q", i, result[i], expected[i])
+		}
+	}
+}
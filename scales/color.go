@@ -17,14 +17,18 @@ import (
 //   )
 //   scale.Apply(50) // Returns middle blue color
 //
+// A named multi-stop ramp can be used instead of two endpoint colors:
+//   scale := NewSequentialColorScaleWithInterpolator([2]float64{0, 100}, InterpolateViridis)
+//
 // Common uses: heatmaps, choropleth maps, magnitude encoding
 type SequentialColorScale struct {
-	domain      [2]float64
-	startColor  color.Color
-	endColor    color.Color
-	clamp       bool
-	space       color.GradientSpace
-	interpolate InterpolatorFunc
+	domain       [2]float64
+	startColor   color.Color
+	endColor     color.Color
+	clamp        bool
+	space        color.GradientSpace
+	interpolate  InterpolatorFunc
+	interpolator ColorInterpolatorFunc // Multi-stop ramp (overrides start/end colors)
 }
 
 // NewSequentialColorScale creates a new sequential color scale
@@ -38,6 +42,19 @@ func NewSequentialColorScale(domain [2]float64, start, end color.Color) *Sequent
 	}
 }
 
+// NewSequentialColorScaleWithInterpolator creates a sequential color scale
+// driven by a color interpolator such as InterpolateViridis
+func NewSequentialColorScaleWithInterpolator(domain [2]float64, interpolator ColorInterpolatorFunc) *SequentialColorScale {
+	return &SequentialColorScale{
+		domain:       domain,
+		startColor:   interpolator(0),
+		endColor:     interpolator(1),
+		clamp:        true,
+		space:        color.GradientOKLCH,
+		interpolator: interpolator,
+	}
+}
+
 // Apply maps a domain value to a color
 func (s *SequentialColorScale) Apply(value interface{}) units.Length {
 	// Color scales don't return units.Length, but we need to satisfy Scale interface
@@ -47,7 +64,19 @@ func (s *SequentialColorScale) Apply(value interface{}) units.Length {
 
 // ApplyColor maps a domain value to a color
 func (s *SequentialColorScale) ApplyColor(value interface{}) color.Color {
-	t := s.ApplyValue(value)
+	return s.colorAt(s.ApplyValue(value))
+}
+
+// Interpolator returns the color interpolation function over [0, 1]
+func (s *SequentialColorScale) Interpolator() func(t float64) color.Color {
+	return s.colorAt
+}
+
+// colorAt returns the color at normalized position t
+func (s *SequentialColorScale) colorAt(t float64) color.Color {
+	if s.interpolator != nil {
+		return s.interpolator(t)
+	}
 	return color.MixInSpace(s.startColor, s.endColor, t, s.space)
 }
 
@@ -99,12 +128,13 @@ func (s *SequentialColorScale) Type() ScaleType {
 // Clone creates a copy of this scale
 func (s *SequentialColorScale) Clone() Scale {
 	return &SequentialColorScale{
-		domain:      s.domain,
-		startColor:  s.startColor,
-		endColor:    s.endColor,
-		clamp:       s.clamp,
-		space:       s.space,
-		interpolate: s.interpolate,
+		domain:       s.domain,
+		startColor:   s.startColor,
+		endColor:     s.endColor,
+		clamp:        s.clamp,
+		space:        s.space,
+		interpolate:  s.interpolate,
+		interpolator: s.interpolator,
 	}
 }
 
@@ -159,16 +189,21 @@ func (s *SequentialColorScale) Samples(n int) []color.Color {
 //   scale.Apply(0)   // Returns white
 //   scale.Apply(50)  // Returns light red
 //
+// A named diverging ramp can be used instead of three colors; the ramp's
+// center (t = 0.5) is placed at the midpoint:
+//   scale := NewDivergingColorScaleWithInterpolator([2]float64{-1, 1}, InterpolateRdBu)
+//
 // Common uses: temperature anomalies, profit/loss, before/after comparisons
 type DivergingColorScale struct {
-	domain      [2]float64
-	startColor  color.Color
-	midColor    color.Color
-	endColor    color.Color
-	midpoint    float64 // Domain value for midColor (default: domain midpoint)
-	clamp       bool
-	space       color.GradientSpace
-	interpolate InterpolatorFunc
+	domain       [2]float64
+	startColor   color.Color
+	midColor     color.Color
+	endColor     color.Color
+	midpoint     float64 // Domain value for midColor (default: domain midpoint)
+	clamp        bool
+	space        color.GradientSpace
+	interpolate  InterpolatorFunc
+	interpolator ColorInterpolatorFunc // Multi-stop ramp (overrides start/mid/end colors)
 }
 
 // NewDivergingColorScale creates a new diverging color scale
@@ -184,6 +219,21 @@ func NewDivergingColorScale(domain [2]float64, start, mid, end color.Color) *Div
 	}
 }
 
+// NewDivergingColorScaleWithInterpolator creates a diverging color scale
+// driven by a color interpolator such as InterpolateRdBu
+func NewDivergingColorScaleWithInterpolator(domain [2]float64, interpolator ColorInterpolatorFunc) *DivergingColorScale {
+	return &DivergingColorScale{
+		domain:       domain,
+		startColor:   interpolator(0),
+		midColor:     interpolator(0.5),
+		endColor:     interpolator(1),
+		midpoint:     (domain[0] + domain[1]) / 2,
+		clamp:        true,
+		space:        color.GradientOKLCH,
+		interpolator: interpolator,
+	}
+}
+
 // Apply maps a domain value to a color (dummy for Scale interface)
 func (s *DivergingColorScale) Apply(value interface{}) units.Length {
 	return units.Px(0)
@@ -224,6 +274,21 @@ func (s *DivergingColorScale) ApplyColor(value interface{}) color.Color {
 		t = s.interpolate(t)
 	}
 
+	return s.colorAt(t)
+}
+
+// Interpolator returns the color interpolation function over [0, 1].
+// t = 0.5 corresponds to the midpoint color.
+func (s *DivergingColorScale) Interpolator() func(t float64) color.Color {
+	return s.colorAt
+}
+
+// colorAt returns the color at normalized position t
+func (s *DivergingColorScale) colorAt(t float64) color.Color {
+	if s.interpolator != nil {
+		return s.interpolator(t)
+	}
+
 	// Interpolate colors
 	if t < 0.5 {
 		// Between start and mid
@@ -280,14 +345,15 @@ func (s *DivergingColorScale) Type() ScaleType {
 // Clone creates a copy of this scale
 func (s *DivergingColorScale) Clone() Scale {
 	return &DivergingColorScale{
-		domain:      s.domain,
-		startColor:  s.startColor,
-		midColor:    s.midColor,
-		endColor:    s.endColor,
-		midpoint:    s.midpoint,
-		clamp:       s.clamp,
-		space:       s.space,
-		interpolate: s.interpolate,
+		domain:       s.domain,
+		startColor:   s.startColor,
+		midColor:     s.midColor,
+		endColor:     s.endColor,
+		midpoint:     s.midpoint,
+		clamp:        s.clamp,
+		space:        s.space,
+		interpolate:  s.interpolate,
+		interpolator: s.interpolator,
 	}
 }
 
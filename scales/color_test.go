@@ -702,3 +702,47 @@ func BenchmarkCategoricalColorScale_ApplyColor(b *testing.B) {
 		scale.ApplyColor(categories[i%len(categories)])
 	}
 }
+
+// ===================== Interpolator-driven scales =====================
+
+func TestSequentialColorScale_WithInterpolator(t *testing.T) {
+	scale := NewSequentialColorScaleWithInterpolator([2]float64{0, 100}, InterpolateViridis)
+
+	if !colorsClose(scale.ApplyColor(0.0), InterpolateViridis(0), 0.0001) {
+		t.Error("ApplyColor(0) should match viridis start")
+	}
+	if !colorsClose(scale.ApplyColor(50.0), InterpolateViridis(0.5), 0.0001) {
+		t.Error("ApplyColor(50) should match viridis middle")
+	}
+	if !colorsClose(scale.ApplyColor(200.0), InterpolateViridis(1), 0.0001) {
+		t.Error("ApplyColor(200) should clamp to viridis end")
+	}
+
+	var _ ColorScale = scale
+
+	clone := scale.Clone().(*SequentialColorScale)
+	if !colorsClose(clone.ApplyColor(25.0), scale.ApplyColor(25.0), 0.0001) {
+		t.Error("Clone should keep interpolator")
+	}
+}
+
+func TestDivergingColorScale_WithInterpolator(t *testing.T) {
+	scale := NewDivergingColorScaleWithInterpolator([2]float64{-10, 30}, InterpolateRdBu).Midpoint(0)
+
+	if !colorsClose(scale.ApplyColor(0.0), InterpolateRdBu(0.5), 0.0001) {
+		t.Error("Midpoint should map to center of RdBu")
+	}
+	if !colorsClose(scale.ApplyColor(-10.0), InterpolateRdBu(0), 0.0001) {
+		t.Error("Domain start should map to start of RdBu")
+	}
+	if !colorsClose(scale.ApplyColor(30.0), InterpolateRdBu(1), 0.0001) {
+		t.Error("Domain end should map to end of RdBu")
+	}
+
+	var _ ColorScale = scale
+
+	interp := scale.Interpolator()
+	if !colorsClose(interp(0.25), InterpolateRdBu(0.25), 0.0001) {
+		t.Error("Interpolator() should return the RdBu ramp")
+	}
+}
@@ -0,0 +1,271 @@
+package scales
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/SCKelemen/color"
+)
+
+// Named color interpolators.
+//
+// Each interpolator maps t in [0, 1] to a color and can be passed to
+// NewSequentialColorScaleWithInterpolator, NewDivergingColorScaleWithInterpolator,
+// or looked up by name with NamedInterpolator. Values of t outside [0, 1]
+// are clamped.
+//
+// Perceptually uniform (matplotlib): viridis, magma, inferno, plasma, cividis
+// Rainbow: turbo
+// Diverging (ColorBrewer): RdBu, RdYlBu, RdYlGn, BrBG, PiYG, PRGn, PuOr, Spectral
+// Sequential (ColorBrewer): Blues, Greens, Greys, Oranges, Purples, Reds, YlGnBu, YlOrRd
+//
+// Example:
+//   scale := NewSequentialColorScaleWithInterpolator([2]float64{0, 100}, InterpolateViridis)
+//   scale.ApplyColor(50) // Returns the middle of the viridis ramp
+//
+//   fn, ok := NamedInterpolator("RdBu")
+var (
+	InterpolateViridis = rampFromHex(
+		"#440154", "#482475", "#414487", "#355f8d", "#2a788e", "#21918c",
+		"#22a884", "#44bf70", "#7ad151", "#bddf26", "#fde725",
+	)
+	InterpolateMagma = rampFromHex(
+		"#000004", "#140e36", "#3b0f70", "#641a80", "#8c2981", "#b73779",
+		"#de4968", "#f7705c", "#fe9f6d", "#fecf92", "#fcfdbf",
+	)
+	InterpolateInferno = rampFromHex(
+		"#000004", "#160b39", "#420a68", "#6a176e", "#932667", "#bc3754",
+		"#dd513a", "#f37819", "#fca50a", "#f6d746", "#fcffa4",
+	)
+	InterpolatePlasma = rampFromHex(
+		"#0d0887", "#41049d", "#6a00a8", "#8f0da4", "#b12a90", "#cc4778",
+		"#e16462", "#f2844b", "#fca636", "#fcce25", "#f0f921",
+	)
+	InterpolateCividis ColorInterpolatorFunc = interpolateCividis
+	InterpolateTurbo   ColorInterpolatorFunc = interpolateTurbo
+
+	// Cool-warm diverging map (Moreland), blue to red through light gray
+	InterpolateCoolWarm = rampFromHex(
+		"#3b4cc0", "#6788ee", "#9abbff", "#c9d7f0", "#dddcdc",
+		"#edd1c2", "#f7a889", "#e26952", "#b40426",
+	)
+
+	InterpolateRdBu = rampFromHex(
+		"#67001f", "#b2182b", "#d6604d", "#f4a582", "#fddbc7", "#f7f7f7",
+		"#d1e5f0", "#92c5de", "#4393c3", "#2166ac", "#053061",
+	)
+	InterpolateRdYlBu = rampFromHex(
+		"#a50026", "#d73027", "#f46d43", "#fdae61", "#fee090", "#ffffbf",
+		"#e0f3f8", "#abd9e9", "#74add1", "#4575b4", "#313695",
+	)
+	InterpolateRdYlGn = rampFromHex(
+		"#a50026", "#d73027", "#f46d43", "#fdae61", "#fee08b", "#ffffbf",
+		"#d9ef8b", "#a6d96a", "#66bd63", "#1a9850", "#006837",
+	)
+	InterpolateBrBG = rampFromHex(
+		"#543005", "#8c510a", "#bf812d", "#dfc27d", "#f6e8c3", "#f5f5f5",
+		"#c7eae5", "#80cdc1", "#35978f", "#01665e", "#003c30",
+	)
+	InterpolatePiYG = rampFromHex(
+		"#8e0152", "#c51b7d", "#de77ae", "#f1b6da", "#fde0ef", "#f7f7f7",
+		"#e6f5d0", "#b8e186", "#7fbc41", "#4d9221", "#276419",
+	)
+	InterpolatePRGn = rampFromHex(
+		"#40004b", "#762a83", "#9970ab", "#c2a5cf", "#e7d4e8", "#f7f7f7",
+		"#d9f0d3", "#a6dba0", "#5aae61", "#1b7837", "#00441b",
+	)
+	InterpolatePuOr = rampFromHex(
+		"#7f3b08", "#b35806", "#e08214", "#fdb863", "#fee0b6", "#f7f7f7",
+		"#d8daeb", "#b2abd2", "#8073ac", "#542788", "#2d004b",
+	)
+	InterpolateSpectral = rampFromHex(
+		"#9e0142", "#d53e4f", "#f46d43", "#fdae61", "#fee08b", "#ffffbf",
+		"#e6f598", "#abdda4", "#66c2a5", "#3288bd", "#5e4fa2",
+	)
+
+	InterpolateBlues = rampFromHex(
+		"#f7fbff", "#deebf7", "#c6dbef", "#9ecae1", "#6baed6",
+		"#4292c6", "#2171b5", "#08519c", "#08306b",
+	)
+	InterpolateGreens = rampFromHex(
+		"#f7fcf5", "#e5f5e0", "#c7e9c0", "#a1d99b", "#74c476",
+		"#41ab5d", "#238b45", "#006d2c", "#00441b",
+	)
+	InterpolateGreys = rampFromHex(
+		"#ffffff", "#f0f0f0", "#d9d9d9", "#bdbdbd", "#969696",
+		"#737373", "#525252", "#252525", "#000000",
+	)
+	InterpolateOranges = rampFromHex(
+		"#fff5eb", "#fee6ce", "#fdd0a2", "#fdae6b", "#fd8d3c",
+		"#f16913", "#d94801", "#a63603", "#7f2704",
+	)
+	InterpolatePurples = rampFromHex(
+		"#fcfbfd", "#efedf5", "#dadaeb", "#bcbddc", "#9e9ac8",
+		"#807dba", "#6a51a3", "#54278f", "#3f007d",
+	)
+	InterpolateReds = rampFromHex(
+		"#fff5f0", "#fee0d2", "#fcbba1", "#fc9272", "#fb6a4a",
+		"#ef3b2c", "#cb181d", "#a50f15", "#67000d",
+	)
+	InterpolateYlGnBu = rampFromHex(
+		"#ffffd9", "#edf8b1", "#c7e9b4", "#7fcdbb", "#41b6c4",
+		"#1d91c0", "#225ea8", "#253494", "#081d58",
+	)
+	InterpolateYlOrRd = rampFromHex(
+		"#ffffcc", "#ffeda0", "#fed976", "#feb24c", "#fd8d3c",
+		"#fc4e2a", "#e31a1c", "#bd0026", "#800026",
+	)
+)
+
+// interpolatorRegistry holds interpolators by lower-cased name
+var (
+	interpolatorMu       sync.RWMutex
+	interpolatorRegistry = map[string]ColorInterpolatorFunc{
+		"viridis":  InterpolateViridis,
+		"magma":    InterpolateMagma,
+		"inferno":  InterpolateInferno,
+		"plasma":   InterpolatePlasma,
+		"cividis":  InterpolateCividis,
+		"turbo":    InterpolateTurbo,
+		"coolwarm": InterpolateCoolWarm,
+		"rdbu":     InterpolateRdBu,
+		"rdylbu":   InterpolateRdYlBu,
+		"rdylgn":   InterpolateRdYlGn,
+		"brbg":     InterpolateBrBG,
+		"piyg":     InterpolatePiYG,
+		"prgn":     InterpolatePRGn,
+		"puor":     InterpolatePuOr,
+		"spectral": InterpolateSpectral,
+		"blues":    InterpolateBlues,
+		"greens":   InterpolateGreens,
+		"greys":    InterpolateGreys,
+		"oranges":  InterpolateOranges,
+		"purples":  InterpolatePurples,
+		"reds":     InterpolateReds,
+		"ylgnbu":   InterpolateYlGnBu,
+		"ylorrd":   InterpolateYlOrRd,
+	}
+)
+
+// NamedInterpolator returns the interpolator registered under name.
+// Lookup is case-insensitive, so "RdBu" and "rdbu" are equivalent.
+func NamedInterpolator(name string) (ColorInterpolatorFunc, bool) {
+	interpolatorMu.RLock()
+	defer interpolatorMu.RUnlock()
+
+	fn, ok := interpolatorRegistry[strings.ToLower(name)]
+	return fn, ok
+}
+
+// RegisterInterpolator adds or replaces a named interpolator.
+// Registered names are available to every chart that accepts a scheme name.
+func RegisterInterpolator(name string, fn ColorInterpolatorFunc) {
+	if name == "" || fn == nil {
+		return
+	}
+
+	interpolatorMu.Lock()
+	defer interpolatorMu.Unlock()
+
+	interpolatorRegistry[strings.ToLower(name)] = fn
+}
+
+// InterpolatorNames returns the registered interpolator names in sorted order
+func InterpolatorNames() []string {
+	interpolatorMu.RLock()
+	defer interpolatorMu.RUnlock()
+
+	names := make([]string, 0, len(interpolatorRegistry))
+	for name := range interpolatorRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewMultiStopInterpolator creates an interpolator through evenly spaced color stops.
+// Adjacent stops are mixed in the given color space.
+func NewMultiStopInterpolator(stops []color.Color, space color.GradientSpace) ColorInterpolatorFunc {
+	n := len(stops)
+	return func(t float64) color.Color {
+		if n == 0 {
+			return color.RGB(0.5, 0.5, 0.5)
+		}
+		if n == 1 {
+			return stops[0]
+		}
+
+		t = clampFloat(t, 0, 1)
+		segment := t * float64(n-1)
+		index := int(segment)
+		if index >= n-1 {
+			return stops[n-1]
+		}
+
+		return color.MixInSpace(stops[index], stops[index+1], segment-float64(index), space)
+	}
+}
+
+// ReverseInterpolator returns an interpolator that runs fn from 1 to 0
+func ReverseInterpolator(fn ColorInterpolatorFunc) ColorInterpolatorFunc {
+	return func(t float64) color.Color {
+		return fn(1 - t)
+	}
+}
+
+// SampleInterpolator returns n evenly spaced colors from fn, including both ends
+func SampleInterpolator(fn ColorInterpolatorFunc, n int) []color.Color {
+	if n <= 0 || fn == nil {
+		return nil
+	}
+	if n == 1 {
+		return []color.Color{fn(0.5)}
+	}
+
+	samples := make([]color.Color, n)
+	for i := 0; i < n; i++ {
+		samples[i] = fn(float64(i) / float64(n-1))
+	}
+	return samples
+}
+
+// rampFromHex creates an RGB interpolator through evenly spaced hex stops
+func rampFromHex(hexes ...string) ColorInterpolatorFunc {
+	stops := make([]color.Color, len(hexes))
+	for i, hex := range hexes {
+		c, err := color.HexToRGB(hex)
+		if err != nil {
+			panic("scales: invalid interpolator stop " + hex)
+		}
+		stops[i] = c
+	}
+	return NewMultiStopInterpolator(stops, color.GradientRGB)
+}
+
+// interpolateCividis uses the polynomial approximation of cividis from d3-scale-chromatic
+func interpolateCividis(t float64) color.Color {
+	t = clampFloat(t, 0, 1)
+	r := -4.54 - t*(35.34-t*(2381.73-t*(6402.7-t*(7024.72-t*2710.57))))
+	g := 32.49 + t*(170.73+t*(52.82-t*(131.46-t*(176.58-t*67.37))))
+	b := 81.24 + t*(442.36-t*(2482.43-t*(6167.24-t*(6614.94-t*2475.67))))
+	return rgb255(r, g, b)
+}
+
+// interpolateTurbo uses the polynomial approximation of turbo from d3-scale-chromatic
+func interpolateTurbo(t float64) color.Color {
+	t = clampFloat(t, 0, 1)
+	r := 34.61 + t*(1172.33-t*(10793.56-t*(33300.12-t*(38394.49-t*14825.05))))
+	g := 23.31 + t*(557.33+t*(1225.33-t*(3574.96-t*(1073.77+t*707.56))))
+	b := 27.2 + t*(3211.1-t*(15327.97-t*(27814-t*(22569.18-t*6838.66))))
+	return rgb255(r, g, b)
+}
+
+// rgb255 builds a color from 0-255 channel values, rounding and clamping each channel
+func rgb255(r, g, b float64) color.Color {
+	channel := func(v float64) float64 {
+		return clampFloat(math.Round(v), 0, 255) / 255
+	}
+	return color.RGB(channel(r), channel(g), channel(b))
+}
@@ -0,0 +1,151 @@
+package scales
+
+import (
+	"testing"
+
+	"github.com/SCKelemen/color"
+)
+
+func TestNamedInterpolator(t *testing.T) {
+	names := []string{
+		"viridis", "magma", "inferno", "plasma", "cividis", "turbo", "coolwarm",
+		"RdBu", "RdYlBu", "RdYlGn", "BrBG", "PiYG", "PRGn", "PuOr", "Spectral",
+		"Blues", "Greens", "Greys", "Oranges", "Purples", "Reds", "YlGnBu", "YlOrRd",
+	}
+
+	for _, name := range names {
+		fn, ok := NamedInterpolator(name)
+		if !ok {
+			t.Errorf("NamedInterpolator(%q) not found", name)
+			continue
+		}
+
+		// Endpoints should differ
+		if colorsClose(fn(0), fn(1), 0.01) {
+			t.Errorf("%s: expected different colors at t=0 and t=1", name)
+		}
+	}
+
+	if _, ok := NamedInterpolator("does-not-exist"); ok {
+		t.Error("NamedInterpolator should fail for unknown names")
+	}
+}
+
+func TestNamedInterpolator_CaseInsensitive(t *testing.T) {
+	upper, ok1 := NamedInterpolator("RDBU")
+	lower, ok2 := NamedInterpolator("rdbu")
+	if !ok1 || !ok2 {
+		t.Fatal("Expected case-insensitive lookup")
+	}
+	if !colorsClose(upper(0.3), lower(0.3), 0.0001) {
+		t.Error("Expected same interpolator regardless of case")
+	}
+}
+
+func TestInterpolateViridis_Stops(t *testing.T) {
+	start, _ := color.HexToRGB("#440154")
+	end, _ := color.HexToRGB("#fde725")
+
+	if !colorsClose(InterpolateViridis(0), start, 0.001) {
+		t.Error("Viridis should start at #440154")
+	}
+	if !colorsClose(InterpolateViridis(1), end, 0.001) {
+		t.Error("Viridis should end at #fde725")
+	}
+
+	// Clamped outside [0, 1]
+	if !colorsClose(InterpolateViridis(-1), start, 0.001) {
+		t.Error("Viridis should clamp t < 0")
+	}
+	if !colorsClose(InterpolateViridis(2), end, 0.001) {
+		t.Error("Viridis should clamp t > 1")
+	}
+}
+
+func TestInterpolateTurbo(t *testing.T) {
+	// d3 turbo starts near rgb(35, 23, 27) and ends near rgb(144, 12, 0)
+	start := InterpolateTurbo(0)
+	r, g, b, _ := start.RGBA()
+	if r*255 < 30 || r*255 > 40 || g*255 < 18 || g*255 > 28 || b*255 < 22 || b*255 > 32 {
+		t.Errorf("Turbo(0) = (%v, %v, %v), expected near (35, 23, 27)", r*255, g*255, b*255)
+	}
+
+	end := InterpolateTurbo(1)
+	r, g, b, _ = end.RGBA()
+	if r*255 < 135 || r*255 > 150 || b*255 > 5 {
+		t.Errorf("Turbo(1) = (%v, %v, %v), expected near (144, 12, 0)", r*255, g*255, b*255)
+	}
+}
+
+func TestDivergingInterpolator_Midpoint(t *testing.T) {
+	neutral, _ := color.HexToRGB("#f7f7f7")
+	if !colorsClose(InterpolateRdBu(0.5), neutral, 0.001) {
+		t.Error("RdBu should be neutral at t=0.5")
+	}
+}
+
+func TestRegisterInterpolator(t *testing.T) {
+	red := color.RGB(1, 0, 0)
+	blue := color.RGB(0, 0, 1)
+	RegisterInterpolator("TestRedBlue", NewMultiStopInterpolator([]color.Color{red, blue}, color.GradientRGB))
+
+	fn, ok := NamedInterpolator("testredblue")
+	if !ok {
+		t.Fatal("Registered interpolator not found")
+	}
+	if !colorsClose(fn(0), red, 0.001) || !colorsClose(fn(1), blue, 0.001) {
+		t.Error("Registered interpolator returned unexpected colors")
+	}
+
+	found := false
+	for _, name := range InterpolatorNames() {
+		if name == "testredblue" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("InterpolatorNames should include registered name")
+	}
+}
+
+func TestNewMultiStopInterpolator(t *testing.T) {
+	red := color.RGB(1, 0, 0)
+	green := color.RGB(0, 1, 0)
+	blue := color.RGB(0, 0, 1)
+
+	fn := NewMultiStopInterpolator([]color.Color{red, green, blue}, color.GradientRGB)
+
+	if !colorsClose(fn(0.5), green, 0.001) {
+		t.Error("Middle stop should be reached at t=0.5")
+	}
+	if !colorsClose(fn(0.25), color.RGB(0.5, 0.5, 0), 0.001) {
+		t.Error("Expected linear mix between first two stops at t=0.25")
+	}
+
+	empty := NewMultiStopInterpolator(nil, color.GradientRGB)
+	if empty(0.5) == nil {
+		t.Error("Empty interpolator should return a fallback color")
+	}
+}
+
+func TestReverseInterpolator(t *testing.T) {
+	reversed := ReverseInterpolator(InterpolateViridis)
+	if !colorsClose(reversed(0), InterpolateViridis(1), 0.0001) {
+		t.Error("Reversed interpolator should swap endpoints")
+	}
+}
+
+func TestSampleInterpolator(t *testing.T) {
+	samples := SampleInterpolator(InterpolateMagma, 5)
+	if len(samples) != 5 {
+		t.Fatalf("Expected 5 samples, got %d", len(samples))
+	}
+	if !colorsClose(samples[0], InterpolateMagma(0), 0.0001) ||
+		!colorsClose(samples[4], InterpolateMagma(1), 0.0001) {
+		t.Error("Samples should include both endpoints")
+	}
+
+	if SampleInterpolator(InterpolateMagma, 0) != nil {
+		t.Error("Expected nil for n=0")
+	}
+}
@@ -127,6 +127,20 @@ func (s *OrdinalScale) Index(value string) int {
 	return -1
 }
 
+// InvertCategory returns the first domain value that maps to the given range value.
+// Returns false if no domain value maps to it.
+func (s *OrdinalScale) InvertCategory(value units.Length) (string, bool) {
+	if len(s.range_) == 0 {
+		return "", false
+	}
+
+	index := indexOfLength(s.range_, value)
+	if index < 0 || index >= len(s.domain) {
+		return "", false
+	}
+	return s.domain[index], true
+}
+
 // Unknown sets the return value for unknown domain values
 func (s *OrdinalScale) Unknown(value units.Length) *OrdinalScale {
 	s.unknown = value
@@ -301,3 +301,21 @@ func BenchmarkOrdinalScale_Apply(b *testing.B) {
 		scale.Apply("C")
 	}
 }
+
+func TestOrdinalScale_InvertCategory(t *testing.T) {
+	scale := NewOrdinalScale(
+		[]string{"small", "medium", "large"},
+		[]units.Length{units.Px(10), units.Px(20), units.Px(30)},
+	)
+
+	if result, ok := scale.InvertCategory(units.Px(20)); !ok || result != "medium" {
+		t.Errorf("InvertCategory(20) = %q, %v, expected \"medium\", true", result, ok)
+	}
+	if _, ok := scale.InvertCategory(units.Px(25)); ok {
+		t.Error("InvertCategory of unmapped value should fail")
+	}
+
+	var _ CategoricalScale = scale
+	var _ CategoricalScale = NewBandScale(nil, [2]units.Length{})
+	var _ CategoricalScale = NewPointScale(nil, [2]units.Length{})
+}
@@ -132,6 +132,62 @@ func (s *PointScale) Index(value string) int {
 	return -1
 }
 
+// InvertCategory maps a range value back to the nearest point.
+// Positions more than half a step away from every point return false.
+//
+// Example:
+//   scale := NewPointScale([]string{"A", "B", "C"}, [2]units.Length{units.Px(0), units.Px(300)})
+//   scale.InvertCategory(units.Px(140)) // Returns "B", true
+func (s *PointScale) InvertCategory(value units.Length) (string, bool) {
+	n := len(s.domain)
+	if n == 0 {
+		return "", false
+	}
+
+	v := value.Value
+
+	// A single point owns the whole range
+	if s.step == 0 {
+		lo, hi := s.range_[0].Value, s.range_[1].Value
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		if v < lo || v > hi {
+			return "", false
+		}
+		return s.domain[0], true
+	}
+
+	index := int(math.Round((v - s.start) / s.step))
+	if index < 0 || index >= n {
+		return "", false
+	}
+
+	point := s.start + float64(index)*s.step
+	if math.Abs(v-point) > math.Abs(s.step)/2 {
+		return "", false
+	}
+	return s.domain[index], true
+}
+
+// InvertRange returns all categories whose points lie within [start, end].
+// Useful for brushing; the order of start and end does not matter.
+func (s *PointScale) InvertRange(start, end units.Length) []string {
+	lo, hi := start.Value, end.Value
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	var categories []string
+	for i, category := range s.domain {
+		point := s.start + float64(i)*s.step
+		if point >= lo && point <= hi {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
 // Step returns the step size (distance between points)
 func (s *PointScale) Step() units.Length {
 	return units.Length{Value: s.step, Unit: s.range_[0].Unit}
@@ -357,3 +357,92 @@ func BenchmarkPointScale_Rescale(b *testing.B) {
 		scale.Padding(0.1)
 	}
 }
+
+func TestPointScale_InvertCategory(t *testing.T) {
+	scale := NewPointScale(
+		[]string{"A", "B", "C"},
+		[2]units.Length{units.Px(0), units.Px(300)},
+	)
+
+	tests := []struct {
+		input    float64
+		expected string
+		ok       bool
+	}{
+		{0, "A", true},
+		{60, "A", true},
+		{140, "B", true},
+		{230, "C", true},
+		{300, "C", true},
+		{-100, "", false},
+		{400, "", false},
+	}
+
+	for _, tt := range tests {
+		result, ok := scale.InvertCategory(units.Px(tt.input))
+		if result != tt.expected || ok != tt.ok {
+			t.Errorf("InvertCategory(%v) = %q, %v, expected %q, %v", tt.input, result, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestPointScale_InvertCategory_PaddingRound(t *testing.T) {
+	scale := NewPointScale(
+		[]string{"A", "B", "C", "D"},
+		[2]units.Length{units.Px(0), units.Px(301)},
+	)
+	scale.Padding(0.5).Round(true)
+
+	for _, category := range scale.Values() {
+		if result, ok := scale.InvertCategory(scale.Apply(category)); !ok || result != category {
+			t.Errorf("InvertCategory(Apply(%q)) = %q, %v", category, result, ok)
+		}
+	}
+}
+
+func TestPointScale_InvertCategory_ReverseRange(t *testing.T) {
+	scale := NewPointScale(
+		[]string{"A", "B", "C"},
+		[2]units.Length{units.Px(300), units.Px(0)},
+	)
+
+	for _, category := range scale.Values() {
+		pos := scale.Apply(category)
+		if result, ok := scale.InvertCategory(units.Px(pos.Value + 10)); !ok || result != category {
+			t.Errorf("InvertCategory(near %q) = %q, %v", category, result, ok)
+		}
+	}
+}
+
+func TestPointScale_InvertCategory_SinglePoint(t *testing.T) {
+	scale := NewPointScale(
+		[]string{"Only"},
+		[2]units.Length{units.Px(0), units.Px(100)},
+	)
+
+	if result, ok := scale.InvertCategory(units.Px(10)); !ok || result != "Only" {
+		t.Errorf("InvertCategory(10) = %q, %v, expected \"Only\", true", result, ok)
+	}
+	if _, ok := scale.InvertCategory(units.Px(200)); ok {
+		t.Error("InvertCategory outside range should fail")
+	}
+}
+
+func TestPointScale_InvertRange(t *testing.T) {
+	scale := NewPointScale(
+		[]string{"A", "B", "C", "D"},
+		[2]units.Length{units.Px(0), units.Px(300)},
+	)
+
+	result := scale.InvertRange(units.Px(50), units.Px(250))
+	expected := []string{"B", "C"}
+
+	if len(result) != len(expected) {
+		t.Fatalf("InvertRange(50, 250) = %v, expected %v", result, expected)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("InvertRange(50, 250)[%d] = %q, expected %q", i, result[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package scales
+
+import (
+	"math"
+	"sort"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/units"
+)
+
+// QuantileScale maps a sampled domain to a discrete range.
+// Unlike QuantizeScale (uniform segments), the domain is a sample of values
+// and the thresholds are quantiles of that sample, so each range value is
+// assigned roughly the same number of observations.
+//
+// Example:
+//   scale := NewQuantileScale(
+//     []float64{3, 6, 7, 8, 8, 10, 13, 15, 16, 20},
+//     []units.Length{units.Px(0), units.Px(1), units.Px(2), units.Px(3)},
+//   )
+//   scale.Quantiles() // Returns [7.25, 9, 14.5] (quartiles)
+//   scale.Apply(8)    // Returns units.Px(1)
+//
+// Common uses: equal-count choropleth classes, skewed distributions
+type QuantileScale struct {
+	domain       []float64 // Sorted sample values
+	range_       []units.Length
+	colors       []color.Color
+	thresholds   []float64 // Computed quantiles
+	unknown      units.Length
+	unknownColor color.Color
+}
+
+// NewQuantileScale creates a new quantile scale with a length range.
+// The domain may be given in any order; NaN values are ignored.
+func NewQuantileScale(domain []float64, range_ []units.Length) *QuantileScale {
+	s := &QuantileScale{
+		domain:       sortedSample(domain),
+		range_:       range_,
+		unknown:      units.Px(0),
+		unknownColor: color.RGB(0.5, 0.5, 0.5),
+	}
+	s.rescale()
+	return s
+}
+
+// NewQuantileColorScale creates a new quantile scale with a color range
+func NewQuantileColorScale(domain []float64, colors []color.Color) *QuantileScale {
+	s := &QuantileScale{
+		domain:       sortedSample(domain),
+		colors:       colors,
+		unknown:      units.Px(0),
+		unknownColor: color.RGB(0.5, 0.5, 0.5),
+	}
+	s.rescale()
+	return s
+}
+
+// Apply maps a domain value to a range value
+func (s *QuantileScale) Apply(value interface{}) units.Length {
+	i := s.bucket(value)
+	if i < 0 || i >= len(s.range_) {
+		return s.unknown
+	}
+	return s.range_[i]
+}
+
+// ApplyColor maps a domain value to a color
+func (s *QuantileScale) ApplyColor(value interface{}) color.Color {
+	i := s.bucket(value)
+	if i < 0 || i >= len(s.colors) {
+		return s.unknownColor
+	}
+	return s.colors[i]
+}
+
+// ApplyValue maps a domain value to its bucket position normalized to [0, 1]
+func (s *QuantileScale) ApplyValue(value interface{}) float64 {
+	return normalizeBucket(s.bucket(value), s.size())
+}
+
+// Interpolator returns a stepped color function over [0, 1].
+// t is treated as a cumulative probability within the sample.
+func (s *QuantileScale) Interpolator() func(t float64) color.Color {
+	return func(t float64) color.Color {
+		if len(s.domain) == 0 {
+			return s.unknownColor
+		}
+		return s.ApplyColor(quantileSorted(s.domain, clampFloat(t, 0, 1)))
+	}
+}
+
+// Domain returns the sorted sample values
+func (s *QuantileScale) Domain() interface{} {
+	return s.domain
+}
+
+// Range returns the first and last range values
+func (s *QuantileScale) Range() [2]units.Length {
+	return discreteRangeExtent(s.range_)
+}
+
+// RangeValues returns all range values
+func (s *QuantileScale) RangeValues() []units.Length {
+	return s.range_
+}
+
+// Colors returns all colors in the scale
+func (s *QuantileScale) Colors() []color.Color {
+	return s.colors
+}
+
+// Type returns the scale type
+func (s *QuantileScale) Type() ScaleType {
+	return ScaleTypeQuantile
+}
+
+// Clone creates a copy of this scale
+func (s *QuantileScale) Clone() Scale {
+	clone := &QuantileScale{
+		domain:       make([]float64, len(s.domain)),
+		range_:       make([]units.Length, len(s.range_)),
+		colors:       make([]color.Color, len(s.colors)),
+		unknown:      s.unknown,
+		unknownColor: s.unknownColor,
+	}
+	copy(clone.domain, s.domain)
+	copy(clone.range_, s.range_)
+	copy(clone.colors, s.colors)
+	clone.rescale()
+	return clone
+}
+
+// Quantiles returns the computed thresholds between buckets.
+// For n buckets there are n-1 quantiles.
+func (s *QuantileScale) Quantiles() []float64 {
+	return s.thresholds
+}
+
+// InvertExtent returns the domain extent [x0, x1) that maps to the given range value.
+// Returns false if the value is not in the range.
+func (s *QuantileScale) InvertExtent(value units.Length) ([2]float64, bool) {
+	return s.extent(indexOfLength(s.range_, value))
+}
+
+// InvertExtentColor returns the domain extent [x0, x1) that maps to the given color.
+// Returns false if the color is not in the range.
+func (s *QuantileScale) InvertExtentColor(c color.Color) ([2]float64, bool) {
+	return s.extent(indexOfColor(s.colors, c))
+}
+
+// Unknown sets the return value for unmappable inputs (e.g. NaN)
+func (s *QuantileScale) Unknown(value units.Length) *QuantileScale {
+	s.unknown = value
+	return s
+}
+
+// UnknownColor sets the color for unmappable inputs (e.g. NaN)
+func (s *QuantileScale) UnknownColor(c color.Color) *QuantileScale {
+	s.unknownColor = c
+	return s
+}
+
+// WithDomain sets a new sample domain
+func (s *QuantileScale) WithDomain(domain []float64) *QuantileScale {
+	s.domain = sortedSample(domain)
+	s.rescale()
+	return s
+}
+
+// WithRange sets a new length range
+func (s *QuantileScale) WithRange(range_ []units.Length) *QuantileScale {
+	s.range_ = range_
+	s.rescale()
+	return s
+}
+
+// WithColors sets a new color range
+func (s *QuantileScale) WithColors(colors []color.Color) *QuantileScale {
+	s.colors = colors
+	s.rescale()
+	return s
+}
+
+// size returns the number of buckets
+func (s *QuantileScale) size() int {
+	if len(s.range_) > 0 {
+		return len(s.range_)
+	}
+	return len(s.colors)
+}
+
+// rescale recomputes the quantile thresholds
+func (s *QuantileScale) rescale() {
+	n := s.size()
+	if n <= 1 || len(s.domain) == 0 {
+		s.thresholds = nil
+		return
+	}
+
+	s.thresholds = make([]float64, n-1)
+	for i := range s.thresholds {
+		s.thresholds[i] = quantileSorted(s.domain, float64(i+1)/float64(n))
+	}
+}
+
+// bucket returns the bucket index for a domain value, or -1 if unmappable
+func (s *QuantileScale) bucket(value interface{}) int {
+	v, ok := toFloat(value)
+	if !ok || math.IsNaN(v) || s.size() == 0 || len(s.domain) == 0 {
+		return -1
+	}
+	return bisectRight(s.thresholds, v)
+}
+
+// extent returns the domain extent for bucket i
+func (s *QuantileScale) extent(i int) ([2]float64, bool) {
+	if i < 0 || len(s.domain) == 0 {
+		return [2]float64{math.NaN(), math.NaN()}, false
+	}
+
+	x0, x1 := s.domain[0], s.domain[len(s.domain)-1]
+	if i > 0 {
+		x0 = s.thresholds[i-1]
+	}
+	if i < len(s.thresholds) {
+		x1 = s.thresholds[i]
+	}
+	return [2]float64{x0, x1}, true
+}
+
+// sortedSample returns a sorted copy of values with NaNs removed
+func sortedSample(values []float64) []float64 {
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// quantileSorted returns the p-quantile of sorted values using linear
+// interpolation between closest ranks (R-7, the same method as d3 and Excel)
+func quantileSorted(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN()
+	}
+	if p <= 0 || n == 1 {
+		return sorted[0]
+	}
+	if p >= 1 {
+		return sorted[n-1]
+	}
+
+	h := float64(n-1) * p
+	lo := int(math.Floor(h))
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
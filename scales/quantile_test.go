@@ -0,0 +1,140 @@
+package scales
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/units"
+)
+
+func TestQuantileScale_Quantiles(t *testing.T) {
+	scale := NewQuantileScale(
+		[]float64{20, 3, 6, 7, 8, 8, 10, 13, 15, 16}, // Unsorted on purpose
+		[]units.Length{units.Px(0), units.Px(1), units.Px(2), units.Px(3)},
+	)
+
+	quantiles := scale.Quantiles()
+	expected := []float64{7.25, 9, 14.5}
+
+	if len(quantiles) != len(expected) {
+		t.Fatalf("Quantiles() length = %d, expected %d", len(quantiles), len(expected))
+	}
+	for i := range expected {
+		if math.Abs(quantiles[i]-expected[i]) > 1e-9 {
+			t.Errorf("Quantiles()[%d] = %v, expected %v", i, quantiles[i], expected[i])
+		}
+	}
+}
+
+func TestQuantileScale_Apply(t *testing.T) {
+	scale := NewQuantileScale(
+		[]float64{3, 6, 7, 8, 8, 10, 13, 15, 16, 20},
+		[]units.Length{units.Px(0), units.Px(1), units.Px(2), units.Px(3)},
+	)
+
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{3, 0},
+		{7, 0},
+		{7.25, 1},
+		{8, 1},
+		{9, 2},
+		{14.5, 3},
+		{20, 3},
+		{100, 3},
+	}
+
+	for _, tt := range tests {
+		result := scale.Apply(tt.input)
+		if result.Value != tt.expected {
+			t.Errorf("Apply(%v) = %v, expected %v", tt.input, result.Value, tt.expected)
+		}
+	}
+}
+
+func TestQuantileScale_InvertExtent(t *testing.T) {
+	scale := NewQuantileScale(
+		[]float64{3, 6, 7, 8, 8, 10, 13, 15, 16, 20},
+		[]units.Length{units.Px(0), units.Px(1), units.Px(2), units.Px(3)},
+	)
+
+	tests := []struct {
+		input    float64
+		expected [2]float64
+	}{
+		{0, [2]float64{3, 7.25}},
+		{1, [2]float64{7.25, 9}},
+		{2, [2]float64{9, 14.5}},
+		{3, [2]float64{14.5, 20}},
+	}
+
+	for _, tt := range tests {
+		extent, ok := scale.InvertExtent(units.Px(tt.input))
+		if !ok {
+			t.Errorf("InvertExtent(%v) should succeed", tt.input)
+			continue
+		}
+		if math.Abs(extent[0]-tt.expected[0]) > 1e-9 || math.Abs(extent[1]-tt.expected[1]) > 1e-9 {
+			t.Errorf("InvertExtent(%v) = %v, expected %v", tt.input, extent, tt.expected)
+		}
+	}
+
+	if _, ok := scale.InvertExtent(units.Px(42)); ok {
+		t.Error("InvertExtent of value not in range should fail")
+	}
+}
+
+func TestQuantileScale_Colors(t *testing.T) {
+	light := color.RGB(0.9, 0.9, 0.9)
+	dark := color.RGB(0.1, 0.1, 0.1)
+
+	scale := NewQuantileColorScale([]float64{1, 2, 3, 4}, []color.Color{light, dark})
+
+	if !colorsClose(scale.ApplyColor(1.0), light, 0.001) {
+		t.Error("ApplyColor(1) should be light")
+	}
+	if !colorsClose(scale.ApplyColor(4.0), dark, 0.001) {
+		t.Error("ApplyColor(4) should be dark")
+	}
+
+	extent, ok := scale.InvertExtentColor(dark)
+	if !ok || extent[0] != 2.5 || extent[1] != 4 {
+		t.Errorf("InvertExtentColor(dark) = %v, %v, expected [2.5 4], true", extent, ok)
+	}
+}
+
+func TestQuantileScale_NaN(t *testing.T) {
+	scale := NewQuantileScale(
+		[]float64{1, math.NaN(), 2, 3},
+		[]units.Length{units.Px(0), units.Px(1)},
+	)
+
+	domain := scale.Domain().([]float64)
+	if len(domain) != 3 {
+		t.Errorf("NaN values should be dropped from domain, got %v", domain)
+	}
+
+	if result := scale.Apply(math.NaN()); result.Value != 0 {
+		t.Errorf("Apply(NaN) = %v, expected unknown (0)", result.Value)
+	}
+}
+
+func TestQuantileScale_Clone(t *testing.T) {
+	scale := NewQuantileScale(
+		[]float64{1, 2, 3, 4},
+		[]units.Length{units.Px(0), units.Px(1)},
+	)
+
+	clone := scale.Clone().(*QuantileScale)
+	clone.WithDomain([]float64{10, 20, 30})
+
+	if scale.Quantiles()[0] != 2.5 {
+		t.Errorf("Modifying clone should not affect original, got %v", scale.Quantiles())
+	}
+	if clone.Type() != ScaleTypeQuantile {
+		t.Errorf("Type() = %v, expected ScaleTypeQuantile", clone.Type())
+	}
+}
@@ -0,0 +1,325 @@
+package scales
+
+import (
+	"math"
+	"sort"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/units"
+)
+
+// QuantizeScale maps a continuous domain to a discrete range.
+// The domain [d0, d1] is divided into uniform segments, one per range value.
+// A reversed domain (d0 > d1) is allowed; the first range value then maps to
+// the segment nearest d0, the top of the domain.
+//
+// The range may be a list of units.Length values, a list of colors, or both.
+// When both are set they should have the same length; the number of buckets
+// is taken from the length range.
+//
+// Example:
+//   scale := NewQuantizeScale(
+//     [2]float64{0, 100},
+//     []units.Length{units.Px(2), units.Px(4), units.Px(8), units.Px(16)},
+//   )
+//   scale.Apply(10)  // Returns units.Px(2)  - bucket [0, 25)
+//   scale.Apply(60)  // Returns units.Px(8)  - bucket [50, 75)
+//   scale.InvertExtent(units.Px(8)) // Returns [50, 75], true
+//
+// Common uses: choropleth maps, bucketed heatmaps, stepped legends
+type QuantizeScale struct {
+	domain       [2]float64
+	range_       []units.Length
+	colors       []color.Color
+	unknown      units.Length
+	unknownColor color.Color
+}
+
+// NewQuantizeScale creates a new quantize scale with a length range
+func NewQuantizeScale(domain [2]float64, range_ []units.Length) *QuantizeScale {
+	return &QuantizeScale{
+		domain:       domain,
+		range_:       range_,
+		unknown:      units.Px(0),
+		unknownColor: color.RGB(0.5, 0.5, 0.5),
+	}
+}
+
+// NewQuantizeColorScale creates a new quantize scale with a color range
+func NewQuantizeColorScale(domain [2]float64, colors []color.Color) *QuantizeScale {
+	return &QuantizeScale{
+		domain:       domain,
+		colors:       colors,
+		unknown:      units.Px(0),
+		unknownColor: color.RGB(0.5, 0.5, 0.5),
+	}
+}
+
+// Apply maps a domain value to a range value
+func (s *QuantizeScale) Apply(value interface{}) units.Length {
+	i := s.bucket(value)
+	if i < 0 || i >= len(s.range_) {
+		return s.unknown
+	}
+	return s.range_[i]
+}
+
+// ApplyColor maps a domain value to a color
+func (s *QuantizeScale) ApplyColor(value interface{}) color.Color {
+	i := s.bucket(value)
+	if i < 0 || i >= len(s.colors) {
+		return s.unknownColor
+	}
+	return s.colors[i]
+}
+
+// ApplyValue maps a domain value to its bucket position normalized to [0, 1]
+func (s *QuantizeScale) ApplyValue(value interface{}) float64 {
+	return normalizeBucket(s.bucket(value), s.size())
+}
+
+// Interpolator returns a stepped color function over [0, 1]
+func (s *QuantizeScale) Interpolator() func(t float64) color.Color {
+	return func(t float64) color.Color {
+		return s.ApplyColor(s.domain[0] + t*(s.domain[1]-s.domain[0]))
+	}
+}
+
+// Domain returns the input domain
+func (s *QuantizeScale) Domain() interface{} {
+	return s.domain
+}
+
+// Range returns the first and last range values
+func (s *QuantizeScale) Range() [2]units.Length {
+	return discreteRangeExtent(s.range_)
+}
+
+// RangeValues returns all range values
+func (s *QuantizeScale) RangeValues() []units.Length {
+	return s.range_
+}
+
+// Colors returns all colors in the scale
+func (s *QuantizeScale) Colors() []color.Color {
+	return s.colors
+}
+
+// Type returns the scale type
+func (s *QuantizeScale) Type() ScaleType {
+	return ScaleTypeQuantize
+}
+
+// Clone creates a copy of this scale
+func (s *QuantizeScale) Clone() Scale {
+	clone := &QuantizeScale{
+		domain:       s.domain,
+		range_:       make([]units.Length, len(s.range_)),
+		colors:       make([]color.Color, len(s.colors)),
+		unknown:      s.unknown,
+		unknownColor: s.unknownColor,
+	}
+	copy(clone.range_, s.range_)
+	copy(clone.colors, s.colors)
+	return clone
+}
+
+// Thresholds returns the computed boundaries between buckets, in bucket
+// order, so they descend for a reversed domain.
+// For n buckets there are n-1 thresholds.
+func (s *QuantizeScale) Thresholds() []float64 {
+	n := s.size()
+	if n <= 1 {
+		return nil
+	}
+
+	thresholds := make([]float64, n-1)
+	for i := range thresholds {
+		thresholds[i] = s.domain[0] + float64(i+1)*(s.domain[1]-s.domain[0])/float64(n)
+	}
+	return thresholds
+}
+
+// InvertExtent returns the domain extent [x0, x1) that maps to the given range value,
+// with x0 < x1 even for a reversed domain.
+// Returns false if the value is not in the range.
+func (s *QuantizeScale) InvertExtent(value units.Length) ([2]float64, bool) {
+	return s.extent(indexOfLength(s.range_, value))
+}
+
+// InvertExtentColor returns the domain extent [x0, x1) that maps to the given color.
+// Returns false if the color is not in the range.
+func (s *QuantizeScale) InvertExtentColor(c color.Color) ([2]float64, bool) {
+	return s.extent(indexOfColor(s.colors, c))
+}
+
+// Nice extends the domain outward to nice numbers, keeping its direction
+func (s *QuantizeScale) Nice(count int) *QuantizeScale {
+	d0, d1 := s.domain[0], s.domain[1]
+	if d0 == d1 {
+		return s
+	}
+	if count <= 1 {
+		count = 10
+	}
+
+	step := niceNumber(math.Abs(d1-d0)/float64(count-1), false)
+	if d0 < d1 {
+		s.domain[0] = math.Floor(d0/step) * step
+		s.domain[1] = math.Ceil(d1/step) * step
+	} else {
+		s.domain[0] = math.Ceil(d0/step) * step
+		s.domain[1] = math.Floor(d1/step) * step
+	}
+	return s
+}
+
+// Unknown sets the return value for values outside a usable domain (e.g. NaN)
+func (s *QuantizeScale) Unknown(value units.Length) *QuantizeScale {
+	s.unknown = value
+	return s
+}
+
+// UnknownColor sets the color for values outside a usable domain (e.g. NaN)
+func (s *QuantizeScale) UnknownColor(c color.Color) *QuantizeScale {
+	s.unknownColor = c
+	return s
+}
+
+// WithDomain sets a new domain
+func (s *QuantizeScale) WithDomain(domain [2]float64) *QuantizeScale {
+	s.domain = domain
+	return s
+}
+
+// WithRange sets a new length range
+func (s *QuantizeScale) WithRange(range_ []units.Length) *QuantizeScale {
+	s.range_ = range_
+	return s
+}
+
+// WithColors sets a new color range
+func (s *QuantizeScale) WithColors(colors []color.Color) *QuantizeScale {
+	s.colors = colors
+	return s
+}
+
+// size returns the number of buckets
+func (s *QuantizeScale) size() int {
+	if len(s.range_) > 0 {
+		return len(s.range_)
+	}
+	return len(s.colors)
+}
+
+// bucket returns the bucket index for a domain value, or -1 if unmappable
+func (s *QuantizeScale) bucket(value interface{}) int {
+	v, ok := toFloat(value)
+	if !ok || math.IsNaN(v) {
+		return -1
+	}
+	n := s.size()
+	if n == 0 {
+		return -1
+	}
+	thresholds := s.Thresholds()
+	if s.domain[0] > s.domain[1] {
+		// Descending thresholds: count those at or above v
+		return sort.Search(len(thresholds), func(i int) bool {
+			return thresholds[i] < v
+		})
+	}
+	return bisectRight(thresholds, v)
+}
+
+// extent returns the domain extent for bucket i
+func (s *QuantizeScale) extent(i int) ([2]float64, bool) {
+	if i < 0 {
+		return [2]float64{math.NaN(), math.NaN()}, false
+	}
+
+	thresholds := s.Thresholds()
+	x0, x1 := s.domain[0], s.domain[1]
+	if i > 0 {
+		x0 = thresholds[i-1]
+	}
+	if i < len(thresholds) {
+		x1 = thresholds[i]
+	}
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	return [2]float64{x0, x1}, true
+}
+
+// Helper functions shared by the discrete scales (quantize, quantile, threshold)
+
+// toFloat converts a numeric domain value to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// bisectRight returns the number of sorted values that are <= x
+func bisectRight(sorted []float64, x float64) int {
+	return sort.Search(len(sorted), func(i int) bool {
+		return sorted[i] > x
+	})
+}
+
+// normalizeBucket maps a bucket index to [0, 1]
+func normalizeBucket(i, n int) float64 {
+	if i < 0 || n == 0 {
+		return 0
+	}
+	if n == 1 {
+		return 0.5
+	}
+	return float64(i) / float64(n-1)
+}
+
+// discreteRangeExtent returns the first and last values of a discrete range
+func discreteRangeExtent(range_ []units.Length) [2]units.Length {
+	if len(range_) == 0 {
+		return [2]units.Length{units.Px(0), units.Px(0)}
+	}
+	return [2]units.Length{range_[0], range_[len(range_)-1]}
+}
+
+// indexOfLength returns the index of a length in a discrete range, or -1
+func indexOfLength(range_ []units.Length, value units.Length) int {
+	for i, r := range range_ {
+		if r.Value == value.Value && r.Unit == value.Unit {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexOfColor returns the index of a color in a discrete color range, or -1
+func indexOfColor(colors []color.Color, c color.Color) int {
+	if c == nil {
+		return -1
+	}
+	r, g, b, a := c.RGBA()
+	for i, candidate := range colors {
+		if candidate == nil {
+			continue
+		}
+		cr, cg, cb, ca := candidate.RGBA()
+		if r == cr && g == cg && b == cb && a == ca {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,232 @@
+package scales
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/units"
+)
+
+// colorsClose compares colors channel by channel in the [0, 1] range
+func colorsClose(c1, c2 color.Color, tolerance float64) bool {
+	r1, g1, b1, a1 := c1.RGBA()
+	r2, g2, b2, a2 := c2.RGBA()
+
+	return math.Abs(r1-r2) <= tolerance &&
+		math.Abs(g1-g2) <= tolerance &&
+		math.Abs(b1-b2) <= tolerance &&
+		math.Abs(a1-a2) <= tolerance
+}
+
+func TestQuantizeScale_Basic(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{0, 100},
+		[]units.Length{units.Px(2), units.Px(4), units.Px(8), units.Px(16)},
+	)
+
+	tests := []struct {
+		input    interface{}
+		expected float64
+	}{
+		{0.0, 2},
+		{10.0, 2},
+		{25.0, 4},  // Threshold belongs to upper bucket
+		{49.9, 4},
+		{60, 8},    // int input
+		{99.0, 16},
+		{100.0, 16},
+		{-50.0, 2}, // Below domain maps to first bucket
+		{500.0, 16},
+	}
+
+	for _, tt := range tests {
+		result := scale.Apply(tt.input)
+		if math.Abs(result.Value-tt.expected) > 0.01 {
+			t.Errorf("Apply(%v) = %v, expected %v", tt.input, result.Value, tt.expected)
+		}
+	}
+}
+
+func TestQuantizeScale_Thresholds(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{0, 1},
+		[]units.Length{units.Px(0), units.Px(1), units.Px(2)},
+	)
+
+	thresholds := scale.Thresholds()
+	expected := []float64{1.0 / 3, 2.0 / 3}
+
+	if len(thresholds) != len(expected) {
+		t.Fatalf("Thresholds() length = %d, expected %d", len(thresholds), len(expected))
+	}
+	for i := range expected {
+		if math.Abs(thresholds[i]-expected[i]) > 1e-9 {
+			t.Errorf("Thresholds()[%d] = %v, expected %v", i, thresholds[i], expected[i])
+		}
+	}
+}
+
+func TestQuantizeScale_InvertExtent(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{0, 100},
+		[]units.Length{units.Px(2), units.Px(4), units.Px(8), units.Px(16)},
+	)
+
+	extent, ok := scale.InvertExtent(units.Px(8))
+	if !ok {
+		t.Fatal("InvertExtent(8px) should succeed")
+	}
+	if extent[0] != 50 || extent[1] != 75 {
+		t.Errorf("InvertExtent(8px) = %v, expected [50 75]", extent)
+	}
+
+	extent, _ = scale.InvertExtent(units.Px(2))
+	if extent[0] != 0 || extent[1] != 25 {
+		t.Errorf("InvertExtent(2px) = %v, expected [0 25]", extent)
+	}
+
+	if _, ok := scale.InvertExtent(units.Px(3)); ok {
+		t.Error("InvertExtent of value not in range should fail")
+	}
+}
+
+func TestQuantizeScale_Colors(t *testing.T) {
+	red := color.RGB(1, 0, 0)
+	green := color.RGB(0, 1, 0)
+	blue := color.RGB(0, 0, 1)
+
+	scale := NewQuantizeColorScale([2]float64{0, 30}, []color.Color{red, green, blue})
+
+	if !colorsClose(scale.ApplyColor(5.0), red, 0.001) {
+		t.Error("ApplyColor(5) should be red")
+	}
+	if !colorsClose(scale.ApplyColor(15.0), green, 0.001) {
+		t.Error("ApplyColor(15) should be green")
+	}
+	if !colorsClose(scale.ApplyColor(29.0), blue, 0.001) {
+		t.Error("ApplyColor(29) should be blue")
+	}
+
+	extent, ok := scale.InvertExtentColor(green)
+	if !ok || extent[0] != 10 || extent[1] != 20 {
+		t.Errorf("InvertExtentColor(green) = %v, %v, expected [10 20], true", extent, ok)
+	}
+
+	// Interpolator steps through the buckets
+	interp := scale.Interpolator()
+	if !colorsClose(interp(0), red, 0.001) || !colorsClose(interp(1), blue, 0.001) {
+		t.Error("Interpolator should map 0 to first color and 1 to last color")
+	}
+}
+
+func TestQuantizeScale_Unknown(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{0, 10},
+		[]units.Length{units.Px(1), units.Px(2)},
+	).Unknown(units.Px(-1))
+
+	if result := scale.Apply(math.NaN()); result.Value != -1 {
+		t.Errorf("Apply(NaN) = %v, expected -1", result.Value)
+	}
+	if result := scale.Apply("x"); result.Value != -1 {
+		t.Errorf("Apply(string) = %v, expected -1", result.Value)
+	}
+}
+
+func TestQuantizeScale_ApplyValue(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{0, 100},
+		[]units.Length{units.Px(0), units.Px(1), units.Px(2)},
+	)
+
+	if v := scale.ApplyValue(10.0); v != 0 {
+		t.Errorf("ApplyValue(10) = %v, expected 0", v)
+	}
+	if v := scale.ApplyValue(50.0); v != 0.5 {
+		t.Errorf("ApplyValue(50) = %v, expected 0.5", v)
+	}
+	if v := scale.ApplyValue(90.0); v != 1 {
+		t.Errorf("ApplyValue(90) = %v, expected 1", v)
+	}
+}
+
+func TestQuantizeScale_Nice(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{0.2, 9.7},
+		[]units.Length{units.Px(0), units.Px(1)},
+	)
+	scale.Nice(10)
+
+	domain := scale.Domain().([2]float64)
+	if domain[0] != 0 || domain[1] != 10 {
+		t.Errorf("Nice() domain = %v, expected [0 10]", domain)
+	}
+}
+
+func TestQuantizeScale_ReversedDomain(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{100, 0},
+		[]units.Length{units.Px(2), units.Px(4), units.Px(8), units.Px(16)},
+	)
+
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{100, 2},
+		{90, 2},
+		{75, 4}, // Threshold belongs to the next bucket
+		{60, 4},
+		{10, 16},
+		{0, 16},
+		{150, 2}, // Above domain maps to first bucket
+		{-50, 16},
+	}
+	for _, tt := range tests {
+		if result := scale.Apply(tt.input); result.Value != tt.expected {
+			t.Errorf("Apply(%v) = %v, expected %v", tt.input, result.Value, tt.expected)
+		}
+	}
+
+	thresholds := scale.Thresholds()
+	if len(thresholds) != 3 || thresholds[0] != 75 || thresholds[2] != 25 {
+		t.Errorf("Thresholds() = %v, expected [75 50 25]", thresholds)
+	}
+	if extent, ok := scale.InvertExtent(units.Px(4)); !ok || extent != [2]float64{50, 75} {
+		t.Errorf("InvertExtent(4px) = %v, %v, expected [50 75]", extent, ok)
+	}
+
+	scale.WithDomain([2]float64{9.7, 0.2}).Nice(10)
+	if domain := scale.Domain().([2]float64); domain != [2]float64{10, 0} {
+		t.Errorf("Nice() domain = %v, expected [10 0]", domain)
+	}
+}
+
+func TestQuantizeScale_Clone(t *testing.T) {
+	scale := NewQuantizeScale(
+		[2]float64{0, 100},
+		[]units.Length{units.Px(0), units.Px(1)},
+	)
+
+	clone := scale.Clone().(*QuantizeScale)
+	clone.WithDomain([2]float64{0, 10})
+	clone.range_[0] = units.Px(99)
+
+	if scale.domain[1] != 100 {
+		t.Error("Modifying clone domain should not affect original")
+	}
+	if scale.range_[0].Value != 0 {
+		t.Error("Modifying clone range should not affect original")
+	}
+}
+
+func TestQuantizeScale_Interfaces(t *testing.T) {
+	var _ Scale = NewQuantizeScale([2]float64{0, 1}, nil)
+	var _ ColorScale = NewQuantizeColorScale([2]float64{0, 1}, nil)
+
+	scale := NewQuantizeScale([2]float64{0, 1}, nil)
+	if scale.Type() != ScaleTypeQuantize {
+		t.Errorf("Type() = %v, expected ScaleTypeQuantize", scale.Type())
+	}
+}
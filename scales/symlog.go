@@ -0,0 +1,281 @@
+package scales
+
+import (
+	"math"
+
+	"github.com/SCKelemen/units"
+)
+
+// SymlogScale implements a continuous bi-symmetric logarithmic scale.
+// Unlike LogScale, the domain may include zero and negative values.
+//
+// Values are transformed with sign(x) * log(1 + |x|/c), where c is the
+// linear-threshold constant. Around zero (|x| < c) the scale is nearly
+// linear; far from zero it behaves like a log scale on both sides.
+//
+// Ranges use units.Length to support relative units (%, px, em, etc.).
+//
+// Example:
+//   scale := NewSymlogScale([2]float64{-1000, 1000}, [2]units.Length{units.Px(0), units.Px(500)})
+//   scale.Apply(0)     // Returns units.Px(250) - center
+//   scale.Apply(-1000) // Returns units.Px(0)
+//   scale.Ticks(10)    // Returns [-1000 -100 -10 -1 0 1 10 100 1000]
+//
+// Symlog scales are useful for signed data spanning multiple orders of magnitude:
+// - Latency deltas
+// - Profit and loss
+// - Signed growth rates
+type SymlogScale struct {
+	domain   [2]float64
+	range_   [2]units.Length
+	constant float64 // Linear threshold
+	clamp    bool
+}
+
+// NewSymlogScale creates a new symlog scale with a linear threshold of 1
+func NewSymlogScale(domain [2]float64, range_ [2]units.Length) *SymlogScale {
+	return &SymlogScale{
+		domain:   domain,
+		range_:   range_,
+		constant: 1,
+		clamp:    false,
+	}
+}
+
+// Apply maps a domain value to a range value
+func (s *SymlogScale) Apply(value interface{}) units.Length {
+	t := s.ApplyValue(value)
+
+	r0 := s.range_[0].Value
+	r1 := s.range_[1].Value
+	unit := s.range_[0].Unit
+
+	result := r0 + t*(r1-r0)
+
+	return units.Length{Value: result, Unit: unit}
+}
+
+// ApplyValue maps a domain value to a normalized value (0-1 interpolation factor)
+func (s *SymlogScale) ApplyValue(value interface{}) float64 {
+	v, ok := value.(float64)
+	if !ok {
+		// Try int
+		if i, ok := value.(int); ok {
+			v = float64(i)
+		} else {
+			return 0
+		}
+	}
+
+	t0 := s.transform(s.domain[0])
+	t1 := s.transform(s.domain[1])
+
+	if t0 == t1 {
+		return 0.5
+	}
+
+	t := (s.transform(v) - t0) / (t1 - t0)
+
+	if s.clamp {
+		t = clampFloat(t, 0, 1)
+	}
+
+	return t
+}
+
+// Invert maps a range value back to a domain value
+func (s *SymlogScale) Invert(value units.Length) float64 {
+	v := value.Value
+	r0 := s.range_[0].Value
+	r1 := s.range_[1].Value
+
+	t := (v - r0) / (r1 - r0)
+
+	if s.clamp {
+		t = clampFloat(t, 0, 1)
+	}
+
+	return s.InvertValue(t)
+}
+
+// InvertValue maps a normalized value (0-1) back to a domain value
+func (s *SymlogScale) InvertValue(t float64) float64 {
+	t0 := s.transform(s.domain[0])
+	t1 := s.transform(s.domain[1])
+
+	return s.untransform(t0 + t*(t1-t0))
+}
+
+// Domain returns the input domain
+func (s *SymlogScale) Domain() interface{} {
+	return s.domain
+}
+
+// Range returns the output range
+func (s *SymlogScale) Range() [2]units.Length {
+	return s.range_
+}
+
+// Type returns the scale type
+func (s *SymlogScale) Type() ScaleType {
+	return ScaleTypeSymlog
+}
+
+// Clone creates a copy of this scale
+func (s *SymlogScale) Clone() Scale {
+	return &SymlogScale{
+		domain:   s.domain,
+		range_:   s.range_,
+		constant: s.constant,
+		clamp:    s.clamp,
+	}
+}
+
+// Clamp enables/disables clamping output to range
+func (s *SymlogScale) Clamp(enabled bool) ContinuousScale {
+	s.clamp = enabled
+	return s
+}
+
+// Constant sets the linear threshold. Values with |x| < c are mapped
+// approximately linearly. Non-positive values default to 1.
+func (s *SymlogScale) Constant(c float64) *SymlogScale {
+	if c <= 0 || math.IsNaN(c) || math.IsInf(c, 0) {
+		c = 1
+	}
+	s.constant = c
+	return s
+}
+
+// Nice rounds each end of the domain away from zero to a nice value
+// (1, 2 or 5 times a power of ten). Zero endpoints are kept as-is.
+func (s *SymlogScale) Nice(count int) ContinuousScale {
+	d0, d1 := s.domain[0], s.domain[1]
+
+	if d0 == d1 {
+		return s
+	}
+
+	s.domain[0] = niceAwayFromZero(d0)
+	s.domain[1] = niceAwayFromZero(d1)
+
+	return s
+}
+
+// Ticks generates tick values for axes.
+//
+// When the domain reaches beyond the linear region, ticks are placed at zero
+// (if in the domain) and at signed powers of ten on each side. Powers are
+// subdivided at 2x and 5x when there is room, or thinned out to stay within
+// count. Narrow domains inside the linear region use linear ticks.
+func (s *SymlogScale) Ticks(count int) []float64 {
+	if count <= 0 {
+		count = 10
+	}
+
+	lo, hi := s.domain[0], s.domain[1]
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	if lo == hi {
+		return []float64{lo}
+	}
+
+	maxAbs := math.Max(math.Abs(lo), math.Abs(hi))
+	if maxAbs <= 10*s.constant {
+		return NewLinearScale([2]float64{lo, hi}, s.range_).Ticks(count)
+	}
+
+	// Powers of ten from the linear threshold up to the domain extent
+	minPow := int(math.Floor(math.Log10(s.constant)))
+	maxPow := int(math.Ceil(math.Log10(maxAbs)))
+
+	// Prefer 1x, 2x and 5x multiples of every power when they fit
+	if dense := s.symlogTicks(lo, hi, minPow, maxPow, 1, []float64{1, 2, 5}); len(dense) <= count {
+		return dense
+	}
+
+	// Otherwise thin out the powers until the tick count fits
+	var ticks []float64
+	for stride := 1; stride <= maxPow-minPow+1; stride++ {
+		ticks = s.symlogTicks(lo, hi, minPow, maxPow, stride, []float64{1})
+		if len(ticks) <= count {
+			break
+		}
+	}
+
+	return ticks
+}
+
+// symlogTicks generates ascending ticks at signed multiples of powers of ten.
+// Only every stride-th power (counting down from maxPow) is used.
+func (s *SymlogScale) symlogTicks(lo, hi float64, minPow, maxPow, stride int, mults []float64) []float64 {
+	var magnitudes []float64
+	for pow := minPow; pow <= maxPow; pow++ {
+		if (maxPow-pow)%stride != 0 {
+			continue
+		}
+		base := math.Pow(10, float64(pow))
+		for _, m := range mults {
+			magnitudes = append(magnitudes, m*base)
+		}
+	}
+
+	var ticks []float64
+
+	// Negative side, most negative first
+	for i := len(magnitudes) - 1; i >= 0; i-- {
+		if v := -magnitudes[i]; v >= lo && v <= hi {
+			ticks = append(ticks, v)
+		}
+	}
+
+	if lo <= 0 && hi >= 0 {
+		ticks = append(ticks, 0)
+	}
+
+	for _, m := range magnitudes {
+		if m >= lo && m <= hi {
+			ticks = append(ticks, m)
+		}
+	}
+
+	return ticks
+}
+
+// WithDomain sets a new domain
+func (s *SymlogScale) WithDomain(domain [2]float64) *SymlogScale {
+	s.domain = domain
+	return s
+}
+
+// WithRange sets a new range
+func (s *SymlogScale) WithRange(range_ [2]units.Length) *SymlogScale {
+	s.range_ = range_
+	return s
+}
+
+// transform applies the symlog transform sign(x) * log1p(|x|/c)
+func (s *SymlogScale) transform(x float64) float64 {
+	if x < 0 {
+		return -math.Log1p(-x / s.constant)
+	}
+	return math.Log1p(x / s.constant)
+}
+
+// untransform inverts the symlog transform
+func (s *SymlogScale) untransform(y float64) float64 {
+	if y < 0 {
+		return -math.Expm1(-y) * s.constant
+	}
+	return math.Expm1(y) * s.constant
+}
+
+// niceAwayFromZero rounds a value away from zero to 1, 2 or 5 times a power of ten
+func niceAwayFromZero(v float64) float64 {
+	if v == 0 {
+		return 0
+	}
+	return niceNumber(v, false)
+}
@@ -0,0 +1,221 @@
+package scales
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SCKelemen/units"
+)
+
+func TestSymlogScale_Basic(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-1000, 1000},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{-1000, 0},
+		{0, 250},
+		{1000, 500},
+	}
+
+	for _, tt := range tests {
+		result := scale.Apply(tt.input)
+		if math.Abs(result.Value-tt.expected) > 0.01 {
+			t.Errorf("Apply(%v) = %v, expected %v", tt.input, result.Value, tt.expected)
+		}
+		if result.Unit != units.PX {
+			t.Errorf("Apply(%v) unit = %v, expected PX", tt.input, result.Unit)
+		}
+	}
+
+	// Symmetric around zero
+	neg := scale.Apply(-100.0).Value
+	pos := scale.Apply(100.0).Value
+	if math.Abs((250-neg)-(pos-250)) > 0.01 {
+		t.Errorf("Apply(-100) = %v and Apply(100) = %v should be symmetric around 250", neg, pos)
+	}
+
+	// Log-like spacing far from zero: 10->100 and 100->1000 take similar space
+	d1 := scale.Apply(100.0).Value - scale.Apply(10.0).Value
+	d2 := scale.Apply(1000.0).Value - scale.Apply(100.0).Value
+	if math.Abs(d1-d2) > 5 {
+		t.Errorf("Decades should have similar width, got %v and %v", d1, d2)
+	}
+}
+
+func TestSymlogScale_Invert(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-500, 2000},
+		[2]units.Length{units.Px(0), units.Px(400)},
+	)
+
+	for _, v := range []float64{-500, -42, -1, 0, 0.5, 7, 2000} {
+		pos := scale.Apply(v)
+		result := scale.Invert(pos)
+		if math.Abs(result-v) > 1e-6 {
+			t.Errorf("Invert(Apply(%v)) = %v", v, result)
+		}
+	}
+}
+
+func TestSymlogScale_Constant(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-100, 100},
+		[2]units.Length{units.Px(0), units.Px(200)},
+	)
+
+	// A large constant makes the scale nearly linear
+	scale.Constant(1e6)
+	result := scale.Apply(50.0)
+	if math.Abs(result.Value-150) > 0.1 {
+		t.Errorf("Apply(50) with large constant = %v, expected ~150", result.Value)
+	}
+
+	// Invalid constants fall back to 1
+	scale.Constant(-5)
+	if scale.constant != 1 {
+		t.Errorf("Constant(-5) = %v, expected 1", scale.constant)
+	}
+}
+
+func TestSymlogScale_Clamp(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-10, 10},
+		[2]units.Length{units.Px(0), units.Px(100)},
+	)
+
+	if result := scale.Apply(1000.0); result.Value <= 100 {
+		t.Errorf("Apply(1000) without clamp = %v, expected > 100", result.Value)
+	}
+
+	scale.Clamp(true)
+	if result := scale.Apply(1000.0); result.Value != 100 {
+		t.Errorf("Apply(1000) with clamp = %v, expected 100", result.Value)
+	}
+	if result := scale.Apply(-1000.0); result.Value != 0 {
+		t.Errorf("Apply(-1000) with clamp = %v, expected 0", result.Value)
+	}
+}
+
+func TestSymlogScale_Ticks(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-1000, 1000},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	ticks := scale.Ticks(10)
+	expected := []float64{-1000, -100, -10, -1, 0, 1, 10, 100, 1000}
+
+	if len(ticks) != len(expected) {
+		t.Fatalf("Ticks(10) = %v, expected %v", ticks, expected)
+	}
+	for i := range expected {
+		if ticks[i] != expected[i] {
+			t.Errorf("Ticks(10)[%d] = %v, expected %v", i, ticks[i], expected[i])
+		}
+	}
+}
+
+func TestSymlogScale_Ticks_Thinned(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-1e6, 1e6},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	ticks := scale.Ticks(5)
+	if len(ticks) > 5 {
+		t.Errorf("Ticks(5) returned %d ticks: %v", len(ticks), ticks)
+	}
+
+	// Ticks should be ascending and symmetric
+	for i := 1; i < len(ticks); i++ {
+		if ticks[i] <= ticks[i-1] {
+			t.Errorf("Ticks should be ascending, got %v", ticks)
+			break
+		}
+	}
+	for i := range ticks {
+		if ticks[i] != -ticks[len(ticks)-1-i] {
+			t.Errorf("Ticks should be symmetric, got %v", ticks)
+			break
+		}
+	}
+}
+
+func TestSymlogScale_Ticks_Dense(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{0, 100},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	ticks := scale.Ticks(10)
+	expected := []float64{0, 1, 2, 5, 10, 20, 50, 100}
+
+	if len(ticks) != len(expected) {
+		t.Fatalf("Ticks(10) = %v, expected %v", ticks, expected)
+	}
+	for i := range expected {
+		if ticks[i] != expected[i] {
+			t.Errorf("Ticks(10)[%d] = %v, expected %v", i, ticks[i], expected[i])
+		}
+	}
+}
+
+func TestSymlogScale_Ticks_LinearRegion(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-5, 5},
+		[2]units.Length{units.Px(0), units.Px(100)},
+	)
+
+	// Same as linear ticks
+	ticks := scale.Ticks(5)
+	expected := []float64{-4, -2, 0, 2, 4}
+
+	if len(ticks) != len(expected) {
+		t.Fatalf("Ticks(5) in linear region = %v, expected %v", ticks, expected)
+	}
+	for i := range expected {
+		if ticks[i] != expected[i] {
+			t.Errorf("Ticks(5)[%d] = %v, expected %v", i, ticks[i], expected[i])
+		}
+	}
+}
+
+func TestSymlogScale_Nice(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-730, 1200},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+	scale.Nice(10)
+
+	domain := scale.Domain().([2]float64)
+	if domain[0] != -1000 || domain[1] != 2000 {
+		t.Errorf("Nice() domain = %v, expected [-1000 2000]", domain)
+	}
+}
+
+func TestSymlogScale_Clone(t *testing.T) {
+	scale := NewSymlogScale(
+		[2]float64{-10, 10},
+		[2]units.Length{units.Px(0), units.Px(100)},
+	).Constant(5)
+
+	clone := scale.Clone().(*SymlogScale)
+	clone.WithDomain([2]float64{0, 1})
+
+	if scale.domain[0] != -10 {
+		t.Error("Modifying clone should not affect original")
+	}
+	if clone.constant != 5 {
+		t.Errorf("Clone constant = %v, expected 5", clone.constant)
+	}
+	if clone.Type() != ScaleTypeSymlog {
+		t.Errorf("Type() = %v, expected ScaleTypeSymlog", clone.Type())
+	}
+
+	var _ ContinuousScale = scale
+}
@@ -0,0 +1,203 @@
+package scales
+
+import (
+	"math"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/units"
+)
+
+// ThresholdScale maps a continuous domain to a discrete range using explicit
+// threshold values. With n thresholds there are n+1 range values: values
+// below the first threshold map to the first range value, values at or above
+// the last threshold map to the last range value.
+//
+// Example:
+//   scale := NewThresholdColorScale(
+//     []float64{0, 50},
+//     []color.Color{red, yellow, green},
+//   )
+//   scale.ApplyColor(-10) // Returns red
+//   scale.ApplyColor(20)  // Returns yellow
+//   scale.ApplyColor(50)  // Returns green
+//
+// Common uses: SLO bands, risk levels, custom choropleth breaks
+type ThresholdScale struct {
+	domain       []float64 // Sorted threshold values
+	range_       []units.Length
+	colors       []color.Color
+	unknown      units.Length
+	unknownColor color.Color
+}
+
+// NewThresholdScale creates a new threshold scale with a length range.
+// The range should have one more value than the thresholds.
+func NewThresholdScale(thresholds []float64, range_ []units.Length) *ThresholdScale {
+	return &ThresholdScale{
+		domain:       sortedSample(thresholds),
+		range_:       range_,
+		unknown:      units.Px(0),
+		unknownColor: color.RGB(0.5, 0.5, 0.5),
+	}
+}
+
+// NewThresholdColorScale creates a new threshold scale with a color range.
+// The colors should have one more value than the thresholds.
+func NewThresholdColorScale(thresholds []float64, colors []color.Color) *ThresholdScale {
+	return &ThresholdScale{
+		domain:       sortedSample(thresholds),
+		colors:       colors,
+		unknown:      units.Px(0),
+		unknownColor: color.RGB(0.5, 0.5, 0.5),
+	}
+}
+
+// Apply maps a domain value to a range value
+func (s *ThresholdScale) Apply(value interface{}) units.Length {
+	i := s.bucket(value)
+	if i < 0 || i >= len(s.range_) {
+		return s.unknown
+	}
+	return s.range_[i]
+}
+
+// ApplyColor maps a domain value to a color
+func (s *ThresholdScale) ApplyColor(value interface{}) color.Color {
+	i := s.bucket(value)
+	if i < 0 || i >= len(s.colors) {
+		return s.unknownColor
+	}
+	return s.colors[i]
+}
+
+// ApplyValue maps a domain value to its bucket position normalized to [0, 1]
+func (s *ThresholdScale) ApplyValue(value interface{}) float64 {
+	return normalizeBucket(s.bucket(value), len(s.domain)+1)
+}
+
+// Interpolator returns a stepped color function over [0, 1].
+// Each bucket occupies an equal share of [0, 1], which suits legends.
+func (s *ThresholdScale) Interpolator() func(t float64) color.Color {
+	return func(t float64) color.Color {
+		if len(s.colors) == 0 {
+			return s.unknownColor
+		}
+		n := len(s.domain) + 1
+		i := int(clampFloat(t, 0, 1) * float64(n))
+		if i >= n {
+			i = n - 1
+		}
+		if i >= len(s.colors) {
+			return s.unknownColor
+		}
+		return s.colors[i]
+	}
+}
+
+// Domain returns the threshold values
+func (s *ThresholdScale) Domain() interface{} {
+	return s.domain
+}
+
+// Range returns the first and last range values
+func (s *ThresholdScale) Range() [2]units.Length {
+	return discreteRangeExtent(s.range_)
+}
+
+// RangeValues returns all range values
+func (s *ThresholdScale) RangeValues() []units.Length {
+	return s.range_
+}
+
+// Colors returns all colors in the scale
+func (s *ThresholdScale) Colors() []color.Color {
+	return s.colors
+}
+
+// Type returns the scale type
+func (s *ThresholdScale) Type() ScaleType {
+	return ScaleTypeThreshold
+}
+
+// Clone creates a copy of this scale
+func (s *ThresholdScale) Clone() Scale {
+	clone := &ThresholdScale{
+		domain:       make([]float64, len(s.domain)),
+		range_:       make([]units.Length, len(s.range_)),
+		colors:       make([]color.Color, len(s.colors)),
+		unknown:      s.unknown,
+		unknownColor: s.unknownColor,
+	}
+	copy(clone.domain, s.domain)
+	copy(clone.range_, s.range_)
+	copy(clone.colors, s.colors)
+	return clone
+}
+
+// InvertExtent returns the domain extent [x0, x1) that maps to the given range value.
+// The first and last buckets are unbounded and use -Inf and +Inf.
+// Returns false if the value is not in the range.
+func (s *ThresholdScale) InvertExtent(value units.Length) ([2]float64, bool) {
+	return s.extent(indexOfLength(s.range_, value))
+}
+
+// InvertExtentColor returns the domain extent [x0, x1) that maps to the given color.
+// Returns false if the color is not in the range.
+func (s *ThresholdScale) InvertExtentColor(c color.Color) ([2]float64, bool) {
+	return s.extent(indexOfColor(s.colors, c))
+}
+
+// Unknown sets the return value for unmappable inputs (e.g. NaN)
+func (s *ThresholdScale) Unknown(value units.Length) *ThresholdScale {
+	s.unknown = value
+	return s
+}
+
+// UnknownColor sets the color for unmappable inputs (e.g. NaN)
+func (s *ThresholdScale) UnknownColor(c color.Color) *ThresholdScale {
+	s.unknownColor = c
+	return s
+}
+
+// WithDomain sets new threshold values
+func (s *ThresholdScale) WithDomain(thresholds []float64) *ThresholdScale {
+	s.domain = sortedSample(thresholds)
+	return s
+}
+
+// WithRange sets a new length range
+func (s *ThresholdScale) WithRange(range_ []units.Length) *ThresholdScale {
+	s.range_ = range_
+	return s
+}
+
+// WithColors sets a new color range
+func (s *ThresholdScale) WithColors(colors []color.Color) *ThresholdScale {
+	s.colors = colors
+	return s
+}
+
+// bucket returns the bucket index for a domain value, or -1 if unmappable
+func (s *ThresholdScale) bucket(value interface{}) int {
+	v, ok := toFloat(value)
+	if !ok || math.IsNaN(v) {
+		return -1
+	}
+	return bisectRight(s.domain, v)
+}
+
+// extent returns the domain extent for bucket i
+func (s *ThresholdScale) extent(i int) ([2]float64, bool) {
+	if i < 0 || i > len(s.domain) {
+		return [2]float64{math.NaN(), math.NaN()}, false
+	}
+
+	x0, x1 := math.Inf(-1), math.Inf(1)
+	if i > 0 {
+		x0 = s.domain[i-1]
+	}
+	if i < len(s.domain) {
+		x1 = s.domain[i]
+	}
+	return [2]float64{x0, x1}, true
+}
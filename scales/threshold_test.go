@@ -0,0 +1,105 @@
+package scales
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/units"
+)
+
+func TestThresholdScale_Apply(t *testing.T) {
+	scale := NewThresholdScale(
+		[]float64{0, 1},
+		[]units.Length{units.Px(10), units.Px(20), units.Px(30)},
+	)
+
+	tests := []struct {
+		input    interface{}
+		expected float64
+	}{
+		{-1.0, 10},
+		{0.0, 20}, // Threshold belongs to upper bucket
+		{0.5, 20},
+		{1, 30},   // int input
+		{1000.0, 30},
+	}
+
+	for _, tt := range tests {
+		result := scale.Apply(tt.input)
+		if result.Value != tt.expected {
+			t.Errorf("Apply(%v) = %v, expected %v", tt.input, result.Value, tt.expected)
+		}
+	}
+}
+
+func TestThresholdScale_InvertExtent(t *testing.T) {
+	scale := NewThresholdScale(
+		[]float64{0, 1},
+		[]units.Length{units.Px(10), units.Px(20), units.Px(30)},
+	)
+
+	extent, ok := scale.InvertExtent(units.Px(10))
+	if !ok || !math.IsInf(extent[0], -1) || extent[1] != 0 {
+		t.Errorf("InvertExtent(10px) = %v, %v, expected [-Inf 0], true", extent, ok)
+	}
+
+	extent, ok = scale.InvertExtent(units.Px(20))
+	if !ok || extent[0] != 0 || extent[1] != 1 {
+		t.Errorf("InvertExtent(20px) = %v, %v, expected [0 1], true", extent, ok)
+	}
+
+	extent, ok = scale.InvertExtent(units.Px(30))
+	if !ok || extent[0] != 1 || !math.IsInf(extent[1], 1) {
+		t.Errorf("InvertExtent(30px) = %v, %v, expected [1 +Inf], true", extent, ok)
+	}
+
+	if _, ok := scale.InvertExtent(units.Em(10)); ok {
+		t.Error("InvertExtent with mismatched unit should fail")
+	}
+}
+
+func TestThresholdScale_Colors(t *testing.T) {
+	red := color.RGB(1, 0, 0)
+	yellow := color.RGB(1, 1, 0)
+	green := color.RGB(0, 1, 0)
+
+	scale := NewThresholdColorScale([]float64{50, 0}, []color.Color{red, yellow, green})
+
+	if !colorsClose(scale.ApplyColor(-10.0), red, 0.001) {
+		t.Error("ApplyColor(-10) should be red")
+	}
+	if !colorsClose(scale.ApplyColor(20.0), yellow, 0.001) {
+		t.Error("ApplyColor(20) should be yellow")
+	}
+	if !colorsClose(scale.ApplyColor(50.0), green, 0.001) {
+		t.Error("ApplyColor(50) should be green")
+	}
+
+	interp := scale.Interpolator()
+	if !colorsClose(interp(0.5), yellow, 0.001) {
+		t.Error("Interpolator(0.5) should be the middle bucket")
+	}
+
+	extent, ok := scale.InvertExtentColor(yellow)
+	if !ok || extent[0] != 0 || extent[1] != 50 {
+		t.Errorf("InvertExtentColor(yellow) = %v, %v, expected [0 50], true", extent, ok)
+	}
+}
+
+func TestThresholdScale_Clone(t *testing.T) {
+	scale := NewThresholdScale(
+		[]float64{5},
+		[]units.Length{units.Px(0), units.Px(1)},
+	)
+
+	clone := scale.Clone().(*ThresholdScale)
+	clone.domain[0] = 100
+
+	if scale.domain[0] != 5 {
+		t.Error("Modifying clone should not affect original")
+	}
+	if clone.Type() != ScaleTypeThreshold {
+		t.Errorf("Type() = %v, expected ScaleTypeThreshold", clone.Type())
+	}
+}
@@ -2,6 +2,7 @@ package scales
 
 import (
 	"math"
+	"sort"
 	"time"
 
 	"github.com/SCKelemen/units"
@@ -12,6 +13,10 @@ import (
 //
 // Ranges use units.Length to support relative units (%, px, em, etc.).
 //
+// Ticks are calendar-aware: they land on second, minute, hour, day, week,
+// month, quarter or year boundaries in the scale's time zone, and the week
+// start and fiscal year start are configurable.
+//
 // Example:
 //   start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 //   end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
@@ -20,18 +25,31 @@ import (
 //     [2]units.Length{units.Px(0), units.Px(500)},
 //   )
 //   scale.Apply(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) // Mid-year position
+//
+//   ny, _ := time.LoadLocation("America/New_York")
+//   scale.In(ny).WeekStart(time.Monday).Locale(&GermanTimeLocale)
+//   format := scale.TickFormat()
+//   for _, tick := range scale.Ticks(10) {
+//     fmt.Println(format(tick)) // "2024", "Feb", "Mär", ...
+//   }
 type TimeScale struct {
-	domain [2]time.Time
-	range_ [2]units.Length
-	clamp  bool
+	domain      [2]time.Time
+	range_      [2]units.Length
+	clamp       bool
+	location    *time.Location
+	weekStart   time.Weekday
+	fiscalStart time.Month
+	locale      *TimeLocale
 }
 
 // NewTimeScale creates a new time scale
 func NewTimeScale(domain [2]time.Time, range_ [2]units.Length) *TimeScale {
 	return &TimeScale{
-		domain: domain,
-		range_: range_,
-		clamp:  false,
+		domain:      domain,
+		range_:      range_,
+		clamp:       false,
+		weekStart:   time.Sunday,
+		fiscalStart: time.January,
 	}
 }
 
@@ -121,9 +139,13 @@ func (s *TimeScale) Type() ScaleType {
 // Clone creates a copy of this scale
 func (s *TimeScale) Clone() Scale {
 	return &TimeScale{
-		domain: s.domain,
-		range_: s.range_,
-		clamp:  s.clamp,
+		domain:      s.domain,
+		range_:      s.range_,
+		clamp:       s.clamp,
+		location:    s.location,
+		weekStart:   s.weekStart,
+		fiscalStart: s.fiscalStart,
+		locale:      s.locale,
 	}
 }
 
@@ -133,6 +155,57 @@ func (s *TimeScale) Clamp(enabled bool) *TimeScale {
 	return s
 }
 
+// In sets the time zone used to place ticks on calendar boundaries and to
+// format tick labels. A nil location uses the location of the domain start.
+func (s *TimeScale) In(loc *time.Location) *TimeScale {
+	s.location = loc
+	return s
+}
+
+// Location returns the time zone used for ticks and tick labels
+func (s *TimeScale) Location() *time.Location {
+	if s.location != nil {
+		return s.location
+	}
+	return s.domain[0].Location()
+}
+
+// WeekStart sets the first day of the week for weekly ticks (default: Sunday)
+func (s *TimeScale) WeekStart(day time.Weekday) *TimeScale {
+	if day < time.Sunday || day > time.Saturday {
+		day = time.Sunday
+	}
+	s.weekStart = day
+	return s
+}
+
+// FiscalYearStart sets the month a fiscal year begins in (default: January).
+// Yearly and quarterly ticks are aligned to fiscal year boundaries.
+func (s *TimeScale) FiscalYearStart(month time.Month) *TimeScale {
+	if month < time.January || month > time.December {
+		month = time.January
+	}
+	s.fiscalStart = month
+	return s
+}
+
+// FiscalYear returns the fiscal year t belongs to. Fiscal years are named
+// by the calendar year they end in, so with an October start, October 2024
+// falls in fiscal year 2025.
+func (s *TimeScale) FiscalYear(t time.Time) int {
+	t = t.In(s.Location())
+	if s.fiscalStart > time.January && t.Month() >= s.fiscalStart {
+		return t.Year() + 1
+	}
+	return t.Year()
+}
+
+// Locale sets the month and day names used by TickFormat (default: English)
+func (s *TimeScale) Locale(locale *TimeLocale) *TimeScale {
+	s.locale = locale
+	return s
+}
+
 // Nice rounds the domain to nice time boundaries
 func (s *TimeScale) Nice(interval TimeInterval) *TimeScale {
 	t0 := s.domain[0]
@@ -162,6 +235,15 @@ func (s *TimeScale) Nice(interval TimeInterval) *TimeScale {
 	case TimeIntervalSecond:
 		t0 = time.Date(t0.Year(), t0.Month(), t0.Day(), t0.Hour(), t0.Minute(), t0.Second(), 0, t0.Location())
 		t1 = time.Date(t1.Year(), t1.Month(), t1.Day(), t1.Hour(), t1.Minute(), t1.Second()+1, 0, t1.Location())
+
+	case TimeIntervalWeek, TimeIntervalQuarter:
+		loc := s.Location()
+		t0 = s.floorTime(t0.In(loc), interval)
+		end := s.floorTime(t1.In(loc), interval)
+		if end.Before(t1) {
+			end = s.offsetTime(end, interval, 1)
+		}
+		t1 = end
 	}
 
 	s.domain[0] = t0
@@ -170,157 +252,276 @@ func (s *TimeScale) Nice(interval TimeInterval) *TimeScale {
 	return s
 }
 
-// Ticks generates nice tick values for axes
-func (s *TimeScale) Ticks(count int) []time.Time {
-	if count <= 0 {
-		count = 10
-	}
-
-	t0 := s.domain[0]
-	t1 := s.domain[1]
-
-	// Calculate duration between domain endpoints
-	duration := t1.Sub(t0)
-
-	// Determine appropriate interval based on duration and desired count
-	interval := s.selectInterval(duration, count)
-
-	var ticks []time.Time
+// timeTickInterval is a candidate tick spacing with its approximate duration
+type timeTickInterval struct {
+	interval TimeInterval
+	step     int
+	duration time.Duration
+}
 
-	switch interval {
-	case TimeIntervalYear:
-		ticks = s.ticksYear(t0, t1, count)
-	case TimeIntervalMonth:
-		ticks = s.ticksMonth(t0, t1, count)
-	case TimeIntervalDay:
-		ticks = s.ticksDay(t0, t1, count)
-	case TimeIntervalHour:
-		ticks = s.ticksHour(t0, t1, count)
-	case TimeIntervalMinute:
-		ticks = s.ticksMinute(t0, t1, count)
-	case TimeIntervalSecond:
-		ticks = s.ticksSecond(t0, t1, count)
-	default:
-		ticks = s.ticksSecond(t0, t1, count)
-	}
+const (
+	approxDay     = 24 * time.Hour
+	approxWeek    = 7 * approxDay
+	approxMonth   = 30 * approxDay
+	approxQuarter = 3 * approxMonth
+	approxYear    = 365 * approxDay
+)
 
-	return ticks
+// timeTickIntervals lists tick spacings from finest to coarsest
+var timeTickIntervals = []timeTickInterval{
+	{TimeIntervalSecond, 1, time.Second},
+	{TimeIntervalSecond, 5, 5 * time.Second},
+	{TimeIntervalSecond, 15, 15 * time.Second},
+	{TimeIntervalSecond, 30, 30 * time.Second},
+	{TimeIntervalMinute, 1, time.Minute},
+	{TimeIntervalMinute, 5, 5 * time.Minute},
+	{TimeIntervalMinute, 15, 15 * time.Minute},
+	{TimeIntervalMinute, 30, 30 * time.Minute},
+	{TimeIntervalHour, 1, time.Hour},
+	{TimeIntervalHour, 3, 3 * time.Hour},
+	{TimeIntervalHour, 6, 6 * time.Hour},
+	{TimeIntervalHour, 12, 12 * time.Hour},
+	{TimeIntervalDay, 1, approxDay},
+	{TimeIntervalDay, 2, 2 * approxDay},
+	{TimeIntervalWeek, 1, approxWeek},
+	{TimeIntervalMonth, 1, approxMonth},
+	{TimeIntervalQuarter, 1, approxQuarter},
+	{TimeIntervalYear, 1, approxYear},
 }
 
-// selectInterval chooses appropriate interval based on duration
-func (s *TimeScale) selectInterval(duration time.Duration, count int) TimeInterval {
-	seconds := duration.Seconds()
-	targetStep := seconds / float64(count)
-
-	if targetStep >= 365*24*3600 {
-		return TimeIntervalYear
-	}
-	if targetStep >= 30*24*3600 {
-		return TimeIntervalMonth
+// TickInterval returns the calendar interval and step Ticks uses for the
+// given tick count, e.g. (TimeIntervalHour, 6) for ticks every six hours.
+func (s *TimeScale) TickInterval(count int) (TimeInterval, int) {
+	if count <= 0 {
+		count = 10
 	}
-	if targetStep >= 24*3600 {
-		return TimeIntervalDay
+
+	duration := s.domain[1].Sub(s.domain[0])
+	if duration < 0 {
+		duration = -duration
 	}
-	if targetStep >= 3600 {
-		return TimeIntervalHour
+	target := float64(duration) / float64(count)
+
+	i := sort.Search(len(timeTickIntervals), func(i int) bool {
+		return float64(timeTickIntervals[i].duration) > target
+	})
+
+	switch {
+	case i == len(timeTickIntervals):
+		// Coarser than a year: step through years in nice multiples
+		step := niceNumber(target/float64(approxYear), true)
+		return TimeIntervalYear, int(math.Max(1, step))
+
+	case i == 0:
+		// Finer than a second: step through milliseconds in nice multiples
+		if target <= 0 {
+			return TimeIntervalMillisecond, 1
+		}
+		step := niceNumber(target/float64(time.Millisecond), true)
+		return TimeIntervalMillisecond, int(math.Max(1, math.Min(step, 500)))
 	}
-	if targetStep >= 60 {
-		return TimeIntervalMinute
+
+	// Pick whichever neighbouring interval is closer in ratio
+	lower, upper := timeTickIntervals[i-1], timeTickIntervals[i]
+	if target/float64(lower.duration) < float64(upper.duration)/target {
+		return lower.interval, lower.step
 	}
-	return TimeIntervalSecond
+	return upper.interval, upper.step
 }
 
-// ticksYear generates yearly ticks
-func (s *TimeScale) ticksYear(t0, t1 time.Time, count int) []time.Time {
-	yearStart := t0.Year()
-	yearEnd := t1.Year()
-	years := yearEnd - yearStart + 1
-
-	step := int(math.Max(1, math.Round(float64(years)/float64(count))))
+// Ticks generates nice tick values for axes.
+// The interval is chosen from a ladder of calendar spacings
+// (1, 5, 15, 30 seconds; 1, 5, 15, 30 minutes; 1, 3, 6, 12 hours;
+// 1, 2 days; weeks; months; quarters; years) closest to the
+// requested count.
+func (s *TimeScale) Ticks(count int) []time.Time {
+	interval, step := s.TickInterval(count)
+	return s.TicksInterval(interval, step)
+}
 
-	var ticks []time.Time
-	for year := yearStart; year <= yearEnd; year += step {
-		ticks = append(ticks, time.Date(year, 1, 1, 0, 0, 0, 0, t0.Location()))
+// TicksInterval generates ticks at every step-th boundary of the given
+// interval that falls within the domain. Steps are aligned to the parent
+// boundary (e.g. 6-hour ticks land on 00, 06, 12 and 18 o'clock), and
+// quarters and years follow the fiscal year start.
+func (s *TimeScale) TicksInterval(interval TimeInterval, step int) []time.Time {
+	if step < 1 {
+		step = 1
 	}
 
-	return ticks
-}
+	loc := s.Location()
+	t0 := s.domain[0].In(loc)
+	t1 := s.domain[1].In(loc)
 
-// ticksMonth generates monthly ticks
-func (s *TimeScale) ticksMonth(t0, t1 time.Time, count int) []time.Time {
-	var ticks []time.Time
-	current := time.Date(t0.Year(), t0.Month(), 1, 0, 0, 0, 0, t0.Location())
-
-	for current.Before(t1) || current.Equal(t1) {
-		ticks = append(ticks, current)
-		current = current.AddDate(0, 1, 0)
+	reversed := t1.Before(t0)
+	if reversed {
+		t0, t1 = t1, t0
 	}
 
-	return ticks
-}
+	current := s.floorTime(t0, interval)
+	if current.Before(t0) {
+		current = s.offsetTime(current, interval, 1)
+	}
 
-// ticksDay generates daily ticks
-func (s *TimeScale) ticksDay(t0, t1 time.Time, count int) []time.Time {
 	var ticks []time.Time
-	current := time.Date(t0.Year(), t0.Month(), t0.Day(), 0, 0, 0, 0, t0.Location())
-
-	days := int(t1.Sub(t0).Hours() / 24)
-	step := int(math.Max(1, math.Round(float64(days)/float64(count))))
+	for !current.After(t1) {
+		if s.alignedTime(current, interval, step) {
+			ticks = append(ticks, current)
+		}
+		next := s.offsetTime(current, interval, 1)
+		if !next.After(current) {
+			break
+		}
+		current = next
+	}
 
-	for current.Before(t1) || current.Equal(t1) {
-		ticks = append(ticks, current)
-		current = current.AddDate(0, 0, step)
+	if reversed {
+		for i, j := 0, len(ticks)-1; i < j; i, j = i+1, j-1 {
+			ticks[i], ticks[j] = ticks[j], ticks[i]
+		}
 	}
 
 	return ticks
 }
 
-// ticksHour generates hourly ticks
-func (s *TimeScale) ticksHour(t0, t1 time.Time, count int) []time.Time {
-	var ticks []time.Time
-	current := time.Date(t0.Year(), t0.Month(), t0.Day(), t0.Hour(), 0, 0, 0, t0.Location())
-
-	hours := int(t1.Sub(t0).Hours())
-	step := int(math.Max(1, math.Round(float64(hours)/float64(count))))
+// floorTime rounds t down to the start of its interval
+func (s *TimeScale) floorTime(t time.Time, interval TimeInterval) time.Time {
+	loc := t.Location()
+	y, m, d := t.Date()
 
-	for current.Before(t1) || current.Equal(t1) {
-		ticks = append(ticks, current)
-		current = current.Add(time.Duration(step) * time.Hour)
+	switch interval {
+	case TimeIntervalMillisecond:
+		ns := t.Nanosecond() - t.Nanosecond()%int(time.Millisecond)
+		return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), ns, loc)
+	case TimeIntervalSecond:
+		return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, loc)
+	case TimeIntervalMinute:
+		return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, loc)
+	case TimeIntervalHour:
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, loc)
+	case TimeIntervalDay:
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	case TimeIntervalWeek:
+		back := (int(t.Weekday()) - int(s.weekStart) + 7) % 7
+		return time.Date(y, m, d-back, 0, 0, 0, 0, loc)
+	case TimeIntervalMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	case TimeIntervalQuarter:
+		return time.Date(y, m-time.Month(s.fiscalMonth(t)%3), 1, 0, 0, 0, 0, loc)
+	case TimeIntervalYear:
+		return time.Date(y, m-time.Month(s.fiscalMonth(t)), 1, 0, 0, 0, 0, loc)
+	default:
+		return t
 	}
-
-	return ticks
 }
 
-// ticksMinute generates minute ticks
-func (s *TimeScale) ticksMinute(t0, t1 time.Time, count int) []time.Time {
-	var ticks []time.Time
-	current := time.Date(t0.Year(), t0.Month(), t0.Day(), t0.Hour(), t0.Minute(), 0, 0, t0.Location())
+// offsetTime moves t forward by n intervals. Sub-day intervals move in
+// absolute time; calendar intervals move in wall-clock time so they stay
+// on midnight across daylight saving changes.
+func (s *TimeScale) offsetTime(t time.Time, interval TimeInterval, n int) time.Time {
+	switch interval {
+	case TimeIntervalMillisecond:
+		return t.Add(time.Duration(n) * time.Millisecond)
+	case TimeIntervalSecond:
+		return t.Add(time.Duration(n) * time.Second)
+	case TimeIntervalMinute:
+		return t.Add(time.Duration(n) * time.Minute)
+	case TimeIntervalHour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case TimeIntervalDay:
+		return t.AddDate(0, 0, n)
+	case TimeIntervalWeek:
+		return t.AddDate(0, 0, 7*n)
+	case TimeIntervalMonth:
+		return t.AddDate(0, n, 0)
+	case TimeIntervalQuarter:
+		return t.AddDate(0, 3*n, 0)
+	case TimeIntervalYear:
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.Add(time.Duration(n) * time.Second)
+	}
+}
 
-	minutes := int(t1.Sub(t0).Minutes())
-	step := int(math.Max(1, math.Round(float64(minutes)/float64(count))))
+// alignedTime reports whether the interval boundary t is a multiple of step
+// within its parent interval
+func (s *TimeScale) alignedTime(t time.Time, interval TimeInterval, step int) bool {
+	if step <= 1 {
+		return true
+	}
 
-	for current.Before(t1) || current.Equal(t1) {
-		ticks = append(ticks, current)
-		current = current.Add(time.Duration(step) * time.Minute)
+	var index int
+	switch interval {
+	case TimeIntervalMillisecond:
+		index = t.Nanosecond() / int(time.Millisecond)
+	case TimeIntervalSecond:
+		index = t.Second()
+	case TimeIntervalMinute:
+		index = t.Minute()
+	case TimeIntervalHour:
+		index = t.Hour()
+	case TimeIntervalDay:
+		index = t.Day() - 1
+	case TimeIntervalWeek:
+		// Weeks have no parent interval, so count them from a fixed epoch
+		days := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+		index = int(math.Floor(float64(days) / 7))
+	case TimeIntervalMonth:
+		index = s.fiscalMonth(t)
+	case TimeIntervalQuarter:
+		index = s.fiscalMonth(t) / 3
+	case TimeIntervalYear:
+		index = s.FiscalYear(t)
 	}
 
-	return ticks
+	return index%step == 0
 }
 
-// ticksSecond generates second ticks
-func (s *TimeScale) ticksSecond(t0, t1 time.Time, count int) []time.Time {
-	var ticks []time.Time
-	current := time.Date(t0.Year(), t0.Month(), t0.Day(), t0.Hour(), t0.Minute(), t0.Second(), 0, t0.Location())
+// fiscalMonth returns the zero-based month of t within its fiscal year
+func (s *TimeScale) fiscalMonth(t time.Time) int {
+	start := s.fiscalStart
+	if start == 0 {
+		start = time.January
+	}
+	return (int(t.Month()) - int(start) + 12) % 12
+}
 
-	seconds := int(t1.Sub(t0).Seconds())
-	step := int(math.Max(1, math.Round(float64(seconds)/float64(count))))
+// TickFormat returns a multi-scale formatter for this scale's ticks.
+// Each tick is labelled by the coarsest boundary it falls on, so a year
+// axis reads "2024", "Feb", "Mar", and a day axis reads "Mar 3", "06 PM".
+// Labels use the scale's time zone and locale.
+func (s *TimeScale) TickFormat() func(time.Time) string {
+	return s.TickFormatLayouts(DefaultTimeTickLayouts())
+}
 
-	for current.Before(t1) || current.Equal(t1) {
-		ticks = append(ticks, current)
-		current = current.Add(time.Duration(step) * time.Second)
+// TickFormatLayouts is like TickFormat with custom layouts per boundary
+func (s *TimeScale) TickFormatLayouts(layouts TimeTickLayouts) func(time.Time) string {
+	return func(t time.Time) string {
+		t = t.In(s.Location())
+		return s.locale.Format(t, s.tickLayout(t, layouts))
 	}
+}
 
-	return ticks
+// tickLayout picks the layout for the coarsest boundary t falls on
+func (s *TimeScale) tickLayout(t time.Time, layouts TimeTickLayouts) string {
+	switch {
+	case !s.floorTime(t, TimeIntervalSecond).Equal(t):
+		return layouts.Millisecond
+	case !s.floorTime(t, TimeIntervalMinute).Equal(t):
+		return layouts.Second
+	case !s.floorTime(t, TimeIntervalHour).Equal(t):
+		return layouts.Minute
+	case !s.floorTime(t, TimeIntervalDay).Equal(t):
+		return layouts.Hour
+	case !s.floorTime(t, TimeIntervalMonth).Equal(t):
+		if !s.floorTime(t, TimeIntervalWeek).Equal(t) {
+			return layouts.Day
+		}
+		return layouts.Week
+	case t.Month() != time.January || t.Day() != 1:
+		return layouts.Month
+	default:
+		return layouts.Year
+	}
 }
 
 // WithDomain sets a new domain
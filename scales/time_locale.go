@@ -0,0 +1,149 @@
+package scales
+
+import (
+	"strings"
+	"time"
+)
+
+// TimeLocale supplies month names, day names and AM/PM markers for
+// formatting time tick labels in languages other than English.
+//
+// Layouts use Go reference-time syntax; "January", "Jan", "Monday", "Mon",
+// "PM" and "pm" are replaced by the locale's names, everything else is
+// formatted by time.Format.
+//
+// Example:
+//   GermanTimeLocale.Format(t, "2 Jan 2006") // "3 Mär 2025"
+type TimeLocale struct {
+	Months      [12]string // Full month names, January first
+	ShortMonths [12]string // Abbreviated month names, January first
+	Days        [7]string  // Full day names, Sunday first
+	ShortDays   [7]string  // Abbreviated day names, Sunday first
+	AM          string     // Ante meridiem marker
+	PM          string     // Post meridiem marker
+}
+
+// Built-in locales
+var (
+	EnglishTimeLocale = TimeLocale{
+		Months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		AM:          "AM",
+		PM:          "PM",
+	}
+
+	GermanTimeLocale = TimeLocale{
+		Months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortDays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		AM:          "AM",
+		PM:          "PM",
+	}
+
+	FrenchTimeLocale = TimeLocale{
+		Months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		AM:          "AM",
+		PM:          "PM",
+	}
+
+	SpanishTimeLocale = TimeLocale{
+		Months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		Days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		AM:          "a. m.",
+		PM:          "p. m.",
+	}
+)
+
+// TimeTickLayouts holds the layout used for each kind of tick boundary
+// in a multi-scale time axis
+type TimeTickLayouts struct {
+	Millisecond string // Ticks between seconds
+	Second      string // Ticks between minutes
+	Minute      string // Ticks between hours
+	Hour        string // Ticks between days
+	Day         string // Day ticks that do not start a week
+	Week        string // Day ticks that start a week
+	Month       string // Month ticks that do not start a year
+	Year        string // Ticks on January 1st
+}
+
+// DefaultTimeTickLayouts returns layouts producing labels such as
+// ".250", ":30", "06:15", "06 PM", "Wed 5", "Mar 3", "Feb" and "2025"
+func DefaultTimeTickLayouts() TimeTickLayouts {
+	return TimeTickLayouts{
+		Millisecond: ".000",
+		Second:      ":05",
+		Minute:      "03:04",
+		Hour:        "03 PM",
+		Day:         "Mon 2",
+		Week:        "Jan 2",
+		Month:       "Jan",
+		Year:        "2006",
+	}
+}
+
+// timeLocaleTokens are the name tokens a TimeLocale substitutes, longest first
+var timeLocaleTokens = []string{"January", "Monday", "Jan", "Mon", "PM", "pm"}
+
+// Format formats t like time.Format, substituting the locale's names.
+// A nil locale formats in English.
+func (l *TimeLocale) Format(t time.Time, layout string) string {
+	if l == nil {
+		l = &EnglishTimeLocale
+	}
+
+	var b strings.Builder
+	for len(layout) > 0 {
+		pos, token := len(layout), ""
+		for _, tok := range timeLocaleTokens {
+			if i := strings.Index(layout, tok); i >= 0 && i < pos {
+				pos, token = i, tok
+			}
+		}
+
+		if pos > 0 {
+			b.WriteString(t.Format(layout[:pos]))
+		}
+		if token == "" {
+			break
+		}
+
+		b.WriteString(l.name(t, token))
+		layout = layout[pos+len(token):]
+	}
+
+	return b.String()
+}
+
+// name returns the locale's text for a name token
+func (l *TimeLocale) name(t time.Time, token string) string {
+	switch token {
+	case "January":
+		return l.Months[t.Month()-1]
+	case "Jan":
+		return l.ShortMonths[t.Month()-1]
+	case "Monday":
+		return l.Days[t.Weekday()]
+	case "Mon":
+		return l.ShortDays[t.Weekday()]
+	case "PM":
+		if t.Hour() < 12 {
+			return l.AM
+		}
+		return l.PM
+	case "pm":
+		if t.Hour() < 12 {
+			return strings.ToLower(l.AM)
+		}
+		return strings.ToLower(l.PM)
+	}
+	return token
+}
@@ -0,0 +1,48 @@
+package scales
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeLocale_Format(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 18, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		locale   *TimeLocale
+		layout   string
+		expected string
+	}{
+		{nil, "Monday, January 2 2006", "Monday, March 3 2025"},
+		{&EnglishTimeLocale, "Mon Jan 2 03:04 PM", "Mon Mar 3 06:05 PM"},
+		{&GermanTimeLocale, "Monday, 2. January 2006", "Montag, 3. März 2025"},
+		{&GermanTimeLocale, "Mon 2 Jan", "Mo 3 Mär"},
+		{&FrenchTimeLocale, "Monday 2 January", "lundi 3 mars"},
+		{&SpanishTimeLocale, "03 PM", "06 p. m."},
+		{&SpanishTimeLocale, "3pm", "6p. m."},
+		{&GermanTimeLocale, "2006-01-02T15:04", "2025-03-03T18:05"},
+	}
+
+	for _, tt := range tests {
+		result := tt.locale.Format(monday, tt.layout)
+		if result != tt.expected {
+			t.Errorf("Format(%q) = %q, expected %q", tt.layout, result, tt.expected)
+		}
+	}
+}
+
+func TestTimeLocale_Custom(t *testing.T) {
+	locale := EnglishTimeLocale
+	locale.ShortMonths[2] = "MRZ"
+	locale.AM, locale.PM = "vorm.", "nachm."
+
+	morning := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	if result := locale.Format(morning, "Jan 3 PM"); result != "MRZ 9 vorm." {
+		t.Errorf("Format = %q, expected \"MRZ 9 vorm.\"", result)
+	}
+
+	// Built-in locales are unaffected by edits to a copy
+	if EnglishTimeLocale.ShortMonths[2] != "Mar" {
+		t.Error("Editing a copied locale should not change EnglishTimeLocale")
+	}
+}
@@ -430,3 +430,284 @@ func BenchmarkTimeScale_Ticks(b *testing.B) {
 		scale.Ticks(10)
 	}
 }
+
+// formatTicks formats ticks as RFC 3339 strings for comparison
+func formatTicks(ticks []time.Time) []string {
+	result := make([]string, len(ticks))
+	for i, tick := range ticks {
+		result[i] = tick.Format(time.RFC3339)
+	}
+	return result
+}
+
+func TestTimeScale_TickInterval(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		span     time.Duration
+		count    int
+		interval TimeInterval
+		step     int
+	}{
+		{"sub-second", time.Second, 4, TimeIntervalMillisecond, 200},
+		{"seconds", time.Minute, 4, TimeIntervalSecond, 15},
+		{"minutes", time.Hour, 4, TimeIntervalMinute, 15},
+		{"hours", 48 * time.Hour, 8, TimeIntervalHour, 6},
+		{"days", 10 * 24 * time.Hour, 5, TimeIntervalDay, 2},
+		{"weeks", 31 * 24 * time.Hour, 4, TimeIntervalWeek, 1},
+		{"months", 365 * 24 * time.Hour, 12, TimeIntervalMonth, 1},
+		{"quarters", 731 * 24 * time.Hour, 8, TimeIntervalQuarter, 1},
+		{"decades", 100 * 365 * 24 * time.Hour, 10, TimeIntervalYear, 10},
+	}
+
+	for _, tt := range tests {
+		scale := NewTimeScale(
+			[2]time.Time{base, base.Add(tt.span)},
+			[2]units.Length{units.Px(0), units.Px(500)},
+		)
+
+		interval, step := scale.TickInterval(tt.count)
+		if interval != tt.interval || step != tt.step {
+			t.Errorf("%s: TickInterval(%d) = %v, %d, expected %v, %d", tt.name, tt.count, interval, step, tt.interval, tt.step)
+		}
+	}
+}
+
+func TestTimeScale_Ticks_Quarter(t *testing.T) {
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	ticks := scale.Ticks(8)
+	if len(ticks) != 9 {
+		t.Fatalf("Ticks(8) = %v, expected 9 quarterly ticks", formatTicks(ticks))
+	}
+	for _, tick := range ticks {
+		if tick.Day() != 1 || (tick.Month()-1)%3 != 0 {
+			t.Errorf("Tick %v is not a quarter boundary", tick.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestTimeScale_Ticks_FiscalYear(t *testing.T) {
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	).FiscalYearStart(time.October)
+
+	ticks := scale.TicksInterval(TimeIntervalYear, 1)
+	expected := []string{"2022-10-01T00:00:00Z", "2023-10-01T00:00:00Z", "2024-10-01T00:00:00Z"}
+
+	result := formatTicks(ticks)
+	if len(result) != len(expected) {
+		t.Fatalf("TicksInterval(Year) = %v, expected %v", result, expected)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("TicksInterval(Year)[%d] = %v, expected %v", i, result[i], expected[i])
+		}
+	}
+
+	if fy := scale.FiscalYear(ticks[2]); fy != 2025 {
+		t.Errorf("FiscalYear(%v) = %d, expected 2025", result[2], fy)
+	}
+	if fy := scale.FiscalYear(time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)); fy != 2024 {
+		t.Errorf("FiscalYear(2024-09-30) = %d, expected 2024", fy)
+	}
+
+	// Fiscal quarters follow the fiscal year start
+	scale.FiscalYearStart(time.February)
+	ticks = scale.TicksInterval(TimeIntervalQuarter, 1)
+	for _, tick := range ticks {
+		if (tick.Month()-time.February)%3 != 0 {
+			t.Errorf("Quarter tick %v should fall on Feb/May/Aug/Nov", tick.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestTimeScale_Ticks_WeekStart(t *testing.T) {
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	for _, day := range []time.Weekday{time.Sunday, time.Monday} {
+		scale.WeekStart(day)
+		ticks := scale.Ticks(4)
+
+		if len(ticks) < 4 {
+			t.Fatalf("Ticks(4) with week start %v = %v", day, formatTicks(ticks))
+		}
+		for _, tick := range ticks {
+			if tick.Weekday() != day {
+				t.Errorf("Weekly tick %v falls on %v, expected %v", tick.Format("2006-01-02"), tick.Weekday(), day)
+			}
+		}
+	}
+}
+
+func TestTimeScale_Ticks_Aligned(t *testing.T) {
+	start := time.Date(2024, 3, 1, 1, 30, 0, 0, time.UTC)
+	scale := NewTimeScale(
+		[2]time.Time{start, start.Add(48 * time.Hour)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	// 6-hour ticks land on 00, 06, 12 and 18 o'clock
+	ticks := scale.Ticks(8)
+	if len(ticks) == 0 {
+		t.Fatal("Expected non-empty ticks")
+	}
+	if !ticks[0].Equal(time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("First tick = %v, expected 06:00", ticks[0].Format(time.RFC3339))
+	}
+	for _, tick := range ticks {
+		if tick.Hour()%6 != 0 || tick.Minute() != 0 {
+			t.Errorf("Tick %v is not on a 6-hour boundary", tick.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestTimeScale_Ticks_TimeZone(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	).In(est)
+
+	ticks := scale.TicksInterval(TimeIntervalDay, 1)
+	if len(ticks) != 3 {
+		t.Fatalf("TicksInterval(Day) = %v, expected 3 ticks", formatTicks(ticks))
+	}
+	for _, tick := range ticks {
+		if tick.Hour() != 0 || tick.UTC().Hour() != 5 {
+			t.Errorf("Tick %v should be local midnight (05:00 UTC)", tick.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestTimeScale_Ticks_DaylightSaving(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2024, 3, 8, 0, 0, 0, 0, ny), time.Date(2024, 3, 12, 0, 0, 0, 0, ny)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	ticks := scale.TicksInterval(TimeIntervalDay, 1)
+	if len(ticks) != 5 {
+		t.Fatalf("TicksInterval(Day) = %v, expected 5 ticks", formatTicks(ticks))
+	}
+	for _, tick := range ticks {
+		if tick.Hour() != 0 {
+			t.Errorf("Tick %v should stay on local midnight across DST", tick.Format(time.RFC3339))
+		}
+	}
+}
+
+func TestTimeScale_Ticks_Reversed(t *testing.T) {
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	)
+
+	ticks := scale.Ticks(12)
+	if len(ticks) != 12 {
+		t.Fatalf("Ticks(12) = %v, expected 12 ticks", formatTicks(ticks))
+	}
+	for i := 1; i < len(ticks); i++ {
+		if !ticks[i].Before(ticks[i-1]) {
+			t.Errorf("Ticks for reversed domain should descend, got %v", formatTicks(ticks))
+			break
+		}
+	}
+}
+
+func TestTimeScale_Nice_Week(t *testing.T) {
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC), time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	).WeekStart(time.Monday)
+	scale.Nice(TimeIntervalWeek)
+
+	domain := scale.Domain().([2]time.Time)
+	if !domain[0].Equal(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Nice(Week) start = %v, expected 2024-03-04", domain[0].Format(time.RFC3339))
+	}
+	if !domain[1].Equal(time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Nice(Week) end = %v, expected 2024-03-25", domain[1].Format(time.RFC3339))
+	}
+}
+
+func TestTimeScale_TickFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		count    int
+		expected []string
+	}{
+		{
+			"year",
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+			6,
+			[]string{"2024", "Feb", "Mar", "Apr", "May", "Jun"},
+		},
+		{
+			"hours",
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+			8,
+			[]string{"Mar", "06 AM", "12 PM", "06 PM", "Sat 2", "06 AM", "12 PM", "06 PM", "Mar 3"},
+		},
+		{
+			"milliseconds",
+			time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 1, 12, 0, 1, 0, time.UTC),
+			4,
+			[]string{"12 PM", ".200", ".400", ".600", ".800", ":01"},
+		},
+	}
+
+	for _, tt := range tests {
+		scale := NewTimeScale(
+			[2]time.Time{tt.start, tt.end},
+			[2]units.Length{units.Px(0), units.Px(500)},
+		)
+
+		format := scale.TickFormat()
+		ticks := scale.Ticks(tt.count)
+
+		if len(ticks) != len(tt.expected) {
+			t.Errorf("%s: Ticks(%d) = %v, expected %d ticks", tt.name, tt.count, formatTicks(ticks), len(tt.expected))
+			continue
+		}
+		for i, tick := range ticks {
+			if label := format(tick); label != tt.expected[i] {
+				t.Errorf("%s: label %d = %q, expected %q", tt.name, i, label, tt.expected[i])
+			}
+		}
+	}
+}
+
+func TestTimeScale_TickFormat_Locale(t *testing.T) {
+	scale := NewTimeScale(
+		[2]time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		[2]units.Length{units.Px(0), units.Px(500)},
+	).Locale(&GermanTimeLocale)
+
+	format := scale.TickFormat()
+	expected := []string{"2024", "Feb", "Mär", "Apr"}
+
+	for i, tick := range scale.TicksInterval(TimeIntervalMonth, 1) {
+		if label := format(tick); label != expected[i] {
+			t.Errorf("label %d = %q, expected %q", i, label, expected[i])
+		}
+	}
+}
@@ -52,6 +52,7 @@ const (
 	ScaleTypeIdentity
 	ScaleTypeSequential
 	ScaleTypeDiverging
+	ScaleTypeSymlog
 )
 
 // String returns the scale type name
@@ -85,6 +86,8 @@ func (t ScaleType) String() string {
 		return "sequential"
 	case ScaleTypeDiverging:
 		return "diverging"
+	case ScaleTypeSymlog:
+		return "symlog"
 	default:
 		return "unknown"
 	}
@@ -119,6 +122,10 @@ type CategoricalScale interface {
 
 	// Index returns the index of a value in the domain
 	Index(value string) int
+
+	// InvertCategory maps a range value back to a domain value.
+	// Returns false if no category is at that position.
+	InvertCategory(value units.Length) (string, bool)
 }
 
 // ColorScale maps domain values to colors
@@ -171,6 +178,7 @@ const (
 	TimeIntervalWeek
 	TimeIntervalMonth
 	TimeIntervalYear
+	TimeIntervalQuarter
 )
 
 // BandScaleOptions configures BandScale behavior
@@ -194,6 +202,12 @@ type LogScaleOptions struct {
 	Clamp bool    // Clamp output to range
 }
 
+// SymlogScaleOptions configures SymlogScale
+type SymlogScaleOptions struct {
+	Constant float64 // Linear threshold around zero (default: 1)
+	Clamp    bool    // Clamp output to range
+}
+
 // PowScaleOptions configures PowScale
 type PowScaleOptions struct {
 	Exponent float64 // Power exponent (default: 1)
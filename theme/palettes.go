@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/SCKelemen/color"
+	"github.com/SCKelemen/dataviz/scales"
 )
 
 // DarkCategorical returns a categorical color palette for dark mode
@@ -185,89 +186,37 @@ func interpolateHex(start, end string, t float64) string {
 // ViridisColors returns colors from the Viridis palette (0-1)
 // Viridis is a perceptually uniform color map
 func ViridisColors(t float64) string {
-	// Clamp t to [0, 1]
-	if t < 0 {
-		t = 0
-	}
-	if t > 1 {
-		t = 1
-	}
-
-	// Viridis control points (approximation)
-	colors := []string{
-		"#440154", // purple
-		"#31688E", // blue
-		"#35B779", // green
-		"#FDE724", // yellow
-	}
-
-	// Find segment
-	segment := t * float64(len(colors)-1)
-	index := int(segment)
-	if index >= len(colors)-1 {
-		return colors[len(colors)-1]
-	}
-
-	// Interpolate within segment
-	t_segment := segment - float64(index)
-	return interpolateHex(colors[index], colors[index+1], t_segment)
+	return color.RGBToHex(scales.InterpolateViridis(t))
 }
 
 // PlasmaColors returns colors from the Plasma palette (0-1)
 // Plasma is another perceptually uniform color map
 func PlasmaColors(t float64) string {
-	// Clamp t to [0, 1]
-	if t < 0 {
-		t = 0
-	}
-	if t > 1 {
-		t = 1
-	}
-
-	// Plasma control points (approximation)
-	colors := []string{
-		"#0D0887", // dark purple
-		"#7E03A8", // purple
-		"#CC4778", // pink
-		"#F89540", // orange
-		"#F0F921", // yellow
-	}
-
-	// Find segment
-	segment := t * float64(len(colors)-1)
-	index := int(segment)
-	if index >= len(colors)-1 {
-		return colors[len(colors)-1]
-	}
-
-	// Interpolate within segment
-	t_segment := segment - float64(index)
-	return interpolateHex(colors[index], colors[index+1], t_segment)
+	return color.RGBToHex(scales.InterpolatePlasma(t))
 }
 
 // CoolWarmColors returns colors from a cool-warm diverging palette (-1 to 1)
 func CoolWarmColors(t float64) string {
 	// Map from [-1, 1] to [0, 1]
-	normalized := (t + 1.0) / 2.0
+	return color.RGBToHex(scales.InterpolateCoolWarm((t + 1.0) / 2.0))
+}
 
-	// Clamp to [0, 1]
-	if normalized < 0 {
-		normalized = 0
-	}
-	if normalized > 1 {
-		normalized = 1
+// InterpolatorPalette returns a palette of evenly spaced hex colors sampled
+// from a named interpolator (e.g. "viridis", "magma", "RdBu").
+// See scales.InterpolatorNames for the available names.
+// Returns nil if the name is not registered.
+func InterpolatorPalette(name string, steps int) []string {
+	interpolator, ok := scales.NamedInterpolator(name)
+	if !ok {
+		return nil
 	}
 
-	// Cool (blue) to neutral (white) to warm (red)
-	if normalized < 0.5 {
-		// Blue to white
-		t_segment := normalized * 2.0
-		return interpolateHex("#3B82F6", "#F9FAFB", t_segment)
-	} else {
-		// White to red
-		t_segment := (normalized - 0.5) * 2.0
-		return interpolateHex("#F9FAFB", "#EF4444", t_segment)
+	samples := scales.SampleInterpolator(interpolator, steps)
+	palette := make([]string, len(samples))
+	for i, c := range samples {
+		palette[i] = color.RGBToHex(c)
 	}
+	return palette
 }
 
 // QualitativeColors returns high-contrast colors for categorical data
@@ -350,3 +350,25 @@ func TestInterpolateHex(t *testing.T) {
 		t.Error("Expected interpolated middle color to differ from start and end")
 	}
 }
+
+func TestInterpolatorPalette(t *testing.T) {
+	palette := InterpolatorPalette("viridis", 5)
+	if len(palette) != 5 {
+		t.Fatalf("Expected 5 colors, got %d", len(palette))
+	}
+
+	for i, c := range palette {
+		if !strings.HasPrefix(c, "#") {
+			t.Errorf("Expected hex color at %d, got %s", i, c)
+		}
+	}
+
+	// Matches ViridisColors at the endpoints
+	if palette[0] != ViridisColors(0) || palette[4] != ViridisColors(1) {
+		t.Errorf("Expected palette endpoints to match ViridisColors, got %v", palette)
+	}
+
+	if InterpolatorPalette("not-a-palette", 5) != nil {
+		t.Error("Expected nil for unknown interpolator")
+	}
+}
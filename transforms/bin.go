@@ -23,11 +23,8 @@ func Bin(opts BinOptions) Transform {
 			opts.Count = 10
 		}
 
-		// Extract values and find domain
-		values := make([]float64, len(data))
-		for i, d := range data {
-			values[i] = d.Y
-		}
+		// Extract values, skipping missing ones, and find domain
+		values := validValues(data)
 
 		domain := opts.Domain
 		if domain[0] == 0 && domain[1] == 0 {
@@ -150,10 +147,10 @@ func BinCount(binSize float64) Transform {
 			return nil
 		}
 
-		// Find min/max
-		values := make([]float64, len(data))
-		for i, d := range data {
-			values[i] = d.Y
+		// Find min/max, skipping missing values
+		values := validValues(data)
+		if len(values) == 0 {
+			return nil
 		}
 		min, max := Min(values), Max(values)
 
@@ -166,6 +163,9 @@ func BinCount(binSize float64) Transform {
 		// Create bins
 		bins := make(map[int]*DataPoint)
 		for _, d := range data {
+			if d.IsMissing() {
+				continue
+			}
 			binIndex := int(math.Floor((d.Y - min) / binSize))
 			if binIndex >= numBins {
 				binIndex = numBins - 1
@@ -0,0 +1,195 @@
+package transforms
+
+import "math"
+
+// Shape-preserving downsampling. Unlike Downsample, which keeps every nth
+// point and can drop spikes entirely, these transforms choose the points
+// that carry the shape of the series. Points are spaced by X when every
+// point has a numeric or time X, and by position otherwise. A bucket that
+// contains missing points keeps its first one so gaps survive.
+
+// LTTB downsamples to about threshold points with the
+// Largest-Triangle-Three-Buckets algorithm. The first and last points are
+// kept, and each bucket in between keeps the point forming the largest
+// triangle with the point kept before it and the average of the next bucket.
+// Buckets with missing points keep one more point each. Data with no more
+// than threshold points, or a threshold under 3, is returned unchanged.
+//
+// Example:
+//
+//	sampled := LTTB(500)(latencies)
+func LTTB(threshold int) Transform {
+	return func(data []DataPoint) []DataPoint {
+		n := len(data)
+		if threshold < 3 || n <= threshold {
+			return data
+		}
+
+		xs := fillPositions(data)
+		result := make([]DataPoint, 0, threshold)
+		result = append(result, data[0])
+
+		// The last kept point with a value, or -1 before there is one
+		anchor := 0
+		if data[0].IsMissing() {
+			anchor = -1
+		}
+
+		size := float64(n-2) / float64(threshold-2)
+		bucket := func(b int) int {
+			return min(int(float64(b)*size)+1, n-1)
+		}
+		for b := 0; b < threshold-2; b++ {
+			start, end := bucket(b), bucket(b+1)
+
+			// Average of the next bucket, which is the last point for the
+			// final bucket
+			next := min(bucket(b+2), n)
+			if b == threshold-3 {
+				next = n
+			}
+			cx, cy, count := 0.0, 0.0, 0
+			for i := end; i < next; i++ {
+				if !data[i].IsMissing() {
+					cx += xs[i]
+					cy += data[i].Y
+					count++
+				}
+			}
+			cx /= float64(count)
+			cy /= float64(count)
+
+			best, gap := -1, -1
+			bestArea := -1.0
+			for i := start; i < end; i++ {
+				if data[i].IsMissing() {
+					if gap < 0 {
+						gap = i
+					}
+					continue
+				}
+				area := 0.0
+				if anchor >= 0 && count > 0 {
+					ax, ay := xs[anchor], data[anchor].Y
+					area = math.Abs((ax-cx)*(data[i].Y-ay) - (ax-xs[i])*(cy-ay))
+				}
+				if area > bestArea {
+					best, bestArea = i, area
+				}
+			}
+
+			if gap >= 0 && (best < 0 || gap < best) {
+				result = append(result, data[gap])
+			}
+			if best >= 0 {
+				result = append(result, data[best])
+				anchor = best
+			}
+			if gap > best && best >= 0 {
+				result = append(result, data[gap])
+			}
+		}
+
+		return append(result, data[n-1])
+	}
+}
+
+// M4 downsamples for drawing into the given number of pixel columns. The
+// X range is split into that many equal columns, and each column keeps its
+// first, last, smallest and largest points, which draws the same line as
+// the full data at that width. Data with no more than four points per
+// column is returned unchanged.
+//
+// Example:
+//
+//	sampled := M4(800)(latencies) // At most 3200 points
+func M4(columns int) Transform {
+	return func(data []DataPoint) []DataPoint {
+		n := len(data)
+		if columns <= 0 || n <= 4*columns {
+			return data
+		}
+
+		xs := fillPositions(data)
+		lo, span := xs[0], xs[n-1]-xs[0]
+		column := func(i int) int {
+			return min(int(float64(columns)*(xs[i]-lo)/span), columns-1)
+		}
+
+		keep := make([]bool, n)
+		for start := 0; start < n; {
+			end := start + 1
+			for end < n && column(end) == column(start) {
+				end++
+			}
+			keep[start] = true
+			keep[end-1] = true
+			markExtremes(data, start, end, keep)
+			start = end
+		}
+
+		return kept(data, keep)
+	}
+}
+
+// MinMax downsamples by splitting the points into buckets of equal count
+// and keeping the smallest and largest point of each, along with the first
+// and last points. Data with no more than two points per bucket is
+// returned unchanged.
+//
+// Example:
+//
+//	sampled := MinMax(250)(latencies) // At most 502 points
+func MinMax(buckets int) Transform {
+	return func(data []DataPoint) []DataPoint {
+		n := len(data)
+		if buckets <= 0 || n <= 2*buckets {
+			return data
+		}
+
+		keep := make([]bool, n)
+		keep[0] = true
+		keep[n-1] = true
+		for b := 0; b < buckets; b++ {
+			markExtremes(data, b*n/buckets, (b+1)*n/buckets, keep)
+		}
+
+		return kept(data, keep)
+	}
+}
+
+// markExtremes marks the smallest and largest points of data[start:end],
+// and the first missing point so the gap survives
+func markExtremes(data []DataPoint, start, end int, keep []bool) {
+	lo, hi, gap := -1, -1, -1
+	for i := start; i < end; i++ {
+		if data[i].IsMissing() {
+			if gap < 0 {
+				gap = i
+			}
+			continue
+		}
+		if lo < 0 || data[i].Y < data[lo].Y {
+			lo = i
+		}
+		if hi < 0 || data[i].Y > data[hi].Y {
+			hi = i
+		}
+	}
+	for _, i := range []int{lo, hi, gap} {
+		if i >= 0 {
+			keep[i] = true
+		}
+	}
+}
+
+// kept returns the marked points in their original order
+func kept(data []DataPoint, keep []bool) []DataPoint {
+	var result []DataPoint
+	for i, d := range data {
+		if keep[i] {
+			result = append(result, d)
+		}
+	}
+	return result
+}
@@ -53,7 +53,12 @@ func GroupBy(opts GroupOptions) Transform {
 				key = "default"
 			}
 
-			groups[key] = append(groups[key], d.Y)
+			if _, exists := groups[key]; !exists {
+				groups[key] = nil
+			}
+			if !d.IsMissing() {
+				groups[key] = append(groups[key], d.Y)
+			}
 			if _, exists := groupData[key]; !exists {
 				groupData[key] = DataPoint{
 					Label: key,
@@ -64,11 +69,11 @@ func GroupBy(opts GroupOptions) Transform {
 			}
 		}
 
-		// Aggregate each group
+		// Aggregate each group's values; groups without any are missing
 		result := make([]DataPoint, 0, len(groups))
 		for key, values := range groups {
 			point := groupData[key]
-			point.Y = opts.Aggregate(values)
+			point.Y = aggregate(opts.Aggregate, values)
 			point.Value = point.Y
 			point.Count = len(values)
 			result = append(result, point)
@@ -82,6 +87,9 @@ func GroupBy(opts GroupOptions) Transform {
 			})
 		case "value":
 			sort.Slice(result, func(i, j int) bool {
+				if result[i].IsMissing() || result[j].IsMissing() {
+					return result[j].IsMissing() && !result[i].IsMissing()
+				}
 				return result[i].Y > result[j].Y
 			})
 		}
@@ -90,22 +98,21 @@ func GroupBy(opts GroupOptions) Transform {
 	}
 }
 
-// Reduce aggregates all data points into a single value using the given function
+// Reduce aggregates all data points into a single value using the given
+// function, skipping missing values
 func Reduce(fn AggregateFunc) Transform {
 	return func(data []DataPoint) []DataPoint {
 		if len(data) == 0 {
 			return nil
 		}
 
-		values := make([]float64, len(data))
-		for i, d := range data {
-			values[i] = d.Y
-		}
+		values := validValues(data)
+		value := aggregate(fn, values)
 
 		result := DataPoint{
-			Y:     fn(values),
-			Value: fn(values),
-			Count: len(data),
+			Y:     value,
+			Value: value,
+			Count: len(values),
 			Label: "aggregate",
 		}
 
@@ -137,7 +144,8 @@ func Map(fn func(DataPoint) DataPoint) Transform {
 	}
 }
 
-// Sort creates a transform that sorts data points
+// Sort creates a transform that sorts data points. Sorting by value puts
+// missing values last in either direction.
 func Sort(by string, ascending bool) Transform {
 	return func(data []DataPoint) []DataPoint {
 		result := make([]DataPoint, len(data))
@@ -147,6 +155,9 @@ func Sort(by string, ascending bool) Transform {
 			var less bool
 			switch by {
 			case "Y", "value":
+				if result[i].IsMissing() || result[j].IsMissing() {
+					return result[j].IsMissing() && !result[i].IsMissing()
+				}
 				less = result[i].Y < result[j].Y
 			case "X":
 				if t1, ok := result[i].X.(time.Time); ok {
@@ -172,9 +183,10 @@ func Sort(by string, ascending bool) Transform {
 	}
 }
 
-// Top returns the top N data points by value
+// Top returns the top N data points by value, leaving out missing values
 func Top(n int) Transform {
 	return func(data []DataPoint) []DataPoint {
+		data = DropMissing()(data)
 		if n <= 0 || n >= len(data) {
 			return data
 		}
@@ -185,18 +197,14 @@ func Top(n int) Transform {
 	}
 }
 
-// Percentile calculates percentiles for the Y values
+// Percentile calculates percentiles for the Y values, skipping missing values
 func Percentile(p float64) Transform {
 	return func(data []DataPoint) []DataPoint {
-		if len(data) == 0 {
+		values := validValues(data)
+		if len(values) == 0 {
 			return nil
 		}
 
-		values := make([]float64, len(data))
-		for i, d := range data {
-			values[i] = d.Y
-		}
-
 		sorted := make([]float64, len(values))
 		copy(sorted, values)
 		sort.Float64s(sorted)
@@ -219,15 +227,19 @@ func Percentile(p float64) Transform {
 	}
 }
 
-// Cumulative creates a cumulative sum transform
+// Cumulative creates a cumulative sum transform. Missing points stay
+// missing and add nothing to the sum.
 func Cumulative() Transform {
 	return func(data []DataPoint) []DataPoint {
 		result := make([]DataPoint, len(data))
 		cumSum := 0.0
 
 		for i, d := range data {
-			cumSum += d.Y
 			result[i] = d
+			if d.IsMissing() {
+				continue
+			}
+			cumSum += d.Y
 			result[i].Y = cumSum
 			result[i].Value = cumSum
 		}
@@ -236,7 +248,8 @@ func Cumulative() Transform {
 	}
 }
 
-// Window applies a windowed aggregation (rolling window)
+// Window applies a windowed aggregation (rolling window), skipping missing
+// values; windows without any values are missing
 func Window(size int, fn AggregateFunc) Transform {
 	return func(data []DataPoint) []DataPoint {
 		if len(data) == 0 || size <= 0 {
@@ -252,15 +265,9 @@ func Window(size int, fn AggregateFunc) Transform {
 			}
 			end := i + 1
 
-			// Extract window values
-			windowValues := make([]float64, end-start)
-			for j := start; j < end; j++ {
-				windowValues[j-start] = data[j].Y
-			}
-
 			// Apply aggregation
 			result[i] = data[i]
-			result[i].Y = fn(windowValues)
+			result[i].Y = aggregate(fn, validValues(data[start:end]))
 			result[i].Value = result[i].Y
 		}
 
@@ -0,0 +1,222 @@
+package transforms
+
+import (
+	"math"
+	"time"
+)
+
+// Missing values are data points whose Y is NaN, such as a sensor reading
+// that never arrived. Zero is an ordinary value. Transforms respect missing
+// values: aggregations skip them, with a missing result when a group or
+// window has no values left, element-wise transforms keep them missing, and
+// Fill replaces them.
+
+// Missing returns a data point at x with no value
+func Missing(x interface{}) DataPoint {
+	return DataPoint{X: x, Y: math.NaN(), Value: math.NaN()}
+}
+
+// IsMissing reports whether the point has no value
+func (d DataPoint) IsMissing() bool {
+	return math.IsNaN(d.Y)
+}
+
+// validValues returns the Y values of the points that are not missing
+func validValues(data []DataPoint) []float64 {
+	values := make([]float64, 0, len(data))
+	for _, d := range data {
+		if !d.IsMissing() {
+			values = append(values, d.Y)
+		}
+	}
+	return values
+}
+
+// aggregate applies fn to values that have had missing values removed,
+// giving NaN when none are left
+func aggregate(fn AggregateFunc, values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return fn(values)
+}
+
+// DropMissing removes points with no value
+func DropMissing() Transform {
+	return Filter(func(d DataPoint) bool {
+		return !d.IsMissing()
+	})
+}
+
+// FillOptions configures how missing values are filled
+type FillOptions struct {
+	// Method specifies the fill strategy:
+	//   - "linear" interpolates between the values on either side (default)
+	//   - "stepForward" repeats the last value before the gap
+	//   - "stepBackward" repeats the first value after the gap
+	//   - "spline" interpolates with a natural cubic spline through all values
+	//   - "constant" uses Value
+	// Only "constant" fills before the first value and after the last;
+	// "stepForward" also fills after the last and "stepBackward" before the first.
+	Method string
+
+	// Value is the fill value for the "constant" method
+	Value float64
+
+	// Limit is the longest run of missing points to fill; longer gaps are
+	// left missing (default: no limit)
+	Limit int
+}
+
+// Fill replaces missing values using the given strategy. Linear and spline
+// interpolation are spaced by X when every point has a numeric or time X,
+// and by position otherwise.
+//
+// Example:
+//
+//	data := []DataPoint{{Y: 1}, {Y: math.NaN()}, {Y: 0}}
+//	filled := Fill(FillOptions{Method: "linear"})(data) // Y: 1, 0.5, 0
+func Fill(opts FillOptions) Transform {
+	return func(data []DataPoint) []DataPoint {
+		result := make([]DataPoint, len(data))
+		copy(result, data)
+
+		// Indices of the points with values
+		var known []int
+		for i, d := range data {
+			if !d.IsMissing() {
+				known = append(known, i)
+			}
+		}
+		if opts.Method != "constant" && len(known) == 0 {
+			return result
+		}
+
+		xs := fillPositions(data)
+		var spline []float64
+		if opts.Method == "spline" && len(known) > 2 {
+			spline = splineSecondDerivatives(xs, data, known)
+		}
+
+		set := func(i int, y float64) {
+			result[i].Y = y
+			result[i].Value = y
+		}
+		next := 0 // Index in known of the first value after i
+		for i := 0; i < len(data); i++ {
+			if !data[i].IsMissing() {
+				next++
+				continue
+			}
+
+			// Find the gap and skip it if it's too long
+			end := i
+			for end < len(data) && data[end].IsMissing() {
+				end++
+			}
+			if opts.Limit > 0 && end-i > opts.Limit {
+				i = end - 1
+				continue
+			}
+
+			before, after := -1, -1
+			if next > 0 {
+				before = known[next-1]
+			}
+			if next < len(known) {
+				after = known[next]
+			}
+			for j := i; j < end; j++ {
+				switch opts.Method {
+				case "constant":
+					set(j, opts.Value)
+				case "stepForward":
+					if before >= 0 {
+						set(j, data[before].Y)
+					}
+				case "stepBackward":
+					if after >= 0 {
+						set(j, data[after].Y)
+					}
+				default:
+					if before < 0 || after < 0 {
+						continue
+					}
+					if spline != nil {
+						set(j, splineAt(xs, data, known, spline, next-1, xs[j]))
+						continue
+					}
+					t := (xs[j] - xs[before]) / (xs[after] - xs[before])
+					set(j, data[before].Y+t*(data[after].Y-data[before].Y))
+				}
+			}
+			i = end - 1
+		}
+
+		return result
+	}
+}
+
+// fillPositions returns the points' X values as numbers, or their indices
+// when any X is not a number or time or the positions don't increase
+func fillPositions(data []DataPoint) []float64 {
+	xs := make([]float64, len(data))
+	for i, d := range data {
+		var x float64
+		switch v := d.X.(type) {
+		case float64:
+			x = v
+		case int:
+			x = float64(v)
+		case time.Time:
+			x = float64(v.UnixNano())
+		default:
+			return indexPositions(len(data))
+		}
+		if i > 0 && x <= xs[i-1] {
+			return indexPositions(len(data))
+		}
+		xs[i] = x
+	}
+	return xs
+}
+
+// indexPositions returns 0, 1, ..., n-1
+func indexPositions(n int) []float64 {
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i)
+	}
+	return xs
+}
+
+// splineSecondDerivatives returns the second derivatives at the known
+// points of the natural cubic spline through them
+func splineSecondDerivatives(xs []float64, data []DataPoint, known []int) []float64 {
+	n := len(known)
+	m := make([]float64, n)
+	c := make([]float64, n) // Forward-eliminated superdiagonal
+	d := make([]float64, n) // Forward-eliminated right-hand side
+	for k := 1; k < n-1; k++ {
+		h0 := xs[known[k]] - xs[known[k-1]]
+		h1 := xs[known[k+1]] - xs[known[k]]
+		rhs := 6 * ((data[known[k+1]].Y-data[known[k]].Y)/h1 - (data[known[k]].Y-data[known[k-1]].Y)/h0)
+		diag := 2*(h0+h1) - h0*c[k-1]
+		c[k] = h1 / diag
+		d[k] = (rhs - h0*d[k-1]) / diag
+	}
+	for k := n - 2; k > 0; k-- {
+		m[k] = d[k] - c[k]*m[k+1]
+	}
+	return m
+}
+
+// splineAt evaluates the spline between known points k and k+1 at x
+func splineAt(xs []float64, data []DataPoint, known []int, m []float64, k int, x float64) float64 {
+	x0, x1 := xs[known[k]], xs[known[k+1]]
+	y0, y1 := data[known[k]].Y, data[known[k+1]].Y
+	h := x1 - x0
+	a := (x1 - x) / h
+	b := (x - x0) / h
+	return a*y0 + b*y1 + ((a*a*a-a)*m[k]+(b*b*b-b)*m[k+1])*h*h/6
+}
@@ -0,0 +1,186 @@
+package transforms
+
+import (
+	"math"
+	"testing"
+)
+
+var nan = math.NaN()
+
+// ys builds points from Y values
+func ys(values ...float64) []DataPoint {
+	data := make([]DataPoint, len(values))
+	for i, v := range values {
+		data[i] = DataPoint{Y: v, Value: v, Index: i}
+	}
+	return data
+}
+
+// sameValues compares Y values, treating missing values as equal
+func sameValues(data []DataPoint, want []float64) bool {
+	if len(data) != len(want) {
+		return false
+	}
+	for i, d := range data {
+		if math.IsNaN(want[i]) != d.IsMissing() || (!d.IsMissing() && !floatEquals(d.Y, want[i], 1e-9)) {
+			return false
+		}
+	}
+	return true
+}
+
+func yValues(data []DataPoint) []float64 {
+	values := make([]float64, len(data))
+	for i, d := range data {
+		values[i] = d.Y
+	}
+	return values
+}
+
+func TestFill(t *testing.T) {
+	data := ys(nan, 2, nan, nan, 8, 0, nan)
+
+	tests := []struct {
+		opts FillOptions
+		want []float64
+	}{
+		{FillOptions{}, []float64{nan, 2, 4, 6, 8, 0, nan}},
+		{FillOptions{Method: "stepForward"}, []float64{nan, 2, 2, 2, 8, 0, 0}},
+		{FillOptions{Method: "stepBackward"}, []float64{2, 2, 8, 8, 8, 0, nan}},
+		{FillOptions{Method: "constant", Value: -1}, []float64{-1, 2, -1, -1, 8, 0, -1}},
+		{FillOptions{Method: "linear", Limit: 1}, []float64{nan, 2, nan, nan, 8, 0, nan}},
+	}
+	for _, tt := range tests {
+		got := Fill(tt.opts)(data)
+		if !sameValues(got, tt.want) {
+			t.Errorf("Fill(%+v) = %v, want %v", tt.opts, yValues(got), tt.want)
+		}
+	}
+
+	if !data[2].IsMissing() {
+		t.Error("Fill modified its input")
+	}
+}
+
+func TestFillByX(t *testing.T) {
+	// Interpolation follows X spacing rather than position
+	data := []DataPoint{{X: 0.0, Y: 0}, {X: 1.0, Y: nan}, {X: 4.0, Y: 8}}
+	got := Interpolate()(data)
+	if !floatEquals(got[1].Y, 2, 1e-9) {
+		t.Errorf("Expected 2, got %f", got[1].Y)
+	}
+}
+
+func TestFillSpline(t *testing.T) {
+	// A natural cubic spline through points on a line is that line, and
+	// through a parabola bends with it
+	line := Fill(FillOptions{Method: "spline"})(ys(0, 1, nan, 3, 4))
+	if !floatEquals(line[2].Y, 2, 1e-9) {
+		t.Errorf("Expected 2 on a line, got %f", line[2].Y)
+	}
+	parabola := Fill(FillOptions{Method: "spline"})(ys(0, 1, 4, nan, 16, 25, 36))
+	if parabola[3].Y <= 9-1 || parabola[3].Y >= 9+1 || floatEquals(parabola[3].Y, 10, 1e-9) {
+		t.Errorf("Expected about 9, not the linear 10, got %f", parabola[3].Y)
+	}
+}
+
+func TestInterpolateKeepsZeros(t *testing.T) {
+	got := Interpolate()(ys(5, 0, 5))
+	if got[1].Y != 0 {
+		t.Errorf("Expected zero to be kept, got %f", got[1].Y)
+	}
+}
+
+func TestMissingAggregations(t *testing.T) {
+	data := []DataPoint{
+		{Label: "A", Y: 10},
+		{Label: "A", Y: nan},
+		{Label: "A", Y: 20},
+		{Label: "B", Y: nan},
+	}
+
+	for _, g := range GroupBy(GroupOptions{By: "Label", Aggregate: Mean})(data) {
+		switch g.Label {
+		case "A":
+			if g.Y != 15 || g.Count != 2 {
+				t.Errorf("Group A: expected mean 15 of 2, got %f of %d", g.Y, g.Count)
+			}
+		case "B":
+			if !g.IsMissing() {
+				t.Errorf("Group B: expected missing, got %f", g.Y)
+			}
+		}
+	}
+
+	if got := Reduce(Sum)(data)[0].Y; got != 30 {
+		t.Errorf("Reduce: expected 30, got %f", got)
+	}
+	if got := Cumulative()(data); !sameValues(got, []float64{10, nan, 30, nan}) {
+		t.Errorf("Cumulative = %v", yValues(got))
+	}
+	if got := NormalizePercentage()(data); !sameValues(got, []float64{100.0 / 3, nan, 200.0 / 3, nan}) {
+		t.Errorf("NormalizePercentage = %v", yValues(got))
+	}
+	if got := Top(3)(data); len(got) != 2 {
+		t.Errorf("Top(3) = %v", yValues(got))
+	}
+}
+
+func TestRollingMinPeriods(t *testing.T) {
+	data := ys(1, nan, 3, nan, nan, 6)
+
+	tests := []struct {
+		name string
+		tr   Transform
+		want []float64
+	}{
+		{"rolling", NewRolling(3).Mean(), []float64{1, 1, 2, 3, 3, 6}},
+		{"rolling min periods", NewRolling(3).MinPeriods(2).Mean(), []float64{nan, nan, 2, nan, nan, nan}},
+		{"expanding", NewExpanding().MinPeriods(2).Sum(), []float64{nan, nan, 4, 4, 4, 10}},
+		{"window", Window(2, Max), []float64{1, 1, 3, 3, nan, 6}},
+		{"moving average", MovingAverage(3), []float64{2, nan, 3, nan, nan, 6}},
+	}
+	for _, tt := range tests {
+		if got := tt.tr(data); !sameValues(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, yValues(got), tt.want)
+		}
+	}
+}
+
+func TestEWMMissing(t *testing.T) {
+	// By default the first value keeps decaying across the gap, weighted
+	// (1-alpha)^2 against alpha for the value after it
+	data := ys(1, nan, 3)
+	got := NewEWM(0.5).Mean()(data)
+	if want := []float64{1, 1, (0.25*1 + 0.5*3) / 0.75}; !sameValues(got, want) {
+		t.Errorf("Mean = %v, want %v", yValues(got), want)
+	}
+
+	ignored := NewEWM(0.5).IgnoreNA(true).Mean()(data)
+	if want := []float64{1, 1, 2}; !sameValues(ignored, want) {
+		t.Errorf("Mean ignoring missing values = %v, want %v", yValues(ignored), want)
+	}
+
+	minPeriods := NewEWM(0.5).MinPeriods(2).Mean()(data)
+	if want := []float64{nan, nan, (0.25*1 + 0.5*3) / 0.75}; !sameValues(minPeriods, want) {
+		t.Errorf("Mean with MinPeriods(2) = %v, want %v", yValues(minPeriods), want)
+	}
+}
+
+func TestStackMissing(t *testing.T) {
+	data := []DataPoint{
+		{X: "2020", Group: "A", Y: 10},
+		{X: "2020", Group: "B", Y: nan},
+		{X: "2020", Group: "C", Y: 5},
+	}
+
+	zero := Stack(StackOptions{})(data)
+	if zero[1].Y0 != 10 || zero[1].Y1 != 10 || zero[2].Y0 != 10 || zero[2].Y1 != 15 {
+		t.Errorf("zero policy: B = [%f, %f], C = [%f, %f]", zero[1].Y0, zero[1].Y1, zero[2].Y0, zero[2].Y1)
+	}
+
+	gap := Stack(StackOptions{Missing: "gap"})(data)
+	if !math.IsNaN(gap[1].Y0) || !math.IsNaN(gap[1].Y1) || gap[2].Y0 != 10 || gap[2].Y1 != 15 {
+		t.Errorf("gap policy: B = [%f, %f], C = [%f, %f]", gap[1].Y0, gap[1].Y1, gap[2].Y0, gap[2].Y1)
+	}
+}
@@ -34,10 +34,7 @@ func NormalizePercentage() Transform {
 		}
 
 		// Calculate total
-		total := 0.0
-		for _, d := range data {
-			total += d.Y
-		}
+		total := Sum(validValues(data))
 
 		if total == 0 {
 			return data
@@ -63,10 +60,7 @@ func NormalizeFraction() Transform {
 		}
 
 		// Calculate total
-		total := 0.0
-		for _, d := range data {
-			total += d.Y
-		}
+		total := Sum(validValues(data))
 
 		if total == 0 {
 			return data
@@ -92,10 +86,7 @@ func NormalizeZScore() Transform {
 		}
 
 		// Calculate mean
-		values := make([]float64, len(data))
-		for i, d := range data {
-			values[i] = d.Y
-		}
+		values := validValues(data)
 		mean := Mean(values)
 
 		// Calculate standard deviation
@@ -111,8 +102,10 @@ func NormalizeZScore() Transform {
 			result := make([]DataPoint, len(data))
 			for i, d := range data {
 				result[i] = d
-				result[i].Y = 0
-				result[i].Value = 0
+				if !d.IsMissing() {
+					result[i].Y = 0
+					result[i].Value = 0
+				}
 			}
 			return result
 		}
@@ -137,10 +130,7 @@ func NormalizeMinMax(targetMin, targetMax float64) Transform {
 		}
 
 		// Find min and max
-		values := make([]float64, len(data))
-		for i, d := range data {
-			values[i] = d.Y
-		}
+		values := validValues(data)
 		dataMin := Min(values)
 		dataMax := Max(values)
 
@@ -150,8 +140,10 @@ func NormalizeMinMax(targetMin, targetMax float64) Transform {
 			midpoint := (targetMin + targetMax) / 2
 			for i, d := range data {
 				result[i] = d
-				result[i].Y = midpoint
-				result[i].Value = midpoint
+				if !d.IsMissing() {
+					result[i].Y = midpoint
+					result[i].Value = midpoint
+				}
 			}
 			return result
 		}
@@ -305,7 +297,7 @@ func Log(base float64) Transform {
 			if d.Y > 0 {
 				result[i].Y = math.Log(d.Y) / logBase
 				result[i].Value = result[i].Y
-			} else {
+			} else if !d.IsMissing() {
 				// Handle non-positive values
 				result[i].Y = 0
 				result[i].Value = 0
@@ -325,7 +317,7 @@ func Sqrt() Transform {
 			if d.Y >= 0 {
 				result[i].Y = math.Sqrt(d.Y)
 				result[i].Value = result[i].Y
-			} else {
+			} else if !d.IsMissing() {
 				result[i].Y = 0
 				result[i].Value = 0
 			}
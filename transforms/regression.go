@@ -0,0 +1,469 @@
+package transforms
+
+import (
+	"math"
+	"time"
+)
+
+// RegressionOptions configures a regression fit
+type RegressionOptions struct {
+	// Method specifies the model:
+	//   - "linear" fits y = c0 + c1 x (default)
+	//   - "polynomial" fits y = c0 + c1 x + ... + cn x^n
+	//   - "exponential" fits y = a e^(b x), using points with positive Y
+	//   - "logarithmic" fits y = a + b ln x, using points with positive X
+	//   - "power" fits y = a x^b, using points with positive X and Y
+	// Exponential and power models are fit by least squares on ln y, so
+	// their intervals are symmetric on that scale.
+	Method string
+
+	// Order is the polynomial degree (default: 2)
+	Order int
+
+	// Level is the confidence level of the intervals (default: 0.95)
+	Level float64
+
+	// Interval selects the band the Regression transform outputs:
+	// "confidence" for the fitted mean (default) or "prediction" for new
+	// observations
+	Interval string
+
+	// Samples is the number of fitted points the Regression transform
+	// outputs, evenly spaced over the X range of the data (default: 100)
+	Samples int
+}
+
+// RegressionModel is a fitted regression. X values are numeric X, time X
+// as Unix nanoseconds, or the index when X is neither.
+type RegressionModel struct {
+	Method string
+
+	// Coefficients are c0, c1, ... for linear and polynomial models, and
+	// a, b for exponential, logarithmic and power models
+	Coefficients []float64
+
+	// RSquared is the coefficient of determination of the fitted values
+	// against the original Y values
+	RSquared float64
+
+	// N is the number of points the model was fit to
+	N int
+
+	// Level is the confidence level of the intervals
+	Level float64
+
+	// XMin and XMax are the range of X the model was fit to
+	XMin, XMax float64
+
+	// The fit is a polynomial in u = (t-center)/scale, where t is x or
+	// ln x, for y or ln y
+	beta          []float64
+	center, scale float64
+	cov           [][]float64 // Inverse of the normal equations matrix
+	sigma         float64     // Residual standard error
+	quantile      float64     // Student's t quantile for Level
+}
+
+// FitRegression fits a regression model to the data, skipping missing
+// values and points outside the model's domain. It returns nil when there
+// are too few points with distinct X values to fit.
+//
+// Example:
+//
+//	model := FitRegression(data, RegressionOptions{Method: "linear"})
+//	slope, r2 := model.Coefficients[1], model.RSquared
+func FitRegression(data []DataPoint, opts RegressionOptions) *RegressionModel {
+	m := &RegressionModel{Method: opts.Method, Level: opts.Level}
+	order := 1
+	switch opts.Method {
+	case "polynomial":
+		order = opts.Order
+		if order <= 0 {
+			order = 2
+		}
+	case "exponential", "logarithmic", "power":
+	default:
+		m.Method = "linear"
+	}
+	if m.Level <= 0 || m.Level >= 1 {
+		m.Level = 0.95
+	}
+
+	var xs, ys, ts, zs []float64
+	for i, d := range data {
+		x, ok := regressionX(d, i)
+		if !ok || d.IsMissing() {
+			continue
+		}
+		t, z := x, d.Y
+		if m.logX() {
+			if x <= 0 {
+				continue
+			}
+			t = math.Log(x)
+		}
+		if m.logY() {
+			if d.Y <= 0 {
+				continue
+			}
+			z = math.Log(d.Y)
+		}
+		xs = append(xs, x)
+		ys = append(ys, d.Y)
+		ts = append(ts, t)
+		zs = append(zs, z)
+	}
+
+	p := order + 1
+	m.N = len(xs)
+	if m.N < p {
+		return nil
+	}
+
+	// Each coefficient needs another distinct X value, counted on the
+	// scale the model is fit on
+	distinct := make(map[float64]bool, m.N)
+	for _, t := range ts {
+		distinct[t] = true
+	}
+	if len(distinct) < p {
+		return nil
+	}
+
+	// Center and scale t so the normal equations stay well conditioned
+	m.XMin, m.XMax = xs[0], xs[0]
+	for i, t := range ts {
+		m.center += t / float64(m.N)
+		m.XMin = min(m.XMin, xs[i])
+		m.XMax = max(m.XMax, xs[i])
+	}
+	for _, t := range ts {
+		m.scale = max(m.scale, math.Abs(t-m.center))
+	}
+	if m.scale == 0 {
+		return nil
+	}
+
+	a := make([][]float64, p)
+	for j := range a {
+		a[j] = make([]float64, p)
+	}
+	b := make([]float64, p)
+	for i, t := range ts {
+		f := powers((t-m.center)/m.scale, order)
+		for j := range f {
+			for k := range f {
+				a[j][k] += f[j] * f[k]
+			}
+			b[j] += f[j] * zs[i]
+		}
+	}
+	m.beta = solveLinear(copyMatrix(a), b)
+
+	m.cov = make([][]float64, p)
+	for j := range m.cov {
+		m.cov[j] = make([]float64, p)
+	}
+	for k := 0; k < p; k++ {
+		unit := make([]float64, p)
+		unit[k] = 1
+		for j, v := range solveLinear(copyMatrix(a), unit) {
+			m.cov[j][k] = v
+		}
+	}
+
+	// Residuals on the fitted scale give the interval widths; residuals on
+	// the original scale give R²
+	sse, ssRes, ssTot, mean := 0.0, 0.0, 0.0, 0.0
+	for _, y := range ys {
+		mean += y / float64(m.N)
+	}
+	for i := range xs {
+		r := zs[i] - m.fit(ts[i])
+		sse += r * r
+		r = ys[i] - m.Predict(xs[i])
+		ssRes += r * r
+		ssTot += (ys[i] - mean) * (ys[i] - mean)
+	}
+	m.RSquared = 1
+	if ssTot > 0 {
+		m.RSquared = 1 - ssRes/ssTot
+	}
+	dof := m.N - p
+	m.sigma = math.NaN()
+	if dof > 0 {
+		m.sigma = math.Sqrt(sse / float64(dof))
+	}
+	m.quantile = studentTQuantile((1+m.Level)/2, dof)
+
+	m.Coefficients = m.coefficients()
+	return m
+}
+
+// Predict returns the fitted value at x
+func (m *RegressionModel) Predict(x float64) float64 {
+	t, ok := m.transformX(x)
+	if !ok {
+		return math.NaN()
+	}
+	z := m.fit(t)
+	if m.logY() {
+		return math.Exp(z)
+	}
+	return z
+}
+
+// ConfidenceInterval returns the bounds at x of the interval expected to
+// contain the true mean at Level. The bounds are NaN when there are no
+// more points than coefficients.
+func (m *RegressionModel) ConfidenceInterval(x float64) (lower, upper float64) {
+	return m.interval(x, 0)
+}
+
+// PredictionInterval returns the bounds at x of the interval expected to
+// contain a new observation at Level. The bounds are NaN when there are
+// no more points than coefficients.
+func (m *RegressionModel) PredictionInterval(x float64) (lower, upper float64) {
+	return m.interval(x, 1)
+}
+
+// interval returns the fit at x plus and minus the t quantile times the
+// standard error, which adds noise variance for predictions
+func (m *RegressionModel) interval(x, noise float64) (lower, upper float64) {
+	t, ok := m.transformX(x)
+	if !ok {
+		return math.NaN(), math.NaN()
+	}
+	f := powers((t-m.center)/m.scale, len(m.beta)-1)
+	v := 0.0
+	for j := range f {
+		for k := range f {
+			v += f[j] * m.cov[j][k] * f[k]
+		}
+	}
+	z := m.fit(t)
+	half := m.quantile * m.sigma * math.Sqrt(noise+v)
+	lower, upper = z-half, z+half
+	if m.logY() {
+		return math.Exp(lower), math.Exp(upper)
+	}
+	return lower, upper
+}
+
+// Regression fits a regression model and outputs points along the fit,
+// with the fitted value in Y and Value and the interval bounds in Y0 and
+// Y1. Data holds the *RegressionModel with the coefficients and R². X is
+// a time when the input X values are times. It outputs nothing when the
+// data can't be fit.
+//
+// Example:
+//
+//	trend := Regression(RegressionOptions{Method: "polynomial", Order: 3})(data)
+//	r2 := trend[0].Data.(*RegressionModel).RSquared
+func Regression(opts RegressionOptions) Transform {
+	return func(data []DataPoint) []DataPoint {
+		m := FitRegression(data, opts)
+		if m == nil {
+			return nil
+		}
+
+		samples := opts.Samples
+		if samples <= 0 {
+			samples = 100
+		}
+		samples = max(samples, 2)
+
+		var loc *time.Location
+		for _, d := range data {
+			if t, ok := d.X.(time.Time); ok {
+				loc = t.Location()
+				break
+			}
+		}
+
+		result := make([]DataPoint, samples)
+		for i := range result {
+			x := m.XMin + (m.XMax-m.XMin)*float64(i)/float64(samples-1)
+			y := m.Predict(x)
+			var lower, upper float64
+			if opts.Interval == "prediction" {
+				lower, upper = m.PredictionInterval(x)
+			} else {
+				lower, upper = m.ConfidenceInterval(x)
+			}
+
+			result[i] = DataPoint{X: x, Y: y, Y0: lower, Y1: upper, Value: y, Label: m.Method, Index: i, Data: m}
+			if loc != nil {
+				result[i].X = time.Unix(0, int64(x)).In(loc)
+			}
+		}
+		return result
+	}
+}
+
+// logX reports whether the model is fit against ln x
+func (m *RegressionModel) logX() bool {
+	return m.Method == "logarithmic" || m.Method == "power"
+}
+
+// logY reports whether the model is fit for ln y
+func (m *RegressionModel) logY() bool {
+	return m.Method == "exponential" || m.Method == "power"
+}
+
+// transformX maps x to the fitted scale, reporting whether x is in the
+// model's domain
+func (m *RegressionModel) transformX(x float64) (float64, bool) {
+	if !m.logX() {
+		return x, true
+	}
+	if x <= 0 {
+		return 0, false
+	}
+	return math.Log(x), true
+}
+
+// fit evaluates the polynomial on the fitted scale at t
+func (m *RegressionModel) fit(t float64) float64 {
+	u := (t - m.center) / m.scale
+	z := 0.0
+	for k := len(m.beta) - 1; k >= 0; k-- {
+		z = z*u + m.beta[k]
+	}
+	return z
+}
+
+// coefficients expands the centered and scaled polynomial into powers of t
+// and converts them to the model's terms
+func (m *RegressionModel) coefficients() []float64 {
+	p := len(m.beta)
+	raw := make([]float64, p)
+	for k := 0; k < p; k++ {
+		// beta_k ((t - center) / scale)^k, expanded binomially
+		binomial := 1.0
+		for j := k; j >= 0; j-- {
+			raw[j] += m.beta[k] / math.Pow(m.scale, float64(k)) * binomial * math.Pow(-m.center, float64(k-j))
+			binomial = binomial * float64(j) / float64(k-j+1)
+		}
+	}
+	if m.logY() {
+		raw[0] = math.Exp(raw[0])
+	}
+	return raw
+}
+
+// regressionX returns the point's X as a number: numeric X as is, time X
+// as Unix nanoseconds and the index otherwise
+func regressionX(d DataPoint, i int) (float64, bool) {
+	switch v := d.X.(type) {
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case int:
+		return float64(v), true
+	case time.Time:
+		return float64(v.UnixNano()), true
+	default:
+		return float64(i), true
+	}
+}
+
+// powers returns 1, u, u², ..., u^order
+func powers(u float64, order int) []float64 {
+	f := make([]float64, order+1)
+	f[0] = 1
+	for k := 1; k <= order; k++ {
+		f[k] = f[k-1] * u
+	}
+	return f
+}
+
+// copyMatrix returns a copy of a, for solveLinear to overwrite
+func copyMatrix(a [][]float64) [][]float64 {
+	c := make([][]float64, len(a))
+	for i, row := range a {
+		c[i] = append([]float64(nil), row...)
+	}
+	return c
+}
+
+// studentTQuantile returns the value below which a fraction p (above one
+// half) of Student's t distribution with dof degrees of freedom lies, or
+// NaN without degrees of freedom
+func studentTQuantile(p float64, dof int) float64 {
+	if dof <= 0 {
+		return math.NaN()
+	}
+	n := float64(dof)
+	cdf := func(t float64) float64 {
+		return 1 - 0.5*regularizedBeta(n/(n+t*t), n/2, 0.5)
+	}
+
+	lo, hi := 0.0, 1.0
+	for cdf(hi) < p {
+		lo, hi = hi, hi*2
+	}
+	for range 100 {
+		mid := (lo + hi) / 2
+		if cdf(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// regularizedBeta returns the regularized incomplete beta function
+// I_x(a, b), evaluated by continued fraction
+func regularizedBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges quickly on this side of the mean
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(x, a, b) / a
+	}
+	return 1 - front*betaFraction(1-x, b, a)/b
+}
+
+// betaFraction evaluates the continued fraction for the incomplete beta
+// function with the modified Lentz method
+func betaFraction(x, a, b float64) float64 {
+	const tiny = 1e-300
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+
+	c := 1.0
+	d := 1 / clamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1.0; m <= 300; m++ {
+		// Even step
+		num := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		h *= d * c
+
+		// Odd step
+		num = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
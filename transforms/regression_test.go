@@ -0,0 +1,170 @@
+package transforms
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// xy builds points from X and Y values
+func xy(xs, ys []float64) []DataPoint {
+	data := make([]DataPoint, len(xs))
+	for i := range xs {
+		data[i] = DataPoint{X: xs[i], Y: ys[i]}
+	}
+	return data
+}
+
+func TestFitRegressionModels(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5, 6}
+	curve := func(f func(x float64) float64) []DataPoint {
+		ys := make([]float64, len(xs))
+		for i, x := range xs {
+			ys[i] = f(x)
+		}
+		return xy(xs, ys)
+	}
+
+	tests := []struct {
+		opts RegressionOptions
+		data []DataPoint
+		want []float64
+	}{
+		{RegressionOptions{}, curve(func(x float64) float64 { return 2*x + 1 }), []float64{1, 2}},
+		{RegressionOptions{Method: "polynomial"}, curve(func(x float64) float64 { return x*x - 3*x + 2 }), []float64{2, -3, 1}},
+		{RegressionOptions{Method: "polynomial", Order: 3}, curve(func(x float64) float64 { return x*x*x - x }), []float64{0, -1, 0, 1}},
+		{RegressionOptions{Method: "exponential"}, curve(func(x float64) float64 { return 3 * math.Exp(0.5*x) }), []float64{3, 0.5}},
+		{RegressionOptions{Method: "logarithmic"}, curve(func(x float64) float64 { return 1 + 2*math.Log(x) }), []float64{1, 2}},
+		{RegressionOptions{Method: "power"}, curve(func(x float64) float64 { return 2 * math.Pow(x, 1.5) }), []float64{2, 1.5}},
+	}
+	for _, tt := range tests {
+		m := FitRegression(tt.data, tt.opts)
+		if m == nil {
+			t.Errorf("%s: expected a fit", tt.opts.Method)
+			continue
+		}
+		if len(m.Coefficients) != len(tt.want) {
+			t.Errorf("%s: expected %d coefficients, got %v", m.Method, len(tt.want), m.Coefficients)
+			continue
+		}
+		for i, c := range tt.want {
+			if !floatEquals(m.Coefficients[i], c, 1e-6) {
+				t.Errorf("%s: coefficient %d = %f, expected %f", m.Method, i, m.Coefficients[i], c)
+			}
+		}
+		if !floatEquals(m.RSquared, 1, 1e-9) {
+			t.Errorf("%s: R² = %f, expected 1", m.Method, m.RSquared)
+		}
+		if got := m.Predict(xs[2]); !floatEquals(got, tt.data[2].Y, 1e-6) {
+			t.Errorf("%s: Predict(%v) = %f, expected %f", m.Method, xs[2], got, tt.data[2].Y)
+		}
+	}
+}
+
+func TestFitRegressionIntervals(t *testing.T) {
+	// y = 2.2 + 0.6x with residual variance 0.8 on 3 degrees of freedom
+	m := FitRegression(xy([]float64{1, 2, 3, 4, 5}, []float64{2, 4, 5, 4, 5}), RegressionOptions{})
+
+	if !floatEquals(m.Coefficients[0], 2.2, 1e-9) || !floatEquals(m.Coefficients[1], 0.6, 1e-9) {
+		t.Errorf("Coefficients = %v, expected [2.2 0.6]", m.Coefficients)
+	}
+	if !floatEquals(m.RSquared, 0.6, 1e-9) {
+		t.Errorf("R² = %f, expected 0.6", m.RSquared)
+	}
+
+	// At the mean of X the standard errors are sqrt(0.8/5) and sqrt(0.8*1.2)
+	const t3 = 3.182446305
+	lower, upper := m.ConfidenceInterval(3)
+	if !floatEquals(lower, 4-t3*0.4, 1e-6) || !floatEquals(upper, 4+t3*0.4, 1e-6) {
+		t.Errorf("ConfidenceInterval(3) = [%f, %f], expected 4 ± %f", lower, upper, t3*0.4)
+	}
+	lower, upper = m.PredictionInterval(3)
+	if half := t3 * math.Sqrt(0.96); !floatEquals(lower, 4-half, 1e-6) || !floatEquals(upper, 4+half, 1e-6) {
+		t.Errorf("PredictionInterval(3) = [%f, %f], expected 4 ± %f", lower, upper, half)
+	}
+
+	// Intervals widen away from the mean
+	lower1, upper1 := m.ConfidenceInterval(1)
+	lower3, upper3 := m.ConfidenceInterval(3)
+	if upper1-lower1 <= upper3-lower3 {
+		t.Error("Expected the interval to be wider at the edge of the data")
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	tests := []struct {
+		p    float64
+		dof  int
+		want float64
+	}{
+		{0.975, 1, 12.706205},
+		{0.975, 3, 3.182446},
+		{0.975, 10, 2.228139},
+		{0.95, 30, 1.697261},
+		{0.995, 100, 2.625891},
+	}
+	for _, tt := range tests {
+		if got := studentTQuantile(tt.p, tt.dof); !floatEquals(got, tt.want, 1e-5) {
+			t.Errorf("studentTQuantile(%v, %d) = %f, expected %f", tt.p, tt.dof, got, tt.want)
+		}
+	}
+}
+
+func TestFitRegressionUnfit(t *testing.T) {
+	tests := []struct {
+		name string
+		data []DataPoint
+		opts RegressionOptions
+	}{
+		{"one point", xy([]float64{1}, []float64{1}), RegressionOptions{}},
+		{"same X", xy([]float64{2, 2, 2}, []float64{1, 2, 3}), RegressionOptions{}},
+		{"too few for order", xy([]float64{1, 2, 3}, []float64{1, 2, 3}), RegressionOptions{Method: "polynomial", Order: 3}},
+		{"too few distinct X for order", xy([]float64{1, 1, 2, 2}, []float64{1, 2, 3, 4}), RegressionOptions{Method: "polynomial", Order: 2}},
+		{"no positive Y", xy([]float64{1, 2, 3}, []float64{-1, 0, -3}), RegressionOptions{Method: "exponential"}},
+	}
+	for _, tt := range tests {
+		if m := FitRegression(tt.data, tt.opts); m != nil {
+			t.Errorf("%s: expected no fit, got %v", tt.name, m.Coefficients)
+		}
+		if got := Regression(tt.opts)(tt.data); got != nil {
+			t.Errorf("%s: expected no points, got %d", tt.name, len(got))
+		}
+	}
+
+	// Two distinct X values are enough for a line
+	if m := FitRegression(xy([]float64{1, 1, 2, 2}, []float64{1, 2, 3, 4}), RegressionOptions{}); m == nil {
+		t.Error("expected a linear fit through two distinct X values")
+	}
+}
+
+func TestRegressionTransform(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := []DataPoint{
+		{X: start, Y: 10},
+		{X: start.AddDate(0, 0, 1), Y: 12},
+		{X: start.AddDate(0, 0, 2), Y: math.NaN()},
+		{X: start.AddDate(0, 0, 3), Y: 13},
+		{X: start.AddDate(0, 0, 4), Y: 17},
+	}
+
+	trend := Regression(RegressionOptions{Interval: "prediction", Samples: 5})(data)
+	if len(trend) != 5 {
+		t.Fatalf("Expected 5 points, got %d", len(trend))
+	}
+	if x, ok := trend[4].X.(time.Time); !ok || !x.Equal(start.AddDate(0, 0, 4)) {
+		t.Errorf("Expected the last point at the last date, got %v", trend[4].X)
+	}
+
+	m, ok := trend[0].Data.(*RegressionModel)
+	if !ok || m.N != 4 {
+		t.Fatalf("Expected the model fit to 4 points, got %v", trend[0].Data)
+	}
+	for i, d := range trend {
+		if !(d.Y0 < d.Y && d.Y < d.Y1) {
+			t.Errorf("Point %d: expected %f < %f < %f", i, d.Y0, d.Y, d.Y1)
+		}
+		if lower, _ := m.PredictionInterval(m.XMin + (m.XMax-m.XMin)*float64(i)/4); !floatEquals(d.Y0, lower, 1e-9) {
+			t.Errorf("Point %d: expected the prediction interval", i)
+		}
+	}
+}
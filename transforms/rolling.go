@@ -18,7 +18,9 @@ func NewRolling(window int) *Rolling {
 	}
 }
 
-// MinPeriods sets the minimum number of observations required
+// MinPeriods sets the minimum number of observations required. Missing
+// values are not observations: windows with fewer values than n give a
+// missing result.
 func (r *Rolling) MinPeriods(n int) *Rolling {
 	r.minPeriods = n
 	return r
@@ -191,9 +193,9 @@ func (r *Rolling) apply(fn AggregateFunc) Transform {
 				end = len(data)
 			}
 
-			// Extract window values
-			windowSize := end - start
-			if windowSize < r.minPeriods {
+			// Extract window values, skipping missing ones
+			values := validValues(data[start:end])
+			if len(values) == 0 || len(values) < r.minPeriods {
 				// Not enough observations
 				result[i] = data[i]
 				result[i].Y = math.NaN()
@@ -201,11 +203,6 @@ func (r *Rolling) apply(fn AggregateFunc) Transform {
 				continue
 			}
 
-			values := make([]float64, windowSize)
-			for j := start; j < end; j++ {
-				values[j-start] = data[j].Y
-			}
-
 			// Apply aggregation
 			result[i] = data[i]
 			result[i].Y = fn(values)
@@ -228,7 +225,8 @@ func NewExpanding() *Expanding {
 	}
 }
 
-// MinPeriods sets the minimum number of observations required
+// MinPeriods sets the minimum number of observations required, not
+// counting missing values
 func (e *Expanding) MinPeriods(n int) *Expanding {
 	e.minPeriods = n
 	return e
@@ -304,12 +302,15 @@ func (e *Expanding) apply(fn AggregateFunc) Transform {
 		}
 
 		result := make([]DataPoint, len(data))
+		var values []float64
 
 		for i := range data {
-			// Window from start to current point
-			windowSize := i + 1
+			// Values from start to current point, skipping missing ones
+			if !data[i].IsMissing() {
+				values = append(values, data[i].Y)
+			}
 
-			if windowSize < e.minPeriods {
+			if len(values) == 0 || len(values) < e.minPeriods {
 				// Not enough observations
 				result[i] = data[i]
 				result[i].Y = math.NaN()
@@ -317,15 +318,9 @@ func (e *Expanding) apply(fn AggregateFunc) Transform {
 				continue
 			}
 
-			// Extract values from start to current
-			values := make([]float64, windowSize)
-			for j := 0; j <= i; j++ {
-				values[j] = data[j].Y
-			}
-
-			// Apply aggregation
+			// Apply aggregation to a copy, as values keeps growing
 			result[i] = data[i]
-			result[i].Y = fn(values)
+			result[i].Y = fn(append([]float64(nil), values...))
 			result[i].Value = result[i].Y
 		}
 
@@ -357,48 +352,35 @@ func (ewm *EWM) Adjust(adjust bool) *EWM {
 	return ewm
 }
 
-// IgnoreNA sets whether to ignore NA values
+// IgnoreNA sets whether to ignore missing values when weighting. By
+// default the weight of earlier values keeps decaying across missing
+// values, as if they were there; ignoring them weights the values on
+// either side of a gap as neighbors.
 func (ewm *EWM) IgnoreNA(ignore bool) *EWM {
 	ewm.ignoreNA = ignore
 	return ewm
 }
 
-// MinPeriods sets the minimum number of observations
+// MinPeriods sets the minimum number of observations, not counting
+// missing values
 func (ewm *EWM) MinPeriods(n int) *EWM {
 	ewm.minPeriods = n
 	return ewm
 }
 
-// Mean calculates exponentially weighted mean
+// Mean calculates exponentially weighted mean. Missing points take the
+// mean so far.
 func (ewm *EWM) Mean() Transform {
 	return func(data []DataPoint) []DataPoint {
 		if len(data) == 0 {
 			return nil
 		}
 
-		result := make([]DataPoint, len(data))
-		ewma := data[0].Y
-
-		for i := range data {
-			if i < ewm.minPeriods {
-				result[i] = data[i]
-				result[i].Y = math.NaN()
-				result[i].Value = math.NaN()
-				continue
-			}
-
-			if i == 0 {
-				ewma = data[i].Y
-			} else {
-				ewma = ewm.alpha*data[i].Y + (1-ewm.alpha)*ewma
-			}
-
-			result[i] = data[i]
-			result[i].Y = ewma
-			result[i].Value = ewma
+		values := make([]float64, len(data))
+		for i, d := range data {
+			values[i] = d.Y
 		}
-
-		return result
+		return ewm.result(data, ewm.average(values))
 	}
 }
 
@@ -409,36 +391,64 @@ func (ewm *EWM) Std() Transform {
 			return nil
 		}
 
-		// First calculate EW mean
-		meanData := ewm.Mean()(data)
-
-		result := make([]DataPoint, len(data))
-		ewmVar := 0.0
-
-		for i := range data {
-			if i < ewm.minPeriods {
-				result[i] = data[i]
-				result[i].Y = math.NaN()
-				result[i].Value = math.NaN()
-				continue
-			}
-
-			// Calculate squared deviation from EW mean
-			sqDev := math.Pow(data[i].Y-meanData[i].Y, 2)
+		// Average the squared deviations from the EW mean
+		values := make([]float64, len(data))
+		for i, d := range data {
+			values[i] = d.Y
+		}
+		mean := ewm.average(values)
+		for i, v := range values {
+			values[i] = (v - mean[i]) * (v - mean[i])
+		}
+		variance := ewm.average(values)
+		for i, v := range variance {
+			variance[i] = math.Sqrt(v)
+		}
+		return ewm.result(data, variance)
+	}
+}
 
-			if i == 0 {
-				ewmVar = sqDev
+// average returns the exponentially weighted average of values at each
+// position, skipping NaNs, and NaN before the first value
+func (ewm *EWM) average(values []float64) []float64 {
+	averages := make([]float64, len(values))
+	avg := math.NaN()
+	last := 0
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			if math.IsNaN(avg) {
+				avg = v
 			} else {
-				ewmVar = ewm.alpha*sqDev + (1-ewm.alpha)*ewmVar
+				decay := 1 - ewm.alpha
+				if !ewm.ignoreNA {
+					decay = math.Pow(decay, float64(i-last))
+				}
+				avg = (decay*avg + ewm.alpha*v) / (decay + ewm.alpha)
 			}
-
-			result[i] = data[i]
-			result[i].Y = math.Sqrt(ewmVar)
-			result[i].Value = result[i].Y
+			last = i
 		}
+		averages[i] = avg
+	}
+	return averages
+}
 
-		return result
+// result copies data with Y set to values, or NaN until there have been
+// minPeriods observations
+func (ewm *EWM) result(data []DataPoint, values []float64) []DataPoint {
+	result := make([]DataPoint, len(data))
+	count := 0
+	for i := range data {
+		if !data[i].IsMissing() {
+			count++
+		}
+		result[i] = data[i]
+		result[i].Y = values[i]
+		if count < ewm.minPeriods {
+			result[i].Y = math.NaN()
+		}
+		result[i].Value = result[i].Y
 	}
+	return result
 }
 
 // Var calculates exponentially weighted variance
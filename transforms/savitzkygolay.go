@@ -0,0 +1,235 @@
+package transforms
+
+import "math"
+
+// SavitzkyGolayOptions configures a Savitzky-Golay filter
+type SavitzkyGolayOptions struct {
+	// WindowSize is the number of points in each fit; even sizes are
+	// rounded up to the next odd size (default: 5)
+	WindowSize int
+
+	// PolyOrder is the degree of the fitted polynomial; 0 is a moving
+	// average and orders of WindowSize or more reproduce the input
+	PolyOrder int
+
+	// Derivative selects the output: 0 smooths, 1 and 2 give the first
+	// and second derivative of the fitted polynomial
+	Derivative int
+
+	// Delta is the spacing between points, which scales derivatives
+	// (default: 1)
+	Delta float64
+
+	// Edge specifies how the first and last WindowSize/2 points are filtered:
+	// "interp" evaluates the polynomial fitted to the first or last full
+	// window (default), "mirror" reflects the data about the end points and
+	// "nearest" repeats the end points
+	Edge string
+}
+
+// SavitzkyGolay applies Savitzky-Golay smoothing: a polynomial of degree
+// polyOrder is fitted by least squares to the windowSize points around
+// each point and evaluated there. Unlike a moving average it keeps the
+// height and width of peaks.
+func SavitzkyGolay(windowSize, polyOrder int) Transform {
+	return SavitzkyGolayFilter(SavitzkyGolayOptions{WindowSize: windowSize, PolyOrder: polyOrder})
+}
+
+// SavitzkyGolayFilter applies a Savitzky-Golay filter, smoothing the data
+// or estimating its derivatives. Series shorter than the window are
+// filtered with the largest odd window that fits. Points whose window
+// includes a missing value are missing; Fill the data first to bridge gaps.
+//
+// Example:
+//
+//	slope := SavitzkyGolayFilter(SavitzkyGolayOptions{WindowSize: 11, PolyOrder: 3, Derivative: 1})(data)
+func SavitzkyGolayFilter(opts SavitzkyGolayOptions) Transform {
+	return func(data []DataPoint) []DataPoint {
+		if len(data) == 0 {
+			return nil
+		}
+
+		window := opts.WindowSize
+		if window <= 0 {
+			window = 5
+		}
+		window = min(window|1, (len(data)-1)|1)
+		order := min(max(opts.PolyOrder, 0), window-1)
+		derivative := max(opts.Derivative, 0)
+		delta := opts.Delta
+		if delta <= 0 {
+			delta = 1
+		}
+		scale := math.Pow(delta, float64(derivative))
+		half := window / 2
+		n := len(data)
+
+		index := func(i int) int {
+			if opts.Edge == "nearest" {
+				return min(max(i, 0), n-1)
+			}
+			return mirrorIndex(i, n)
+		}
+		interp := opts.Edge != "mirror" && opts.Edge != "nearest"
+		center := savitzkyGolayWeights(window, order, derivative, 0)
+
+		result := make([]DataPoint, n)
+		for i := range data {
+			y := 0.0
+			switch {
+			case interp && i < half:
+				for k, w := range savitzkyGolayWeights(window, order, derivative, float64(i-half)) {
+					y += w * data[k].Y
+				}
+			case interp && i >= n-half:
+				start := n - window
+				for k, w := range savitzkyGolayWeights(window, order, derivative, float64(i-start-half)) {
+					y += w * data[start+k].Y
+				}
+			default:
+				for k, w := range center {
+					y += w * data[index(i-half+k)].Y
+				}
+			}
+
+			result[i] = data[i]
+			result[i].Y = y / scale
+			result[i].Value = result[i].Y
+		}
+
+		return result
+	}
+}
+
+// SavitzkyGolayCoefficients returns the weights of a Savitzky-Golay filter
+// for the center of a window: summing each weight times the matching point
+// gives the fitted polynomial's value, or its derivative for a derivative
+// above 0, at the window's center point. Delta is the spacing between
+// points.
+func SavitzkyGolayCoefficients(windowSize, polyOrder, derivative int, delta float64) []float64 {
+	if windowSize <= 0 {
+		return nil
+	}
+	if delta <= 0 {
+		delta = 1
+	}
+	derivative = max(derivative, 0)
+	weights := savitzkyGolayWeights(windowSize, min(max(polyOrder, 0), windowSize-1), derivative, 0)
+	scale := math.Pow(delta, float64(derivative))
+	for i := range weights {
+		weights[i] /= scale
+	}
+	return weights
+}
+
+// savitzkyGolayWeights returns the weights that evaluate the derivative of
+// the least-squares polynomial through a window at pos points from its
+// center. Positions are scaled to [-1, 1] to keep the normal equations
+// well conditioned.
+func savitzkyGolayWeights(window, order, derivative int, pos float64) []float64 {
+	weights := make([]float64, window)
+	if derivative > order {
+		return weights
+	}
+	half := float64(window-1) / 2
+	unit := math.Max(half, 1)
+
+	// Powers of each point's scaled position, up to the order
+	powers := make([][]float64, window)
+	for i := range powers {
+		u := (float64(i) - half) / unit
+		powers[i] = make([]float64, order+1)
+		powers[i][0] = 1
+		for j := 1; j <= order; j++ {
+			powers[i][j] = powers[i][j-1] * u
+		}
+	}
+
+	// Normal equations of the fit, with the right-hand side differentiating
+	// each power at pos
+	normal := make([][]float64, order+1)
+	for j := range normal {
+		normal[j] = make([]float64, order+1)
+		for k := range normal[j] {
+			for _, p := range powers {
+				normal[j][k] += p[j] * p[k]
+			}
+		}
+	}
+	target := make([]float64, order+1)
+	u := pos / unit
+	for j := derivative; j <= order; j++ {
+		factor := 1.0
+		for k := j - derivative + 1; k <= j; k++ {
+			factor *= float64(k)
+		}
+		target[j] = factor * math.Pow(u, float64(j-derivative))
+	}
+
+	z := solveLinear(normal, target)
+	scale := math.Pow(unit, float64(derivative))
+	for i, p := range powers {
+		for j, v := range p {
+			weights[i] += v * z[j]
+		}
+		weights[i] /= scale
+	}
+	return weights
+}
+
+// solveLinear solves a x = b by Gaussian elimination with partial
+// pivoting, overwriting a and b. Singular systems give zeros for the
+// undetermined unknowns.
+func solveLinear(a [][]float64, b []float64) []float64 {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		if a[col][col] == 0 {
+			continue
+		}
+		for row := col + 1; row < n; row++ {
+			f := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+			b[row] -= f * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		if a[row][row] == 0 {
+			continue
+		}
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x
+}
+
+// mirrorIndex reflects an index outside 0..n-1 about the end points, so
+// -1 maps to 1 and n maps to n-2
+func mirrorIndex(i, n int) int {
+	if n == 1 {
+		return 0
+	}
+	period := 2 * (n - 1)
+	i %= period
+	if i < 0 {
+		i += period
+	}
+	if i >= n {
+		i = period - i
+	}
+	return i
+}
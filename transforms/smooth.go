@@ -20,6 +20,8 @@ func Smooth(opts SmoothOptions) Transform {
 		return ExponentialSmoothing(opts.Alpha)
 	case "loess":
 		return Loess(opts.Bandwidth)
+	case "savitzkyGolay":
+		return SavitzkyGolay(opts.WindowSize, opts.PolyOrder)
 	default:
 		return MovingAverage(3)
 	}
@@ -58,16 +60,19 @@ func MovingAverage(windowSize int) Transform {
 				}
 			}
 
-			// Calculate average
+			// Calculate average, skipping missing values
 			sum := 0.0
 			count := 0
 			for j := start; j < end; j++ {
+				if data[j].IsMissing() {
+					continue
+				}
 				sum += data[j].Y
 				count++
 			}
 
 			result[i] = data[i]
-			if count > 0 {
+			if count > 0 && !data[i].IsMissing() {
 				result[i].Y = sum / float64(count)
 				result[i].Value = result[i].Y
 			}
@@ -113,14 +118,14 @@ func WeightedMovingAverage(weights []float64) Transform {
 			actualWeightSum := 0.0
 			for j := start; j < end; j++ {
 				weightIdx := j - start
-				if weightIdx < len(weights) {
+				if weightIdx < len(weights) && !data[j].IsMissing() {
 					sum += data[j].Y * weights[weightIdx]
 					actualWeightSum += weights[weightIdx]
 				}
 			}
 
 			result[i] = data[i]
-			if actualWeightSum > 0 {
+			if actualWeightSum > 0 && !data[i].IsMissing() {
 				result[i].Y = sum / actualWeightSum
 				result[i].Value = result[i].Y
 			}
@@ -142,13 +147,23 @@ func ExponentialSmoothing(alpha float64) Transform {
 			alpha = 0.3
 		}
 
+		// Missing points stay missing and the smoothing carries on from
+		// the last value
 		result := make([]DataPoint, len(data))
-		result[0] = data[0]
+		level := math.NaN()
 
-		for i := 1; i < len(data); i++ {
+		for i := range data {
 			result[i] = data[i]
-			result[i].Y = alpha*data[i].Y + (1-alpha)*result[i-1].Y
-			result[i].Value = result[i].Y
+			if data[i].IsMissing() {
+				continue
+			}
+			if math.IsNaN(level) {
+				level = data[i].Y
+			} else {
+				level = alpha*data[i].Y + (1-alpha)*level
+			}
+			result[i].Y = level
+			result[i].Value = level
 		}
 
 		return result
@@ -168,27 +183,41 @@ func Loess(bandwidth float64) Transform {
 			bandwidth = 0.3
 		}
 
+		// Neighbors are drawn from the points with values
+		var valid []int
+		for i, d := range data {
+			if !d.IsMissing() {
+				valid = append(valid, i)
+			}
+		}
+
 		result := make([]DataPoint, len(data))
-		windowSize := int(float64(len(data)) * bandwidth)
+		copy(result, data)
+		if len(valid) == 0 {
+			return result
+		}
+		windowSize := int(float64(len(valid)) * bandwidth)
 		if windowSize < 2 {
 			windowSize = 2
 		}
-		if windowSize > len(data) {
-			windowSize = len(data)
+		if windowSize > len(valid) {
+			windowSize = len(valid)
 		}
 
 		for i := range data {
+			if data[i].IsMissing() {
+				continue
+			}
+
 			// Find nearest neighbors
 			distances := make([]float64, len(data))
-			for j := range data {
+			for _, j := range valid {
 				distances[j] = math.Abs(float64(i - j))
 			}
 
 			// Find the k nearest neighbors
-			indices := make([]int, len(data))
-			for j := range indices {
-				indices[j] = j
-			}
+			indices := make([]int, len(valid))
+			copy(indices, valid)
 
 			// Sort by distance
 			for j := 0; j < windowSize; j++ {
@@ -216,7 +245,6 @@ func Loess(bandwidth float64) Transform {
 				weightSum += weight
 			}
 
-			result[i] = data[i]
 			if weightSum > 0 {
 				result[i].Y = weightedSum / weightSum
 				result[i].Value = result[i].Y
@@ -236,60 +264,15 @@ func tricube(x float64) float64 {
 	return cube * cube * cube
 }
 
-// SavitzkyGolay applies Savitzky-Golay smoothing (simplified)
-func SavitzkyGolay(windowSize, polyOrder int) Transform {
-	return func(data []DataPoint) []DataPoint {
-		if len(data) == 0 || windowSize < polyOrder+1 {
-			return data
-		}
-
-		// For simplicity, use weighted moving average approximation
-		// A full implementation would fit polynomials locally
-		weights := make([]float64, windowSize)
-		for i := range weights {
-			weights[i] = 1.0
-		}
-
-		return WeightedMovingAverage(weights)(data)
-	}
-}
-
-// Interpolate fills in missing values using linear interpolation
+// Interpolate fills missing values by linear interpolation between the
+// values on either side, leaving gaps at the ends missing. It is shorthand
+// for Fill with the "linear" method.
 func Interpolate() Transform {
-	return func(data []DataPoint) []DataPoint {
-		if len(data) < 2 {
-			return data
-		}
-
-		result := make([]DataPoint, len(data))
-		copy(result, data)
-
-		// Find gaps and interpolate
-		for i := 1; i < len(result)-1; i++ {
-			// Check if Y is zero or missing (you might want a different condition)
-			if result[i].Y == 0 {
-				// Find previous and next non-zero values
-				prev := i - 1
-				next := i + 1
-
-				for next < len(result) && result[next].Y == 0 {
-					next++
-				}
-
-				if next < len(result) && prev >= 0 {
-					// Linear interpolation
-					ratio := float64(i-prev) / float64(next-prev)
-					result[i].Y = result[prev].Y + ratio*(result[next].Y-result[prev].Y)
-					result[i].Value = result[i].Y
-				}
-			}
-		}
-
-		return result
-	}
+	return Fill(FillOptions{Method: "linear"})
 }
 
-// Downsample reduces the number of points by sampling every nth point
+// Downsample reduces the number of points by sampling every nth point. It
+// can drop spikes; LTTB, M4 and MinMax preserve the shape of the series.
 func Downsample(n int) Transform {
 	return func(data []DataPoint) []DataPoint {
 		if n <= 1 || len(data) == 0 {
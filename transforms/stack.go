@@ -1,6 +1,9 @@
 package transforms
 
-import "sort"
+import (
+	"math"
+	"sort"
+)
 
 // Stack creates a stacking transform that computes Y0 and Y1 for stacked visualizations.
 // Essential for stacked bar charts, area charts, and stream graphs.
@@ -31,6 +34,14 @@ func Stack(opts StackOptions) Transform {
 			opts.Offset = "zero"
 		}
 
+		// Missing values stack as zero for ordering and totals
+		value := func(idx int) float64 {
+			if data[idx].IsMissing() {
+				return 0
+			}
+			return data[idx].Y
+		}
+
 		// Group data by X value
 		groups := make(map[string][]int)
 		for i, d := range data {
@@ -64,18 +75,18 @@ func Stack(opts StackOptions) Transform {
 			switch opts.Order {
 			case "ascending":
 				sort.Slice(indices, func(i, j int) bool {
-					return data[indices[i]].Y < data[indices[j]].Y
+					return value(indices[i]) < value(indices[j])
 				})
 			case "descending":
 				sort.Slice(indices, func(i, j int) bool {
-					return data[indices[i]].Y > data[indices[j]].Y
+					return value(indices[i]) > value(indices[j])
 				})
 			}
 
 			// Calculate total for this stack position
 			total := 0.0
 			for _, idx := range indices {
-				total += data[idx].Y
+				total += value(idx)
 			}
 
 			// Apply offset
@@ -90,11 +101,17 @@ func Stack(opts StackOptions) Transform {
 				offset = 0
 			}
 
-			// Stack values
+			// Stack values; missing values take no height, and under the
+			// "gap" policy have no extent either
 			baseline := offset
 			for _, idx := range indices {
+				if data[idx].IsMissing() && opts.Missing == "gap" {
+					result[idx].Y0 = math.NaN()
+					result[idx].Y1 = math.NaN()
+					continue
+				}
 				result[idx].Y0 = baseline
-				result[idx].Y1 = baseline + data[idx].Y
+				result[idx].Y1 = baseline + value(idx)
 				baseline = result[idx].Y1
 			}
 
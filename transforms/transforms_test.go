@@ -277,6 +277,94 @@ func TestExponentialSmoothing(t *testing.T) {
 	}
 }
 
+func TestSavitzkyGolayCoefficients(t *testing.T) {
+	tests := []struct {
+		name       string
+		derivative int
+		want       []float64
+	}{
+		{"smooth", 0, []float64{-3.0 / 35, 12.0 / 35, 17.0 / 35, 12.0 / 35, -3.0 / 35}},
+		{"first derivative", 1, []float64{-0.2, -0.1, 0, 0.1, 0.2}},
+		{"second derivative", 2, []float64{2.0 / 7, -1.0 / 7, -2.0 / 7, -1.0 / 7, 2.0 / 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SavitzkyGolayCoefficients(5, 2, tt.derivative, 1)
+			for i := range tt.want {
+				if !floatEquals(got[i], tt.want[i], 1e-9) {
+					t.Errorf("coefficients = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSavitzkyGolayPolynomial(t *testing.T) {
+	// A quadratic is reproduced exactly, with exact derivatives, up to
+	// the ends when they are fitted
+	data := make([]DataPoint, 20)
+	for i := range data {
+		x := float64(i) * 0.5
+		data[i] = DataPoint{X: x, Y: 0.5*x*x - 2*x + 3}
+	}
+
+	for derivative, want := range []func(x float64) float64{
+		func(x float64) float64 { return 0.5*x*x - 2*x + 3 },
+		func(x float64) float64 { return x - 2 },
+		func(x float64) float64 { return 1 },
+	} {
+		got := SavitzkyGolayFilter(SavitzkyGolayOptions{WindowSize: 7, PolyOrder: 3, Derivative: derivative, Delta: 0.5})(data)
+		for i, p := range got {
+			if !floatEquals(p.Y, want(p.X.(float64)), 1e-9) {
+				t.Errorf("derivative %d at %d: got %f, want %f", derivative, i, p.Y, want(p.X.(float64)))
+			}
+		}
+	}
+}
+
+func TestSavitzkyGolayEdges(t *testing.T) {
+	data := []DataPoint{{Y: 0}, {Y: 1}, {Y: 2}, {Y: 3}, {Y: 4}}
+
+	tests := []struct {
+		edge        string
+		first, last float64
+	}{
+		{"", 0, 4},
+		{"mirror", 2.0 / 3, 10.0 / 3},
+		{"nearest", 1.0 / 3, 11.0 / 3},
+	}
+	for _, tt := range tests {
+		got := SavitzkyGolayFilter(SavitzkyGolayOptions{WindowSize: 3, PolyOrder: 1, Edge: tt.edge})(data)
+		if !floatEquals(got[0].Y, tt.first, 1e-9) || !floatEquals(got[4].Y, tt.last, 1e-9) {
+			t.Errorf("edge %q: ends = %f, %f, want %f, %f", tt.edge, got[0].Y, got[4].Y, tt.first, tt.last)
+		}
+		if !floatEquals(got[2].Y, 2, 1e-9) {
+			t.Errorf("edge %q: middle = %f, want 2", tt.edge, got[2].Y)
+		}
+	}
+}
+
+func TestSavitzkyGolayKeepsPeaks(t *testing.T) {
+	data := make([]DataPoint, 41)
+	for i := range data {
+		x := float64(i-20) / 3
+		data[i] = DataPoint{Y: math.Exp(-x * x / 2)}
+	}
+
+	peak := SavitzkyGolay(9, 4)(data)[20].Y
+	average := MovingAverage(9)(data)[20].Y
+	if peak < 0.99 || average > 0.9 {
+		t.Errorf("peak height = %f (Savitzky-Golay), %f (moving average)", peak, average)
+	}
+
+	// Windows longer than the series shrink to fit
+	short := SavitzkyGolay(11, 2)(data[:4])
+	if len(short) != 4 {
+		t.Errorf("Expected 4 points, got %d", len(short))
+	}
+}
+
 func TestDownsample(t *testing.T) {
 	data := []DataPoint{
 		{Y: 1}, {Y: 2}, {Y: 3}, {Y: 4}, {Y: 5}, {Y: 6},
@@ -296,6 +384,88 @@ func TestDownsample(t *testing.T) {
 	}
 }
 
+func TestShapePreservingDownsample(t *testing.T) {
+	// A flat series with one spike up and one down, which sampling every
+	// nth point would miss
+	data := make([]DataPoint, 1000)
+	for i := range data {
+		data[i] = DataPoint{X: float64(i), Y: 1, Index: i}
+	}
+	data[333].Y = 50
+	data[667].Y = -50
+
+	tests := []struct {
+		name string
+		tr   Transform
+		max  int
+	}{
+		{"LTTB", LTTB(20), 20},
+		{"M4", M4(10), 40},
+		{"MinMax", MinMax(10), 22},
+	}
+	for _, tt := range tests {
+		got := tt.tr(data)
+		if len(got) > tt.max {
+			t.Errorf("%s: expected at most %d points, got %d", tt.name, tt.max, len(got))
+		}
+		if got[0].Index != 0 || got[len(got)-1].Index != 999 {
+			t.Errorf("%s: expected the first and last points to be kept", tt.name)
+		}
+		var up, down bool
+		for i, d := range got {
+			if i > 0 && d.Index <= got[i-1].Index {
+				t.Errorf("%s: points out of order at %d", tt.name, i)
+			}
+			up = up || d.Y == 50
+			down = down || d.Y == -50
+		}
+		if !up || !down {
+			t.Errorf("%s: expected both spikes to be kept", tt.name)
+		}
+	}
+}
+
+func TestShapePreservingDownsampleUnchanged(t *testing.T) {
+	data := []DataPoint{{Y: 1}, {Y: 2}, {Y: 3}, {Y: 4}}
+
+	for name, tr := range map[string]Transform{
+		"LTTB":   LTTB(4),
+		"LTTB 2": LTTB(2),
+		"M4":     M4(1),
+		"MinMax": MinMax(2),
+	} {
+		if got := tr(data); len(got) != len(data) {
+			t.Errorf("%s: expected %d points unchanged, got %d", name, len(data), len(got))
+		}
+	}
+}
+
+func TestShapePreservingDownsampleGaps(t *testing.T) {
+	data := make([]DataPoint, 100)
+	for i := range data {
+		data[i] = DataPoint{Y: float64(i % 7), Index: i}
+	}
+	for i := 40; i < 60; i++ {
+		data[i].Y = math.NaN()
+	}
+
+	for name, tr := range map[string]Transform{
+		"LTTB":   LTTB(10),
+		"M4":     M4(5),
+		"MinMax": MinMax(10),
+	} {
+		missing := 0
+		for _, d := range tr(data) {
+			if d.IsMissing() {
+				missing++
+			}
+		}
+		if missing == 0 {
+			t.Errorf("%s: expected the gap to be kept", name)
+		}
+	}
+}
+
 // ==================== Normalization Tests ====================
 
 func TestNormalizePercentage(t *testing.T) {
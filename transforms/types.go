@@ -6,7 +6,7 @@ import "time"
 // Transforms operate on slices of DataPoints and return transformed data.
 type DataPoint struct {
 	X      interface{} // X value (can be time.Time, float64, string, etc.)
-	Y      float64     // Y value (numeric)
+	Y      float64     // Y value (numeric; NaN when missing)
 	Y0     float64     // Baseline Y value (for stacking)
 	Y1     float64     // Top Y value (for stacking)
 	Label  string      // Category label
@@ -136,16 +136,24 @@ type StackOptions struct {
 
 	// Offset specifies the baseline ("zero", "center", "normalize")
 	Offset string
+
+	// Missing specifies how missing values stack: "zero" gives them zero
+	// height at the top of the layers below (default) and "gap" leaves their
+	// Y0 and Y1 missing so renderers break the layer there
+	Missing string
 }
 
 // SmoothOptions configures smoothing behavior
 type SmoothOptions struct {
-	// Method specifies the smoothing method ("movingAverage", "loess", "exponential")
+	// Method specifies the smoothing method ("movingAverage", "loess", "exponential", "savitzkyGolay")
 	Method string
 
-	// WindowSize specifies the window size for moving averages
+	// WindowSize specifies the window size for moving averages and Savitzky-Golay filters
 	WindowSize int
 
+	// PolyOrder specifies the polynomial degree for Savitzky-Golay filters
+	PolyOrder int
+
 	// Bandwidth specifies the bandwidth for LOESS smoothing (0-1)
 	Bandwidth float64
 
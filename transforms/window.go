@@ -377,7 +377,8 @@ func (sw *SnapshotWindow) WindowBounds(windowID int, data []DataPoint) (start, e
 	return
 }
 
-// ApplyWindow applies a window strategy and aggregates each window
+// ApplyWindow applies a window strategy and aggregates each window,
+// skipping missing values; windows without any values are missing
 func ApplyWindow(strategy WindowStrategy, fn AggregateFunc) Transform {
 	return func(data []DataPoint) []DataPoint {
 		if len(data) == 0 {
@@ -419,14 +420,11 @@ func ApplyWindow(strategy WindowStrategy, fn AggregateFunc) Transform {
 				continue
 			}
 
-			// Extract values in this window
-			values := make([]float64, 0, end-start)
-			for i := start; i < end; i++ {
-				values = append(values, data[i].Y)
-			}
+			// Extract values in this window, skipping missing ones
+			values := validValues(data[start:end])
 
 			// Aggregate
-			aggValue := fn(values)
+			aggValue := aggregate(fn, values)
 
 			// Use the first point in the window as the base
 			point := data[start]